@@ -167,6 +167,9 @@ func (w *webServer) handleQuery(rw http.ResponseWriter, req *http.Request) {
 	}
 	review.EnsureDefaults()
 	review.Spec.IncludeRuleMetadata = true
+	if review.Spec.IncludePolicyRules == "" {
+		review.Spec.IncludePolicyRules = v1alpha1.PolicyRulesModeMatched
+	}
 
 	payload, err := json.Marshal(review)
 	if err != nil {
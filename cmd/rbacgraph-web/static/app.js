@@ -24,13 +24,17 @@
     const showPermissionsEl = document.getElementById('showPermissions');
     const showRolePermissionsEl = document.getElementById('showRolePermissions');
     const spreadEdgesEl = document.getElementById('spreadEdges');
+    const sortByRiskEl = document.getElementById('sortByRisk');
     const focusModeEl = document.getElementById('focusMode');
     const includePodsEl = document.getElementById('includePods');
     const includeWorkloadsEl = document.getElementById('includeWorkloads');
     const filterPhantomAPIsEl = document.getElementById('filterPhantomAPIs');
+    const includeEscalationPathsEl = document.getElementById('includeEscalationPaths');
     const podPhaseModeEl = document.getElementById('podPhaseMode');
     const maxPodsPerSubjectEl = document.getElementById('maxPodsPerSubject');
     const maxWorkloadsPerPodEl = document.getElementById('maxWorkloadsPerPod');
+    const minRiskScoreEl = document.getElementById('minRiskScore');
+    const asOfEl = document.getElementById('asOf');
     const namespaceScopeStrictEl = document.getElementById('namespaceScopeStrict');
     const impersonateUserEl = document.getElementById('impersonateUser');
     const impersonateGroupEl = document.getElementById('impersonateGroup');
@@ -433,6 +437,30 @@
       return html;
     }
 
+    // renderPolicyRules lists the rules embedded in the role node, as written
+    // in the role, with its resourceVersion.
+    function renderPolicyRules(node) {
+      const rules = Array.isArray(node.policyRules) ? node.policyRules : [];
+      if (rules.length === 0) return '';
+      let html = `<div style="border-top:1px solid var(--line);margin:10px 0;padding-top:4px;font-size:0.7rem;color:var(--muted);text-transform:uppercase;letter-spacing:0.05em;">`;
+      html += `Role Rules (${rules.length} of ${node.ruleCount || rules.length})`;
+      if (node.resourceVersion) html += ` &middot; resourceVersion ${escapeHTML(node.resourceVersion)}`;
+      html += '</div>';
+      const list = values => (values || []).map(v => escapeHTML(v === '' ? '""' : v)).join(', ');
+      for (const rule of rules) {
+        html += `<div class="rf-modal-rule rf-rule-concrete"><div class="rf-modal-rule-top">`;
+        html += `<span class="rf-perm-verb">#${rule.index}</span>`;
+        html += `<span class="rf-perm-resource">${list(rule.verbs)}</span>`;
+        html += '</div>';
+        if (rule.apiGroups && rule.apiGroups.length > 0) html += `<div class="rf-perm-meta">apiGroups: ${list(rule.apiGroups)}</div>`;
+        if (rule.resources && rule.resources.length > 0) html += `<div class="rf-perm-meta">resources: ${list(rule.resources)}</div>`;
+        if (rule.resourceNames && rule.resourceNames.length > 0) html += `<div class="rf-perm-meta">resourceNames: ${list(rule.resourceNames)}</div>`;
+        if (rule.nonResourceURLs && rule.nonResourceURLs.length > 0) html += `<div class="rf-perm-meta">nonResourceURLs: ${list(rule.nonResourceURLs)}</div>`;
+        html += '</div>';
+      }
+      return html;
+    }
+
     // -- Right column update -------------------------------------------------
     function updateExpansionColumn() {
       let html = '<h4>Expanded Permissions</h4>';
@@ -572,6 +600,8 @@
         }
       }
 
+      leftHTML += renderPolicyRules(node);
+
       roleModalOriginalEl.innerHTML = leftHTML;
 
       // Build right column
@@ -597,6 +627,29 @@
       return raw.split(',').map(v => v.trim()).filter(v => v.length > 0);
     }
 
+    // labelSelector parses "key=value", "key!=value", "key" and "!key"
+    // terms into a metav1.LabelSelector; null when the input is empty.
+    function labelSelector(id) {
+      const terms = csv(id);
+      if (terms.length === 0) return null;
+      const selector = { matchLabels: {}, matchExpressions: [] };
+      for (const term of terms) {
+        let match;
+        if ((match = term.match(/^([^!=\s]+)\s*!=\s*(.*)$/))) {
+          selector.matchExpressions.push({ key: match[1], operator: 'NotIn', values: [match[2]] });
+        } else if ((match = term.match(/^([^!=\s]+)\s*==?\s*(.*)$/))) {
+          selector.matchLabels[match[1]] = match[2];
+        } else if (term.startsWith('!')) {
+          selector.matchExpressions.push({ key: term.slice(1).trim(), operator: 'DoesNotExist' });
+        } else {
+          selector.matchExpressions.push({ key: term, operator: 'Exists' });
+        }
+      }
+      if (Object.keys(selector.matchLabels).length === 0) delete selector.matchLabels;
+      if (selector.matchExpressions.length === 0) delete selector.matchExpressions;
+      return selector;
+    }
+
     function intOrDefault(value, fallback) {
       const parsed = Number.parseInt(String(value || '').trim(), 10);
       if (!Number.isFinite(parsed) || parsed <= 0) {
@@ -773,15 +826,51 @@
         includePods: !!includePodsEl.checked,
         includeWorkloads: !!includeWorkloadsEl.checked,
         filterPhantomAPIs: !!filterPhantomAPIsEl.checked,
+        includeEscalationPaths: !!includeEscalationPathsEl.checked,
         podPhaseMode: podPhaseModeEl.value,
         maxPodsPerSubject: intOrDefault(maxPodsPerSubjectEl.value, 20),
         maxWorkloadsPerPod: intOrDefault(maxWorkloadsPerPodEl.value, 10)
       };
-      if (namespaceScopeNamespaces.length > 0 || namespaceScopeStrict) {
+      const minRiskScore = intOrDefault(minRiskScoreEl.value, 0);
+      if (minRiskScore > 0) {
+        spec.minRiskScore = Math.min(minRiskScore, 100);
+      }
+      const subjects = {
+        kinds: csv('subjectKinds'),
+        namePatterns: csv('subjectNamePatterns'),
+        namespaces: csv('subjectNamespaces')
+      };
+      const subjectNameRegex = document.getElementById('subjectNameRegex').value.trim();
+      if (subjectNameRegex) subjects.nameRegex = subjectNameRegex;
+      if (subjects.kinds.length > 0 || subjects.namePatterns.length > 0 || subjects.namespaces.length > 0 || subjects.nameRegex) {
+        spec.selector.subjects = subjects;
+      }
+      const roleSelector = labelSelector('roleLabelSelector');
+      if (roleSelector) spec.roleLabelSelector = roleSelector;
+      const roleNamePatterns = csv('roleNamePatterns');
+      if (roleNamePatterns.length > 0) spec.roleNamePatterns = roleNamePatterns;
+      const bindingSelector = labelSelector('bindingLabelSelector');
+      if (bindingSelector) spec.bindingLabelSelector = bindingSelector;
+      const bindingNamePatterns = csv('bindingNamePatterns');
+      if (bindingNamePatterns.length > 0) spec.bindingNamePatterns = bindingNamePatterns;
+      if (asOfEl.value) {
+        const asOf = new Date(asOfEl.value);
+        if (!Number.isNaN(asOf.getTime())) {
+          spec.asOf = asOf.toISOString().replace(/\.\d{3}Z$/, 'Z');
+        }
+      }
+      const namespaceScopeSelector = labelSelector('namespaceScopeSelector');
+      const namespaceScopePatterns = csv('namespaceScopePatterns');
+      const namespaceScopeExclude = csv('namespaceScopeExclude');
+      if (namespaceScopeNamespaces.length > 0 || namespaceScopeStrict || namespaceScopeSelector ||
+          namespaceScopePatterns.length > 0 || namespaceScopeExclude.length > 0) {
         spec.namespaceScope = {
           namespaces: namespaceScopeNamespaces,
           strict: namespaceScopeStrict
         };
+        if (namespaceScopeSelector) spec.namespaceScope.selector = namespaceScopeSelector;
+        if (namespaceScopePatterns.length > 0) spec.namespaceScope.patterns = namespaceScopePatterns;
+        if (namespaceScopeExclude.length > 0) spec.namespaceScope.exclude = namespaceScopeExclude;
       }
       return {
         spec: spec
@@ -866,6 +955,7 @@
         showRolePermissions: !!showRolePermissionsEl.checked,
         showPermissions: !!showPermissionsEl.checked,
         spreadEdges: !!spreadEdgesEl.checked,
+        sortByRisk: !!sortByRiskEl.checked,
         focusMode: !!focusModeEl.checked,
         runtimeView,
         laneSpacing: Number.parseInt(String(laneSpacingEl?.value || ''), 10) || 540,
@@ -1229,6 +1319,7 @@
       const showRolePermissions = !!options?.showRolePermissions;
       const showPermissions = !!options?.showPermissions;
       const spreadEdges = !!options?.spreadEdges;
+      const sortByRisk = !!options?.sortByRisk;
       const runtimeView = options?.runtimeView === 'ownership' ? 'ownership' : 'access';
       const allNodes = graph?.nodes || [];
       const allRawEdges = graph?.edges || [];
@@ -1417,6 +1508,9 @@
           if (layer === 'Permissions') {
             return comparePermissionNodes(a, b);
           }
+          if (sortByRisk && (a.riskScore || 0) !== (b.riskScore || 0)) {
+            return (b.riskScore || 0) - (a.riskScore || 0);
+          }
           const left = (a.name || a.id || '').toLowerCase();
           const right = (b.name || b.id || '').toLowerCase();
           return left.localeCompare(right);
@@ -1608,7 +1702,12 @@
               inlineRolePermissionCount: inlineRolePermissions.length,
               inlineRolePermissions: visibleInlineRolePermissions,
               inlineRolePermissionHidden: hiddenInlineRolePermissions,
-              phantom: !!node.phantom
+              phantom: !!node.phantom,
+              namespaceState: node.namespaceState || '',
+              serviceAccount: node.serviceAccount || null,
+              tokenExposure: node.tokenExposure || null,
+              riskScore: node.riskScore || 0,
+              riskReasons: Array.isArray(node.riskReasons) ? node.riskReasons : []
             },
             draggable: false,
             selectable: true,
@@ -1729,6 +1828,32 @@
         if (data.phantom) {
           badges.push(h('span', { key: 'phantom-badge', className: 'rf-badge phantom' }, 'phantom'));
         }
+        if (data.serviceAccount) {
+          const sa = data.serviceAccount;
+          if (!sa.exists) {
+            badges.push(h('span', { key: 'sa-missing-badge', className: 'rf-badge phantom' }, 'sa missing'));
+          }
+          for (const identity of sa.cloudIdentities || []) {
+            badges.push(h('span', { key: `cloud-${identity.provider}`, className: 'rf-badge risk medium', title: `${identity.annotation}: ${identity.identity}` }, `${identity.provider} identity`));
+          }
+          if ((sa.podCount || 0) > 0) {
+            badges.push(h('span', { key: 'sa-pods-badge', className: 'rf-badge pod' }, `pods ${sa.podCount}`));
+          }
+        }
+        if (data.tokenExposure) {
+          const exposure = data.tokenExposure;
+          const audiences = (exposure.projectedTokens || []).map(token => `${token.volume}: ${token.audience || 'api-server'}`).join(', ');
+          const title = `automount ${exposure.automount} (${exposure.automountSource})${audiences ? `; ${audiences}` : ''}`;
+          badges.push(h('span', { key: 'token-badge', className: exposure.exposed ? 'rf-badge risk high' : 'rf-badge', title }, exposure.exposed ? 'token exposed' : 'no token'));
+        }
+        if (data.namespaceState) {
+          badges.push(h('span', { key: 'ns-state-badge', className: 'rf-badge phantom', title: `namespace is ${data.namespaceState}` }, `ns ${data.namespaceState}`));
+        }
+        if ((data.riskScore || 0) > 0) {
+          const level = data.riskScore >= 70 ? 'high' : data.riskScore >= 40 ? 'medium' : 'low';
+          const reasons = (data.riskReasons || []).map(reason => `${reason.id} (+${reason.score})`).join(', ');
+          badges.push(h('span', { key: 'risk-badge', className: `rf-badge risk ${level}`, title: reasons }, `risk ${data.riskScore}`));
+        }
         if (!data.permissionCard && (data.inlineRolePermissionCount || 0) > 0) {
           badges.push(h('span', { key: 'perm-inline-badge', className: 'rf-badge permission' }, `rules ${data.inlineRolePermissionCount}`));
         }
@@ -1994,19 +2119,26 @@
         if (node.data?.layer === 'Pods') podsVisibleCount++;
         if (node.data?.layer === 'Workloads') workloadsVisibleCount++;
       }
-      statsEl.textContent = `matchedRoles=${status.matchedRoles || 0}, matchedBindings=${status.matchedBindings || 0}, matchedSubjects=${status.matchedSubjects || 0}, matchedPods=${status.matchedPods || 0}, matchedWorkloads=${status.matchedWorkloads || 0}, permissionsVisible=${permissionVisibleCount}, inlineRolePermissionsVisible=${inlineRolePermissionCount}, aggregationRelationsVisible=${aggregationRelationVisibleCount}, podsVisible=${podsVisibleCount}, workloadsVisible=${workloadsVisibleCount}, nodesVisible=${lastRenderModel.nodes.length}, edgesVisible=${lastRenderModel.edges.length}, totalNodes=${(graph.nodes || []).length}, totalEdges=${allEdges.length}, aggregateLinks=${aggregateLinks}, aggregationSourceRoles=${sourceRoleCount}, aggregateVisible=${showAggregateEdgesEl.checked ? 'on' : 'off'}, permissionsLane=${showPermissionsEl.checked ? 'on' : 'off'}, rolePermissions=${showRolePermissionsEl.checked ? 'on' : 'off'}, spreadEdges=${spreadEdgesEl.checked ? 'on' : 'off'}, focusMode=${focusModeEl.checked ? 'on' : 'off'}, runtimeView=${runtimeViewEl?.value || 'access'}, focusedNode=${lastFocusNodeID || '-'}, onlyReachable=${onlyReachableEl.checked ? 'on' : 'off'}`;
+      statsEl.textContent = `matchedRoles=${status.matchedRoles || 0}, matchedBindings=${status.matchedBindings || 0}, matchedSubjects=${status.matchedSubjects || 0}, matchedPods=${status.matchedPods || 0}, matchedWorkloads=${status.matchedWorkloads || 0}, snapshotGeneration=${status.snapshotGeneration || '-'}, permissionsVisible=${permissionVisibleCount}, inlineRolePermissionsVisible=${inlineRolePermissionCount}, aggregationRelationsVisible=${aggregationRelationVisibleCount}, podsVisible=${podsVisibleCount}, workloadsVisible=${workloadsVisibleCount}, nodesVisible=${lastRenderModel.nodes.length}, edgesVisible=${lastRenderModel.edges.length}, totalNodes=${(graph.nodes || []).length}, totalEdges=${allEdges.length}, aggregateLinks=${aggregateLinks}, aggregationSourceRoles=${sourceRoleCount}, aggregateVisible=${showAggregateEdgesEl.checked ? 'on' : 'off'}, permissionsLane=${showPermissionsEl.checked ? 'on' : 'off'}, rolePermissions=${showRolePermissionsEl.checked ? 'on' : 'off'}, spreadEdges=${spreadEdgesEl.checked ? 'on' : 'off'}, focusMode=${focusModeEl.checked ? 'on' : 'off'}, runtimeView=${runtimeViewEl?.value || 'access'}, focusedNode=${lastFocusNodeID || '-'}, onlyReachable=${onlyReachableEl.checked ? 'on' : 'off'}`;
     }
 
     function renderChips(container, values, type) {
       container.innerHTML = (values || []).map(v => `<span class="chip ${type}">${escapeHTML(v)}</span>`).join('');
     }
 
+    function formatScope(scope) {
+      if (!scope) return '-';
+      if (scope.clusterWide) return 'cluster-wide';
+      return (scope.namespaces || []).join(', ') || '-';
+    }
+
     function renderResourceMap(rows) {
       resourceMapBody.innerHTML = (rows || []).map(row => `
         <tr>
           <td>${escapeHTML(row.apiGroup || '-')}</td>
           <td>${escapeHTML(row.resource || '-')}</td>
           <td>${escapeHTML(row.verb || '-')}</td>
+          <td>${escapeHTML(formatScope(row.scope))}</td>
           <td>${escapeHTML(row.roleCount)}</td>
           <td>${escapeHTML(row.bindingCount)}</td>
           <td>${escapeHTML(row.subjectCount)}</td>
@@ -2176,14 +2308,14 @@
         renderSelectorOptions(kind);
       });
     }
-    ['apiGroups', 'resources', 'verbs', 'resourceNames', 'nonResourceURLs', 'namespaceScopeNamespaces', 'maxPodsPerSubject', 'maxWorkloadsPerPod'].forEach(id => {
+    ['apiGroups', 'resources', 'verbs', 'resourceNames', 'nonResourceURLs', 'namespaceScopeNamespaces', 'namespaceScopeSelector', 'namespaceScopePatterns', 'namespaceScopeExclude', 'maxPodsPerSubject', 'maxWorkloadsPerPod', 'minRiskScore', 'asOf', 'subjectKinds', 'subjectNamePatterns', 'subjectNameRegex', 'subjectNamespaces', 'roleLabelSelector', 'roleNamePatterns', 'bindingLabelSelector', 'bindingNamePatterns'].forEach(id => {
       const el = document.getElementById(id);
       el.addEventListener('input', () => {
         rawStore.request = JSON.stringify(payload());
         updateRequestText();
       });
     });
-    ['matchMode', 'wildcardMode', 'includePods', 'includeWorkloads', 'filterPhantomAPIs', 'podPhaseMode', 'namespaceScopeStrict'].forEach(id => {
+    ['matchMode', 'wildcardMode', 'includePods', 'includeWorkloads', 'filterPhantomAPIs', 'includeEscalationPaths', 'podPhaseMode', 'namespaceScopeStrict'].forEach(id => {
       const el = document.getElementById(id);
       el.addEventListener('change', () => {
         rawStore.request = JSON.stringify(payload());
@@ -2226,6 +2358,7 @@
     showPermissionsEl.addEventListener('change', refreshGraphFromToggles);
     showRolePermissionsEl.addEventListener('change', refreshGraphFromToggles);
     spreadEdgesEl.addEventListener('change', refreshGraphFromToggles);
+    sortByRiskEl.addEventListener('change', refreshGraphFromToggles);
     runtimeViewEl.addEventListener('change', () => {
       updateRuntimeLegend();
       refreshGraphFromToggles();
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"k8s-role-graph/internal/app"
+)
+
+// Exit codes: 1 for policy violations, 2 for usage and load errors.
+const (
+	exitViolations = 1
+	exitError      = 2
+)
+
+func main() {
+	root := &cobra.Command{
+		Use:           "rbacgraph",
+		Short:         "Offline RBAC graph tools",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+	root.AddCommand(app.NewCommandLint(app.NewLintOptions(os.Stdin, os.Stdout)))
+
+	err := root.Execute()
+	switch {
+	case err == nil:
+	case errors.Is(err, app.ErrPolicyViolations):
+		os.Exit(exitViolations)
+	default:
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(exitError)
+	}
+}
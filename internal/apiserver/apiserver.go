@@ -15,7 +15,10 @@ import (
 	"k8s-role-graph/internal/engine"
 	"k8s-role-graph/internal/indexer"
 	nonresourceurlstorage "k8s-role-graph/internal/registry/nonresourceurl"
+	hygienestorage "k8s-role-graph/internal/registry/rbachygienereport"
+	diffstorage "k8s-role-graph/internal/registry/rolegraphdiff"
 	reviewstorage "k8s-role-graph/internal/registry/rolegraphreview"
+	subjectreviewstorage "k8s-role-graph/internal/registry/subjectpermissionreview"
 	"k8s-role-graph/pkg/apis/rbacgraph"
 	"k8s-role-graph/pkg/apis/rbacgraph/v1alpha1"
 )
@@ -69,6 +72,9 @@ func (c CompletedConfig) New() (*RbacGraphServer, error) {
 	v1alpha1storage := map[string]rest.Storage{}
 	v1alpha1storage["rolegraphreviews"] = reviewstorage.NewREST(c.Engine, c.Indexer, Scheme, c.AuthzResolver)
 	v1alpha1storage["nonresourceurls"] = nonresourceurlstorage.NewREST(c.Indexer)
+	v1alpha1storage[v1alpha1.SubjectPermissionReviewResource] = subjectreviewstorage.NewREST(c.Engine, c.Indexer, c.AuthzResolver)
+	v1alpha1storage[v1alpha1.RBACHygieneReportResource] = hygienestorage.NewREST(c.Engine, c.Indexer, c.AuthzResolver)
+	v1alpha1storage[v1alpha1.RoleGraphDiffResource] = diffstorage.NewREST(c.Engine, c.Indexer, c.AuthzResolver)
 	apiGroupInfo.VersionedResourcesStorageMap[v1alpha1.Version] = v1alpha1storage
 
 	if err := s.GenericAPIServer.InstallAPIGroup(&apiGroupInfo); err != nil {
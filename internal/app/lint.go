@@ -0,0 +1,164 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"k8s-role-graph/internal/engine"
+	"k8s-role-graph/internal/indexer"
+	"k8s-role-graph/internal/policy"
+)
+
+// Lint output formats.
+const (
+	LintFormatText  = "text"
+	LintFormatJUnit = "junit"
+	LintFormatSARIF = "sarif"
+)
+
+// ErrPolicyViolations is returned by the lint command when a policy with
+// error severity has violations.
+var ErrPolicyViolations = errors.New("policy violations found")
+
+type LintOptions struct {
+	Policies         []string
+	DefaultNamespace string
+	DiscoveryFile    string
+	Format           string
+	JUnitFile        string
+	SARIFFile        string
+
+	StdIn  io.Reader
+	StdOut io.Writer
+}
+
+func NewLintOptions(in io.Reader, out io.Writer) *LintOptions {
+	return &LintOptions{
+		DefaultNamespace: "default",
+		Format:           LintFormatText,
+		StdIn:            in,
+		StdOut:           out,
+	}
+}
+
+// NewCommandLint returns the `lint` command. Manifest paths are positional;
+// "-" reads manifests from stdin.
+func NewCommandLint(defaults *LintOptions) *cobra.Command {
+	o := defaults
+	cmd := &cobra.Command{
+		Use:   "lint [flags] PATH...",
+		Short: "Check manifests against RBAC policies",
+		Long: "Build a snapshot from manifest files or directories and evaluate the policies in --policies.\n" +
+			"Exits with status 1 when a policy with error severity has violations.",
+		Args:          cobra.MinimumNArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Validate(); err != nil {
+				return err
+			}
+
+			return o.Run(args)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringSliceVarP(&o.Policies, "policies", "p", nil, "Policy files to evaluate (repeatable)")
+	flags.StringVar(&o.DefaultNamespace, "default-namespace", o.DefaultNamespace,
+		"Namespace assigned to namespaced manifests that do not set one")
+	flags.StringVar(&o.DiscoveryFile, "discovery-file", "",
+		"APIResourceList documents used for wildcard expansion and phantom API checks")
+	flags.StringVarP(&o.Format, "format", "o", o.Format, "Output format on stdout: text, junit or sarif")
+	flags.StringVar(&o.JUnitFile, "junit-file", "", "Also write a JUnit report to this file")
+	flags.StringVar(&o.SARIFFile, "sarif-file", "", "Also write a SARIF report to this file")
+
+	return cmd
+}
+
+func (o *LintOptions) Validate() error {
+	if len(o.Policies) == 0 {
+		return errors.New("--policies is required")
+	}
+	switch o.Format {
+	case LintFormatText, LintFormatJUnit, LintFormatSARIF:
+	default:
+		return fmt.Errorf("--format must be %s, %s or %s, got %q", LintFormatText, LintFormatJUnit, LintFormatSARIF, o.Format)
+	}
+
+	return nil
+}
+
+func (o *LintOptions) Run(paths []string) error {
+	policies, err := policy.LoadFiles(o.Policies...)
+	if err != nil {
+		return fmt.Errorf("load policies: %w", err)
+	}
+
+	source := indexer.NewManifestSource(o.DefaultNamespace)
+	for _, path := range paths {
+		if path == "-" {
+			err = source.Add(o.StdIn, "stdin")
+		} else {
+			err = source.AddPath(path)
+		}
+		if err != nil {
+			return fmt.Errorf("load manifests: %w", err)
+		}
+	}
+	var discoveryCache *indexer.APIDiscoveryCache
+	if o.DiscoveryFile != "" {
+		discoveryCache, err = indexer.LoadDiscoveryCache(o.DiscoveryFile)
+		if err != nil {
+			return fmt.Errorf("load discovery file: %w", err)
+		}
+	}
+
+	results := policy.Evaluate(engine.New(), indexer.BuildSnapshot(source), discoveryCache, policies)
+	if err := o.writeReport(o.StdOut, o.Format, results, source.Origin); err != nil {
+		return err
+	}
+	for file, format := range map[string]string{o.JUnitFile: LintFormatJUnit, o.SARIFFile: LintFormatSARIF} {
+		if file == "" {
+			continue
+		}
+		if err := o.writeReportFile(file, format, results, source.Origin); err != nil {
+			return err
+		}
+	}
+	for _, result := range results {
+		if result.Failed() {
+			return ErrPolicyViolations
+		}
+	}
+
+	return nil
+}
+
+func (o *LintOptions) writeReportFile(file, format string, results []policy.Result, origin policy.OriginFunc) error {
+	f, err := os.Create(file) //nolint:gosec // path is supplied by the operator
+	if err != nil {
+		return err
+	}
+	if err := o.writeReport(f, format, results, origin); err != nil {
+		f.Close() //nolint:errcheck,gosec // the write error is returned
+
+		return err
+	}
+
+	return f.Close()
+}
+
+func (o *LintOptions) writeReport(w io.Writer, format string, results []policy.Result, origin policy.OriginFunc) error {
+	switch format {
+	case LintFormatJUnit:
+		return policy.WriteJUnit(w, results)
+	case LintFormatSARIF:
+		return policy.WriteSARIF(w, results, origin)
+	default:
+		return policy.WriteText(w, results)
+	}
+}
@@ -0,0 +1,86 @@
+package app
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const (
+	lintPolicies  = "../policy/testdata/policies.yaml"
+	lintManifests = "../policy/testdata/manifests"
+)
+
+func runLint(t *testing.T, stdin string, args ...string) (string, error) {
+	t.Helper()
+	var out bytes.Buffer
+	cmd := NewCommandLint(NewLintOptions(strings.NewReader(stdin), &out))
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+
+	return out.String(), err
+}
+
+func TestLint_FailsOnErrorViolations(t *testing.T) {
+	dir := t.TempDir()
+	junit := filepath.Join(dir, "junit.xml")
+	sarif := filepath.Join(dir, "lint.sarif")
+
+	out, err := runLint(t, "", "-p", lintPolicies, "--junit-file", junit, "--sarif-file", sarif, lintManifests)
+	if !errors.Is(err, ErrPolicyViolations) {
+		t.Fatalf("expected ErrPolicyViolations, got %v", err)
+	}
+	if !strings.Contains(out, "ERROR no-cluster-wide-secrets") {
+		t.Fatalf("expected text report on stdout, got:\n%s", out)
+	}
+	for _, file := range []string{junit, sarif} {
+		if info, err := os.Stat(file); err != nil || info.Size() == 0 {
+			t.Fatalf("expected report %s, got %v", file, err)
+		}
+	}
+}
+
+func TestLint_PassesWithOnlyWarnings(t *testing.T) {
+	manifest := `apiVersion: rbac.authorization.k8s.io/v1
+kind: RoleBinding
+metadata:
+  name: debug
+  namespace: team-b
+subjects:
+  - kind: User
+    name: alice
+roleRef:
+  apiGroup: rbac.authorization.k8s.io
+  kind: ClusterRole
+  name: exec
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: exec
+rules:
+  - apiGroups: [""]
+    resources: ["pods/exec"]
+    verbs: ["create"]
+`
+	out, err := runLint(t, manifest, "-p", lintPolicies, "--format", LintFormatSARIF, "-")
+	if err != nil {
+		t.Fatalf("expected warnings not to fail, got %v", err)
+	}
+	if !strings.Contains(out, `"level": "warning"`) {
+		t.Fatalf("expected SARIF warning, got:\n%s", out)
+	}
+}
+
+func TestLint_RejectsUsageErrors(t *testing.T) {
+	if _, err := runLint(t, "", lintManifests); err == nil || !strings.Contains(err.Error(), "--policies is required") {
+		t.Fatalf("expected missing policies error, got %v", err)
+	}
+	if _, err := runLint(t, "", "-p", lintPolicies, "--format", "html", lintManifests); err == nil ||
+		errors.Is(err, ErrPolicyViolations) {
+		t.Fatalf("expected format error, got %v", err)
+	}
+}
@@ -2,6 +2,7 @@ package app
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"time"
@@ -13,19 +14,34 @@ import (
 	serveroptions "k8s.io/apiserver/pkg/server/options"
 	"k8s.io/apiserver/pkg/util/compatibility"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/metadata"
 
 	internalserver "k8s-role-graph/internal/apiserver"
 	"k8s-role-graph/internal/authz"
 	"k8s-role-graph/internal/engine"
 	"k8s-role-graph/internal/indexer"
+	"k8s-role-graph/internal/policy"
 	"k8s-role-graph/pkg/apis/rbacgraph/v1alpha1"
 	"k8s-role-graph/pkg/kube"
 )
 
 type ServerOptions struct {
-	RecommendedOptions *serveroptions.RecommendedOptions
-	ResyncPeriod       time.Duration
-	EnforceCallerScope bool
+	RecommendedOptions  *serveroptions.RecommendedOptions
+	ResyncPeriod        time.Duration
+	MaxStaleness        time.Duration
+	DisableRuntimeIndex bool
+	OwnerKinds          []string
+	EnforceCallerScope  bool
+
+	SnapshotHistorySize     int
+	SnapshotHistoryInterval time.Duration
+	SnapshotHistoryDir      string
+
+	Manifests                 []string
+	ManifestsDefaultNamespace string
+	DiscoveryFile             string
+
+	PolicyFiles []string
 
 	StdOut io.Writer
 	StdErr io.Writer
@@ -70,8 +86,28 @@ func NewCommandStartServer(ctx context.Context, defaults *ServerOptions) *cobra.
 	flags := cmd.Flags()
 	o.RecommendedOptions.AddFlags(flags)
 	flags.DurationVar(&o.ResyncPeriod, "resync-period", 0, "Informer resync period (0 = no periodic resync)")
+	flags.DurationVar(&o.MaxStaleness, "max-staleness", indexer.DefaultMaxStaleness,
+		"Maximum delay before informer events are applied to the snapshot under continuous churn (0 = no bound)")
+	flags.BoolVar(&o.DisableRuntimeIndex, "disable-runtime-index", false,
+		"Do not watch pods and workloads; includePods queries return no runtime chain")
+	flags.StringSliceVar(&o.OwnerKinds, "owner-kinds", nil,
+		"Owner kinds beyond built-in workloads to follow in runtime chains, as Kind.group globs (e.g. Rollout.argoproj.io,*.kubevirt.io)")
 	flags.BoolVar(&o.EnforceCallerScope, "enforce-caller-scope", false,
 		"Restrict query results to RBAC objects the caller has permission to list")
+	flags.IntVar(&o.SnapshotHistorySize, "snapshot-history-size", 0,
+		"Number of past snapshots retained for asOf queries (0 = history disabled)")
+	flags.DurationVar(&o.SnapshotHistoryInterval, "snapshot-history-interval", time.Minute,
+		"Minimum interval between retained snapshots; newer rebuilds replace the latest entry")
+	flags.StringVar(&o.SnapshotHistoryDir, "snapshot-history-dir", "",
+		"Directory to persist retained snapshots across restarts (empty = in memory only)")
+	flags.StringSliceVar(&o.Manifests, "manifests", nil,
+		"Build the snapshot from these manifest files or directories instead of cluster informers")
+	flags.StringVar(&o.ManifestsDefaultNamespace, "manifests-default-namespace", "default",
+		"Namespace assigned to namespaced manifests that do not set one")
+	flags.StringVar(&o.DiscoveryFile, "discovery-file", "",
+		"APIResourceList documents used as the discovery cache with --manifests")
+	flags.StringSliceVar(&o.PolicyFiles, "policy-file", nil,
+		"Policy files evaluated on every snapshot rebuild and exported as metrics (repeatable)")
 
 	return cmd
 }
@@ -82,9 +118,36 @@ func (o *ServerOptions) Complete() error {
 	return nil
 }
 
-// Validate checks ServerOptions for consistency. Currently a no-op because
-// ResyncPeriod=0 is valid (disables resync) and EnforceCallerScope is a boolean.
+// Validate checks ServerOptions for consistency. ResyncPeriod=0 is valid
+// (disables resync) and EnforceCallerScope is a boolean.
 func (o *ServerOptions) Validate() error {
+	if o.MaxStaleness < 0 {
+		return fmt.Errorf("--max-staleness must not be negative, got %s", o.MaxStaleness)
+	}
+	if o.SnapshotHistorySize < 0 {
+		return fmt.Errorf("--snapshot-history-size must not be negative, got %d", o.SnapshotHistorySize)
+	}
+	if o.SnapshotHistoryInterval < 0 {
+		return fmt.Errorf("--snapshot-history-interval must not be negative, got %s", o.SnapshotHistoryInterval)
+	}
+	if o.SnapshotHistoryDir != "" && o.SnapshotHistorySize == 0 {
+		return errors.New("--snapshot-history-dir requires --snapshot-history-size > 0")
+	}
+	if o.DisableRuntimeIndex && len(o.Manifests) > 0 {
+		return errors.New("--disable-runtime-index cannot be used with --manifests")
+	}
+	if len(o.OwnerKinds) > 0 && (o.DisableRuntimeIndex || len(o.Manifests) > 0) {
+		return errors.New("--owner-kinds cannot be used with --disable-runtime-index or --manifests")
+	}
+	for _, pattern := range o.OwnerKinds {
+		if err := indexer.OwnerKindPattern(pattern); err != nil {
+			return fmt.Errorf("--owner-kinds: %w", err)
+		}
+	}
+	if o.DiscoveryFile != "" && len(o.Manifests) == 0 {
+		return errors.New("--discovery-file requires --manifests")
+	}
+
 	return nil
 }
 
@@ -104,13 +167,33 @@ func (o *ServerOptions) Run(ctx context.Context) error {
 	serverConfig.OpenAPIV3Config.Info.Title = "RbacGraph"
 	serverConfig.OpenAPIV3Config.Info.Version = v1alpha1.Version
 
-	clientset, err := buildClientset(o.RecommendedOptions.CoreAPI.CoreAPIKubeconfigPath)
+	indexer.RegisterMetrics()
+	engine.RegisterMetrics()
+	eng := engine.New()
+	idx, err := o.buildIndexer()
 	if err != nil {
-		return fmt.Errorf("build kubernetes clientset: %w", err)
+		return err
+	}
+	if o.SnapshotHistorySize > 0 {
+		history, err := indexer.NewHistory(o.SnapshotHistorySize, o.SnapshotHistoryInterval, o.SnapshotHistoryDir)
+		if err != nil {
+			return fmt.Errorf("create snapshot history: %w", err)
+		}
+		idx.SetHistory(history)
+	}
+	if len(o.PolicyFiles) > 0 {
+		policies, err := policy.LoadFiles(o.PolicyFiles...)
+		if err != nil {
+			return fmt.Errorf("load policies: %w", err)
+		}
+		monitor := policy.NewMonitor(eng, policies, idx.DiscoveryCache)
+		idx.AddRebuildHandler(monitor.Notify)
+		if idx.IsReady() {
+			// Offline indexers build their only snapshot before handlers exist.
+			monitor.Notify(idx.Snapshot())
+		}
+		go monitor.Run(ctx)
 	}
-
-	eng := engine.New()
-	idx := indexer.New(clientset, o.ResyncPeriod)
 
 	var resolver authz.ScopeResolver
 	if o.EnforceCallerScope {
@@ -133,11 +216,47 @@ func (o *ServerOptions) Run(ctx context.Context) error {
 	return rbacGraphServer.GenericAPIServer.PrepareRun().RunWithContext(ctx)
 }
 
-func buildClientset(kubeconfig string) (kubernetes.Interface, error) {
-	cfg, err := kube.ClientConfig(kubeconfig)
+// buildIndexer returns an informer-backed indexer, or an offline one over
+// the --manifests objects.
+func (o *ServerOptions) buildIndexer() (*indexer.Indexer, error) {
+	if len(o.Manifests) == 0 {
+		cfg, err := kube.ClientConfig(o.RecommendedOptions.CoreAPI.CoreAPIKubeconfigPath)
+		if err != nil {
+			return nil, fmt.Errorf("build client config: %w", err)
+		}
+		clientset, err := kubernetes.NewForConfig(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("build kubernetes clientset: %w", err)
+		}
+
+		var opts []indexer.Option
+		if o.DisableRuntimeIndex {
+			opts = append(opts, indexer.WithoutRuntimeIndex())
+		}
+		if len(o.OwnerKinds) > 0 {
+			metadataClient, err := metadata.NewForConfig(cfg)
+			if err != nil {
+				return nil, fmt.Errorf("build metadata client: %w", err)
+			}
+			opts = append(opts, indexer.WithOwnerKinds(metadataClient, o.OwnerKinds...))
+		}
+		idx := indexer.New(clientset, o.ResyncPeriod, opts...)
+		idx.SetMaxStaleness(o.MaxStaleness)
+
+		return idx, nil
+	}
+
+	source, err := indexer.LoadManifests(o.ManifestsDefaultNamespace, o.Manifests...)
 	if err != nil {
-		return nil, fmt.Errorf("build client config: %w", err)
+		return nil, fmt.Errorf("load manifests: %w", err)
+	}
+	var discoveryCache *indexer.APIDiscoveryCache
+	if o.DiscoveryFile != "" {
+		discoveryCache, err = indexer.LoadDiscoveryCache(o.DiscoveryFile)
+		if err != nil {
+			return nil, fmt.Errorf("load discovery file: %w", err)
+		}
 	}
 
-	return kubernetes.NewForConfig(cfg)
+	return indexer.NewOffline(source, discoveryCache), nil
 }
@@ -19,7 +19,9 @@ const (
 	idxRoleBindings        = 3
 	idxPods                = 4
 	idxDeployments         = 5
-	numChecks              = 6
+	idxServiceAccounts     = 6
+	idxNamespaces          = 7
+	numChecks              = 8
 )
 
 type resourceCheck struct {
@@ -34,6 +36,8 @@ var knownChecks = []resourceCheck{
 	{resource: "rolebindings", apiGroup: "rbac.authorization.k8s.io"},
 	{resource: "pods", apiGroup: ""},
 	{resource: "deployments", apiGroup: "apps"},
+	{resource: "serviceaccounts", apiGroup: ""},
+	{resource: "namespaces", apiGroup: ""},
 }
 
 type grantSet struct {
@@ -119,6 +123,7 @@ func (lr *LocalResolver) buildScope(gs *grantSet, namespacesToCheck []string) *A
 
 	scope.CanListClusterRoles = gs.clusterWide[idxClusterRoles]
 	scope.CanListClusterRoleBindings = gs.clusterWide[idxClusterRoleBindings]
+	scope.CanListNamespaces = gs.clusterWide[idxNamespaces]
 
 	scope.CanListRoles = gs.clusterWide[idxRoles]
 	if !scope.CanListRoles {
@@ -140,6 +145,11 @@ func (lr *LocalResolver) buildScope(gs *grantSet, namespacesToCheck []string) *A
 		scope.AllowedWorkloadNamespaces = filterNamespaces(gs, idxDeployments, namespacesToCheck)
 	}
 
+	scope.CanListServiceAccounts = gs.clusterWide[idxServiceAccounts]
+	if !scope.CanListServiceAccounts {
+		scope.AllowedServiceAccountNamespaces = filterNamespaces(gs, idxServiceAccounts, namespacesToCheck)
+	}
+
 	return scope
 }
 
@@ -14,8 +14,10 @@ import (
 // helper to build a minimal snapshot with RBAC data.
 func newTestSnapshot() *indexer.Snapshot {
 	return &indexer.Snapshot{
-		RolesByID:         make(map[indexer.RoleID]*indexer.RoleRecord),
-		BindingsByRoleRef: make(map[indexer.RoleRefKey][]*indexer.BindingRecord),
+		RBACIndex: indexer.RBACIndex{
+			RolesByID:         make(map[indexer.RoleID]*indexer.RoleRecord),
+			BindingsByRoleRef: make(map[indexer.RoleRefKey][]*indexer.BindingRecord),
+		},
 	}
 }
 
@@ -58,6 +60,9 @@ func TestLocalResolver_ClusterAdmin(t *testing.T) {
 	if !scope.IsUnrestricted() {
 		t.Error("expected unrestricted scope for cluster-admin")
 	}
+	if !scope.CanListNamespaces {
+		t.Error("expected cluster-admin to list namespaces")
+	}
 }
 
 func TestLocalResolver_NoAccess(t *testing.T) {
@@ -97,13 +102,13 @@ func TestLocalResolver_NoAccess(t *testing.T) {
 func TestLocalResolver_NamespaceScoped(t *testing.T) {
 	snap := newTestSnapshot()
 
-	// Role in ns-a granting list on roles, rolebindings, pods, deployments.
+	// Role in ns-a granting list on roles, rolebindings, pods, serviceaccounts, deployments.
 	roleID := indexer.RecID("Role", "ns-a", "ns-reader")
 	snap.RolesByID[roleID] = &indexer.RoleRecord{
 		UID: types.UID("r-1"), Kind: "Role", Namespace: "ns-a", Name: "ns-reader",
 		Rules: []rbacv1.PolicyRule{
 			{Verbs: []string{"list"}, APIGroups: []string{"rbac.authorization.k8s.io"}, Resources: []string{"roles", "rolebindings"}},
-			{Verbs: []string{"list"}, APIGroups: []string{""}, Resources: []string{"pods"}},
+			{Verbs: []string{"list"}, APIGroups: []string{""}, Resources: []string{"pods", "serviceaccounts"}},
 			{Verbs: []string{"list"}, APIGroups: []string{"apps"}, Resources: []string{"deployments"}},
 		},
 	}
@@ -143,11 +148,19 @@ func TestLocalResolver_NamespaceScoped(t *testing.T) {
 	if _, ok := scope.AllowedWorkloadNamespaces["ns-a"]; !ok {
 		t.Error("expected ns-a in AllowedWorkloadNamespaces")
 	}
+	if _, ok := scope.AllowedServiceAccountNamespaces["ns-a"]; !ok || scope.CanListServiceAccounts {
+		t.Error("expected ns-a in AllowedServiceAccountNamespaces")
+	}
 
 	// ns-b should not be allowed.
 	if _, ok := scope.AllowedRoleNamespaces["ns-b"]; ok {
 		t.Error("expected ns-b NOT in AllowedRoleNamespaces")
 	}
+
+	// Namespace objects are only visible where roles or bindings are.
+	if scope.CanListNamespaces || !scope.AllowNamespace("ns-a") || scope.AllowNamespace("ns-b") {
+		t.Error("expected only ns-a namespace to be visible")
+	}
 }
 
 func TestLocalResolver_GroupMatching(t *testing.T) {
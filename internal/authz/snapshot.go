@@ -0,0 +1,80 @@
+package authz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"k8s.io/apiserver/pkg/endpoints/request"
+
+	"k8s-role-graph/internal/indexer"
+)
+
+// ScopeSnapshot resolves the access scope of the caller found in ctx and
+// returns the snapshot narrowed to what that caller may list, together with
+// any scope warnings. A nil resolver (--enforce-caller-scope disabled)
+// returns the snapshot unchanged.
+func ScopeSnapshot(ctx context.Context, resolver ScopeResolver, snapshot *indexer.Snapshot, requestedNamespaces []string) (*indexer.Snapshot, []string, error) {
+	if resolver == nil {
+		return snapshot, nil, nil
+	}
+	userInfo, ok := request.UserFrom(ctx)
+	if !ok {
+		return nil, nil, errors.New("cannot enforce caller scope: no user info in request context")
+	}
+	namespacesToCheck := collectNamespaces(snapshot, requestedNamespaces)
+	scope, err := resolver.Resolve(ctx, userInfo, namespacesToCheck)
+	if err != nil {
+		return nil, nil, fmt.Errorf("resolve caller access scope: %w", err)
+	}
+
+	return indexer.Scoped(snapshot, scope), scope.Warnings, nil
+}
+
+// collectNamespaces extracts unique namespaces from the snapshot,
+// optionally intersected with the namespaces requested by the caller.
+func collectNamespaces(s *indexer.Snapshot, requested []string) []string {
+	nsSet := make(map[string]struct{})
+	addNS := func(ns string) {
+		if ns != "" {
+			nsSet[ns] = struct{}{}
+		}
+	}
+	for _, rec := range s.RolesByID {
+		addNS(rec.Namespace)
+	}
+	for _, bindings := range s.BindingsByRoleRef {
+		for _, b := range bindings {
+			addNS(b.Namespace)
+		}
+	}
+	for key := range s.PodsByServiceAccount {
+		addNS(key.Namespace)
+	}
+	for key := range s.ServiceAccounts {
+		addNS(key.Namespace)
+	}
+	for _, w := range s.WorkloadsByUID {
+		addNS(w.Namespace)
+	}
+
+	// If the caller specified explicit namespaces, intersect.
+	if len(requested) > 0 {
+		allowed := make(map[string]struct{}, len(requested))
+		for _, ns := range requested {
+			allowed[ns] = struct{}{}
+		}
+		for ns := range nsSet {
+			if _, ok := allowed[ns]; !ok {
+				delete(nsSet, ns)
+			}
+		}
+	}
+
+	out := make([]string, 0, len(nsSet))
+	for ns := range nsSet {
+		out = append(out, ns)
+	}
+
+	return out
+}
@@ -0,0 +1,84 @@
+package authz
+
+import (
+	"context"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	"k8s-role-graph/internal/indexer"
+)
+
+func TestScopeSnapshot_NilResolver(t *testing.T) {
+	snap := newTestSnapshot()
+
+	scoped, warnings, err := ScopeSnapshot(context.Background(), nil, snap, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if scoped != snap {
+		t.Error("expected snapshot to be returned unchanged")
+	}
+	if len(warnings) != 0 {
+		t.Errorf("expected no warnings, got %v", warnings)
+	}
+}
+
+func TestScopeSnapshot_NoUserInfo(t *testing.T) {
+	lr := NewLocalResolver(snapshotFn(newTestSnapshot()))
+
+	if _, _, err := ScopeSnapshot(context.Background(), lr, newTestSnapshot(), nil); err == nil {
+		t.Fatal("expected error when no user info in context")
+	}
+}
+
+func TestCollectNamespaces(t *testing.T) {
+	snap := &indexer.Snapshot{
+		RBACIndex: indexer.RBACIndex{
+			RolesByID: map[indexer.RoleID]*indexer.RoleRecord{
+				"Role/ns-a/read": {Namespace: "ns-a", Name: "read"},
+				"Role/ns-b/edit": {Namespace: "ns-b", Name: "edit"},
+			},
+			BindingsByRoleRef:     make(map[indexer.RoleRefKey][]*indexer.BindingRecord),
+			AggregatedRoleSources: make(map[indexer.RoleID][]indexer.RoleID),
+		},
+		RuntimeIndex: indexer.RuntimeIndex{
+			PodsByServiceAccount: make(map[indexer.ServiceAccountKey][]*indexer.PodRecord),
+			WorkloadsByUID:       make(map[types.UID]*indexer.WorkloadRecord),
+		},
+	}
+
+	namespaces := collectNamespaces(snap, nil)
+	nsSet := make(map[string]struct{})
+	for _, ns := range namespaces {
+		nsSet[ns] = struct{}{}
+	}
+	if _, ok := nsSet["ns-a"]; !ok {
+		t.Error("expected ns-a in namespaces")
+	}
+	if _, ok := nsSet["ns-b"]; !ok {
+		t.Error("expected ns-b in namespaces")
+	}
+}
+
+func TestCollectNamespaces_WithFilter(t *testing.T) {
+	snap := &indexer.Snapshot{
+		RBACIndex: indexer.RBACIndex{
+			RolesByID: map[indexer.RoleID]*indexer.RoleRecord{
+				"Role/ns-a/read": {Namespace: "ns-a", Name: "read"},
+				"Role/ns-b/edit": {Namespace: "ns-b", Name: "edit"},
+			},
+			BindingsByRoleRef:     make(map[indexer.RoleRefKey][]*indexer.BindingRecord),
+			AggregatedRoleSources: make(map[indexer.RoleID][]indexer.RoleID),
+		},
+		RuntimeIndex: indexer.RuntimeIndex{
+			PodsByServiceAccount: make(map[indexer.ServiceAccountKey][]*indexer.PodRecord),
+			WorkloadsByUID:       make(map[types.UID]*indexer.WorkloadRecord),
+		},
+	}
+
+	namespaces := collectNamespaces(snap, []string{"ns-a"})
+	if len(namespaces) != 1 || namespaces[0] != "ns-a" {
+		t.Errorf("expected [ns-a], got %v", namespaces)
+	}
+}
@@ -32,6 +32,13 @@ type AccessScope struct {
 	CanListWorkloads          bool
 	AllowedWorkloadNamespaces map[string]struct{}
 
+	CanListServiceAccounts          bool
+	AllowedServiceAccountNamespaces map[string]struct{}
+
+	// CanListNamespaces shows every Namespace object; without it only the
+	// namespaces whose roles or bindings are visible are shown.
+	CanListNamespaces bool
+
 	Warnings []string
 }
 
@@ -42,7 +49,8 @@ func (s *AccessScope) IsUnrestricted() bool {
 		s.CanListRoles && s.AllowedRoleNamespaces == nil &&
 		s.CanListRoleBindings && s.AllowedBindingNamespaces == nil &&
 		s.CanListPods && s.AllowedPodNamespaces == nil &&
-		s.CanListWorkloads && s.AllowedWorkloadNamespaces == nil
+		s.CanListWorkloads && s.AllowedWorkloadNamespaces == nil &&
+		s.CanListServiceAccounts && s.AllowedServiceAccountNamespaces == nil
 }
 
 func (s *AccessScope) AllowRole(namespace string) bool {
@@ -61,6 +69,14 @@ func (s *AccessScope) AllowWorkload(namespace string) bool {
 	return allowNS(namespace, false, s.CanListWorkloads, s.AllowedWorkloadNamespaces)
 }
 
+func (s *AccessScope) AllowServiceAccount(namespace string) bool {
+	return allowNS(namespace, false, s.CanListServiceAccounts, s.AllowedServiceAccountNamespaces)
+}
+
+func (s *AccessScope) AllowNamespace(name string) bool {
+	return s.CanListNamespaces || s.AllowRole(name) || s.AllowBinding(name)
+}
+
 // allowNS checks whether the caller may access a resource in the given namespace.
 // For cluster-scoped resources (ns==""), clusterWide controls access.
 // For namespaced resources, allNS grants unconditional access; otherwise the
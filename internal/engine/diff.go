@@ -0,0 +1,168 @@
+package engine
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"k8s-role-graph/internal/indexer"
+	api "k8s-role-graph/pkg/apis/rbacgraph"
+)
+
+// Diff runs the review query of spec against both snapshots and reports
+// what matches in only one of them. Roles, bindings, subjects and the
+// subjects of each binding are compared by identity; resource map rows by
+// apiGroup, resource, verb and namespace.
+func (e *Engine) Diff(from, to *indexer.Snapshot, spec api.RoleGraphDiffSpec, discovery *indexer.APIDiscoveryCache) api.RoleGraphDiffStatus {
+	reviewSpec := spec.ReviewSpec()
+	before := collectDiffSide(e.Query(from, reviewSpec, discovery))
+	after := collectDiffSide(e.Query(to, reviewSpec, discovery))
+
+	status := api.RoleGraphDiffStatus{
+		From:    snapshotRef(from),
+		To:      snapshotRef(to),
+		Added:   before.missingFrom(after),
+		Removed: after.missingFrom(before),
+	}
+	seen := make(map[string]struct{})
+	for _, warnings := range [][]string{before.warnings, after.warnings} {
+		for _, warning := range warnings {
+			appendUniqueString(&status.Warnings, seen, warning)
+		}
+	}
+
+	return status
+}
+
+func snapshotRef(s *indexer.Snapshot) api.SnapshotRef {
+	ref := api.SnapshotRef{Generation: s.Generation}
+	if !s.BuiltAt.IsZero() {
+		builtAt := metav1.NewTime(s.BuiltAt)
+		ref.BuiltAt = &builtAt
+	}
+
+	return ref
+}
+
+// diffSide holds the objects matched in one snapshot, keyed for comparison.
+type diffSide struct {
+	roles       map[api.ObjectRef]struct{}
+	bindings    map[api.ObjectRef]struct{}
+	subjects    map[api.SubjectRef]struct{}
+	memberships map[api.BindingSubject]struct{}
+	permissions map[resourceMapKey]api.ResourceMapRow
+	warnings    []string
+}
+
+type resourceMapKey struct {
+	APIGroup  string
+	Resource  string
+	Verb      string
+	Namespace string
+}
+
+var diffNodeKinds = map[api.GraphNodeType]string{
+	api.GraphNodeTypeRole:               indexer.KindRole,
+	api.GraphNodeTypeClusterRole:        indexer.KindClusterRole,
+	api.GraphNodeTypeRoleBinding:        indexer.KindRoleBinding,
+	api.GraphNodeTypeClusterRoleBinding: indexer.KindClusterRoleBinding,
+	api.GraphNodeTypeUser:               api.SubjectKindUser,
+	api.GraphNodeTypeGroup:              api.SubjectKindGroup,
+	api.GraphNodeTypeServiceAccount:     api.SubjectKindServiceAccount,
+}
+
+func collectDiffSide(status api.RoleGraphReviewStatus) *diffSide {
+	side := &diffSide{
+		roles:       make(map[api.ObjectRef]struct{}),
+		bindings:    make(map[api.ObjectRef]struct{}),
+		subjects:    make(map[api.SubjectRef]struct{}),
+		memberships: make(map[api.BindingSubject]struct{}),
+		permissions: make(map[resourceMapKey]api.ResourceMapRow, len(status.ResourceMap)),
+		warnings:    status.Warnings,
+	}
+	bindingRefs := make(map[string]api.ObjectRef)
+	subjectRefs := make(map[string]api.SubjectRef)
+	for _, node := range status.Graph.Nodes {
+		kind, ok := diffNodeKinds[node.Type]
+		if !ok {
+			continue
+		}
+		switch node.Type {
+		case api.GraphNodeTypeRole, api.GraphNodeTypeClusterRole:
+			side.roles[api.ObjectRef{Kind: kind, Namespace: node.Namespace, Name: node.Name}] = struct{}{}
+		case api.GraphNodeTypeRoleBinding, api.GraphNodeTypeClusterRoleBinding:
+			ref := api.ObjectRef{Kind: kind, Namespace: node.Namespace, Name: node.Name}
+			side.bindings[ref] = struct{}{}
+			bindingRefs[node.ID] = ref
+		default:
+			ref := api.SubjectRef{Kind: kind, Namespace: node.Namespace, Name: node.Name}
+			side.subjects[ref] = struct{}{}
+			subjectRefs[node.ID] = ref
+		}
+	}
+	for _, edge := range status.Graph.Edges {
+		if edge.Type != api.GraphEdgeTypeSubjects {
+			continue
+		}
+		binding, okBinding := bindingRefs[edge.From]
+		subject, okSubject := subjectRefs[edge.To]
+		if okBinding && okSubject {
+			side.memberships[api.BindingSubject{Binding: binding, Subject: subject}] = struct{}{}
+		}
+	}
+	for _, row := range status.ResourceMap {
+		key := resourceMapKey{APIGroup: row.APIGroup, Resource: row.Resource, Verb: row.Verb, Namespace: row.Namespace}
+		side.permissions[key] = row
+	}
+
+	return side
+}
+
+// missingFrom returns the objects in other that are not in side.
+func (side *diffSide) missingFrom(other *diffSide) api.RoleGraphChanges {
+	changes := api.RoleGraphChanges{
+		Roles:       missingObjectRefs(side.roles, other.roles),
+		Bindings:    missingObjectRefs(side.bindings, other.bindings),
+		Subjects:    []api.SubjectRef{},
+		Permissions: []api.ResourceMapRow{},
+	}
+	for ref := range other.subjects {
+		if _, ok := side.subjects[ref]; !ok {
+			changes.Subjects = append(changes.Subjects, ref)
+		}
+	}
+	sortByQuad(changes.Subjects, func(ref *api.SubjectRef) (string, string, string, string) {
+		return ref.Kind, ref.Namespace, ref.Name, ""
+	})
+	changes.BindingSubjects = []api.BindingSubject{}
+	for membership := range other.memberships {
+		if _, ok := side.memberships[membership]; !ok {
+			changes.BindingSubjects = append(changes.BindingSubjects, membership)
+		}
+	}
+	sortByQuad(changes.BindingSubjects, func(m *api.BindingSubject) (string, string, string, string) {
+		return m.Binding.Namespace, m.Binding.Kind + "/" + m.Binding.Name, m.Subject.Kind, m.Subject.Namespace + "/" + m.Subject.Name
+	})
+	for key, row := range other.permissions {
+		if _, ok := side.permissions[key]; !ok {
+			changes.Permissions = append(changes.Permissions, row)
+		}
+	}
+	sortByQuad(changes.Permissions, func(row *api.ResourceMapRow) (string, string, string, string) {
+		return row.APIGroup, row.Resource, row.Verb, row.Namespace
+	})
+
+	return changes
+}
+
+func missingObjectRefs(have, other map[api.ObjectRef]struct{}) []api.ObjectRef {
+	out := []api.ObjectRef{}
+	for ref := range other {
+		if _, ok := have[ref]; !ok {
+			out = append(out, ref)
+		}
+	}
+	sortByQuad(out, func(ref *api.ObjectRef) (string, string, string, string) {
+		return ref.Kind, ref.Namespace, ref.Name, ""
+	})
+
+	return out
+}
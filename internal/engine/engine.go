@@ -3,13 +3,22 @@ package engine
 import (
 	"k8s-role-graph/internal/indexer"
 	"k8s-role-graph/internal/matcher"
+	"k8s-role-graph/internal/risk"
 	api "k8s-role-graph/pkg/apis/rbacgraph"
 )
 
-type Engine struct{}
+type Engine struct {
+	riskCatalog *risk.Catalog
+}
 
 func New() *Engine {
-	return &Engine{}
+	return NewWithRiskCatalog(risk.DefaultCatalog())
+}
+
+// NewWithRiskCatalog returns an engine that scores graph nodes with the given
+// catalog. A nil catalog disables risk scoring.
+func NewWithRiskCatalog(catalog *risk.Catalog) *Engine {
+	return &Engine{riskCatalog: catalog}
 }
 
 type queryContext struct {
@@ -23,19 +32,32 @@ type queryContext struct {
 	roleSeen        map[indexer.RoleID]struct{}
 	bindingSeen     map[string]struct{}
 	subjectSeen     map[string]struct{}
+	subjectKeys     map[string]indexer.SubjectKey
 	resourceRows    map[resourceRowKey]*resourceAccumulator
-	namespaceFilter map[string]struct{}
+	namespaceFilter *namespaceFilter
 	namespaceStrict bool
+	roleFilter      *metadataFilter
+	bindingFilter   *metadataFilter
+	subjectFilter   *subjectFilter
 	saSubjects      map[string]subjectServiceAccount
+	groupSubjects   map[string]implicitGroupSubject
 	warningSeen     map[string]struct{}
 	knownGapSeen    map[string]struct{}
 	podSeen         map[string]struct{}
 	workloadSeen    map[string]struct{}
+	riskCatalog     *risk.Catalog
+	roleRisk        map[indexer.RoleID][]api.RiskReason
 }
 
-func newQueryContext(snapshot *indexer.Snapshot, spec api.RoleGraphReviewSpec) *queryContext {
+func newQueryContext(snapshot *indexer.Snapshot, spec api.RoleGraphReviewSpec, riskCatalog *risk.Catalog) *queryContext {
 	normalizedSpec := spec
 	normalizedSpec.EnsureDefaults()
+	if normalizedSpec.Object != nil {
+		subjects := normalizedSpec.Selector.Subjects
+		normalizedSpec.Selector = normalizedSpec.Object.Selector()
+		normalizedSpec.Selector.Subjects = subjects
+		normalizedSpec.MatchMode = api.MatchModeAll
+	}
 
 	status := api.RoleGraphReviewStatus{
 		Graph: api.Graph{
@@ -54,6 +76,27 @@ func newQueryContext(snapshot *indexer.Snapshot, spec api.RoleGraphReviewSpec) *
 	for _, warning := range normalizedSpec.NormalizeRuntimeFlags() {
 		appendUniqueString(&status.Warnings, warningSeen, warning)
 	}
+	if normalizedSpec.IncludePods && snapshot.RuntimeUnavailable != "" {
+		appendUniqueString(&status.Warnings, warningSeen, snapshot.RuntimeUnavailable)
+	}
+
+	roleFilter, err := makeMetadataFilter(normalizedSpec.RoleLabelSelector, normalizedSpec.RoleNamePatterns)
+	if err != nil {
+		appendUniqueString(&status.Warnings, warningSeen, "roleLabelSelector: "+err.Error())
+	}
+	bindingFilter, err := makeMetadataFilter(normalizedSpec.BindingLabelSelector, normalizedSpec.BindingNamePatterns)
+	if err != nil {
+		appendUniqueString(&status.Warnings, warningSeen, "bindingLabelSelector: "+err.Error())
+	}
+
+	subjectFilter, err := makeSubjectFilter(normalizedSpec.Selector.Subjects)
+	if err != nil {
+		appendUniqueString(&status.Warnings, warningSeen, "selector.subjects: "+err.Error())
+	}
+	nsFilter, err := makeNamespaceFilter(normalizedSpec.NamespaceScope, snapshot.Namespaces)
+	if err != nil {
+		appendUniqueString(&status.Warnings, warningSeen, "namespaceScope: "+err.Error())
+	}
 
 	knownGapSeen := make(map[string]struct{}, len(status.KnownGaps))
 	for _, knownGap := range status.KnownGaps {
@@ -63,7 +106,7 @@ func newQueryContext(snapshot *indexer.Snapshot, spec api.RoleGraphReviewSpec) *
 		appendUniqueString(
 			&status.KnownGaps,
 			knownGapSeen,
-			"runtime chain is currently limited to serviceAccount subjects; user/group subject to workload mapping is not included",
+			"runtime chain covers serviceAccount subjects and implicit serviceaccount groups; user and other group subject to workload mapping is not included",
 		)
 	}
 
@@ -77,14 +120,21 @@ func newQueryContext(snapshot *indexer.Snapshot, spec api.RoleGraphReviewSpec) *
 		roleSeen:        make(map[indexer.RoleID]struct{}),
 		bindingSeen:     make(map[string]struct{}),
 		subjectSeen:     make(map[string]struct{}),
+		subjectKeys:     make(map[string]indexer.SubjectKey),
 		resourceRows:    make(map[resourceRowKey]*resourceAccumulator),
-		namespaceFilter: makeNamespaceFilter(normalizedSpec.NamespaceScope.Namespaces),
+		namespaceFilter: nsFilter,
 		namespaceStrict: normalizedSpec.NamespaceScope.Strict,
+		roleFilter:      roleFilter,
+		bindingFilter:   bindingFilter,
+		subjectFilter:   subjectFilter,
 		saSubjects:      make(map[string]subjectServiceAccount),
+		groupSubjects:   make(map[string]implicitGroupSubject),
 		warningSeen:     warningSeen,
 		knownGapSeen:    knownGapSeen,
 		podSeen:         make(map[string]struct{}),
 		workloadSeen:    make(map[string]struct{}),
+		riskCatalog:     riskCatalog,
+		roleRisk:        make(map[indexer.RoleID][]api.RiskReason),
 	}
 }
 
@@ -95,29 +145,39 @@ func (qc *queryContext) finalize() api.RoleGraphReviewStatus {
 	qc.status.MatchedPods = len(qc.podSeen)
 	qc.status.MatchedWorkloads = len(qc.workloadSeen)
 	qc.status.ResourceMap = collapseResourceRows(qc.resourceRows)
+	qc.propagateRisk()
 	sortNodes(qc.status.Graph.Nodes)
+	qc.annotateNamespaceStates()
+	qc.describeServiceAccounts()
+	if qc.spec.SortByRiskScore {
+		sortNodesByRisk(qc.status.Graph.Nodes)
+	}
 	sortEdges(qc.status.Graph.Edges)
 
 	return qc.status
 }
 
 func (e *Engine) Query(snapshot *indexer.Snapshot, spec api.RoleGraphReviewSpec, discovery *indexer.APIDiscoveryCache) api.RoleGraphReviewStatus {
-	qc := newQueryContext(snapshot, spec)
+	qc := newQueryContext(snapshot, spec, e.riskCatalog)
 	qc.discovery = discovery
 
-	roleIDs := snapshot.CandidateRoleIDs(qc.spec.Selector, qc.spec.WildcardMode)
+	roleIDs := qc.filterCandidateRoles(snapshot.CandidateRoleIDs(qc.spec.Selector, qc.spec.WildcardMode))
 	if len(roleIDs) == 0 {
 		return qc.status
 	}
 
 	qc.buildRBACGraph(roleIDs)
 	qc.expandRuntimeChain()
+	qc.expandEscalationPaths()
 
 	return qc.finalize()
 }
 
-func matchRole(role *indexer.RoleRecord, spec api.RoleGraphReviewSpec) []api.RuleRef {
+// matchRole returns the rule refs of role that match the selector and the
+// indexes of the rules they come from.
+func matchRole(role *indexer.RoleRecord, spec api.RoleGraphReviewSpec) ([]api.RuleRef, []int) {
 	refs := make([]api.RuleRef, 0)
+	var ruleIndexes []int
 	for idx, rule := range role.Rules {
 		result := matcher.MatchRule(matcher.MatchInput{
 			Rule:         rule,
@@ -126,11 +186,14 @@ func matchRole(role *indexer.RoleRecord, spec api.RoleGraphReviewSpec) []api.Rul
 			WildcardMode: spec.WildcardMode,
 			SourceUID:    string(role.UID),
 			RuleIndex:    idx,
+			// Object reviews must honour resourceNames restrictions exactly.
+			ExactResourceNames: spec.Object != nil,
 		})
 		if !result.Matched {
 			continue
 		}
 		refs = append(refs, result.RuleRefs...)
+		ruleIndexes = append(ruleIndexes, idx)
 	}
 	if !spec.IncludeRuleMetadata {
 		for i := range refs {
@@ -139,5 +202,5 @@ func matchRole(role *indexer.RoleRecord, spec api.RoleGraphReviewSpec) []api.Rul
 		}
 	}
 
-	return refs
+	return refs, ruleIndexes
 }
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
 	"slices"
@@ -15,21 +16,26 @@ import (
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/component-base/metrics/testutil"
 
+	"k8s-role-graph/internal/authz"
 	"k8s-role-graph/internal/indexer"
+	"k8s-role-graph/internal/risk"
 	api "k8s-role-graph/pkg/apis/rbacgraph"
 	"k8s-role-graph/pkg/apis/rbacgraph/v1alpha1"
 )
 
 func TestQuery_BuildsGraph(t *testing.T) {
 	snapshot := &indexer.Snapshot{
-		BuiltAt:           time.Now(),
-		RolesByID:         map[indexer.RoleID]*indexer.RoleRecord{},
-		BindingsByRoleRef: map[indexer.RoleRefKey][]*indexer.BindingRecord{},
-		RoleIDsByVerb:     map[string]map[indexer.RoleID]struct{}{},
-		RoleIDsByResource: map[string]map[indexer.RoleID]struct{}{},
-		RoleIDsByAPIGroup: map[string]map[indexer.RoleID]struct{}{},
-		AllRoleIDs:        []indexer.RoleID{},
+		BuiltAt: time.Now(),
+		RBACIndex: indexer.RBACIndex{
+			RolesByID:         map[indexer.RoleID]*indexer.RoleRecord{},
+			BindingsByRoleRef: map[indexer.RoleRefKey][]*indexer.BindingRecord{},
+			RoleIDsByVerb:     map[string]map[indexer.RoleID]struct{}{},
+			RoleIDsByResource: map[string]map[indexer.RoleID]struct{}{},
+			RoleIDsByAPIGroup: map[string]map[indexer.RoleID]struct{}{},
+			AllRoleIDs:        []indexer.RoleID{},
+		},
 	}
 
 	role := &indexer.RoleRecord{
@@ -93,14 +99,16 @@ func TestQuery_BuildsGraph(t *testing.T) {
 
 func TestQuery_AnnotatesAggregatedClusterRoles(t *testing.T) {
 	snapshot := &indexer.Snapshot{
-		BuiltAt:               time.Now(),
-		RolesByID:             map[indexer.RoleID]*indexer.RoleRecord{},
-		BindingsByRoleRef:     map[indexer.RoleRefKey][]*indexer.BindingRecord{},
-		AggregatedRoleSources: map[indexer.RoleID][]indexer.RoleID{},
-		RoleIDsByVerb:         map[string]map[indexer.RoleID]struct{}{},
-		RoleIDsByResource:     map[string]map[indexer.RoleID]struct{}{},
-		RoleIDsByAPIGroup:     map[string]map[indexer.RoleID]struct{}{},
-		AllRoleIDs:            []indexer.RoleID{},
+		BuiltAt: time.Now(),
+		RBACIndex: indexer.RBACIndex{
+			RolesByID:             map[indexer.RoleID]*indexer.RoleRecord{},
+			BindingsByRoleRef:     map[indexer.RoleRefKey][]*indexer.BindingRecord{},
+			AggregatedRoleSources: map[indexer.RoleID][]indexer.RoleID{},
+			RoleIDsByVerb:         map[string]map[indexer.RoleID]struct{}{},
+			RoleIDsByResource:     map[string]map[indexer.RoleID]struct{}{},
+			RoleIDsByAPIGroup:     map[string]map[indexer.RoleID]struct{}{},
+			AllRoleIDs:            []indexer.RoleID{},
+		},
 	}
 
 	sourceRole := &indexer.RoleRecord{
@@ -176,6 +184,123 @@ func TestQuery_AnnotatesAggregatedClusterRoles(t *testing.T) {
 	}
 }
 
+func TestQuery_FollowsAggregationChains(t *testing.T) {
+	rule := rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"pods/exec"}, Verbs: []string{"create"}}
+	adminID := indexer.RoleID("clusterrole:admin")
+	editID := indexer.RoleID("clusterrole:edit")
+	sourceID := indexer.RoleID("clusterrole:pods-exec")
+	snapshot := &indexer.Snapshot{
+		BuiltAt: time.Now(),
+		RBACIndex: indexer.RBACIndex{
+			RolesByID: map[indexer.RoleID]*indexer.RoleRecord{
+				adminID:  {UID: "admin", Kind: indexer.KindClusterRole, Name: "admin", Rules: []rbacv1.PolicyRule{rule}},
+				editID:   {UID: "edit", Kind: indexer.KindClusterRole, Name: "edit", Rules: []rbacv1.PolicyRule{rule}},
+				sourceID: {UID: "src", Kind: indexer.KindClusterRole, Name: "pods-exec", Rules: []rbacv1.PolicyRule{rule}},
+			},
+			BindingsByRoleRef: map[indexer.RoleRefKey][]*indexer.BindingRecord{},
+			// edit also lists admin to check that cycles are walked once.
+			AggregatedRoleSources: map[indexer.RoleID][]indexer.RoleID{
+				adminID: {editID},
+				editID:  {adminID, sourceID},
+			},
+			AggregationMatches: map[indexer.RoleID][]indexer.AggregationMatch{
+				adminID: {{Source: editID, Selector: "aggregate-to-admin=true", MatchedLabels: map[string]string{"aggregate-to-admin": "true"}}},
+				editID: {
+					{Source: adminID, SelectorIndex: 1, Selector: "loop=admin"},
+					{Source: sourceID, Selector: "aggregate-to-edit=true", MatchedLabels: map[string]string{"aggregate-to-edit": "true"}},
+				},
+			},
+			RoleIDsByVerb:     map[string]map[indexer.RoleID]struct{}{"create": {adminID: {}}},
+			RoleIDsByResource: map[string]map[indexer.RoleID]struct{}{"pods/exec": {adminID: {}}},
+			RoleIDsByAPIGroup: map[string]map[indexer.RoleID]struct{}{"": {adminID: {}}},
+			AllRoleIDs:        []indexer.RoleID{adminID},
+		},
+	}
+
+	status := New().Query(snapshot, api.RoleGraphReviewSpec{
+		Selector:  api.Selector{APIGroups: []string{""}, Resources: []string{"pods/exec"}, Verbs: []string{"create"}},
+		MatchMode: api.MatchModeAny,
+	}, nil)
+
+	edges := map[string]api.GraphEdge{}
+	for _, edge := range status.Graph.Edges {
+		if edge.Type == api.GraphEdgeTypeAggregates {
+			edges[edge.From+" -> "+edge.To] = edge
+		}
+	}
+	if len(edges) != 3 {
+		t.Fatalf("expected 3 aggregates edges, got %v", edges)
+	}
+	toAdmin := edges["role:"+string(editID)+" -> role:"+string(adminID)]
+	if len(toAdmin.AggregationMatches) != 1 || toAdmin.AggregationMatches[0].Selector != "aggregate-to-admin=true" {
+		t.Fatalf("expected edit -> admin edge with its selector, got %#v", toAdmin)
+	}
+	toEdit := edges["role:"+string(sourceID)+" -> role:"+string(editID)]
+	if len(toEdit.AggregationMatches) != 1 || toEdit.AggregationMatches[0].MatchedLabels["aggregate-to-edit"] != "true" {
+		t.Fatalf("expected pods-exec -> edit edge with matched labels, got %#v", toEdit)
+	}
+	loop := edges["role:"+string(adminID)+" -> role:"+string(editID)]
+	if len(loop.AggregationMatches) != 1 || loop.AggregationMatches[0].SelectorIndex != 1 {
+		t.Fatalf("expected admin -> edit cycle edge, got %#v", loop)
+	}
+}
+
+func TestQuery_IncludePolicyRules(t *testing.T) {
+	roleID := indexer.RoleID("role:team-a/deployer")
+	role := &indexer.RoleRecord{
+		UID:             "deployer",
+		ResourceVersion: "4711",
+		Kind:            indexer.KindRole,
+		Namespace:       "team-a",
+		Name:            "deployer",
+		Rules: []rbacv1.PolicyRule{
+			{APIGroups: []string{"apps"}, Resources: []string{"deployments"}, Verbs: []string{"get", "list"}},
+			{APIGroups: []string{""}, Resources: []string{"secrets"}, ResourceNames: []string{"registry"}, Verbs: []string{"get"}},
+			{APIGroups: []string{""}, Resources: []string{"configmaps"}, Verbs: []string{"get"}},
+		},
+		RuleCount: 3,
+	}
+	snapshot := &indexer.Snapshot{
+		BuiltAt: time.Now(),
+		RBACIndex: indexer.RBACIndex{
+			RolesByID:         map[indexer.RoleID]*indexer.RoleRecord{roleID: role},
+			BindingsByRoleRef: map[indexer.RoleRefKey][]*indexer.BindingRecord{},
+			RoleIDsByVerb:     map[string]map[indexer.RoleID]struct{}{"get": {roleID: {}}},
+			RoleIDsByResource: map[string]map[indexer.RoleID]struct{}{"secrets": {roleID: {}}},
+			RoleIDsByAPIGroup: map[string]map[indexer.RoleID]struct{}{"": {roleID: {}}},
+			AllRoleIDs:        []indexer.RoleID{roleID},
+		},
+	}
+	query := func(mode api.PolicyRulesMode) api.GraphNode {
+		t.Helper()
+		status := New().Query(snapshot, api.RoleGraphReviewSpec{
+			Selector:           api.Selector{Resources: []string{"secrets"}, Verbs: []string{"get"}},
+			MatchMode:          api.MatchModeAll,
+			IncludePolicyRules: mode,
+		}, nil)
+		if len(status.Graph.Nodes) != 1 {
+			t.Fatalf("expected only the role node, got %#v", status.Graph.Nodes)
+		}
+
+		return status.Graph.Nodes[0]
+	}
+
+	if node := query(""); node.PolicyRules != nil || node.RuleCount != 0 || node.ResourceVersion != "" {
+		t.Fatalf("expected no policy rules by default, got %#v", node)
+	}
+	matched := query(api.PolicyRulesModeMatched)
+	if len(matched.PolicyRules) != 1 || matched.RuleCount != 3 || matched.ResourceVersion != "4711" {
+		t.Fatalf("expected the matched rule with rule count and resourceVersion, got %#v", matched)
+	}
+	if rule := matched.PolicyRules[0]; rule.Index != 1 || len(rule.ResourceNames) != 1 || rule.ResourceNames[0] != "registry" {
+		t.Fatalf("unexpected matched rule %#v", rule)
+	}
+	all := query(api.PolicyRulesModeAll)
+	if len(all.PolicyRules) != 3 || all.PolicyRules[2].Index != 2 || all.PolicyRules[2].Resources[0] != "configmaps" {
+		t.Fatalf("expected every rule of the role, got %#v", all.PolicyRules)
+	}
+}
+
 func TestQuery_RuntimeChainServiceAccountToWorkload(t *testing.T) {
 	snapshot := runtimeSnapshotForTests()
 	e := New()
@@ -203,6 +328,84 @@ func TestQuery_RuntimeChainServiceAccountToWorkload(t *testing.T) {
 	assertHasEdgeType(t, status.Graph.Edges, api.GraphEdgeTypeOwnedBy)
 }
 
+func TestQuery_MarksTokenExposure(t *testing.T) {
+	roleID := indexer.RoleID("clusterrole:exec")
+	roleRef := indexer.RoleRefKey{Kind: indexer.KindClusterRole, Name: "exec"}
+	disabled, enabled := false, true
+	appKey := indexer.ServiceAccountKey{Namespace: "team-a", Name: "app"}
+	snapshot := &indexer.Snapshot{
+		BuiltAt: time.Now(),
+		RBACIndex: indexer.RBACIndex{
+			RolesByID: map[indexer.RoleID]*indexer.RoleRecord{roleID: {
+				UID:   "exec",
+				Kind:  indexer.KindClusterRole,
+				Name:  "exec",
+				Rules: []rbacv1.PolicyRule{{APIGroups: []string{""}, Resources: []string{"pods/exec"}, Verbs: []string{"create"}}},
+			}},
+			BindingsByRoleRef: map[indexer.RoleRefKey][]*indexer.BindingRecord{roleRef: {{
+				UID: "rb", Kind: indexer.KindRoleBinding, Namespace: "team-a", Name: "exec", RoleRef: roleRef,
+				Subjects: []rbacv1.Subject{{Kind: indexer.SubjectKindServiceAccount, Namespace: "team-a", Name: "app"}},
+			}}},
+			ServiceAccounts: map[indexer.ServiceAccountKey]*indexer.ServiceAccountRecord{appKey: {
+				Namespace: "team-a", Name: "app", AutomountServiceAccountToken: &disabled,
+			}},
+			RoleIDsByVerb:     map[string]map[indexer.RoleID]struct{}{"create": {roleID: {}}},
+			RoleIDsByResource: map[string]map[indexer.RoleID]struct{}{"pods/exec": {roleID: {}}},
+			RoleIDsByAPIGroup: map[string]map[indexer.RoleID]struct{}{"": {roleID: {}}},
+			AllRoleIDs:        []indexer.RoleID{roleID},
+		},
+		RuntimeIndex: indexer.RuntimeIndex{
+			PodsByServiceAccount: map[indexer.ServiceAccountKey][]*indexer.PodRecord{appKey: {
+				{UID: "pod-1", Namespace: "team-a", Name: "no-token", ServiceAccountName: "app", Phase: corev1.PodRunning},
+				{
+					UID: "pod-2", Namespace: "team-a", Name: "vault-token", ServiceAccountName: "app", Phase: corev1.PodRunning,
+					ProjectedTokens: []indexer.ProjectedToken{{Volume: "vault", Audience: "vault", ExpirationSeconds: 600}},
+				},
+				{
+					UID: "pod-3", Namespace: "team-a", Name: "api-token", ServiceAccountName: "app", Phase: corev1.PodRunning,
+					ProjectedTokens: []indexer.ProjectedToken{{Volume: "api"}},
+				},
+				{
+					UID: "pod-4", Namespace: "team-a", Name: "automount", ServiceAccountName: "app", Phase: corev1.PodRunning,
+					AutomountServiceAccountToken: &enabled,
+				},
+			}},
+		},
+	}
+
+	status := New().Query(snapshot, api.RoleGraphReviewSpec{
+		Selector:    api.Selector{Resources: []string{"pods/exec"}, Verbs: []string{"create"}},
+		IncludePods: true,
+	}, nil)
+
+	pods := make(map[string]api.GraphNode)
+	for _, node := range status.Graph.Nodes {
+		if node.Type == api.GraphNodeTypePod {
+			pods[node.Name] = node
+		}
+	}
+	for name, expected := range map[string]struct {
+		exposed bool
+		source  api.TokenAutomountSource
+	}{
+		"no-token":    {exposed: false, source: api.TokenAutomountSourceServiceAccount},
+		"vault-token": {exposed: false, source: api.TokenAutomountSourceServiceAccount},
+		"api-token":   {exposed: true, source: api.TokenAutomountSourceServiceAccount},
+		"automount":   {exposed: true, source: api.TokenAutomountSourcePod},
+	} {
+		pod := pods[name]
+		if pod.TokenExposure == nil || pod.TokenExposure.Exposed != expected.exposed || pod.TokenExposure.AutomountSource != expected.source {
+			t.Fatalf("unexpected token exposure of pod %s: %#v", name, pod.TokenExposure)
+		}
+		if (pod.RiskScore > 0) != expected.exposed {
+			t.Fatalf("expected pod %s to inherit risk only when its token is exposed, got score %d", name, pod.RiskScore)
+		}
+	}
+	if tokens := pods["vault-token"].TokenExposure.ProjectedTokens; len(tokens) != 1 || tokens[0].Audience != "vault" {
+		t.Fatalf("expected the vault token to be reported, got %#v", tokens)
+	}
+}
+
 func TestQuery_RuntimeIncludeWorkloadsAutoEnablesPods(t *testing.T) {
 	snapshot := runtimeSnapshotForTests()
 	e := New()
@@ -223,6 +426,31 @@ func TestQuery_RuntimeIncludeWorkloadsAutoEnablesPods(t *testing.T) {
 	}
 }
 
+func TestQuery_RuntimeUnavailableWarning(t *testing.T) {
+	snapshot := runtimeSnapshotForTests()
+	snapshot.RuntimeIndex = indexer.RuntimeIndex{}
+	snapshot.RuntimeUnavailable = indexer.RuntimeIndexDisabled
+	e := New()
+	spec := api.RoleGraphReviewSpec{
+		Selector: api.Selector{
+			Resources: []string{"pods/exec"},
+			Verbs:     []string{"get"},
+		},
+	}
+
+	if status := e.Query(snapshot, spec, nil); contains(status.Warnings, indexer.RuntimeIndexDisabled) {
+		t.Fatalf("expected no runtime warning without includePods, warnings=%v", status.Warnings)
+	}
+	spec.IncludePods = true
+	status := e.Query(snapshot, spec, nil)
+	if !contains(status.Warnings, indexer.RuntimeIndexDisabled) {
+		t.Fatalf("expected runtime index warning, warnings=%v", status.Warnings)
+	}
+	if status.MatchedPods != 0 {
+		t.Fatalf("expected no pods, got %d", status.MatchedPods)
+	}
+}
+
 func TestQuery_RuntimeLimitsAndPhaseFilter(t *testing.T) {
 	snapshot := runtimeSnapshotForTests()
 	saKey := indexer.ServiceAccountKey{Namespace: "team", Name: "demo-sa"}
@@ -355,15 +583,282 @@ func TestQuery_NamespaceScopeStrictTrueKeepsClusterRoleWhenNamespacedBindingMatc
 	}
 }
 
+func TestQuery_NamespaceScopeSelectorPatternsAndExclude(t *testing.T) {
+	roleID := indexer.RoleID("clusterrole:exec")
+	roleRef := indexer.RoleRefKey{Kind: indexer.KindClusterRole, Name: "exec"}
+	var bindings []*indexer.BindingRecord
+	for _, ns := range []string{"payments-api", "tenant-b", "tenant-c", "other"} {
+		bindings = append(bindings, &indexer.BindingRecord{
+			UID:       types.UID("binding-" + ns),
+			Kind:      indexer.KindRoleBinding,
+			Namespace: ns,
+			Name:      "exec",
+			RoleRef:   roleRef,
+			Subjects:  []rbacv1.Subject{{Kind: indexer.SubjectKindServiceAccount, Name: "app"}},
+		})
+	}
+	snapshot := &indexer.Snapshot{
+		BuiltAt: time.Now(),
+		RBACIndex: indexer.RBACIndex{
+			RolesByID: map[indexer.RoleID]*indexer.RoleRecord{roleID: {
+				UID:   "exec",
+				Kind:  indexer.KindClusterRole,
+				Name:  "exec",
+				Rules: []rbacv1.PolicyRule{{APIGroups: []string{""}, Resources: []string{"pods/exec"}, Verbs: []string{"create"}}},
+			}},
+			BindingsByRoleRef: map[indexer.RoleRefKey][]*indexer.BindingRecord{roleRef: bindings},
+			Namespaces: map[string]*indexer.NamespaceRecord{
+				"payments-api": {Name: "payments-api", Labels: map[string]string{"team": "payments"}},
+				"tenant-b":     {Name: "tenant-b"},
+				"tenant-c":     {Name: "tenant-c", Labels: map[string]string{"team": "payments"}},
+				"other":        {Name: "other"},
+			},
+			RoleIDsByVerb:     map[string]map[indexer.RoleID]struct{}{"create": {roleID: {}}},
+			RoleIDsByResource: map[string]map[indexer.RoleID]struct{}{"pods/exec": {roleID: {}}},
+			RoleIDsByAPIGroup: map[string]map[indexer.RoleID]struct{}{"": {roleID: {}}},
+			AllRoleIDs:        []indexer.RoleID{roleID},
+		},
+	}
+
+	status := New().Query(snapshot, api.RoleGraphReviewSpec{
+		Selector: api.Selector{Resources: []string{"pods/exec"}, Verbs: []string{"create"}},
+		NamespaceScope: api.NamespaceScope{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "payments"}},
+			Patterns: []string{"tenant-*"},
+			Exclude:  []string{"tenant-c"},
+			Strict:   true,
+		},
+	}, nil)
+
+	var namespaces []string
+	for _, node := range status.Graph.Nodes {
+		if node.Type == api.GraphNodeTypeRoleBinding {
+			namespaces = append(namespaces, node.Namespace)
+		}
+	}
+	slices.Sort(namespaces)
+	if !slices.Equal(namespaces, []string{"payments-api", "tenant-b"}) {
+		t.Fatalf("expected bindings in the labelled and matching namespaces, got %v", namespaces)
+	}
+}
+
+func TestQuery_FlagsMissingAndTerminatingNamespaces(t *testing.T) {
+	roleID := indexer.RoleID("clusterrole:exec")
+	roleRef := indexer.RoleRefKey{Kind: indexer.KindClusterRole, Name: "exec"}
+	snapshot := &indexer.Snapshot{
+		BuiltAt: time.Now(),
+		RBACIndex: indexer.RBACIndex{
+			RolesByID: map[indexer.RoleID]*indexer.RoleRecord{roleID: {
+				UID:   "exec",
+				Kind:  indexer.KindClusterRole,
+				Name:  "exec",
+				Rules: []rbacv1.PolicyRule{{APIGroups: []string{""}, Resources: []string{"pods/exec"}, Verbs: []string{"create"}}},
+			}},
+			BindingsByRoleRef: map[indexer.RoleRefKey][]*indexer.BindingRecord{roleRef: {
+				{
+					UID: "crb", Kind: indexer.KindClusterRoleBinding, Name: "exec", RoleRef: roleRef,
+					Subjects: []rbacv1.Subject{{Kind: indexer.SubjectKindServiceAccount, Namespace: "old", Name: "app"}},
+				},
+				{
+					UID: "rb-closing", Kind: indexer.KindRoleBinding, Namespace: "closing", Name: "exec", RoleRef: roleRef,
+					Subjects: []rbacv1.Subject{{Kind: indexer.SubjectKindServiceAccount, Name: "app"}},
+				},
+				{
+					UID: "rb-live", Kind: indexer.KindRoleBinding, Namespace: "live", Name: "exec", RoleRef: roleRef,
+					Subjects: []rbacv1.Subject{{Kind: indexer.SubjectKindServiceAccount, Namespace: "live", Name: "app"}},
+				},
+			}},
+			Namespaces: map[string]*indexer.NamespaceRecord{
+				"closing": {Name: "closing", Phase: corev1.NamespaceTerminating},
+				"live":    {Name: "live", Phase: corev1.NamespaceActive},
+			},
+			RoleIDsByVerb:     map[string]map[indexer.RoleID]struct{}{"create": {roleID: {}}},
+			RoleIDsByResource: map[string]map[indexer.RoleID]struct{}{"pods/exec": {roleID: {}}},
+			RoleIDsByAPIGroup: map[string]map[indexer.RoleID]struct{}{"": {roleID: {}}},
+			AllRoleIDs:        []indexer.RoleID{roleID},
+		},
+	}
+
+	status := New().Query(snapshot, api.RoleGraphReviewSpec{
+		Selector: api.Selector{Resources: []string{"pods/exec"}, Verbs: []string{"create"}},
+	}, nil)
+
+	states := make(map[string]api.NamespaceState)
+	for _, node := range status.Graph.Nodes {
+		if node.NamespaceState != "" {
+			states[node.ID] = node.NamespaceState
+		}
+	}
+	expected := map[string]api.NamespaceState{
+		"subject:serviceAccount:old/app":   api.NamespaceStateMissing,
+		"binding:rolebinding:closing/exec": api.NamespaceStateTerminating,
+		"subject:serviceAccount:app":       api.NamespaceStateTerminating,
+	}
+	if !maps.Equal(states, expected) {
+		t.Fatalf("expected namespace states %v, got %v", expected, states)
+	}
+	if !slices.Contains(status.Warnings,
+		`serviceAccount old/app: namespace "old" does not exist; its grants become live again if the namespace is recreated`) {
+		t.Fatalf("expected a missing namespace warning, got %v", status.Warnings)
+	}
+}
+
+func TestQuery_ScopedNamespacesOutsideScopeAreNotFlagged(t *testing.T) {
+	roleID := indexer.RoleID("clusterrole:exec")
+	roleRef := indexer.RoleRefKey{Kind: indexer.KindClusterRole, Name: "exec"}
+	snapshot := &indexer.Snapshot{
+		BuiltAt: time.Now(),
+		RBACIndex: indexer.RBACIndex{
+			RolesByID: map[indexer.RoleID]*indexer.RoleRecord{roleID: {
+				UID:   "exec",
+				Kind:  indexer.KindClusterRole,
+				Name:  "exec",
+				Rules: []rbacv1.PolicyRule{{APIGroups: []string{""}, Resources: []string{"pods/exec"}, Verbs: []string{"create"}}},
+			}},
+			BindingsByRoleRef: map[indexer.RoleRefKey][]*indexer.BindingRecord{roleRef: {{
+				UID: "crb", Kind: indexer.KindClusterRoleBinding, Name: "exec", RoleRef: roleRef,
+				Subjects: []rbacv1.Subject{
+					{Kind: indexer.SubjectKindServiceAccount, Namespace: "hidden", Name: "app"},
+					{Kind: indexer.SubjectKindServiceAccount, Namespace: "gone", Name: "app"},
+				},
+			}}},
+			Namespaces: map[string]*indexer.NamespaceRecord{
+				"hidden": {Name: "hidden", Phase: corev1.NamespaceTerminating},
+				"live":   {Name: "live", Phase: corev1.NamespaceActive},
+			},
+			RoleIDsByVerb:     map[string]map[indexer.RoleID]struct{}{"create": {roleID: {}}},
+			RoleIDsByResource: map[string]map[indexer.RoleID]struct{}{"pods/exec": {roleID: {}}},
+			RoleIDsByAPIGroup: map[string]map[indexer.RoleID]struct{}{"": {roleID: {}}},
+			AllRoleIDs:        []indexer.RoleID{roleID},
+		},
+	}
+	scoped := indexer.Scoped(snapshot, &authz.AccessScope{
+		CanListClusterRoles:        true,
+		CanListClusterRoleBindings: true,
+		AllowedRoleNamespaces:      map[string]struct{}{"live": {}},
+		AllowedBindingNamespaces:   map[string]struct{}{"live": {}},
+	})
+
+	status := New().Query(scoped, api.RoleGraphReviewSpec{
+		Selector: api.Selector{Resources: []string{"pods/exec"}, Verbs: []string{"create"}},
+	}, nil)
+
+	for _, node := range status.Graph.Nodes {
+		if node.NamespaceState != "" {
+			t.Fatalf("expected no namespace state outside the caller scope, got %s on %s", node.NamespaceState, node.ID)
+		}
+	}
+	if _, ok := scoped.Namespaces["hidden"]; ok {
+		t.Fatalf("expected the hidden namespace to be dropped, got %v", scoped.Namespaces)
+	}
+}
+
+func serviceAccountSnapshotForTests() *indexer.Snapshot {
+	roleID := indexer.RoleID("clusterrole:exec")
+	roleRef := indexer.RoleRefKey{Kind: indexer.KindClusterRole, Name: "exec"}
+	automount := false
+	appKey := indexer.ServiceAccountKey{Namespace: "team-a", Name: "app"}
+
+	return &indexer.Snapshot{
+		BuiltAt: time.Now(),
+		RBACIndex: indexer.RBACIndex{
+			RolesByID: map[indexer.RoleID]*indexer.RoleRecord{roleID: {
+				UID:   "exec",
+				Kind:  indexer.KindClusterRole,
+				Name:  "exec",
+				Rules: []rbacv1.PolicyRule{{APIGroups: []string{""}, Resources: []string{"pods/exec"}, Verbs: []string{"create"}}},
+			}},
+			BindingsByRoleRef: map[indexer.RoleRefKey][]*indexer.BindingRecord{roleRef: {{
+				UID: "rb", Kind: indexer.KindRoleBinding, Namespace: "team-a", Name: "exec", RoleRef: roleRef,
+				Subjects: []rbacv1.Subject{
+					{Kind: indexer.SubjectKindServiceAccount, Name: "app"},
+					{Kind: indexer.SubjectKindServiceAccount, Namespace: "team-a", Name: "gone"},
+				},
+			}}},
+			ServiceAccounts: map[indexer.ServiceAccountKey]*indexer.ServiceAccountRecord{appKey: {
+				Namespace:                    "team-a",
+				Name:                         "app",
+				Annotations:                  map[string]string{"eks.amazonaws.com/role-arn": "arn:aws:iam::123456789012:role/app"},
+				AutomountServiceAccountToken: &automount,
+				ImagePullSecrets:             []string{"registry"},
+			}},
+			RoleIDsByVerb:     map[string]map[indexer.RoleID]struct{}{"create": {roleID: {}}},
+			RoleIDsByResource: map[string]map[indexer.RoleID]struct{}{"pods/exec": {roleID: {}}},
+			RoleIDsByAPIGroup: map[string]map[indexer.RoleID]struct{}{"": {roleID: {}}},
+			AllRoleIDs:        []indexer.RoleID{roleID},
+		},
+		RuntimeIndex: indexer.RuntimeIndex{
+			PodsByServiceAccount: map[indexer.ServiceAccountKey][]*indexer.PodRecord{appKey: {
+				{UID: "pod-1", Namespace: "team-a", Name: "app-1", ServiceAccountName: "app"},
+				{UID: "pod-2", Namespace: "team-a", Name: "app-2", ServiceAccountName: "app"},
+			}},
+		},
+	}
+}
+
+func TestQuery_DescribesServiceAccounts(t *testing.T) {
+	snapshot := serviceAccountSnapshotForTests()
+
+	status := New().Query(snapshot, api.RoleGraphReviewSpec{
+		Selector: api.Selector{Resources: []string{"pods/exec"}, Verbs: []string{"create"}},
+	}, nil)
+
+	nodes := make(map[string]api.GraphNode)
+	for _, node := range status.Graph.Nodes {
+		nodes[node.ID] = node
+	}
+	app := nodes["subject:serviceAccount:app"].ServiceAccount
+	if app == nil || !app.Exists || app.PodCount != 2 || !slices.Equal(app.ImagePullSecrets, []string{"registry"}) ||
+		app.AutomountServiceAccountToken == nil || *app.AutomountServiceAccountToken {
+		t.Fatalf("unexpected app service account %#v", app)
+	}
+	expected := []api.CloudIdentity{{
+		Provider:   api.CloudIdentityProviderAWS,
+		Annotation: "eks.amazonaws.com/role-arn",
+		Identity:   "arn:aws:iam::123456789012:role/app",
+	}}
+	if !slices.Equal(app.CloudIdentities, expected) {
+		t.Fatalf("expected cloud identities %v, got %v", expected, app.CloudIdentities)
+	}
+	if gone := nodes["subject:serviceAccount:team-a/gone"].ServiceAccount; gone == nil || gone.Exists {
+		t.Fatalf("expected gone service account to be reported missing, got %#v", gone)
+	}
+}
+
+func TestQuery_ScopedServiceAccountsOutsideScopeAreUnknown(t *testing.T) {
+	scope := &authz.AccessScope{
+		CanListClusterRoles:             true,
+		CanListRoleBindings:             true,
+		CanListServiceAccounts:          false,
+		AllowedServiceAccountNamespaces: map[string]struct{}{"team-b": {}},
+	}
+	full := serviceAccountSnapshotForTests()
+	otherKey := indexer.ServiceAccountKey{Namespace: "team-b", Name: "other"}
+	full.ServiceAccounts[otherKey] = &indexer.ServiceAccountRecord{Namespace: "team-b", Name: "other"}
+	snapshot := indexer.Scoped(full, scope)
+
+	status := New().Query(snapshot, api.RoleGraphReviewSpec{
+		Selector: api.Selector{Resources: []string{"pods/exec"}, Verbs: []string{"create"}},
+	}, nil)
+
+	for _, node := range status.Graph.Nodes {
+		if node.Type == api.GraphNodeTypeServiceAccount && node.ServiceAccount != nil {
+			t.Fatalf("expected service accounts outside the caller scope to stay undescribed, got %s: %#v", node.ID, node.ServiceAccount)
+		}
+	}
+}
+
 func phantomSnapshotForTests() (*indexer.Snapshot, *indexer.APIDiscoveryCache) {
 	snapshot := &indexer.Snapshot{
-		BuiltAt:           time.Now(),
-		RolesByID:         map[indexer.RoleID]*indexer.RoleRecord{},
-		BindingsByRoleRef: map[indexer.RoleRefKey][]*indexer.BindingRecord{},
-		RoleIDsByVerb:     map[string]map[indexer.RoleID]struct{}{},
-		RoleIDsByResource: map[string]map[indexer.RoleID]struct{}{},
-		RoleIDsByAPIGroup: map[string]map[indexer.RoleID]struct{}{},
-		AllRoleIDs:        []indexer.RoleID{},
+		BuiltAt: time.Now(),
+		RBACIndex: indexer.RBACIndex{
+			RolesByID:         map[indexer.RoleID]*indexer.RoleRecord{},
+			BindingsByRoleRef: map[indexer.RoleRefKey][]*indexer.BindingRecord{},
+			RoleIDsByVerb:     map[string]map[indexer.RoleID]struct{}{},
+			RoleIDsByResource: map[string]map[indexer.RoleID]struct{}{},
+			RoleIDsByAPIGroup: map[string]map[indexer.RoleID]struct{}{},
+			AllRoleIDs:        []indexer.RoleID{},
+		},
 	}
 
 	// Role with a real API group ("") and a phantom group ("custom.metrics.k8s.io").
@@ -492,13 +987,15 @@ func TestQuery_PhantomFilterRemovesPhantomRefs(t *testing.T) {
 
 func TestQuery_PhantomFilterExcludesRoleWhenAllRefsPhantom(t *testing.T) {
 	snapshot := &indexer.Snapshot{
-		BuiltAt:           time.Now(),
-		RolesByID:         map[indexer.RoleID]*indexer.RoleRecord{},
-		BindingsByRoleRef: map[indexer.RoleRefKey][]*indexer.BindingRecord{},
-		RoleIDsByVerb:     map[string]map[indexer.RoleID]struct{}{},
-		RoleIDsByResource: map[string]map[indexer.RoleID]struct{}{},
-		RoleIDsByAPIGroup: map[string]map[indexer.RoleID]struct{}{},
-		AllRoleIDs:        []indexer.RoleID{},
+		BuiltAt: time.Now(),
+		RBACIndex: indexer.RBACIndex{
+			RolesByID:         map[indexer.RoleID]*indexer.RoleRecord{},
+			BindingsByRoleRef: map[indexer.RoleRefKey][]*indexer.BindingRecord{},
+			RoleIDsByVerb:     map[string]map[indexer.RoleID]struct{}{},
+			RoleIDsByResource: map[string]map[indexer.RoleID]struct{}{},
+			RoleIDsByAPIGroup: map[string]map[indexer.RoleID]struct{}{},
+			AllRoleIDs:        []indexer.RoleID{},
+		},
 	}
 
 	// Role referencing ONLY a phantom API group.
@@ -556,13 +1053,15 @@ func TestQuery_PhantomFilterExcludesRoleWhenAllRefsPhantom(t *testing.T) {
 
 func TestQuery_PhantomWildcardNeverPhantom(t *testing.T) {
 	snapshot := &indexer.Snapshot{
-		BuiltAt:           time.Now(),
-		RolesByID:         map[indexer.RoleID]*indexer.RoleRecord{},
-		BindingsByRoleRef: map[indexer.RoleRefKey][]*indexer.BindingRecord{},
-		RoleIDsByVerb:     map[string]map[indexer.RoleID]struct{}{},
-		RoleIDsByResource: map[string]map[indexer.RoleID]struct{}{},
-		RoleIDsByAPIGroup: map[string]map[indexer.RoleID]struct{}{},
-		AllRoleIDs:        []indexer.RoleID{},
+		BuiltAt: time.Now(),
+		RBACIndex: indexer.RBACIndex{
+			RolesByID:         map[indexer.RoleID]*indexer.RoleRecord{},
+			BindingsByRoleRef: map[indexer.RoleRefKey][]*indexer.BindingRecord{},
+			RoleIDsByVerb:     map[string]map[indexer.RoleID]struct{}{},
+			RoleIDsByResource: map[string]map[indexer.RoleID]struct{}{},
+			RoleIDsByAPIGroup: map[string]map[indexer.RoleID]struct{}{},
+			AllRoleIDs:        []indexer.RoleID{},
+		},
 	}
 
 	role := &indexer.RoleRecord{
@@ -642,13 +1141,15 @@ func TestQuery_PhantomNilDiscoverySkipsDetection(t *testing.T) {
 
 func TestQuery_PhantomResourceInExistingGroup(t *testing.T) {
 	snapshot := &indexer.Snapshot{
-		BuiltAt:           time.Now(),
-		RolesByID:         map[indexer.RoleID]*indexer.RoleRecord{},
-		BindingsByRoleRef: map[indexer.RoleRefKey][]*indexer.BindingRecord{},
-		RoleIDsByVerb:     map[string]map[indexer.RoleID]struct{}{},
-		RoleIDsByResource: map[string]map[indexer.RoleID]struct{}{},
-		RoleIDsByAPIGroup: map[string]map[indexer.RoleID]struct{}{},
-		AllRoleIDs:        []indexer.RoleID{},
+		BuiltAt: time.Now(),
+		RBACIndex: indexer.RBACIndex{
+			RolesByID:         map[indexer.RoleID]*indexer.RoleRecord{},
+			BindingsByRoleRef: map[indexer.RoleRefKey][]*indexer.BindingRecord{},
+			RoleIDsByVerb:     map[string]map[indexer.RoleID]struct{}{},
+			RoleIDsByResource: map[string]map[indexer.RoleID]struct{}{},
+			RoleIDsByAPIGroup: map[string]map[indexer.RoleID]struct{}{},
+			AllRoleIDs:        []indexer.RoleID{},
+		},
 	}
 
 	// Role references "widgets" in core group — resource doesn't exist.
@@ -750,13 +1251,15 @@ func discoveryWithVerbs() *indexer.APIDiscoveryCache {
 
 func wildcardSnapshotForTests(rules []rbacv1.PolicyRule) (*indexer.Snapshot, *indexer.APIDiscoveryCache) {
 	snapshot := &indexer.Snapshot{
-		BuiltAt:           time.Now(),
-		RolesByID:         map[indexer.RoleID]*indexer.RoleRecord{},
-		BindingsByRoleRef: map[indexer.RoleRefKey][]*indexer.BindingRecord{},
-		RoleIDsByVerb:     map[string]map[indexer.RoleID]struct{}{},
-		RoleIDsByResource: map[string]map[indexer.RoleID]struct{}{},
-		RoleIDsByAPIGroup: map[string]map[indexer.RoleID]struct{}{},
-		AllRoleIDs:        []indexer.RoleID{},
+		BuiltAt: time.Now(),
+		RBACIndex: indexer.RBACIndex{
+			RolesByID:         map[indexer.RoleID]*indexer.RoleRecord{},
+			BindingsByRoleRef: map[indexer.RoleRefKey][]*indexer.BindingRecord{},
+			RoleIDsByVerb:     map[string]map[indexer.RoleID]struct{}{},
+			RoleIDsByResource: map[string]map[indexer.RoleID]struct{}{},
+			RoleIDsByAPIGroup: map[string]map[indexer.RoleID]struct{}{},
+			AllRoleIDs:        []indexer.RoleID{},
+		},
 	}
 
 	role := &indexer.RoleRecord{
@@ -802,6 +1305,47 @@ func wildcardSnapshotForTests(rules []rbacv1.PolicyRule) (*indexer.Snapshot, *in
 	return snapshot, discoveryWithVerbs()
 }
 
+func TestQuery_RoleBindingOmitsClusterScopedRules(t *testing.T) {
+	snapshot, discovery := wildcardSnapshotForTests([]rbacv1.PolicyRule{
+		{NonResourceURLs: []string{"/metrics"}, Verbs: []string{"get"}},
+		{APIGroups: []string{""}, Resources: []string{"nodes", "configmaps"}, Verbs: []string{"get"}},
+	})
+	discovery.ResourcesByGroup[""]["nodes"] = struct{}{}
+	discovery.VerbsByGroupResource[""]["nodes"] = []string{"get", "list"}
+	discovery.AllResources["nodes"] = struct{}{}
+	discovery.ClusterScopedByGroup = map[string]map[string]struct{}{"": {"nodes": {}}}
+	roleRef := indexer.RoleRefKey{Kind: indexer.KindClusterRole, Name: "wildcard-test-role"}
+	snapshot.BindingsByRoleRef[roleRef] = []*indexer.BindingRecord{{
+		UID:       types.UID("rb-wc"),
+		Kind:      indexer.KindRoleBinding,
+		Namespace: "team",
+		Name:      "bind-wildcard-test",
+		RoleRef:   roleRef,
+		Subjects:  []rbacv1.Subject{{Kind: indexer.SubjectKindUser, Name: "dev"}},
+	}}
+
+	status := New().Query(snapshot, api.RoleGraphReviewSpec{Selector: api.Selector{Verbs: []string{"get"}}}, discovery)
+
+	var grants *api.GraphEdge
+	for i := range status.Graph.Edges {
+		if status.Graph.Edges[i].Type == api.GraphEdgeTypeGrants {
+			grants = &status.Graph.Edges[i]
+		}
+	}
+	if grants == nil {
+		t.Fatalf("expected a grants edge to the RoleBinding, got %#v", status.Graph.Edges)
+	}
+	if len(grants.RuleRefs) != 1 || grants.RuleRefs[0].Resource != "configmaps" {
+		t.Fatalf("expected the RoleBinding to grant only configmaps, got %#v", grants.RuleRefs)
+	}
+	if len(status.ResourceMap) != 1 || status.ResourceMap[0].Resource != "configmaps" {
+		t.Fatalf("expected a single configmaps row, got %#v", status.ResourceMap)
+	}
+	if scope := status.ResourceMap[0].Scope; scope == nil || !slices.Equal(scope.Namespaces, []string{"team"}) {
+		t.Fatalf("expected the configmaps row to be scoped to team, got %#v", scope)
+	}
+}
+
 func TestQuery_WildcardExpansion_APIGroup(t *testing.T) {
 	snapshot, discovery := wildcardSnapshotForTests([]rbacv1.PolicyRule{{
 		APIGroups: []string{"*"},
@@ -1080,6 +1624,12 @@ func TestQuery_WildcardExpansion_Cap(t *testing.T) {
 		Verbs:     []string{"*"},
 	}})
 
+	RegisterMetrics()
+	truncationsBefore, err := testutil.GetCounterMetricValue(wildcardTruncationsMetric)
+	if err != nil {
+		t.Fatalf("read truncation counter: %v", err)
+	}
+
 	e := New()
 	status := e.Query(snapshot, api.RoleGraphReviewSpec{
 		Selector:            api.Selector{Verbs: []string{"get"}},
@@ -1118,20 +1668,83 @@ func TestQuery_WildcardExpansion_Cap(t *testing.T) {
 	if !hasWarning {
 		t.Fatalf("expected truncation warning, got warnings=%v", status.Warnings)
 	}
+	truncationsAfter, err := testutil.GetCounterMetricValue(wildcardTruncationsMetric)
+	if err != nil {
+		t.Fatalf("read truncation counter: %v", err)
+	}
+	if truncationsAfter-truncationsBefore != 1 {
+		t.Fatalf("expected one truncation counted, got %v", truncationsAfter-truncationsBefore)
+	}
+}
+
+func TestQuery_RuntimeChainImplicitServiceAccountGroups(t *testing.T) {
+	snapshot := runtimeSnapshotForTests()
+	for _, binding := range snapshot.BindingsByRoleRef[indexer.RoleRefKey{Kind: indexer.KindClusterRole, Name: "exec-role"}] {
+		binding.Subjects = []rbacv1.Subject{
+			{Kind: indexer.SubjectKindGroup, Name: "system:serviceaccounts:team"},
+			{Kind: indexer.SubjectKindGroup, Name: "system:authenticated"},
+			{Kind: indexer.SubjectKindGroup, Name: "developers"},
+		}
+	}
+	for _, name := range []string{"other-a", "other-b"} {
+		key := indexer.ServiceAccountKey{Namespace: "other", Name: "other-sa"}
+		snapshot.PodsByServiceAccount[key] = append(snapshot.PodsByServiceAccount[key], &indexer.PodRecord{
+			UID:                types.UID(name),
+			Namespace:          "other",
+			Name:               name,
+			ServiceAccountName: "other-sa",
+			Phase:              corev1.PodRunning,
+		})
+	}
+
+	e := New()
+	status := e.Query(snapshot, api.RoleGraphReviewSpec{
+		Selector: api.Selector{
+			Resources: []string{"pods/exec"},
+			Verbs:     []string{"get"},
+		},
+		IncludePods:       true,
+		MaxPodsPerSubject: 2,
+	}, nil)
+
+	runsAs := make(map[string][]string)
+	for _, edge := range status.Graph.Edges {
+		if edge.Type == api.GraphEdgeTypeRunsAs {
+			runsAs[edge.From] = append(runsAs[edge.From], edge.To)
+		}
+	}
+	nsGroup := runsAs["subject:group:system:serviceaccounts:team"]
+	if len(nsGroup) != 1 || nsGroup[0] != "pod:team/running-pod" {
+		t.Fatalf("expected namespaced group to reach only team pods, got %v", nsGroup)
+	}
+	allGroup := runsAs["subject:group:system:authenticated"]
+	if len(allGroup) != 3 || !contains(allGroup, "overflow:pod:subject:group:system:authenticated") {
+		t.Fatalf("expected system:authenticated to reach 2 pods plus overflow, got %v", allGroup)
+	}
+	if got := runsAs["subject:group:developers"]; len(got) != 0 {
+		t.Fatalf("expected non-implicit group to have no pods, got %v", got)
+	}
+	if status.MatchedPods != 3 {
+		t.Fatalf("expected 3 matched pods, got %d", status.MatchedPods)
+	}
 }
 
 func runtimeSnapshotForTests() *indexer.Snapshot {
 	snapshot := &indexer.Snapshot{
-		BuiltAt:               time.Now(),
-		RolesByID:             map[indexer.RoleID]*indexer.RoleRecord{},
-		BindingsByRoleRef:     map[indexer.RoleRefKey][]*indexer.BindingRecord{},
-		AggregatedRoleSources: map[indexer.RoleID][]indexer.RoleID{},
-		PodsByServiceAccount:  map[indexer.ServiceAccountKey][]*indexer.PodRecord{},
-		WorkloadsByUID:        map[types.UID]*indexer.WorkloadRecord{},
-		RoleIDsByVerb:         map[string]map[indexer.RoleID]struct{}{},
-		RoleIDsByResource:     map[string]map[indexer.RoleID]struct{}{},
-		RoleIDsByAPIGroup:     map[string]map[indexer.RoleID]struct{}{},
-		AllRoleIDs:            []indexer.RoleID{},
+		BuiltAt: time.Now(),
+		RBACIndex: indexer.RBACIndex{
+			RolesByID:             map[indexer.RoleID]*indexer.RoleRecord{},
+			BindingsByRoleRef:     map[indexer.RoleRefKey][]*indexer.BindingRecord{},
+			AggregatedRoleSources: map[indexer.RoleID][]indexer.RoleID{},
+			RoleIDsByVerb:         map[string]map[indexer.RoleID]struct{}{},
+			RoleIDsByResource:     map[string]map[indexer.RoleID]struct{}{},
+			RoleIDsByAPIGroup:     map[string]map[indexer.RoleID]struct{}{},
+			AllRoleIDs:            []indexer.RoleID{},
+		},
+		RuntimeIndex: indexer.RuntimeIndex{
+			PodsByServiceAccount: map[indexer.ServiceAccountKey][]*indexer.PodRecord{},
+			WorkloadsByUID:       map[types.UID]*indexer.WorkloadRecord{},
+		},
 	}
 
 	role := &indexer.RoleRecord{
@@ -1260,10 +1873,13 @@ func TestAccumulateResourceRows_AggregatesByStructuredKey(t *testing.T) {
 		NonResourceURLs: []string{"/healthz"},
 	}
 
-	accumulateResourceRowsInto(rows, []api.RuleRef{rbacRef}, roleA, "binding:a", "subject:a")
-	accumulateResourceRowsInto(rows, []api.RuleRef{rbacRef}, roleA, "binding:a", "subject:a")
-	accumulateResourceRowsInto(rows, []api.RuleRef{rbacRef}, roleB, "binding:b", "subject:b")
-	accumulateResourceRowsInto(rows, []api.RuleRef{nonResourceRef}, roleA, "", "")
+	clusterScope := &api.EffectiveScope{ClusterWide: true}
+	namespacedScope := &api.EffectiveScope{Namespaces: []string{"team-a"}}
+
+	accumulateResourceRowsInto(rows, []api.RuleRef{rbacRef}, "", roleA, "binding:a", "subject:a", namespacedScope)
+	accumulateResourceRowsInto(rows, []api.RuleRef{rbacRef}, "", roleA, "binding:a", "subject:a", namespacedScope)
+	accumulateResourceRowsInto(rows, []api.RuleRef{rbacRef}, "", roleB, "binding:b", "subject:b", clusterScope)
+	accumulateResourceRowsInto(rows, []api.RuleRef{nonResourceRef}, "", roleA, "", "", nil)
 
 	collapsed := collapseResourceRows(rows)
 	if len(collapsed) != 2 {
@@ -1290,12 +1906,18 @@ func TestAccumulateResourceRows_AggregatesByStructuredKey(t *testing.T) {
 	if rbacRow.RoleCount != 2 || rbacRow.BindingCount != 2 || rbacRow.SubjectCount != 2 {
 		t.Fatalf("unexpected pods/exec counts: %#v", rbacRow)
 	}
+	if rbacRow.Scope == nil || !rbacRow.Scope.ClusterWide || len(rbacRow.Scope.Namespaces) != 0 {
+		t.Fatalf("expected cluster-wide binding to dominate pods/exec scope, got %#v", rbacRow.Scope)
+	}
 	if nonResourceRow == nil {
 		t.Fatalf("expected non-resource row in collapsed output: %#v", collapsed)
 	}
 	if nonResourceRow.RoleCount != 1 || nonResourceRow.BindingCount != 0 || nonResourceRow.SubjectCount != 0 {
 		t.Fatalf("unexpected non-resource counts: %#v", nonResourceRow)
 	}
+	if nonResourceRow.Scope != nil {
+		t.Fatalf("expected no scope for a row without bindings, got %#v", nonResourceRow.Scope)
+	}
 }
 
 // statusToV1alpha1 converts an internal RoleGraphReviewStatus to v1alpha1
@@ -1396,13 +2018,15 @@ func TestQuery_GoldenJSONContracts(t *testing.T) {
 
 func basicSnapshotForGolden() *indexer.Snapshot {
 	snapshot := &indexer.Snapshot{
-		BuiltAt:           time.Now(),
-		RolesByID:         map[indexer.RoleID]*indexer.RoleRecord{},
-		BindingsByRoleRef: map[indexer.RoleRefKey][]*indexer.BindingRecord{},
-		RoleIDsByVerb:     map[string]map[indexer.RoleID]struct{}{},
-		RoleIDsByResource: map[string]map[indexer.RoleID]struct{}{},
-		RoleIDsByAPIGroup: map[string]map[indexer.RoleID]struct{}{},
-		AllRoleIDs:        []indexer.RoleID{},
+		BuiltAt: time.Now(),
+		RBACIndex: indexer.RBACIndex{
+			RolesByID:         map[indexer.RoleID]*indexer.RoleRecord{},
+			BindingsByRoleRef: map[indexer.RoleRefKey][]*indexer.BindingRecord{},
+			RoleIDsByVerb:     map[string]map[indexer.RoleID]struct{}{},
+			RoleIDsByResource: map[string]map[indexer.RoleID]struct{}{},
+			RoleIDsByAPIGroup: map[string]map[indexer.RoleID]struct{}{},
+			AllRoleIDs:        []indexer.RoleID{},
+		},
 	}
 
 	role := &indexer.RoleRecord{
@@ -1437,14 +2061,16 @@ func basicSnapshotForGolden() *indexer.Snapshot {
 
 func aggregatedSnapshotForGolden() *indexer.Snapshot {
 	snapshot := &indexer.Snapshot{
-		BuiltAt:               time.Now(),
-		RolesByID:             map[indexer.RoleID]*indexer.RoleRecord{},
-		BindingsByRoleRef:     map[indexer.RoleRefKey][]*indexer.BindingRecord{},
-		AggregatedRoleSources: map[indexer.RoleID][]indexer.RoleID{},
-		RoleIDsByVerb:         map[string]map[indexer.RoleID]struct{}{},
-		RoleIDsByResource:     map[string]map[indexer.RoleID]struct{}{},
-		RoleIDsByAPIGroup:     map[string]map[indexer.RoleID]struct{}{},
-		AllRoleIDs:            []indexer.RoleID{},
+		BuiltAt: time.Now(),
+		RBACIndex: indexer.RBACIndex{
+			RolesByID:             map[indexer.RoleID]*indexer.RoleRecord{},
+			BindingsByRoleRef:     map[indexer.RoleRefKey][]*indexer.BindingRecord{},
+			AggregatedRoleSources: map[indexer.RoleID][]indexer.RoleID{},
+			RoleIDsByVerb:         map[string]map[indexer.RoleID]struct{}{},
+			RoleIDsByResource:     map[string]map[indexer.RoleID]struct{}{},
+			RoleIDsByAPIGroup:     map[string]map[indexer.RoleID]struct{}{},
+			AllRoleIDs:            []indexer.RoleID{},
+		},
 	}
 
 	sourceRole := &indexer.RoleRecord{
@@ -1517,3 +2143,1089 @@ func assertHasEdgeType(t *testing.T, edges []api.GraphEdge, edgeType api.GraphEd
 func contains(values []string, expected string) bool {
 	return slices.Contains(values, expected)
 }
+
+func subjectSnapshotForTests() *indexer.Snapshot {
+	snapshot := &indexer.Snapshot{
+		BuiltAt: time.Now(),
+		RBACIndex: indexer.RBACIndex{
+			RolesByID:             map[indexer.RoleID]*indexer.RoleRecord{},
+			BindingsByRoleRef:     map[indexer.RoleRefKey][]*indexer.BindingRecord{},
+			BindingsBySubject:     map[indexer.SubjectKey][]*indexer.BindingRecord{},
+			AggregatedRoleSources: map[indexer.RoleID][]indexer.RoleID{},
+		},
+	}
+
+	snapshot.RolesByID[indexer.RoleID("clusterrole:pod-reader")] = &indexer.RoleRecord{
+		UID:  types.UID("role-1"),
+		Kind: indexer.KindClusterRole,
+		Name: "pod-reader",
+		Rules: []rbacv1.PolicyRule{{
+			APIGroups: []string{""},
+			Resources: []string{"pods"},
+			Verbs:     []string{"get", "list"},
+		}},
+	}
+	snapshot.RolesByID[indexer.RoleID("clusterrole:secret-reader")] = &indexer.RoleRecord{
+		UID:  types.UID("role-2"),
+		Kind: indexer.KindClusterRole,
+		Name: "secret-reader",
+		Rules: []rbacv1.PolicyRule{{
+			APIGroups:     []string{""},
+			Resources:     []string{"secrets"},
+			Verbs:         []string{"get"},
+			ResourceNames: []string{"db-creds"},
+		}},
+	}
+
+	sa := rbacv1.Subject{Kind: indexer.SubjectKindServiceAccount, Namespace: "team", Name: "builder"}
+	bindings := []*indexer.BindingRecord{
+		{
+			UID:      types.UID("crb-1"),
+			Kind:     indexer.KindClusterRoleBinding,
+			Name:     "read-pods",
+			RoleRef:  indexer.RoleRefKey{Kind: indexer.KindClusterRole, Name: "pod-reader"},
+			Subjects: []rbacv1.Subject{sa},
+		},
+		{
+			UID:       types.UID("rb-1"),
+			Kind:      indexer.KindRoleBinding,
+			Namespace: "team",
+			Name:      "read-pods-team",
+			RoleRef:   indexer.RoleRefKey{Kind: indexer.KindClusterRole, Name: "pod-reader"},
+			Subjects:  []rbacv1.Subject{{Kind: indexer.SubjectKindGroup, Name: "system:serviceaccounts:team"}},
+		},
+		{
+			UID:       types.UID("rb-2"),
+			Kind:      indexer.KindRoleBinding,
+			Namespace: "prod",
+			Name:      "read-db-creds",
+			RoleRef:   indexer.RoleRefKey{Kind: indexer.KindClusterRole, Name: "secret-reader"},
+			Subjects:  []rbacv1.Subject{sa},
+		},
+		{
+			UID:       types.UID("rb-3"),
+			Kind:      indexer.KindRoleBinding,
+			Namespace: "prod",
+			Name:      "dangling",
+			RoleRef:   indexer.RoleRefKey{Kind: indexer.KindClusterRole, Name: "deleted-role"},
+			Subjects:  []rbacv1.Subject{sa},
+		},
+	}
+	for _, binding := range bindings {
+		for _, subject := range binding.Subjects {
+			key := indexer.NewSubjectKey(subject, binding.Namespace)
+			snapshot.BindingsBySubject[key] = append(snapshot.BindingsBySubject[key], binding)
+		}
+	}
+
+	return snapshot
+}
+
+func TestQuerySubject_ServiceAccountPermissions(t *testing.T) {
+	status := New().QuerySubject(subjectSnapshotForTests(), api.SubjectPermissionReviewSpec{
+		Subject: api.SubjectRef{Kind: api.SubjectKindServiceAccount, Namespace: "team", Name: "builder"},
+	}, nil)
+
+	expectedGroups := []string{"system:authenticated", "system:serviceaccounts", "system:serviceaccounts:team"}
+	if !slices.Equal(status.EffectiveGroups, expectedGroups) {
+		t.Fatalf("expected effective groups %v, got %v", expectedGroups, status.EffectiveGroups)
+	}
+	if status.MatchedRoles != 2 {
+		t.Fatalf("expected 2 matched roles, got %d", status.MatchedRoles)
+	}
+	if status.MatchedBindings != 3 {
+		t.Fatalf("expected 3 matched bindings, got %d", status.MatchedBindings)
+	}
+	if len(status.Permissions) != 3 {
+		t.Fatalf("expected 3 permissions, got %#v", status.Permissions)
+	}
+
+	getPods := status.Permissions[0]
+	if getPods.Resource != "pods" || getPods.Verb != "get" {
+		t.Fatalf("unexpected first permission: %#v", getPods)
+	}
+	if !getPods.ClusterWide || len(getPods.Namespaces) != 0 {
+		t.Fatalf("expected get pods to be cluster-wide without namespace list, got %#v", getPods)
+	}
+	if len(getPods.Bindings) != 2 {
+		t.Fatalf("expected get pods to be granted by both bindings, got %v", getPods.Bindings)
+	}
+
+	getSecret := status.Permissions[2]
+	if getSecret.Resource != "secrets" || getSecret.ClusterWide {
+		t.Fatalf("expected namespaced secrets permission, got %#v", getSecret)
+	}
+	if !slices.Equal(getSecret.Namespaces, []string{"prod"}) || !slices.Equal(getSecret.ResourceNames, []string{"db-creds"}) {
+		t.Fatalf("expected secrets permission limited to prod/db-creds, got %#v", getSecret)
+	}
+
+	if !slices.ContainsFunc(status.Warnings, func(w string) bool { return strings.Contains(w, "deleted-role") }) {
+		t.Fatalf("expected warning about missing role, got %v", status.Warnings)
+	}
+	assertHasEdgeType(t, status.Graph.Edges, api.GraphEdgeTypeGrants)
+	assertHasNodeType(t, status.Graph.Nodes, api.GraphNodeTypeGroup)
+}
+
+func TestQuerySubject_ServiceAccountUsernameBindings(t *testing.T) {
+	snapshot := subjectSnapshotForTests()
+	binding := &indexer.BindingRecord{
+		UID:       types.UID("rb-user"),
+		Kind:      indexer.KindRoleBinding,
+		Namespace: "ci",
+		Name:      "read-db-creds-as-user",
+		RoleRef:   indexer.RoleRefKey{Kind: indexer.KindClusterRole, Name: "secret-reader"},
+		Subjects:  []rbacv1.Subject{{Kind: indexer.SubjectKindUser, Name: "system:serviceaccount:team:builder"}},
+	}
+	key := indexer.NewSubjectKey(binding.Subjects[0], binding.Namespace)
+	snapshot.BindingsBySubject[key] = append(snapshot.BindingsBySubject[key], binding)
+
+	status := New().QuerySubject(snapshot, api.SubjectPermissionReviewSpec{
+		Subject: api.SubjectRef{Kind: api.SubjectKindServiceAccount, Namespace: "team", Name: "builder"},
+	}, nil)
+
+	index := slices.IndexFunc(status.Permissions, func(p api.SubjectPermission) bool { return p.Resource == "secrets" })
+	if index < 0 {
+		t.Fatalf("expected a secrets permission, got %#v", status.Permissions)
+	}
+	getSecret := status.Permissions[index]
+	if !slices.Equal(getSecret.Namespaces, []string{"ci", "prod"}) ||
+		!slices.Contains(getSecret.Bindings, "binding:rolebinding:ci/read-db-creds-as-user") {
+		t.Fatalf("expected the username binding to grant secrets in ci, got %#v", getSecret)
+	}
+}
+
+func TestQuerySubject_RoleBindingOmitsClusterScopedRules(t *testing.T) {
+	snapshot := subjectSnapshotForTests()
+	snapshot.RolesByID[indexer.RoleID("clusterrole:node-metrics")] = &indexer.RoleRecord{
+		UID:  types.UID("role-3"),
+		Kind: indexer.KindClusterRole,
+		Name: "node-metrics",
+		Rules: []rbacv1.PolicyRule{
+			{NonResourceURLs: []string{"/metrics"}, Verbs: []string{"get"}},
+			{APIGroups: []string{""}, Resources: []string{"nodes", "configmaps"}, Verbs: []string{"list"}},
+		},
+	}
+	binding := &indexer.BindingRecord{
+		UID:       types.UID("rb-metrics"),
+		Kind:      indexer.KindRoleBinding,
+		Namespace: "team",
+		Name:      "node-metrics",
+		RoleRef:   indexer.RoleRefKey{Kind: indexer.KindClusterRole, Name: "node-metrics"},
+		Subjects:  []rbacv1.Subject{{Kind: indexer.SubjectKindServiceAccount, Namespace: "team", Name: "builder"}},
+	}
+	key := indexer.NewSubjectKey(binding.Subjects[0], binding.Namespace)
+	snapshot.BindingsBySubject[key] = append(snapshot.BindingsBySubject[key], binding)
+	discovery := &indexer.APIDiscoveryCache{
+		ResourcesByGroup:     map[string]map[string]struct{}{"": {"nodes": {}, "configmaps": {}}},
+		ClusterScopedByGroup: map[string]map[string]struct{}{"": {"nodes": {}}},
+	}
+
+	status := New().QuerySubject(snapshot, api.SubjectPermissionReviewSpec{
+		Subject: api.SubjectRef{Kind: api.SubjectKindServiceAccount, Namespace: "team", Name: "builder"},
+	}, discovery)
+
+	for _, permission := range status.Permissions {
+		if len(permission.NonResourceURLs) > 0 || permission.Resource == "nodes" {
+			t.Fatalf("expected no cluster-scoped permission through a RoleBinding, got %#v", permission)
+		}
+	}
+	if !slices.ContainsFunc(status.Permissions, func(p api.SubjectPermission) bool {
+		return p.Resource == "configmaps" && slices.Equal(p.Namespaces, []string{"team"})
+	}) {
+		t.Fatalf("expected list configmaps in team, got %#v", status.Permissions)
+	}
+}
+
+func TestQuerySubject_NamespaceScopeStrictDropsClusterBindings(t *testing.T) {
+	status := New().QuerySubject(subjectSnapshotForTests(), api.SubjectPermissionReviewSpec{
+		Subject:        api.SubjectRef{Kind: api.SubjectKindServiceAccount, Namespace: "team", Name: "builder"},
+		NamespaceScope: api.NamespaceScope{Namespaces: []string{"team"}, Strict: true},
+	}, nil)
+
+	if status.MatchedBindings != 1 {
+		t.Fatalf("expected only the team RoleBinding, got %d bindings", status.MatchedBindings)
+	}
+	for _, permission := range status.Permissions {
+		if permission.ClusterWide || !slices.Equal(permission.Namespaces, []string{"team"}) {
+			t.Fatalf("expected permissions scoped to team, got %#v", permission)
+		}
+	}
+}
+
+func TestEffectiveGroups(t *testing.T) {
+	tests := []struct {
+		name     string
+		subject  api.SubjectRef
+		explicit []string
+		expected []string
+	}{
+		{
+			name:     "user",
+			subject:  api.SubjectRef{Kind: api.SubjectKindUser, Name: "alice"},
+			explicit: []string{"devs", "devs"},
+			expected: []string{"devs", "system:authenticated"},
+		},
+		{
+			name:     "anonymous",
+			subject:  api.SubjectRef{Kind: api.SubjectKindUser, Name: "system:anonymous"},
+			expected: []string{"system:unauthenticated"},
+		},
+		{
+			name:     "group",
+			subject:  api.SubjectRef{Kind: api.SubjectKindGroup, Name: "devs"},
+			explicit: []string{"ignored"},
+			expected: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := EffectiveGroups(tt.subject, tt.explicit)
+			if !slices.Equal(got, tt.expected) {
+				t.Fatalf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func objectSnapshotForTests() *indexer.Snapshot {
+	snapshot := &indexer.Snapshot{
+		BuiltAt: time.Now(),
+		RBACIndex: indexer.RBACIndex{
+			RolesByID:             map[indexer.RoleID]*indexer.RoleRecord{},
+			BindingsByRoleRef:     map[indexer.RoleRefKey][]*indexer.BindingRecord{},
+			AggregatedRoleSources: map[indexer.RoleID][]indexer.RoleID{},
+			RoleIDsByVerb:         map[string]map[indexer.RoleID]struct{}{},
+			RoleIDsByResource:     map[string]map[indexer.RoleID]struct{}{},
+			RoleIDsByAPIGroup:     map[string]map[indexer.RoleID]struct{}{},
+			AllRoleIDs:            []indexer.RoleID{},
+		},
+	}
+
+	addRole := func(role *indexer.RoleRecord) {
+		roleID := indexer.RecID(role.Kind, role.Namespace, role.Name)
+		snapshot.RolesByID[roleID] = role
+		snapshot.AllRoleIDs = append(snapshot.AllRoleIDs, roleID)
+		for token, index := range map[string]map[string]map[indexer.RoleID]struct{}{
+			"":        snapshot.RoleIDsByAPIGroup,
+			"secrets": snapshot.RoleIDsByResource,
+			"get":     snapshot.RoleIDsByVerb,
+		} {
+			if index[token] == nil {
+				index[token] = map[indexer.RoleID]struct{}{}
+			}
+			index[token][roleID] = struct{}{}
+		}
+	}
+	addRole(&indexer.RoleRecord{
+		UID:  types.UID("cr-secrets"),
+		Kind: indexer.KindClusterRole,
+		Name: "secret-reader",
+		Rules: []rbacv1.PolicyRule{{
+			APIGroups: []string{""},
+			Resources: []string{"secrets"},
+			Verbs:     []string{"get"},
+		}},
+	})
+	addRole(&indexer.RoleRecord{
+		UID:       types.UID("r-other-secret"),
+		Kind:      indexer.KindRole,
+		Namespace: "prod",
+		Name:      "tls-reader",
+		Rules: []rbacv1.PolicyRule{{
+			APIGroups:     []string{""},
+			Resources:     []string{"secrets"},
+			Verbs:         []string{"get"},
+			ResourceNames: []string{"tls"},
+		}},
+	})
+
+	bindings := []*indexer.BindingRecord{
+		{
+			UID:       types.UID("rb-prod"),
+			Kind:      indexer.KindRoleBinding,
+			Namespace: "prod",
+			Name:      "prod-secrets",
+			RoleRef:   indexer.RoleRefKey{Kind: indexer.KindClusterRole, Name: "secret-reader"},
+			Subjects:  []rbacv1.Subject{{Kind: indexer.SubjectKindUser, Name: "prod-oncall"}},
+		},
+		{
+			UID:       types.UID("rb-dev"),
+			Kind:      indexer.KindRoleBinding,
+			Namespace: "dev",
+			Name:      "dev-secrets",
+			RoleRef:   indexer.RoleRefKey{Kind: indexer.KindClusterRole, Name: "secret-reader"},
+			Subjects:  []rbacv1.Subject{{Kind: indexer.SubjectKindUser, Name: "dev-user"}},
+		},
+		{
+			UID:      types.UID("crb-admin"),
+			Kind:     indexer.KindClusterRoleBinding,
+			Name:     "global-secrets",
+			RoleRef:  indexer.RoleRefKey{Kind: indexer.KindClusterRole, Name: "secret-reader"},
+			Subjects: []rbacv1.Subject{{Kind: indexer.SubjectKindGroup, Name: "admins"}},
+		},
+		{
+			UID:       types.UID("rb-tls"),
+			Kind:      indexer.KindRoleBinding,
+			Namespace: "prod",
+			Name:      "tls",
+			RoleRef:   indexer.RoleRefKey{Kind: indexer.KindRole, Namespace: "prod", Name: "tls-reader"},
+			Subjects:  []rbacv1.Subject{{Kind: indexer.SubjectKindUser, Name: "cert-manager"}},
+		},
+	}
+	for _, binding := range bindings {
+		snapshot.BindingsByRoleRef[binding.RoleRef] = append(snapshot.BindingsByRoleRef[binding.RoleRef], binding)
+	}
+
+	return snapshot
+}
+
+func TestQuery_ObjectModeNarrowsBindingsAndResourceNames(t *testing.T) {
+	status := New().Query(objectSnapshotForTests(), api.RoleGraphReviewSpec{
+		Object: &api.ObjectTarget{Resource: "secrets", Namespace: "prod", Name: "db-creds", Verb: "get"},
+	}, nil)
+
+	subjects := make([]string, 0)
+	for _, node := range status.Graph.Nodes {
+		switch node.Type {
+		case api.GraphNodeTypeUser, api.GraphNodeTypeGroup:
+			subjects = append(subjects, node.Name)
+		}
+	}
+	slices.Sort(subjects)
+	if !slices.Equal(subjects, []string{"admins", "prod-oncall"}) {
+		t.Fatalf("expected only prod-oncall and admins to read prod/db-creds, got %v", subjects)
+	}
+	if status.MatchedRoles != 1 {
+		t.Fatalf("expected tls-reader to be excluded by resourceNames, got %d roles", status.MatchedRoles)
+	}
+}
+
+func TestQuery_ObjectModeClusterScopedIgnoresRoleBindings(t *testing.T) {
+	status := New().Query(objectSnapshotForTests(), api.RoleGraphReviewSpec{
+		Object: &api.ObjectTarget{Resource: "secrets", Verb: "get"},
+	}, nil)
+
+	if status.MatchedBindings != 1 {
+		t.Fatalf("expected only the ClusterRoleBinding to apply, got %d bindings", status.MatchedBindings)
+	}
+	if status.MatchedSubjects != 1 {
+		t.Fatalf("expected only admins group, got %d subjects", status.MatchedSubjects)
+	}
+}
+
+func TestQuery_GrantsEdgesCarryEffectiveScope(t *testing.T) {
+	status := New().Query(objectSnapshotForTests(), api.RoleGraphReviewSpec{
+		Selector: api.Selector{Resources: []string{"secrets"}, Verbs: []string{"get"}},
+	}, nil)
+
+	scopes := make(map[string]*api.EffectiveScope)
+	for _, edge := range status.Graph.Edges {
+		if edge.Type == api.GraphEdgeTypeGrants {
+			scopes[edge.To] = edge.Scope
+		}
+	}
+	prod := scopes["binding:rolebinding:prod/prod-secrets"]
+	if prod == nil || prod.ClusterWide || !slices.Equal(prod.Namespaces, []string{"prod"}) {
+		t.Fatalf("expected RoleBinding->ClusterRole grant to be limited to prod, got %#v", prod)
+	}
+	global := scopes["binding:clusterrolebinding:global-secrets"]
+	if global == nil || !global.ClusterWide {
+		t.Fatalf("expected ClusterRoleBinding grant to be cluster-wide, got %#v", global)
+	}
+
+	if len(status.ResourceMap) != 1 {
+		t.Fatalf("expected a single secrets/get row, got %#v", status.ResourceMap)
+	}
+	if row := status.ResourceMap[0]; row.Namespace != "" || row.Scope == nil || !row.Scope.ClusterWide {
+		t.Fatalf("expected merged row to be cluster-wide, got %#v", row)
+	}
+}
+
+func TestQuery_ResourceMapByNamespace(t *testing.T) {
+	status := New().Query(objectSnapshotForTests(), api.RoleGraphReviewSpec{
+		Selector:               api.Selector{Resources: []string{"secrets"}, Verbs: []string{"get"}},
+		ResourceMapByNamespace: true,
+	}, nil)
+
+	namespaces := make([]string, 0, len(status.ResourceMap))
+	for _, row := range status.ResourceMap {
+		namespaces = append(namespaces, row.Namespace)
+		if row.Scope == nil {
+			t.Fatalf("expected every row to carry a scope, got %#v", row)
+		}
+		if (row.Namespace == "") != row.Scope.ClusterWide {
+			t.Fatalf("row namespace and scope disagree: %#v", row)
+		}
+	}
+	if !slices.Equal(namespaces, []string{"", "dev", "prod"}) {
+		t.Fatalf("expected cluster-wide, dev and prod rows, got %v", namespaces)
+	}
+}
+
+func escalationSnapshotForTests() *indexer.Snapshot {
+	snapshot := &indexer.Snapshot{
+		BuiltAt: time.Now(),
+		RBACIndex: indexer.RBACIndex{
+			RolesByID:             map[indexer.RoleID]*indexer.RoleRecord{},
+			BindingsByRoleRef:     map[indexer.RoleRefKey][]*indexer.BindingRecord{},
+			BindingsBySubject:     map[indexer.SubjectKey][]*indexer.BindingRecord{},
+			AggregatedRoleSources: map[indexer.RoleID][]indexer.RoleID{},
+			RoleIDsByVerb:         map[string]map[indexer.RoleID]struct{}{},
+			RoleIDsByResource:     map[string]map[indexer.RoleID]struct{}{},
+			RoleIDsByAPIGroup:     map[string]map[indexer.RoleID]struct{}{},
+			AllRoleIDs:            []indexer.RoleID{},
+		},
+	}
+
+	addClusterRole := func(name string, rules ...rbacv1.PolicyRule) {
+		roleID := indexer.RecID(indexer.KindClusterRole, "", name)
+		snapshot.RolesByID[roleID] = &indexer.RoleRecord{
+			UID:   types.UID("cr-" + name),
+			Kind:  indexer.KindClusterRole,
+			Name:  name,
+			Rules: rules,
+		}
+		snapshot.AllRoleIDs = append(snapshot.AllRoleIDs, roleID)
+	}
+	addBinding := func(kind, namespace, name, roleName string, subjects ...rbacv1.Subject) {
+		binding := &indexer.BindingRecord{
+			UID:       types.UID(name),
+			Kind:      kind,
+			Namespace: namespace,
+			Name:      name,
+			RoleRef:   indexer.RoleRefKey{Kind: indexer.KindClusterRole, Name: roleName},
+			Subjects:  subjects,
+		}
+		snapshot.BindingsByRoleRef[binding.RoleRef] = append(snapshot.BindingsByRoleRef[binding.RoleRef], binding)
+		for _, subject := range subjects {
+			key := indexer.NewSubjectKey(subject, namespace)
+			snapshot.BindingsBySubject[key] = append(snapshot.BindingsBySubject[key], binding)
+		}
+	}
+
+	addClusterRole("secret-reader", rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"secrets"}, Verbs: []string{"get"}})
+	addClusterRole("deployer", rbacv1.PolicyRule{APIGroups: []string{"apps"}, Resources: []string{"deployments"}, Verbs: []string{"create"}})
+	addClusterRole("token-minter", rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"serviceaccounts/token"}, Verbs: []string{"create"}})
+	addClusterRole("impersonator", rbacv1.PolicyRule{
+		APIGroups: []string{""}, Resources: []string{"users"}, Verbs: []string{"impersonate"}, ResourceNames: []string{"root"},
+	})
+	addClusterRole("binder", rbacv1.PolicyRule{
+		APIGroups: []string{rbacv1.GroupName}, Resources: []string{"clusterroles"}, Verbs: []string{"bind"}, ResourceNames: []string{"cluster-admin"},
+	})
+	addClusterRole("cluster-admin", rbacv1.PolicyRule{APIGroups: []string{"*"}, Resources: []string{"*"}, Verbs: []string{"*"}})
+
+	for token, index := range map[string]map[string]map[indexer.RoleID]struct{}{
+		"":        snapshot.RoleIDsByAPIGroup,
+		"secrets": snapshot.RoleIDsByResource,
+		"get":     snapshot.RoleIDsByVerb,
+	} {
+		index[token] = map[indexer.RoleID]struct{}{"clusterrole:secret-reader": {}}
+	}
+
+	alice := rbacv1.Subject{Kind: indexer.SubjectKindUser, Name: "alice"}
+	deployer := rbacv1.Subject{Kind: indexer.SubjectKindServiceAccount, Namespace: "ci", Name: "deployer"}
+	builder := rbacv1.Subject{Kind: indexer.SubjectKindServiceAccount, Namespace: "ci", Name: "builder"}
+	outsider := rbacv1.Subject{Kind: indexer.SubjectKindServiceAccount, Namespace: "other", Name: "outsider"}
+
+	addBinding(indexer.KindClusterRoleBinding, "", "alice-secrets", "secret-reader", alice)
+	addBinding(indexer.KindClusterRoleBinding, "", "alice-binder", "binder", alice)
+	addBinding(indexer.KindRoleBinding, "ci", "alice-deploy", "deployer", alice)
+	addBinding(indexer.KindRoleBinding, "ci", "deployer-tokens", "token-minter", deployer)
+	addBinding(indexer.KindClusterRoleBinding, "", "builder-impersonate", "impersonator", builder)
+	addBinding(indexer.KindRoleBinding, "other", "outsider-deploy", "deployer", outsider)
+
+	return snapshot
+}
+
+func TestQuery_EscalationPathsFollowPrimitivesTransitively(t *testing.T) {
+	status := New().Query(escalationSnapshotForTests(), api.RoleGraphReviewSpec{
+		Selector:               api.Selector{Resources: []string{"secrets"}, Verbs: []string{"get"}},
+		IncludeEscalationPaths: true,
+	}, nil)
+
+	edges := make(map[string]api.GraphEdge)
+	for _, edge := range status.Graph.Edges {
+		edges[edge.ID] = edge
+	}
+	expected := []struct {
+		from, to string
+		edgeType api.GraphEdgeType
+	}{
+		{from: "subject:user:alice", to: "subject:serviceAccount:ci/deployer", edgeType: api.GraphEdgeTypeCanRunAs},
+		{from: "subject:user:alice", to: "subject:serviceAccount:ci/builder", edgeType: api.GraphEdgeTypeCanRunAs},
+		{from: "subject:user:alice", to: "role:clusterrole:cluster-admin", edgeType: api.GraphEdgeTypeCanBind},
+		{from: "subject:serviceAccount:ci/deployer", to: "subject:serviceAccount:ci/builder", edgeType: api.GraphEdgeTypeCanMintToken},
+		{from: "subject:serviceAccount:ci/builder", to: "subject:user:root", edgeType: api.GraphEdgeTypeCanImpersonate},
+	}
+	for _, want := range expected {
+		if _, ok := edges[edgeIDFor(want.from, want.to, want.edgeType)]; !ok {
+			t.Fatalf("expected %s edge %s -> %s, got edges %v", want.edgeType, want.from, want.to, status.Graph.Edges)
+		}
+	}
+
+	runAs := edges[edgeIDFor("subject:user:alice", "subject:serviceAccount:ci/deployer", api.GraphEdgeTypeCanRunAs)]
+	if runAs.Scope == nil || !slices.Equal(runAs.Scope.Namespaces, []string{"ci"}) {
+		t.Fatalf("expected canRunAs to be scoped to ci, got %#v", runAs.Scope)
+	}
+	if _, ok := edges[edgeIDFor("subject:user:alice", "subject:serviceAccount:other/outsider", api.GraphEdgeTypeCanRunAs)]; ok {
+		t.Fatalf("RoleBinding in ci must not let alice run as ServiceAccounts in other namespaces")
+	}
+	// Cluster-wide secret reads reach token secrets in every namespace.
+	if _, ok := edges[edgeIDFor("subject:user:alice", "subject:serviceAccount:other/outsider", api.GraphEdgeTypeCanReadToken)]; !ok {
+		t.Fatalf("expected cluster-wide secret read to reach other/outsider")
+	}
+	if status.MatchedSubjects != 1 {
+		t.Fatalf("escalation targets must not count as matched subjects, got %d", status.MatchedSubjects)
+	}
+}
+
+func TestQuery_EscalationPathsDisabledByDefault(t *testing.T) {
+	status := New().Query(escalationSnapshotForTests(), api.RoleGraphReviewSpec{
+		Selector: api.Selector{Resources: []string{"secrets"}, Verbs: []string{"get"}},
+	}, nil)
+
+	for _, edge := range status.Graph.Edges {
+		if edge.Type != api.GraphEdgeTypeGrants && edge.Type != api.GraphEdgeTypeSubjects {
+			t.Fatalf("unexpected edge without includeEscalationPaths: %#v", edge)
+		}
+	}
+}
+
+func TestQuery_RiskScoresPropagateAndFilter(t *testing.T) {
+	selector := api.Selector{Resources: []string{"secrets"}, Verbs: []string{"get"}}
+	status := New().Query(objectSnapshotForTests(), api.RoleGraphReviewSpec{Selector: selector}, nil)
+
+	nodes := make(map[string]api.GraphNode, len(status.Graph.Nodes))
+	for _, node := range status.Graph.Nodes {
+		nodes[node.ID] = node
+	}
+	for _, id := range []string{
+		"role:clusterrole:secret-reader",
+		"binding:clusterrolebinding:global-secrets",
+		"subject:group:admins",
+	} {
+		node, ok := nodes[id]
+		if !ok {
+			t.Fatalf("expected node %s in graph", id)
+		}
+		if node.RiskScore != 40 || len(node.RiskReasons) != 1 || node.RiskReasons[0].ID != risk.RuleSecretsRead {
+			t.Fatalf("expected %s to carry the secrets-read reason, got score %d reasons %#v", id, node.RiskScore, node.RiskReasons)
+		}
+	}
+
+	filtered := New().Query(objectSnapshotForTests(), api.RoleGraphReviewSpec{Selector: selector, MinRiskScore: 50}, nil)
+	if filtered.MatchedRoles != 0 || len(filtered.Graph.Nodes) != 0 {
+		t.Fatalf("expected minRiskScore=50 to drop every role, got %d roles", filtered.MatchedRoles)
+	}
+
+	unscored := NewWithRiskCatalog(nil).Query(objectSnapshotForTests(), api.RoleGraphReviewSpec{Selector: selector}, nil)
+	for _, node := range unscored.Graph.Nodes {
+		if node.RiskScore != 0 || len(node.RiskReasons) != 0 {
+			t.Fatalf("expected no risk data without a catalog, got %#v", node)
+		}
+	}
+}
+
+func TestQuery_RoleAndBindingMetadataFilters(t *testing.T) {
+	snapshot := objectSnapshotForTests()
+	snapshot.RolesByID[indexer.RecID(indexer.KindClusterRole, "", "secret-reader")].Labels = map[string]string{
+		"kubernetes.io/bootstrapping": "rbac-defaults",
+	}
+	snapshot.RolesByID[indexer.RecID(indexer.KindRole, "prod", "tls-reader")].Labels = map[string]string{
+		"app.kubernetes.io/managed-by": "Helm",
+	}
+	for _, binding := range snapshot.BindingsByRoleRef[indexer.RoleRefKey{Kind: indexer.KindClusterRole, Name: "secret-reader"}] {
+		if binding.Namespace != "" {
+			binding.Labels = map[string]string{"team": binding.Namespace}
+		}
+	}
+	selector := api.Selector{Resources: []string{"secrets"}, Verbs: []string{"get"}}
+
+	tests := []struct {
+		name      string
+		spec      api.RoleGraphReviewSpec
+		wantRoles []string
+		wantBinds []string
+	}{
+		{
+			name: "role label selector",
+			spec: api.RoleGraphReviewSpec{RoleLabelSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"app.kubernetes.io/managed-by": "Helm"},
+			}},
+			wantRoles: []string{"role:role:prod/tls-reader"},
+			wantBinds: []string{"binding:rolebinding:prod/tls"},
+		},
+		{
+			name: "role label exclusion",
+			spec: api.RoleGraphReviewSpec{RoleLabelSelector: &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{{
+					Key: "kubernetes.io/bootstrapping", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"rbac-defaults"},
+				}},
+			}},
+			wantRoles: []string{"role:role:prod/tls-reader"},
+			wantBinds: []string{"binding:rolebinding:prod/tls"},
+		},
+		{
+			name:      "role name pattern",
+			spec:      api.RoleGraphReviewSpec{RoleNamePatterns: []string{"secret-*"}},
+			wantRoles: []string{"role:clusterrole:secret-reader"},
+			wantBinds: []string{"binding:clusterrolebinding:global-secrets", "binding:rolebinding:dev/dev-secrets", "binding:rolebinding:prod/prod-secrets"},
+		},
+		{
+			name: "binding label selector drops roles without matching bindings",
+			spec: api.RoleGraphReviewSpec{BindingLabelSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"team": "dev"},
+			}},
+			wantRoles: []string{"role:clusterrole:secret-reader"},
+			wantBinds: []string{"binding:rolebinding:dev/dev-secrets"},
+		},
+		{
+			name:      "binding name pattern",
+			spec:      api.RoleGraphReviewSpec{BindingNamePatterns: []string{"global-*", "tls"}},
+			wantRoles: []string{"role:clusterrole:secret-reader", "role:role:prod/tls-reader"},
+			wantBinds: []string{"binding:clusterrolebinding:global-secrets", "binding:rolebinding:prod/tls"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.spec.Selector = selector
+			status := New().Query(snapshot, tt.spec, nil)
+
+			var roles, binds []string
+			for _, node := range status.Graph.Nodes {
+				switch node.Type {
+				case api.GraphNodeTypeRole, api.GraphNodeTypeClusterRole:
+					roles = append(roles, node.ID)
+				case api.GraphNodeTypeRoleBinding, api.GraphNodeTypeClusterRoleBinding:
+					binds = append(binds, node.ID)
+				}
+			}
+			slices.Sort(roles)
+			slices.Sort(binds)
+			if !slices.Equal(roles, tt.wantRoles) || !slices.Equal(binds, tt.wantBinds) {
+				t.Fatalf("expected roles %v and bindings %v, got %v and %v", tt.wantRoles, tt.wantBinds, roles, binds)
+			}
+		})
+	}
+}
+
+func subjectSelectorSnapshotForTests() *indexer.Snapshot {
+	snapshot := &indexer.Snapshot{
+		BuiltAt: time.Now(),
+		RBACIndex: indexer.RBACIndex{
+			RolesByID:         map[indexer.RoleID]*indexer.RoleRecord{},
+			BindingsByRoleRef: map[indexer.RoleRefKey][]*indexer.BindingRecord{},
+			RoleIDsByVerb:     map[string]map[indexer.RoleID]struct{}{},
+		},
+	}
+	roleID := indexer.RecID(indexer.KindClusterRole, "", "deployer")
+	snapshot.RolesByID[roleID] = &indexer.RoleRecord{
+		UID:  types.UID("cr-deployer"),
+		Kind: indexer.KindClusterRole,
+		Name: "deployer",
+		Rules: []rbacv1.PolicyRule{{
+			APIGroups: []string{"apps"},
+			Resources: []string{"deployments"},
+			Verbs:     []string{"delete"},
+		}},
+	}
+	snapshot.AllRoleIDs = []indexer.RoleID{roleID}
+	snapshot.RoleIDsByVerb["delete"] = map[indexer.RoleID]struct{}{roleID: {}}
+
+	ref := indexer.RoleRefKey{Kind: indexer.KindClusterRole, Name: "deployer"}
+	snapshot.BindingsByRoleRef[ref] = []*indexer.BindingRecord{
+		{
+			UID:     types.UID("crb-deployers"),
+			Kind:    indexer.KindClusterRoleBinding,
+			Name:    "deployers",
+			RoleRef: ref,
+			Subjects: []rbacv1.Subject{
+				{Kind: indexer.SubjectKindServiceAccount, Namespace: "ci", Name: "ci-runner"},
+				{Kind: indexer.SubjectKindServiceAccount, Namespace: "prod", Name: "app"},
+				{Kind: indexer.SubjectKindUser, Name: "alice"},
+				{Kind: indexer.SubjectKindGroup, Name: "ops"},
+			},
+		},
+		{
+			UID:       types.UID("rb-nightly"),
+			Kind:      indexer.KindRoleBinding,
+			Namespace: "ci",
+			Name:      "nightly",
+			RoleRef:   ref,
+			// The namespace of a ServiceAccount subject defaults to the binding's.
+			Subjects: []rbacv1.Subject{{Kind: indexer.SubjectKindServiceAccount, Name: "ci-nightly"}},
+		},
+	}
+
+	return snapshot
+}
+
+func TestQuery_SubjectSelector(t *testing.T) {
+	tests := []struct {
+		name         string
+		subjects     api.SubjectSelector
+		wantSubjects []string
+		wantBindings int
+	}{
+		{
+			name:         "service accounts in namespace",
+			subjects:     api.SubjectSelector{Kinds: []string{api.SubjectKindServiceAccount}, Namespaces: []string{"ci"}},
+			wantSubjects: []string{"subject:serviceAccount:ci-nightly", "subject:serviceAccount:ci/ci-runner"},
+			wantBindings: 2,
+		},
+		{
+			name:         "name pattern",
+			subjects:     api.SubjectSelector{NamePatterns: []string{"ci-*"}},
+			wantSubjects: []string{"subject:serviceAccount:ci-nightly", "subject:serviceAccount:ci/ci-runner"},
+			wantBindings: 2,
+		},
+		{
+			name:         "anchored regex and exact names",
+			subjects:     api.SubjectSelector{Names: []string{"ops"}, NameRegex: "ali"},
+			wantSubjects: []string{"subject:group:ops"},
+			wantBindings: 1,
+		},
+		{
+			name:     "no match drops the role",
+			subjects: api.SubjectSelector{Kinds: []string{api.SubjectKindUser}, NameRegex: "bob|carol"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status := New().Query(subjectSelectorSnapshotForTests(), api.RoleGraphReviewSpec{
+				Selector: api.Selector{Verbs: []string{"delete"}, Subjects: &tt.subjects},
+			}, nil)
+
+			var subjects []string
+			for _, node := range status.Graph.Nodes {
+				switch node.Type {
+				case api.GraphNodeTypeUser, api.GraphNodeTypeGroup, api.GraphNodeTypeServiceAccount:
+					subjects = append(subjects, node.ID)
+				}
+			}
+			slices.Sort(subjects)
+			if !slices.Equal(subjects, tt.wantSubjects) {
+				t.Fatalf("expected subjects %v, got %v", tt.wantSubjects, subjects)
+			}
+			if status.MatchedBindings != tt.wantBindings {
+				t.Fatalf("expected %d bindings, got %d", tt.wantBindings, status.MatchedBindings)
+			}
+			if len(tt.wantSubjects) == 0 {
+				if status.MatchedRoles != 0 || len(status.ResourceMap) != 0 {
+					t.Fatalf("expected no roles and no resource map, got %d roles and %#v", status.MatchedRoles, status.ResourceMap)
+				}
+
+				return
+			}
+			if len(status.ResourceMap) != 1 || status.ResourceMap[0].SubjectCount != len(tt.wantSubjects) {
+				t.Fatalf("expected resource map to count %d subjects, got %#v", len(tt.wantSubjects), status.ResourceMap)
+			}
+		})
+	}
+}
+
+func TestQuery_SortByRiskScore(t *testing.T) {
+	status := New().Query(escalationSnapshotForTests(), api.RoleGraphReviewSpec{
+		Selector:               api.Selector{Resources: []string{"secrets"}, Verbs: []string{"get"}},
+		IncludeEscalationPaths: true,
+		SortByRiskScore:        true,
+	}, nil)
+
+	if len(status.Graph.Nodes) == 0 {
+		t.Fatalf("expected nodes in graph")
+	}
+	if first := status.Graph.Nodes[0]; first.ID != "role:clusterrole:cluster-admin" {
+		t.Fatalf("expected cluster-admin to sort first, got %s (score %d)", first.ID, first.RiskScore)
+	}
+	for i := 1; i < len(status.Graph.Nodes); i++ {
+		if status.Graph.Nodes[i-1].RiskScore < status.Graph.Nodes[i].RiskScore {
+			t.Fatalf("nodes not sorted by descending risk at %d: %d < %d",
+				i, status.Graph.Nodes[i-1].RiskScore, status.Graph.Nodes[i].RiskScore)
+		}
+	}
+}
+
+func hygieneSnapshotForTests() *indexer.Snapshot {
+	snapshot := &indexer.Snapshot{
+		RBACIndex: indexer.RBACIndex{
+			RolesByID:             map[indexer.RoleID]*indexer.RoleRecord{},
+			BindingsByRoleRef:     map[indexer.RoleRefKey][]*indexer.BindingRecord{},
+			AggregatedRoleSources: map[indexer.RoleID][]indexer.RoleID{},
+			ServiceAccounts:       map[indexer.ServiceAccountKey]*indexer.ServiceAccountRecord{},
+		},
+	}
+	addRole := func(kind, namespace, name string, labels map[string]string) {
+		id := indexer.RecID(kind, namespace, name)
+		snapshot.RolesByID[id] = &indexer.RoleRecord{Kind: kind, Namespace: namespace, Name: name, Labels: labels}
+		snapshot.AllRoleIDs = append(snapshot.AllRoleIDs, id)
+	}
+	addBinding := func(kind, namespace, name string, roleRef indexer.RoleRefKey, subjects ...rbacv1.Subject) {
+		snapshot.BindingsByRoleRef[roleRef] = append(snapshot.BindingsByRoleRef[roleRef], &indexer.BindingRecord{
+			Kind: kind, Namespace: namespace, Name: name, RoleRef: roleRef, Subjects: subjects,
+		})
+	}
+
+	addRole(indexer.KindClusterRole, "", "reader", nil)
+	addRole(indexer.KindClusterRole, "", "unused", nil)
+	addRole(indexer.KindClusterRole, "", "aggregate-to-reader", nil)
+	addRole(indexer.KindClusterRole, "", "view", map[string]string{"kubernetes.io/bootstrapping": "rbac-defaults"})
+	addRole(indexer.KindClusterRole, "", "system:unused", nil)
+	addRole(indexer.KindRole, "prod", "local", nil)
+	snapshot.AggregatedRoleSources[indexer.RecID(indexer.KindClusterRole, "", "reader")] = []indexer.RoleID{
+		indexer.RecID(indexer.KindClusterRole, "", "aggregate-to-reader"),
+	}
+	snapshot.ServiceAccounts[indexer.ServiceAccountKey{Namespace: "prod", Name: "app"}] = &indexer.ServiceAccountRecord{Namespace: "prod", Name: "app"}
+
+	reader := indexer.RoleRefKey{Kind: indexer.KindClusterRole, Name: "reader"}
+	addBinding(indexer.KindRoleBinding, "prod", "reader-app", reader,
+		rbacv1.Subject{Kind: indexer.SubjectKindServiceAccount, Name: "app"},
+		rbacv1.Subject{Kind: indexer.SubjectKindServiceAccount, Name: "deleted-sa"},
+	)
+	addBinding(indexer.KindClusterRoleBinding, "", "leftover", indexer.RoleRefKey{Kind: indexer.KindClusterRole, Name: "deleted"},
+		rbacv1.Subject{Kind: indexer.SubjectKindUser, Name: "alice"},
+	)
+	addBinding(indexer.KindRoleBinding, "prod", "local-empty", indexer.RoleRefKey{Kind: indexer.KindRole, Namespace: "prod", Name: "local"})
+	addBinding(indexer.KindRoleBinding, "dev", "local-missing", indexer.RoleRefKey{Kind: indexer.KindRole, Namespace: "dev", Name: "local"},
+		rbacv1.Subject{Kind: indexer.SubjectKindServiceAccount, Namespace: "dev", Name: "ci"},
+	)
+
+	return snapshot
+}
+
+func TestQueryHygiene_ReportsAllChecks(t *testing.T) {
+	snapshot := hygieneSnapshotForTests()
+	status := New().QueryHygiene(snapshot, api.RBACHygieneReportSpec{})
+
+	got := make([]string, 0, len(status.Findings))
+	for _, finding := range status.Findings {
+		got = append(got, fmt.Sprintf("%s %s %s/%s", finding.Check, finding.Kind, finding.Namespace, finding.Name))
+	}
+	want := []string{
+		"unboundRole ClusterRole /unused",
+		"missingRole ClusterRoleBinding /leftover",
+		"missingRole RoleBinding dev/local-missing",
+		"missingServiceAccount RoleBinding dev/local-missing",
+		"emptySubjects RoleBinding prod/local-empty",
+		"missingServiceAccount RoleBinding prod/reader-app",
+	}
+	if !slices.Equal(got, want) {
+		t.Fatalf("unexpected findings:\n got: %v\nwant: %v", got, want)
+	}
+	if subject := status.Findings[5].Subject; subject == nil || subject.Namespace != "prod" || subject.Name != "deleted-sa" {
+		t.Fatalf("expected missing ServiceAccount prod/deleted-sa, got %+v", subject)
+	}
+}
+
+func TestQueryHygiene_ChecksNamespaceScopeAndSystemObjects(t *testing.T) {
+	snapshot := hygieneSnapshotForTests()
+	status := New().QueryHygiene(snapshot, api.RBACHygieneReportSpec{
+		NamespaceScope:       api.NamespaceScope{Namespaces: []string{"prod"}},
+		Checks:               []api.HygieneCheck{api.HygieneCheckUnboundRole, api.HygieneCheckEmptySubjects},
+		IncludeSystemObjects: true,
+	})
+
+	got := make([]string, 0, len(status.Findings))
+	for _, finding := range status.Findings {
+		got = append(got, fmt.Sprintf("%s %s", finding.Check, finding.Name))
+	}
+	want := []string{"unboundRole system:unused", "unboundRole unused", "unboundRole view", "emptySubjects local-empty"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("unexpected findings:\n got: %v\nwant: %v", got, want)
+	}
+}
+
+func TestQueryHygiene_ScopedReferencesAreUnresolved(t *testing.T) {
+	full := hygieneSnapshotForTests()
+	full.ServiceAccounts[indexer.ServiceAccountKey{Namespace: "dev", Name: "builder"}] = &indexer.ServiceAccountRecord{Namespace: "dev", Name: "builder"}
+	reader := indexer.RoleRefKey{Kind: indexer.KindClusterRole, Name: "reader"}
+	full.BindingsByRoleRef[reader] = append(full.BindingsByRoleRef[reader], &indexer.BindingRecord{
+		Kind: indexer.KindRoleBinding, Namespace: "dev", Name: "reader-prod-app", RoleRef: reader,
+		Subjects: []rbacv1.Subject{{Kind: indexer.SubjectKindServiceAccount, Namespace: "prod", Name: "app"}},
+	})
+	dev := map[string]struct{}{"dev": {}}
+	snapshot := indexer.Scoped(full, &authz.AccessScope{
+		AllowedRoleNamespaces:           dev,
+		AllowedBindingNamespaces:        dev,
+		AllowedServiceAccountNamespaces: dev,
+	})
+
+	status := New().QueryHygiene(snapshot, api.RBACHygieneReportSpec{})
+
+	got := make([]string, 0, len(status.Findings))
+	for _, finding := range status.Findings {
+		got = append(got, fmt.Sprintf("%s %s/%s unresolved=%t", finding.Check, finding.Namespace, finding.Name, finding.Unresolved))
+	}
+	want := []string{
+		"missingRole dev/local-missing unresolved=false",
+		"missingServiceAccount dev/local-missing unresolved=false",
+		"missingRole dev/reader-prod-app unresolved=true",
+		"missingServiceAccount dev/reader-prod-app unresolved=true",
+	}
+	if !slices.Equal(got, want) {
+		t.Fatalf("unexpected findings:\n got: %v\nwant: %v", got, want)
+	}
+	if message := status.Findings[2].Message; message != `RoleBinding dev/reader-prod-app references ClusterRole "reader" outside the caller's scope` {
+		t.Fatalf("unexpected unresolved message %q", message)
+	}
+}
+
+func TestQueryHygiene_UnboundClusterRoleWithHiddenBindingsIsUnresolved(t *testing.T) {
+	snapshot := indexer.Scoped(hygieneSnapshotForTests(), &authz.AccessScope{
+		CanListClusterRoles:      true,
+		CanListRoles:             true,
+		AllowedBindingNamespaces: map[string]struct{}{"dev": {}},
+	})
+
+	status := New().QueryHygiene(snapshot, api.RBACHygieneReportSpec{
+		Checks: []api.HygieneCheck{api.HygieneCheckUnboundRole},
+	})
+
+	got := make([]string, 0, len(status.Findings))
+	for _, finding := range status.Findings {
+		got = append(got, fmt.Sprintf("%s %s/%s unresolved=%t", finding.Check, finding.Namespace, finding.Name, finding.Unresolved))
+	}
+	want := []string{
+		"unboundRole /reader unresolved=true",
+		"unboundRole /unused unresolved=true",
+		"unboundRole prod/local unresolved=true",
+	}
+	if !slices.Equal(got, want) {
+		t.Fatalf("unexpected findings:\n got: %v\nwant: %v", got, want)
+	}
+}
+
+func TestQueryHygiene_SkipsServiceAccountCheckWithoutIndex(t *testing.T) {
+	snapshot := hygieneSnapshotForTests()
+	clear(snapshot.ServiceAccounts)
+	status := New().QueryHygiene(snapshot, api.RBACHygieneReportSpec{
+		Checks: []api.HygieneCheck{api.HygieneCheckMissingServiceAccount},
+	})
+
+	if len(status.Findings) != 0 {
+		t.Fatalf("expected no findings, got %+v", status.Findings)
+	}
+	if !contains(status.Warnings, "no ServiceAccounts are indexed; missingServiceAccount check skipped") {
+		t.Fatalf("expected skipped check warning, got %v", status.Warnings)
+	}
+}
+
+func TestDiff_ReportsAddedAndRemovedAccess(t *testing.T) {
+	from := basicSnapshotForGolden()
+	from.Generation = 1
+	bobRef := indexer.RoleRefKey{Kind: indexer.KindClusterRole, Name: "exec-role"}
+	from.BindingsByRoleRef[bobRef] = append(from.BindingsByRoleRef[bobRef], &indexer.BindingRecord{
+		UID:      types.UID("b2"),
+		Kind:     indexer.KindClusterRoleBinding,
+		Name:     "bind-exec-bob",
+		RoleRef:  bobRef,
+		Subjects: []rbacv1.Subject{{Kind: indexer.SubjectKindUser, Name: "bob"}},
+	})
+
+	to := basicSnapshotForGolden()
+	to.Generation = 2
+	role := &indexer.RoleRecord{
+		UID:       types.UID("r2"),
+		Kind:      indexer.KindRole,
+		Namespace: "dev",
+		Name:      "exec-dev",
+		Rules: []rbacv1.PolicyRule{{
+			APIGroups: []string{""},
+			Resources: []string{"pods/exec"},
+			Verbs:     []string{"create"},
+		}},
+	}
+	roleID := indexer.RecID(role.Kind, role.Namespace, role.Name)
+	to.RolesByID[roleID] = role
+	to.AllRoleIDs = append(to.AllRoleIDs, roleID)
+	to.RoleIDsByAPIGroup[""][roleID] = struct{}{}
+	to.RoleIDsByResource["pods/exec"][roleID] = struct{}{}
+	to.RoleIDsByVerb["create"][roleID] = struct{}{}
+	devRef := indexer.RoleRefKey{Kind: indexer.KindRole, Namespace: "dev", Name: "exec-dev"}
+	to.BindingsByRoleRef[devRef] = []*indexer.BindingRecord{{
+		UID:       types.UID("b3"),
+		Kind:      indexer.KindRoleBinding,
+		Namespace: "dev",
+		Name:      "ci-exec",
+		RoleRef:   devRef,
+		Subjects:  []rbacv1.Subject{{Kind: indexer.SubjectKindServiceAccount, Namespace: "dev", Name: "ci"}},
+	}}
+
+	status := New().Diff(from, to, api.RoleGraphDiffSpec{
+		Selector:               api.Selector{Resources: []string{"pods/exec"}, Verbs: []string{"create"}},
+		ResourceMapByNamespace: true,
+	}, nil)
+
+	if status.From.Generation != 1 || status.To.Generation != 2 {
+		t.Fatalf("unexpected generations from=%d to=%d", status.From.Generation, status.To.Generation)
+	}
+	wantRoles := []api.ObjectRef{{Kind: indexer.KindRole, Namespace: "dev", Name: "exec-dev"}}
+	if !slices.Equal(status.Added.Roles, wantRoles) || len(status.Removed.Roles) != 0 {
+		t.Fatalf("unexpected roles: added=%v removed=%v", status.Added.Roles, status.Removed.Roles)
+	}
+	if !slices.Equal(status.Added.Bindings, []api.ObjectRef{{Kind: indexer.KindRoleBinding, Namespace: "dev", Name: "ci-exec"}}) {
+		t.Fatalf("unexpected added bindings: %v", status.Added.Bindings)
+	}
+	if !slices.Equal(status.Removed.Bindings, []api.ObjectRef{{Kind: indexer.KindClusterRoleBinding, Name: "bind-exec-bob"}}) {
+		t.Fatalf("unexpected removed bindings: %v", status.Removed.Bindings)
+	}
+	if !slices.Equal(status.Added.Subjects, []api.SubjectRef{{Kind: api.SubjectKindServiceAccount, Namespace: "dev", Name: "ci"}}) {
+		t.Fatalf("unexpected added subjects: %v", status.Added.Subjects)
+	}
+	if !slices.Equal(status.Removed.Subjects, []api.SubjectRef{{Kind: api.SubjectKindUser, Name: "bob"}}) {
+		t.Fatalf("unexpected removed subjects: %v", status.Removed.Subjects)
+	}
+	if len(status.Added.Permissions) != 1 || status.Added.Permissions[0].Namespace != "dev" {
+		t.Fatalf("expected one added permission row in dev, got %+v", status.Added.Permissions)
+	}
+	if len(status.Removed.Permissions) != 0 {
+		t.Fatalf("expected no removed permission rows, got %+v", status.Removed.Permissions)
+	}
+}
+
+func TestDiff_ReportsSubjectAddedToExistingBinding(t *testing.T) {
+	roleRef := indexer.RoleRefKey{Kind: indexer.KindClusterRole, Name: "exec-role"}
+	build := func(generation int64, devSubjects ...rbacv1.Subject) *indexer.Snapshot {
+		snapshot := basicSnapshotForGolden()
+		snapshot.Generation = generation
+		snapshot.BindingsByRoleRef[roleRef] = append(snapshot.BindingsByRoleRef[roleRef],
+			&indexer.BindingRecord{
+				UID:      types.UID("b2"),
+				Kind:     indexer.KindClusterRoleBinding,
+				Name:     "bind-exec-bob",
+				RoleRef:  roleRef,
+				Subjects: []rbacv1.Subject{{Kind: indexer.SubjectKindUser, Name: "bob"}},
+			},
+			&indexer.BindingRecord{
+				UID:       types.UID("b3"),
+				Kind:      indexer.KindRoleBinding,
+				Namespace: "dev",
+				Name:      "dev-exec",
+				RoleRef:   roleRef,
+				Subjects:  devSubjects,
+			},
+		)
+
+		return snapshot
+	}
+	alice := rbacv1.Subject{Kind: indexer.SubjectKindUser, Name: "alice"}
+	bob := rbacv1.Subject{Kind: indexer.SubjectKindUser, Name: "bob"}
+
+	status := New().Diff(build(1, alice), build(2, alice, bob), api.RoleGraphDiffSpec{
+		Selector: api.Selector{Resources: []string{"pods/exec"}, Verbs: []string{"create"}},
+	}, nil)
+
+	if len(status.Added.Roles)+len(status.Added.Bindings)+len(status.Added.Subjects) != 0 {
+		t.Fatalf("expected no added identities, got %+v", status.Added)
+	}
+	want := []api.BindingSubject{{
+		Binding: api.ObjectRef{Kind: indexer.KindRoleBinding, Namespace: "dev", Name: "dev-exec"},
+		Subject: api.SubjectRef{Kind: api.SubjectKindUser, Name: "bob"},
+	}}
+	if !slices.Equal(status.Added.BindingSubjects, want) {
+		t.Fatalf("unexpected added binding subjects: %+v", status.Added.BindingSubjects)
+	}
+	if len(status.Removed.BindingSubjects) != 0 {
+		t.Fatalf("expected no removed binding subjects, got %+v", status.Removed.BindingSubjects)
+	}
+}
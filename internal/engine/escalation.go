@@ -0,0 +1,382 @@
+package engine
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+
+	"k8s-role-graph/internal/indexer"
+	api "k8s-role-graph/pkg/apis/rbacgraph"
+)
+
+const (
+	// maxEscalationDepth bounds how many "can become" hops are followed from
+	// the subjects matched by the query.
+	maxEscalationDepth = 3
+	// maxEscalationTargets bounds the fan-out of a single primitive, e.g.
+	// unrestricted impersonation of every known user.
+	maxEscalationTargets = 25
+
+	edgeExplainCanImpersonate = "Subject can impersonate target"
+	edgeExplainCanRunAs       = "Subject can create pods or workloads running as target ServiceAccount"
+	edgeExplainCanReadToken   = "Subject can read ServiceAccount token secrets of target"
+	edgeExplainCanMintToken   = "Subject can create tokens for target ServiceAccount"
+	edgeExplainCanBind        = "Subject can bind target role to itself"
+	edgeExplainCanEscalate    = "Subject can escalate target role beyond its own permissions"
+)
+
+// podCreatingResources lists the resources whose create verb lets a subject
+// start a pod with any ServiceAccount of the namespace, keyed by API group.
+var podCreatingResources = map[string][]string{
+	"":      {"pods", "replicationcontrollers"},
+	"apps":  {"deployments", "replicasets", "statefulsets", "daemonsets"},
+	"batch": {"jobs", "cronjobs"},
+}
+
+// escalationTarget is a node reachable from a subject through one
+// escalation primitive. Exactly one of subject and role is set.
+type escalationTarget struct {
+	edgeType api.GraphEdgeType
+	explain  string
+	scope    *api.EffectiveScope
+	subject  *indexer.SubjectKey
+	role     *indexer.RoleRecord
+}
+
+// escalationIndex holds the candidate targets of escalation primitives,
+// built once per query from the reverse subject index.
+type escalationIndex struct {
+	serviceAccounts []indexer.SubjectKey
+	usersAndGroups  []indexer.SubjectKey
+	roles           []*indexer.RoleRecord
+}
+
+func newEscalationIndex(snapshot *indexer.Snapshot) *escalationIndex {
+	idx := &escalationIndex{}
+	for key := range snapshot.BindingsBySubject {
+		if key.Kind == indexer.SubjectKindServiceAccount {
+			idx.serviceAccounts = append(idx.serviceAccounts, key)
+		} else {
+			idx.usersAndGroups = append(idx.usersAndGroups, key)
+		}
+	}
+	sortSubjectKeys(idx.serviceAccounts)
+	sortSubjectKeys(idx.usersAndGroups)
+	for _, roleID := range snapshot.AllRoleIDs {
+		if role, ok := snapshot.RolesByID[roleID]; ok {
+			idx.roles = append(idx.roles, role)
+		}
+	}
+
+	return idx
+}
+
+// expandEscalationPaths follows escalation primitives held by the matched
+// subjects and adds "can become" edges to the subjects and roles they lead
+// to. Reached subjects are expanded in turn up to maxEscalationDepth.
+func (qc *queryContext) expandEscalationPaths() {
+	if !qc.spec.IncludeEscalationPaths || len(qc.subjectKeys) == 0 {
+		return
+	}
+	idx := newEscalationIndex(qc.snapshot)
+
+	visited := make(map[indexer.SubjectKey]struct{}, len(qc.subjectKeys))
+	frontier := make([]indexer.SubjectKey, 0, len(qc.subjectKeys))
+	for _, key := range qc.subjectKeys {
+		if _, ok := visited[key]; ok {
+			continue
+		}
+		visited[key] = struct{}{}
+		frontier = append(frontier, key)
+	}
+	sortSubjectKeys(frontier)
+
+	for depth := 0; depth < maxEscalationDepth && len(frontier) > 0; depth++ {
+		next := make([]indexer.SubjectKey, 0)
+		for _, from := range frontier {
+			fromNodeID := qc.addEscalationSubjectNode(from)
+			for _, target := range qc.escalationTargets(idx, from) {
+				var toNodeID string
+				if target.role != nil {
+					roleID := indexer.RecID(target.role.Kind, target.role.Namespace, target.role.Name)
+					toNodeID = qc.upsertRoleNode(target.role, qc.snapshot.AggregatedRoleSources[roleID], nil)
+				} else {
+					toNodeID = qc.addEscalationSubjectNode(*target.subject)
+					if _, ok := visited[*target.subject]; !ok {
+						visited[*target.subject] = struct{}{}
+						next = append(next, *target.subject)
+					}
+				}
+				qc.appendEdgeIfMissing(api.GraphEdge{
+					ID:      edgeIDFor(fromNodeID, toNodeID, target.edgeType),
+					From:    fromNodeID,
+					To:      toNodeID,
+					Type:    target.edgeType,
+					Explain: target.explain,
+					Scope:   target.scope,
+				})
+			}
+		}
+		frontier = next
+	}
+}
+
+func (qc *queryContext) addEscalationSubjectNode(key indexer.SubjectKey) string {
+	subject := rbacv1.Subject{Kind: key.Kind, Name: key.Name, Namespace: key.Namespace}
+	nodeID := subjectNodeID(subject)
+	qc.addNodeIfMissing(api.GraphNode{
+		ID:        nodeID,
+		Type:      subjectType(subject.Kind),
+		Name:      subject.Name,
+		Namespace: subject.Namespace,
+	})
+
+	return nodeID
+}
+
+// escalationTargets evaluates every rule granted to the subject (directly or
+// through its implicit groups) against the known escalation primitives.
+//
+//nolint:gocognit // walks subject keys -> bindings -> role rules -> primitives
+func (qc *queryContext) escalationTargets(idx *escalationIndex, from indexer.SubjectKey) []escalationTarget {
+	keys := []indexer.SubjectKey{from}
+	if from.Kind != indexer.SubjectKindGroup {
+		subject := api.SubjectRef{Kind: from.Kind, Name: from.Name, Namespace: from.Namespace}
+		for _, group := range EffectiveGroups(subject, nil) {
+			keys = append(keys, indexer.SubjectKey{Kind: indexer.SubjectKindGroup, Name: group})
+		}
+	}
+
+	targets := make([]escalationTarget, 0)
+	for _, key := range keys {
+		for _, binding := range qc.snapshot.BindingsBySubject[key] {
+			roleID := indexer.RecID(binding.RoleRef.Kind, binding.RoleRef.Namespace, binding.RoleRef.Name)
+			role, ok := qc.snapshot.RolesByID[roleID]
+			if !ok {
+				continue
+			}
+			for _, rule := range role.Rules {
+				targets = append(targets, qc.ruleEscalationTargets(idx, from, binding, rule)...)
+			}
+		}
+	}
+
+	return targets
+}
+
+//nolint:gocognit,gocyclo // one branch per escalation primitive
+func (qc *queryContext) ruleEscalationTargets(
+	idx *escalationIndex, from indexer.SubjectKey, binding *indexer.BindingRecord, rule rbacv1.PolicyRule,
+) []escalationTarget {
+	scope := bindingScope(binding)
+	out := make([]escalationTarget, 0)
+	add := func(edgeType api.GraphEdgeType, explain string, subjects []indexer.SubjectKey) {
+		subjects = qc.capEscalationTargets(from, edgeType, subjects)
+		for i := range subjects {
+			if subjects[i] == from {
+				continue
+			}
+			out = append(out, escalationTarget{edgeType: edgeType, explain: explain, scope: scope, subject: &subjects[i]})
+		}
+	}
+
+	// Impersonation of users and groups is checked cluster-wide, so only
+	// ClusterRoleBindings grant it; ServiceAccounts are namespaced.
+	if ruleAllows(rule, "", "impersonate") {
+		if binding.Namespace == "" {
+			for _, resource := range []string{"users", "groups"} {
+				if !ruleCoversResource(rule, resource) {
+					continue
+				}
+				kind := indexer.SubjectKindUser
+				if resource == "groups" {
+					kind = indexer.SubjectKindGroup
+				}
+				add(api.GraphEdgeTypeCanImpersonate, edgeExplainCanImpersonate,
+					namedOrAll(rule.ResourceNames, kind, idx.usersAndGroups))
+			}
+		}
+		if ruleCoversResource(rule, "serviceaccounts") {
+			add(api.GraphEdgeTypeCanImpersonate, edgeExplainCanImpersonate,
+				serviceAccountsInScope(idx.serviceAccounts, binding.Namespace, rule.ResourceNames))
+		}
+	}
+
+	if ruleCreatesPods(rule) {
+		add(api.GraphEdgeTypeCanRunAs, edgeExplainCanRunAs,
+			serviceAccountsInScope(idx.serviceAccounts, binding.Namespace, nil))
+	}
+
+	// Reading named secrets cannot be mapped to ServiceAccounts without the
+	// secret objects, so only unrestricted reads count.
+	if len(rule.ResourceNames) == 0 && ruleCoversResource(rule, "secrets") &&
+		(ruleAllows(rule, "", "get") || ruleAllows(rule, "", "list") || ruleAllows(rule, "", "watch")) {
+		add(api.GraphEdgeTypeCanReadToken, edgeExplainCanReadToken,
+			serviceAccountsInScope(idx.serviceAccounts, binding.Namespace, nil))
+	}
+
+	if ruleAllows(rule, "", "create") && ruleCoversResource(rule, "serviceaccounts/token") {
+		add(api.GraphEdgeTypeCanMintToken, edgeExplainCanMintToken,
+			serviceAccountsInScope(idx.serviceAccounts, binding.Namespace, rule.ResourceNames))
+	}
+
+	for _, primitive := range []struct {
+		verb     string
+		edgeType api.GraphEdgeType
+		explain  string
+	}{
+		{verb: "bind", edgeType: api.GraphEdgeTypeCanBind, explain: edgeExplainCanBind},
+		{verb: "escalate", edgeType: api.GraphEdgeTypeCanEscalate, explain: edgeExplainCanEscalate},
+	} {
+		if !ruleAllows(rule, rbacv1.GroupName, primitive.verb) {
+			continue
+		}
+		roles := rolesInScope(idx.roles, rule, binding.Namespace)
+		if len(roles) > maxEscalationTargets {
+			qc.warnEscalationTruncated(from, primitive.edgeType)
+			roles = roles[:maxEscalationTargets]
+		}
+		for _, role := range roles {
+			out = append(out, escalationTarget{edgeType: primitive.edgeType, explain: primitive.explain, scope: scope, role: role})
+		}
+	}
+
+	return out
+}
+
+func (qc *queryContext) capEscalationTargets(from indexer.SubjectKey, edgeType api.GraphEdgeType, subjects []indexer.SubjectKey) []indexer.SubjectKey {
+	if len(subjects) <= maxEscalationTargets {
+		return subjects
+	}
+	qc.warnEscalationTruncated(from, edgeType)
+
+	return subjects[:maxEscalationTargets]
+}
+
+func (qc *queryContext) warnEscalationTruncated(from indexer.SubjectKey, edgeType api.GraphEdgeType) {
+	qc.addWarning(fmt.Sprintf("escalation targets of type %s for %s truncated at %d entries",
+		edgeType, from, maxEscalationTargets))
+}
+
+// ruleAllows reports whether the rule grants verb in apiGroup. Resources are
+// checked separately.
+func ruleAllows(rule rbacv1.PolicyRule, apiGroup, verb string) bool {
+	return containsOrWildcard(rule.APIGroups, apiGroup) && containsOrWildcard(rule.Verbs, verb)
+}
+
+// ruleCoversResource reports whether the rule names the resource, either
+// literally, via "*", or via "*/<subresource>".
+func ruleCoversResource(rule rbacv1.PolicyRule, resource string) bool {
+	_, subresource, hasSub := strings.Cut(resource, "/")
+	for _, candidate := range rule.Resources {
+		if candidate == rbacv1.ResourceAll || candidate == resource {
+			return true
+		}
+		if hasSub && candidate == rbacv1.ResourceAll+"/"+subresource {
+			return true
+		}
+	}
+
+	return false
+}
+
+func ruleCreatesPods(rule rbacv1.PolicyRule) bool {
+	if !containsOrWildcard(rule.Verbs, "create") {
+		return false
+	}
+	for group, resources := range podCreatingResources {
+		if !containsOrWildcard(rule.APIGroups, group) {
+			continue
+		}
+		for _, resource := range resources {
+			if ruleCoversResource(rule, resource) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func containsOrWildcard(values []string, value string) bool {
+	return slices.Contains(values, value) || slices.Contains(values, "*")
+}
+
+// namedOrAll returns the named subjects of the given kind, or every known
+// subject of that kind when the rule has no resourceNames.
+func namedOrAll(names []string, kind string, known []indexer.SubjectKey) []indexer.SubjectKey {
+	if len(names) > 0 {
+		out := make([]indexer.SubjectKey, 0, len(names))
+		for _, name := range names {
+			out = append(out, indexer.SubjectKey{Kind: kind, Name: name})
+		}
+		sortSubjectKeys(out)
+
+		return out
+	}
+	out := make([]indexer.SubjectKey, 0)
+	for _, key := range known {
+		if key.Kind == kind {
+			out = append(out, key)
+		}
+	}
+
+	return out
+}
+
+// serviceAccountsInScope returns the known ServiceAccounts a binding in
+// namespace can reach; an empty namespace means every namespace.
+func serviceAccountsInScope(known []indexer.SubjectKey, namespace string, names []string) []indexer.SubjectKey {
+	out := make([]indexer.SubjectKey, 0)
+	for _, key := range known {
+		if namespace != "" && key.Namespace != namespace {
+			continue
+		}
+		if len(names) > 0 && !slices.Contains(names, key.Name) {
+			continue
+		}
+		out = append(out, key)
+	}
+
+	return out
+}
+
+// rolesInScope returns the roles a bind/escalate rule applies to. A
+// RoleBinding only reaches Roles in its own namespace and ClusterRoles
+// bound there.
+func rolesInScope(roles []*indexer.RoleRecord, rule rbacv1.PolicyRule, namespace string) []*indexer.RoleRecord {
+	out := make([]*indexer.RoleRecord, 0)
+	for _, role := range roles {
+		resource := "roles"
+		if role.Kind == indexer.KindClusterRole {
+			resource = "clusterroles"
+		} else if namespace != "" && role.Namespace != namespace {
+			continue
+		}
+		if !ruleCoversResource(rule, resource) {
+			continue
+		}
+		if len(rule.ResourceNames) > 0 && !slices.Contains(rule.ResourceNames, role.Name) {
+			continue
+		}
+		out = append(out, role)
+	}
+
+	return out
+}
+
+func sortSubjectKeys(keys []indexer.SubjectKey) {
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].Kind != keys[j].Kind {
+			return keys[i].Kind < keys[j].Kind
+		}
+		if keys[i].Namespace != keys[j].Namespace {
+			return keys[i].Namespace < keys[j].Namespace
+		}
+
+		return keys[i].Name < keys[j].Name
+	})
+}
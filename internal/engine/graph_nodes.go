@@ -8,22 +8,24 @@ import (
 	rbacv1 "k8s.io/api/rbac/v1"
 
 	"k8s-role-graph/internal/indexer"
+	"k8s-role-graph/internal/risk"
 	api "k8s-role-graph/pkg/apis/rbacgraph"
 )
 
 const (
-	nodeIDPrefixRole      = "role:"
-	nodeIDPrefixBinding   = "binding:"
-	nodeIDPrefixSubject   = "subject:"
-	nodeIDPrefixPod       = "pod:"
-	nodeIDPrefixWorkload  = "workload:"
-	nodeIDPrefixOverflow  = "overflow:"
-	edgeIDPrefix          = "edge:"
-	edgeExplainAggregates = "ClusterRole contributes rules via aggregationRule"
-	edgeExplainGrants     = "Role referenced by binding"
-	edgeExplainSubjects   = "Binding targets subject"
-	edgeExplainRunsAs     = "ServiceAccount used by pod"
-	edgeExplainOwnedBy    = "Owner reference chain"
+	nodeIDPrefixRole       = "role:"
+	nodeIDPrefixBinding    = "binding:"
+	nodeIDPrefixSubject    = "subject:"
+	nodeIDPrefixPod        = "pod:"
+	nodeIDPrefixWorkload   = "workload:"
+	nodeIDPrefixOverflow   = "overflow:"
+	edgeIDPrefix           = "edge:"
+	edgeExplainAggregates  = "ClusterRole contributes rules via aggregationRule"
+	edgeExplainGrants      = "Role referenced by binding"
+	edgeExplainSubjects    = "Binding targets subject"
+	edgeExplainRunsAs      = "ServiceAccount used by pod"
+	edgeExplainRunsAsGroup = "Pod runs as a ServiceAccount included in implicit group"
+	edgeExplainOwnedBy     = "Owner reference chain"
 )
 
 func roleNodeID(role *indexer.RoleRecord) string {
@@ -122,6 +124,9 @@ func (qc *queryContext) upsertRoleNode(role *indexer.RoleRecord, aggregationSour
 		if len(matchedRefs) > 0 {
 			node.MatchedRuleRefs = append([]api.RuleRef(nil), matchedRefs...)
 		}
+		qc.describeRole(&node, role)
+		node.RiskReasons = qc.roleRiskReasons(role, matchedRefs)
+		node.RiskScore = risk.Score(node.RiskReasons)
 		*nodes = append(*nodes, node)
 		qc.nodeSeen[roleID] = struct{}{}
 		qc.nodeIndex[roleID] = len(*nodes) - 1
@@ -0,0 +1,260 @@
+package engine
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"k8s-role-graph/internal/indexer"
+	api "k8s-role-graph/pkg/apis/rbacgraph"
+)
+
+const (
+	systemNamePrefix       = "system:"
+	bootstrappingLabel     = "kubernetes.io/bootstrapping"
+	bootstrappingLabelRBAC = "rbac-defaults"
+)
+
+// QueryHygiene reports RBAC objects that the graph queries skip silently.
+// The snapshot may be narrowed to the caller's scope. A reference to a role
+// or ServiceAccount outside that scope, and a role whose bindings may lie
+// outside it, is reported as unresolved rather than checked, so the report
+// does not disclose objects the caller cannot list.
+func (e *Engine) QueryHygiene(snapshot *indexer.Snapshot, spec api.RBACHygieneReportSpec) api.RBACHygieneReportStatus {
+	hc := &hygieneContext{
+		snapshot:        snapshot,
+		spec:            spec,
+		checks:          hygieneChecks(spec.Checks),
+		namespaceStrict: spec.NamespaceScope.Strict,
+		status: api.RBACHygieneReportStatus{
+			Findings: []api.HygieneFinding{},
+			Warnings: snapshot.CloneWarnings(),
+		},
+	}
+	namespaceFilter, err := makeNamespaceFilter(spec.NamespaceScope, snapshot.Namespaces)
+	if err != nil {
+		hc.status.Warnings = append(hc.status.Warnings, "namespaceScope: "+err.Error())
+	}
+	hc.namespaceFilter = namespaceFilter
+	if hc.checks[api.HygieneCheckMissingServiceAccount] && len(snapshot.ServiceAccounts) == 0 {
+		hc.status.Warnings = append(hc.status.Warnings, "no ServiceAccounts are indexed; missingServiceAccount check skipped")
+		delete(hc.checks, api.HygieneCheckMissingServiceAccount)
+	}
+
+	hc.checkBindings()
+	hc.checkRoles()
+	sortHygieneFindings(hc.status.Findings)
+
+	return hc.status
+}
+
+type hygieneContext struct {
+	snapshot        *indexer.Snapshot
+	spec            api.RBACHygieneReportSpec
+	checks          map[api.HygieneCheck]bool
+	namespaceFilter *namespaceFilter
+	namespaceStrict bool
+	status          api.RBACHygieneReportStatus
+}
+
+func hygieneChecks(requested []api.HygieneCheck) map[api.HygieneCheck]bool {
+	if len(requested) == 0 {
+		requested = api.AllHygieneChecks
+	}
+	checks := make(map[api.HygieneCheck]bool, len(requested))
+	for _, check := range requested {
+		checks[check] = true
+	}
+
+	return checks
+}
+
+func (hc *hygieneContext) addFinding(finding api.HygieneFinding) {
+	hc.status.Findings = append(hc.status.Findings, finding)
+}
+
+//nolint:gocognit // one pass over bindings feeds three independent checks
+func (hc *hygieneContext) checkBindings() {
+	for _, bindings := range hc.snapshot.BindingsByRoleRef {
+		for _, binding := range bindings {
+			if !allowNamespace(hc.namespaceFilter, binding.Namespace, hc.namespaceStrict) {
+				continue
+			}
+			if hc.checks[api.HygieneCheckMissingRole] {
+				hc.checkMissingRole(binding)
+			}
+			if hc.checks[api.HygieneCheckEmptySubjects] && len(binding.Subjects) == 0 &&
+				(hc.spec.IncludeSystemObjects || !strings.HasPrefix(binding.Name, systemNamePrefix)) {
+				hc.addFinding(bindingFinding(api.HygieneCheckEmptySubjects, binding,
+					fmt.Sprintf("%s %s has no subjects", binding.Kind, bindingDisplayName(binding))))
+			}
+			if hc.checks[api.HygieneCheckMissingServiceAccount] {
+				hc.checkMissingServiceAccounts(binding)
+			}
+		}
+	}
+}
+
+func (hc *hygieneContext) checkMissingRole(binding *indexer.BindingRecord) {
+	roleID := indexer.RecID(binding.RoleRef.Kind, binding.RoleRef.Namespace, binding.RoleRef.Name)
+	if _, ok := hc.snapshot.RolesByID[roleID]; ok {
+		return
+	}
+	finding := bindingFinding(api.HygieneCheckMissingRole, binding, fmt.Sprintf(
+		"%s %s references missing %s %q",
+		binding.Kind, bindingDisplayName(binding), binding.RoleRef.Kind, binding.RoleRef.Name,
+	))
+	if !hc.snapshot.RoleVisible(binding.RoleRef.Namespace) {
+		finding.Unresolved = true
+		finding.Message = fmt.Sprintf("%s %s references %s %q outside the caller's scope",
+			binding.Kind, bindingDisplayName(binding), binding.RoleRef.Kind, binding.RoleRef.Name)
+	}
+	finding.RoleRefKind = binding.RoleRef.Kind
+	finding.RoleRefName = binding.RoleRef.Name
+	hc.addFinding(finding)
+}
+
+func (hc *hygieneContext) checkMissingServiceAccounts(binding *indexer.BindingRecord) {
+	seen := make(map[indexer.SubjectKey]struct{}, len(binding.Subjects))
+	for _, subject := range binding.Subjects {
+		key := indexer.NewSubjectKey(subject, binding.Namespace)
+		if key.Kind != indexer.SubjectKindServiceAccount {
+			continue
+		}
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		if !allowNamespace(hc.namespaceFilter, key.Namespace, hc.namespaceStrict) {
+			continue
+		}
+		if _, ok := hc.snapshot.ServiceAccounts[indexer.ServiceAccountKey{Namespace: key.Namespace, Name: key.Name}]; ok {
+			continue
+		}
+		finding := bindingFinding(api.HygieneCheckMissingServiceAccount, binding, fmt.Sprintf(
+			"%s %s binds missing ServiceAccount %s/%s",
+			binding.Kind, bindingDisplayName(binding), key.Namespace, key.Name,
+		))
+		if !hc.snapshot.ServiceAccountVisible(key.Namespace) {
+			finding.Unresolved = true
+			finding.Message = fmt.Sprintf("%s %s binds ServiceAccount %s/%s outside the caller's scope",
+				binding.Kind, bindingDisplayName(binding), key.Namespace, key.Name)
+		}
+		finding.Subject = &api.SubjectRef{Kind: key.Kind, Namespace: key.Namespace, Name: key.Name}
+		hc.addFinding(finding)
+	}
+}
+
+func (hc *hygieneContext) checkRoles() {
+	if !hc.checks[api.HygieneCheckUnboundRole] {
+		return
+	}
+	aggregationSources := make(map[indexer.RoleID]struct{})
+	for _, sources := range hc.snapshot.AggregatedRoleSources {
+		for _, source := range sources {
+			aggregationSources[source] = struct{}{}
+		}
+	}
+	for roleID, role := range hc.snapshot.RolesByID {
+		if !allowNamespace(hc.namespaceFilter, role.Namespace, hc.namespaceStrict) {
+			continue
+		}
+		if !hc.spec.IncludeSystemObjects && isSystemRole(role) {
+			continue
+		}
+		if _, ok := aggregationSources[roleID]; ok {
+			continue
+		}
+		key := indexer.RoleRefKey{Kind: role.Kind, Namespace: role.Namespace, Name: role.Name}
+		if len(hc.snapshot.BindingsByRoleRef[key]) > 0 {
+			continue
+		}
+		finding := api.HygieneFinding{
+			Check:     api.HygieneCheckUnboundRole,
+			Kind:      role.Kind,
+			Namespace: role.Namespace,
+			Name:      role.Name,
+			Message:   fmt.Sprintf("%s %s is not referenced by any binding", role.Kind, roleDisplayName(role)),
+		}
+		if !hc.bindingsVisible(role) {
+			finding.Unresolved = true
+			finding.Message = fmt.Sprintf("%s %s is not referenced by any binding in the caller's scope", role.Kind, roleDisplayName(role))
+		}
+		hc.addFinding(finding)
+	}
+}
+
+// bindingsVisible reports whether the snapshot shows every binding that can
+// reference role: the RoleBindings of its namespace for a Role, and all
+// bindings for a ClusterRole.
+func (hc *hygieneContext) bindingsVisible(role *indexer.RoleRecord) bool {
+	if hc.snapshot.Scope == nil {
+		return true
+	}
+	if role.Namespace != "" {
+		return hc.snapshot.BindingVisible(role.Namespace)
+	}
+	if !hc.snapshot.BindingVisible("") || len(hc.snapshot.Namespaces) == 0 || hc.snapshot.NamespacesHidden {
+		return false
+	}
+	for name := range hc.snapshot.Namespaces {
+		if !hc.snapshot.BindingVisible(name) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isSystemRole reports roles created by the control plane: system:* names and
+// the bootstrap roles (admin, edit, view, ...) labelled rbac-defaults.
+func isSystemRole(role *indexer.RoleRecord) bool {
+	return strings.HasPrefix(role.Name, systemNamePrefix) || role.Labels[bootstrappingLabel] == bootstrappingLabelRBAC
+}
+
+func roleDisplayName(role *indexer.RoleRecord) string {
+	if role.Namespace == "" {
+		return role.Name
+	}
+
+	return role.Namespace + "/" + role.Name
+}
+
+func bindingFinding(check api.HygieneCheck, binding *indexer.BindingRecord, message string) api.HygieneFinding {
+	return api.HygieneFinding{
+		Check:     check,
+		Kind:      binding.Kind,
+		Namespace: binding.Namespace,
+		Name:      binding.Name,
+		Message:   message,
+	}
+}
+
+func sortHygieneFindings(findings []api.HygieneFinding) {
+	sort.Slice(findings, func(i, j int) bool {
+		left, right := findings[i], findings[j]
+		if left.Namespace != right.Namespace {
+			return left.Namespace < right.Namespace
+		}
+		if left.Kind != right.Kind {
+			return left.Kind < right.Kind
+		}
+		if left.Name != right.Name {
+			return left.Name < right.Name
+		}
+		if left.Check != right.Check {
+			return slices.Index(api.AllHygieneChecks, left.Check) < slices.Index(api.AllHygieneChecks, right.Check)
+		}
+
+		return hygieneSubjectKey(left.Subject) < hygieneSubjectKey(right.Subject)
+	})
+}
+
+func hygieneSubjectKey(subject *api.SubjectRef) string {
+	if subject == nil {
+		return ""
+	}
+
+	return subject.Namespace + "/" + subject.Name
+}
@@ -0,0 +1,89 @@
+package engine
+
+import (
+	"fmt"
+	"path"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"k8s-role-graph/internal/indexer"
+)
+
+// metadataFilter restricts roles or bindings by labels and name. A nil
+// filter allows everything.
+type metadataFilter struct {
+	selector labels.Selector
+	patterns []string
+}
+
+// makeMetadataFilter compiles a label selector and name globs. Specs are
+// validated before they reach the engine, so an invalid selector only occurs
+// for direct callers; it matches nothing rather than everything.
+func makeMetadataFilter(selector *metav1.LabelSelector, patterns []string) (*metadataFilter, error) {
+	if selector == nil && len(patterns) == 0 {
+		return nil, nil //nolint:nilnil // nil filter allows everything
+	}
+	filter := &metadataFilter{selector: labels.Everything(), patterns: patterns}
+	if selector != nil {
+		compiled, err := metav1.LabelSelectorAsSelector(selector)
+		if err != nil {
+			filter.selector = labels.Nothing()
+
+			return filter, fmt.Errorf("invalid label selector: %w", err)
+		}
+		filter.selector = compiled
+	}
+
+	return filter, nil
+}
+
+func (f *metadataFilter) allows(name string, lbls map[string]string) bool {
+	if f == nil {
+		return true
+	}
+	if !f.selector.Matches(labels.Set(lbls)) {
+		return false
+	}
+	if len(f.patterns) == 0 {
+		return true
+	}
+	for _, pattern := range f.patterns {
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+// filterCandidateRoles drops candidate roles rejected by the role filter
+// before their rules are matched.
+func (qc *queryContext) filterCandidateRoles(roleIDs []indexer.RoleID) []indexer.RoleID {
+	if qc.roleFilter == nil {
+		return roleIDs
+	}
+	out := roleIDs[:0]
+	for _, roleID := range roleIDs {
+		role, ok := qc.snapshot.RolesByID[roleID]
+		if ok && qc.roleFilter.allows(role.Name, role.Labels) {
+			out = append(out, roleID)
+		}
+	}
+
+	return out
+}
+
+func filterBindingsByMetadata(filter *metadataFilter, bindings []*indexer.BindingRecord) []*indexer.BindingRecord {
+	if filter == nil || len(bindings) == 0 {
+		return bindings
+	}
+	out := make([]*indexer.BindingRecord, 0, len(bindings))
+	for _, binding := range bindings {
+		if filter.allows(binding.Name, binding.Labels) {
+			out = append(out, binding)
+		}
+	}
+
+	return out
+}
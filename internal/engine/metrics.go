@@ -0,0 +1,78 @@
+package engine
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/component-base/metrics"
+	"k8s.io/component-base/metrics/legacyregistry"
+
+	api "k8s-role-graph/pkg/apis/rbacgraph"
+)
+
+const metricsSubsystem = "rbacgraph_query"
+
+var (
+	queryDurationMetric = metrics.NewHistogramVec(
+		&metrics.HistogramOpts{
+			Subsystem:      metricsSubsystem,
+			Name:           "duration_seconds",
+			Help:           "Latency of review requests, including snapshot scoping, by resource.",
+			Buckets:        metrics.ExponentialBuckets(0.001, 2, 14),
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"resource"},
+	)
+	graphNodesMetric = metrics.NewHistogram(
+		&metrics.HistogramOpts{
+			Subsystem:      metricsSubsystem,
+			Name:           "graph_nodes",
+			Help:           "Number of graph nodes returned by RoleGraphReview.",
+			Buckets:        metrics.ExponentialBuckets(1, 4, 9),
+			StabilityLevel: metrics.ALPHA,
+		},
+	)
+	graphEdgesMetric = metrics.NewHistogram(
+		&metrics.HistogramOpts{
+			Subsystem:      metricsSubsystem,
+			Name:           "graph_edges",
+			Help:           "Number of graph edges returned by RoleGraphReview.",
+			Buckets:        metrics.ExponentialBuckets(1, 4, 9),
+			StabilityLevel: metrics.ALPHA,
+		},
+	)
+	wildcardTruncationsMetric = metrics.NewCounter(
+		&metrics.CounterOpts{
+			Subsystem:      metricsSubsystem,
+			Name:           "wildcard_expansion_truncations_total",
+			Help:           "Number of wildcard rule expansions truncated at the per-rule limit.",
+			StabilityLevel: metrics.ALPHA,
+		},
+	)
+
+	registerMetricsOnce sync.Once
+)
+
+// RegisterMetrics registers the query metrics with the legacy registry
+// served on the apiserver /metrics endpoint. It is safe to call repeatedly.
+func RegisterMetrics() {
+	registerMetricsOnce.Do(func() {
+		legacyregistry.MustRegister(
+			queryDurationMetric,
+			graphNodesMetric,
+			graphEdgesMetric,
+			wildcardTruncationsMetric,
+		)
+	})
+}
+
+// ObserveQuery records the latency of a review request for resource.
+func ObserveQuery(resource string, start time.Time) {
+	queryDurationMetric.WithLabelValues(resource).Observe(time.Since(start).Seconds())
+}
+
+// ObserveGraph records the size of a returned graph.
+func ObserveGraph(graph api.Graph) {
+	graphNodesMetric.Observe(float64(len(graph.Nodes)))
+	graphEdgesMetric.Observe(float64(len(graph.Edges)))
+}
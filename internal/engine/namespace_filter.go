@@ -1,41 +1,114 @@
 package engine
 
 import (
+	"fmt"
+	"path"
 	"strings"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
 	"k8s-role-graph/internal/indexer"
+	api "k8s-role-graph/pkg/apis/rbacgraph"
 )
 
-func makeNamespaceFilter(namespaces []string) map[string]struct{} {
-	filter := make(map[string]struct{}, len(namespaces))
+// namespaceFilter is the compiled form of api.NamespaceScope. A nil filter
+// allows every namespace.
+type namespaceFilter struct {
+	names    map[string]struct{}
+	patterns []string
+	// selector is nil when the scope has no label selector.
+	selector labels.Selector
+	exclude  []string
+	// namespaces supplies the labels the selector matches on.
+	namespaces map[string]*indexer.NamespaceRecord
+}
+
+// makeNamespaceFilter compiles scope against the indexed namespaces. As with
+// the metadata filters, an invalid selector only reaches the engine from
+// direct callers; it matches no namespace.
+func makeNamespaceFilter(scope api.NamespaceScope, namespaces map[string]*indexer.NamespaceRecord) (*namespaceFilter, error) {
+	filter := &namespaceFilter{
+		names:      makeNamespaceSet(scope.Namespaces),
+		patterns:   scope.Patterns,
+		exclude:    scope.Exclude,
+		namespaces: namespaces,
+	}
+	var err error
+	if scope.Selector != nil {
+		filter.selector, err = metav1.LabelSelectorAsSelector(scope.Selector)
+		if err != nil {
+			filter.selector = labels.Nothing()
+			err = fmt.Errorf("invalid selector: %w", err)
+		}
+	}
+	if filter.names == nil && len(filter.patterns) == 0 && filter.selector == nil && len(filter.exclude) == 0 {
+		return nil, nil //nolint:nilnil // nil filter allows everything
+	}
+
+	return filter, err
+}
+
+func makeNamespaceSet(namespaces []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(namespaces))
 	for _, ns := range namespaces {
 		ns = strings.TrimSpace(ns)
 		if ns == "" {
 			continue
 		}
-		filter[ns] = struct{}{}
+		set[ns] = struct{}{}
 	}
-	if len(filter) == 0 {
+	if len(set) == 0 {
 		return nil
 	}
 
-	return filter
+	return set
+}
+
+func (f *namespaceFilter) allows(namespace string) bool {
+	if matchesAnyPattern(f.exclude, namespace) {
+		return false
+	}
+	if f.names == nil && len(f.patterns) == 0 && f.selector == nil {
+		return true
+	}
+	if _, ok := f.names[namespace]; ok {
+		return true
+	}
+	if matchesAnyPattern(f.patterns, namespace) {
+		return true
+	}
+	if f.selector == nil {
+		return false
+	}
+	ns, ok := f.namespaces[namespace]
+
+	return ok && f.selector.Matches(labels.Set(ns.Labels))
+}
+
+func matchesAnyPattern(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+
+	return false
 }
 
-func allowNamespace(filter map[string]struct{}, namespace string, strict bool) bool {
-	if len(filter) == 0 {
+func allowNamespace(filter *namespaceFilter, namespace string, strict bool) bool {
+	if filter == nil {
 		return true
 	}
 	if namespace == "" {
 		return !strict
 	}
-	_, ok := filter[namespace]
 
-	return ok
+	return filter.allows(namespace)
 }
 
-func filterBindingsByNamespace(filter map[string]struct{}, strict bool, bindings []*indexer.BindingRecord) []*indexer.BindingRecord {
-	if len(filter) == 0 || len(bindings) == 0 {
+func filterBindingsByNamespace(filter *namespaceFilter, strict bool, bindings []*indexer.BindingRecord) []*indexer.BindingRecord {
+	if filter == nil || len(bindings) == 0 {
 		return bindings
 	}
 	out := make([]*indexer.BindingRecord, 0, len(bindings))
@@ -47,7 +120,7 @@ func filterBindingsByNamespace(filter map[string]struct{}, strict bool, bindings
 
 			continue
 		}
-		if _, ok := filter[binding.Namespace]; ok {
+		if filter.allows(binding.Namespace) {
 			out = append(out, binding)
 		}
 	}
@@ -0,0 +1,57 @@
+package engine
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	api "k8s-role-graph/pkg/apis/rbacgraph"
+)
+
+// annotateNamespaceStates flags Roles, RoleBindings and ServiceAccounts whose
+// namespace is missing or terminating. ClusterRoleBindings and RoleBindings
+// elsewhere keep granting to ServiceAccounts of a deleted namespace, and
+// those grants become live again once the namespace is recreated. Nothing is
+// flagged when no namespaces are indexed, e.g. for manifests without them,
+// nor for namespaces the caller may not see.
+func (qc *queryContext) annotateNamespaceStates() {
+	if len(qc.snapshot.Namespaces) == 0 {
+		return
+	}
+	for i := range qc.status.Graph.Nodes {
+		node := &qc.status.Graph.Nodes[i]
+		namespace := qc.nodeNamespace(node)
+		if namespace == "" || !qc.snapshot.NamespaceVisible(namespace) {
+			continue
+		}
+		ns, ok := qc.snapshot.Namespaces[namespace]
+		switch {
+		case !ok:
+			node.NamespaceState = api.NamespaceStateMissing
+			qc.addWarning(fmt.Sprintf("%s %s/%s: namespace %q does not exist; its grants become live again if the namespace is recreated",
+				node.Type, namespace, node.Name, namespace))
+		case ns.Phase == corev1.NamespaceTerminating:
+			node.NamespaceState = api.NamespaceStateTerminating
+			qc.addWarning(fmt.Sprintf("%s %s/%s: namespace %q is terminating; its grants become live again if the namespace is recreated",
+				node.Type, namespace, node.Name, namespace))
+		}
+	}
+}
+
+// nodeNamespace returns the namespace a node's grants depend on. Namespaced
+// ServiceAccount subjects without an explicit namespace live in the namespace
+// of their binding.
+func (qc *queryContext) nodeNamespace(node *api.GraphNode) string {
+	switch node.Type {
+	case api.GraphNodeTypeRole, api.GraphNodeTypeRoleBinding:
+		return node.Namespace
+	case api.GraphNodeTypeServiceAccount:
+		if node.Namespace != "" {
+			return node.Namespace
+		}
+
+		return qc.subjectKeys[node.ID].Namespace
+	default:
+		return ""
+	}
+}
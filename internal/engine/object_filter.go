@@ -0,0 +1,34 @@
+package engine
+
+import (
+	"k8s-role-graph/internal/indexer"
+	api "k8s-role-graph/pkg/apis/rbacgraph"
+)
+
+// allowRoleForObject reports whether a role can grant access to the target
+// object at all: a namespaced Role only applies inside its own namespace.
+func allowRoleForObject(object *api.ObjectTarget, role *indexer.RoleRecord) bool {
+	if object == nil || role.Namespace == "" {
+		return true
+	}
+
+	return role.Namespace == object.Namespace
+}
+
+// filterBindingsForObject keeps only bindings that are effective for the
+// target object. ClusterRoleBindings apply everywhere; a RoleBinding (including
+// one that references a ClusterRole) only applies to objects in its own
+// namespace and never to cluster-scoped objects.
+func filterBindingsForObject(object *api.ObjectTarget, bindings []*indexer.BindingRecord) []*indexer.BindingRecord {
+	if object == nil || len(bindings) == 0 {
+		return bindings
+	}
+	out := make([]*indexer.BindingRecord, 0, len(bindings))
+	for _, binding := range bindings {
+		if binding.Namespace == "" || (object.Namespace != "" && binding.Namespace == object.Namespace) {
+			out = append(out, binding)
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,65 @@
+package engine
+
+import (
+	"slices"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+
+	"k8s-role-graph/internal/indexer"
+	api "k8s-role-graph/pkg/apis/rbacgraph"
+)
+
+func includesPolicyRules(mode api.PolicyRulesMode) bool {
+	return mode == api.PolicyRulesModeMatched || mode == api.PolicyRulesModeAll
+}
+
+// describeRole sets the rule count and resourceVersion of a new role node,
+// and every rule of the role in "all" mode. Matched rules are added by
+// addMatchedPolicyRules once the role has matched.
+func (qc *queryContext) describeRole(node *api.GraphNode, role *indexer.RoleRecord) {
+	if !includesPolicyRules(qc.spec.IncludePolicyRules) {
+		return
+	}
+	node.RuleCount = role.RuleCount
+	node.ResourceVersion = role.ResourceVersion
+	if qc.spec.IncludePolicyRules != api.PolicyRulesModeAll {
+		return
+	}
+	node.PolicyRules = make([]api.PolicyRule, 0, len(role.Rules))
+	for idx, rule := range role.Rules {
+		node.PolicyRules = append(node.PolicyRules, policyRule(idx, rule))
+	}
+}
+
+// addMatchedPolicyRules adds the rules at ruleIndexes to a role node in
+// "matched" mode, keeping them ordered by their index in the role.
+func (qc *queryContext) addMatchedPolicyRules(nodeID string, role *indexer.RoleRecord, ruleIndexes []int) {
+	if qc.spec.IncludePolicyRules != api.PolicyRulesModeMatched {
+		return
+	}
+	idx, ok := qc.nodeIndex[nodeID]
+	if !ok {
+		return
+	}
+	node := &qc.status.Graph.Nodes[idx]
+	for _, ruleIndex := range ruleIndexes {
+		if ruleIndex >= len(role.Rules) || slices.ContainsFunc(node.PolicyRules, func(rule api.PolicyRule) bool {
+			return rule.Index == ruleIndex
+		}) {
+			continue
+		}
+		node.PolicyRules = append(node.PolicyRules, policyRule(ruleIndex, role.Rules[ruleIndex]))
+	}
+	slices.SortFunc(node.PolicyRules, func(a, b api.PolicyRule) int { return a.Index - b.Index })
+}
+
+func policyRule(index int, rule rbacv1.PolicyRule) api.PolicyRule {
+	return api.PolicyRule{
+		Index:           index,
+		Verbs:           slices.Clone(rule.Verbs),
+		APIGroups:       slices.Clone(rule.APIGroups),
+		Resources:       slices.Clone(rule.Resources),
+		ResourceNames:   slices.Clone(rule.ResourceNames),
+		NonResourceURLs: slices.Clone(rule.NonResourceURLs),
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"k8s-role-graph/internal/indexer"
+	"k8s-role-graph/internal/risk"
 	api "k8s-role-graph/pkg/apis/rbacgraph"
 )
 
@@ -19,8 +20,11 @@ func (qc *queryContext) buildRBACGraph(roleIDs []indexer.RoleID) {
 		if !allowNamespace(qc.namespaceFilter, role.Namespace, false) {
 			continue
 		}
+		if !allowRoleForObject(qc.spec.Object, role) {
+			continue
+		}
 
-		matches := matchRole(role, qc.spec)
+		matches, ruleIndexes := matchRole(role, qc.spec)
 		if qc.discovery != nil {
 			qc.annotatePhantomRefs(matches)
 			if qc.spec.FilterPhantomAPIs {
@@ -32,6 +36,9 @@ func (qc *queryContext) buildRBACGraph(roleIDs []indexer.RoleID) {
 		if len(matches) == 0 {
 			continue
 		}
+		if qc.spec.MinRiskScore > 0 && risk.Score(qc.roleRiskReasons(role, matches)) < qc.spec.MinRiskScore {
+			continue
+		}
 
 		roleRefKey := indexer.RoleRefKey{Kind: role.Kind, Namespace: role.Namespace, Name: role.Name}
 		bindings := qc.snapshot.BindingsByRoleRef[roleRefKey]
@@ -39,31 +46,38 @@ func (qc *queryContext) buildRBACGraph(roleIDs []indexer.RoleID) {
 		if qc.namespaceStrict && role.Namespace == "" && len(filteredBindings) == 0 {
 			continue
 		}
+		filteredBindings = filterBindingsForObject(qc.spec.Object, filteredBindings)
+		// In object mode a role only matters if some binding makes it effective there.
+		if qc.spec.Object != nil && len(filteredBindings) == 0 {
+			continue
+		}
+		filteredBindings = filterBindingsByMetadata(qc.bindingFilter, filteredBindings)
+		if qc.bindingFilter != nil && len(filteredBindings) == 0 {
+			continue
+		}
+		filteredBindings = filterBindingsBySubjects(qc.subjectFilter, filteredBindings)
+		if qc.subjectFilter != nil && len(filteredBindings) == 0 {
+			continue
+		}
 
 		roleNodeID := qc.upsertRoleNode(role, qc.snapshot.AggregatedRoleSources[roleID], matches)
+		qc.addMatchedPolicyRules(roleNodeID, role, ruleIndexes)
 		qc.roleSeen[roleID] = struct{}{}
-		for _, sourceRoleID := range qc.snapshot.AggregatedRoleSources[roleID] {
-			sourceRole, ok := qc.snapshot.RolesByID[sourceRoleID]
-			if !ok {
-				continue
-			}
-			sourceNodeID := qc.upsertRoleNode(sourceRole, qc.snapshot.AggregatedRoleSources[sourceRoleID], nil)
-			qc.appendEdgeIfMissing(api.GraphEdge{
-				ID:      edgeIDFor(sourceNodeID, roleNodeID, api.GraphEdgeTypeAggregates),
-				From:    sourceNodeID,
-				To:      roleNodeID,
-				Type:    api.GraphEdgeTypeAggregates,
-				Explain: edgeExplainAggregates,
-			})
-		}
+		qc.addAggregationChain(roleID, roleNodeID)
 
 		if len(filteredBindings) == 0 {
-			qc.accumulateResourceRows(matches, roleID, "", "")
+			qc.accumulateResourceRows(matches, roleID, "", "", nil)
 
 			continue
 		}
 
 		for _, binding := range filteredBindings {
+			// A RoleBinding cannot grant the cluster-scoped rules of a ClusterRole.
+			refs := effectiveRefs(matches, binding, qc.discovery)
+			if len(refs) == 0 {
+				continue
+			}
+			scope := bindingScope(binding)
 			bindingNodeIDValue := bindingNodeID(binding)
 			qc.addNodeIfMissing(api.GraphNode{
 				ID:        bindingNodeIDValue,
@@ -78,17 +92,22 @@ func (qc *queryContext) buildRBACGraph(roleIDs []indexer.RoleID) {
 				From:     roleNodeID,
 				To:       bindingNodeIDValue,
 				Type:     api.GraphEdgeTypeGrants,
-				RuleRefs: matches,
+				RuleRefs: refs,
 				Explain:  edgeExplainGrants,
+				Scope:    scope,
 			})
 
 			if len(binding.Subjects) == 0 {
-				qc.accumulateResourceRows(matches, roleID, bindingNodeIDValue, "")
+				qc.accumulateResourceRows(refs, roleID, bindingNodeIDValue, "", scope)
 
 				continue
 			}
 
 			for _, subject := range binding.Subjects {
+				subjectKey := indexer.NewSubjectKey(subject, binding.Namespace)
+				if !qc.subjectFilter.allows(subjectKey) {
+					continue
+				}
 				subjectNodeIDValue := subjectNodeID(subject)
 				qc.addNodeIfMissing(api.GraphNode{
 					ID:        subjectNodeIDValue,
@@ -97,7 +116,9 @@ func (qc *queryContext) buildRBACGraph(roleIDs []indexer.RoleID) {
 					Namespace: subject.Namespace,
 				})
 				qc.subjectSeen[subjectNodeIDValue] = struct{}{}
+				qc.subjectKeys[subjectNodeIDValue] = subjectKey
 				qc.trackServiceAccountSubject(subjectNodeIDValue, subject, binding.Namespace)
+				qc.trackImplicitGroupSubject(subjectNodeIDValue, subject)
 
 				qc.appendEdgeIfMissing(api.GraphEdge{
 					ID:      edgeIDFor(bindingNodeIDValue, subjectNodeIDValue, api.GraphEdgeTypeSubjects),
@@ -105,12 +126,64 @@ func (qc *queryContext) buildRBACGraph(roleIDs []indexer.RoleID) {
 					To:      subjectNodeIDValue,
 					Type:    api.GraphEdgeTypeSubjects,
 					Explain: edgeExplainSubjects,
+					Scope:   scope,
 				})
 
-				qc.accumulateResourceRows(matches, roleID, bindingNodeIDValue, subjectNodeIDValue)
+				qc.accumulateResourceRows(refs, roleID, bindingNodeIDValue, subjectNodeIDValue, scope)
+			}
+		}
+	}
+}
+
+// addAggregationChain adds aggregates edges from every ClusterRole that
+// contributes rules to roleID, following aggregated sources transitively.
+// Cycles are walked once; the indexer reports them as warnings.
+func (qc *queryContext) addAggregationChain(roleID indexer.RoleID, roleNodeID string) {
+	type target struct {
+		id     indexer.RoleID
+		nodeID string
+	}
+	visited := map[indexer.RoleID]struct{}{roleID: {}}
+	queue := []target{{id: roleID, nodeID: roleNodeID}}
+	for len(queue) > 0 {
+		next := queue[0]
+		queue = queue[1:]
+		for _, sourceRoleID := range qc.snapshot.AggregatedRoleSources[next.id] {
+			sourceRole, ok := qc.snapshot.RolesByID[sourceRoleID]
+			if !ok {
+				continue
 			}
+			sourceNodeID := qc.upsertRoleNode(sourceRole, qc.snapshot.AggregatedRoleSources[sourceRoleID], nil)
+			qc.appendEdgeIfMissing(api.GraphEdge{
+				ID:                 edgeIDFor(sourceNodeID, next.nodeID, api.GraphEdgeTypeAggregates),
+				From:               sourceNodeID,
+				To:                 next.nodeID,
+				Type:               api.GraphEdgeTypeAggregates,
+				Explain:            edgeExplainAggregates,
+				AggregationMatches: aggregationMatches(qc.snapshot.AggregationMatches[next.id], sourceRoleID),
+			})
+			if _, seen := visited[sourceRoleID]; !seen {
+				visited[sourceRoleID] = struct{}{}
+				queue = append(queue, target{id: sourceRoleID, nodeID: sourceNodeID})
+			}
+		}
+	}
+}
+
+func aggregationMatches(matches []indexer.AggregationMatch, source indexer.RoleID) []api.AggregationMatch {
+	var out []api.AggregationMatch
+	for _, match := range matches {
+		if match.Source != source {
+			continue
 		}
+		out = append(out, api.AggregationMatch{
+			SelectorIndex: match.SelectorIndex,
+			Selector:      match.Selector,
+			MatchedLabels: match.MatchedLabels,
+		})
 	}
+
+	return out
 }
 
 //nolint:gocognit,gocyclo // multi-condition validation logic
@@ -188,6 +261,7 @@ func (qc *queryContext) expandWildcardRefs(refs []api.RuleRef) {
 		expanded := qc.resolveWildcardRef(ref)
 		if len(expanded) > maxExpandedRefsPerParent {
 			expanded = expanded[:maxExpandedRefsPerParent]
+			wildcardTruncationsMetric.Inc()
 			qc.addWarning(fmt.Sprintf(
 				"wildcard expansion for %s/%s/%s truncated at %d entries",
 				ref.APIGroup, ref.Resource, ref.Verb, maxExpandedRefsPerParent,
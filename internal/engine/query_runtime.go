@@ -14,7 +14,6 @@ import (
 	api "k8s-role-graph/pkg/apis/rbacgraph"
 )
 
-//nolint:gocognit,gocyclo,funlen // runtime chain expansion has necessary branching
 func (qc *queryContext) expandRuntimeChain() {
 	if !qc.spec.IncludePods {
 		return
@@ -29,106 +28,120 @@ func (qc *queryContext) expandRuntimeChain() {
 			continue
 		}
 		podRecords := qc.filterPods(qc.snapshot.PodsByServiceAccount[subject.ServiceAccountKey()])
-		if len(podRecords) == 0 {
+		qc.expandSubjectPods(subject.SubjectNodeID, subject.Namespace, podRecords, edgeExplainRunsAs)
+	}
+	for _, group := range sortedImplicitGroups(qc.groupSubjects) {
+		podRecords := qc.filterPods(qc.podsForImplicitGroup(group))
+		qc.expandSubjectPods(group.SubjectNodeID, group.Namespace, podRecords, edgeExplainRunsAsGroup)
+	}
+}
+
+// expandSubjectPods adds runsAs edges from a subject node to its pods and,
+// when requested, the owner chain of every pod. Pods beyond
+// MaxPodsPerSubject are folded into an overflow node.
+//
+//nolint:gocognit,funlen // pod and workload chain expansion with overflow handling
+func (qc *queryContext) expandSubjectPods(subjectNodeID, namespace string, podRecords []*indexer.PodRecord, explain string) {
+	if len(podRecords) == 0 {
+		return
+	}
+
+	visiblePods := podRecords
+	if len(visiblePods) > qc.spec.MaxPodsPerSubject {
+		visiblePods = visiblePods[:qc.spec.MaxPodsPerSubject]
+	}
+	for _, pod := range visiblePods {
+		podNodeIDValue := podNodeID(pod)
+		if qc.addNodeIfMissing(api.GraphNode{
+			ID:            podNodeIDValue,
+			Type:          api.GraphNodeTypePod,
+			Name:          pod.Name,
+			Namespace:     pod.Namespace,
+			PodPhase:      string(pod.Phase),
+			TokenExposure: qc.tokenExposure(pod),
+		}) {
+			qc.podSeen[podNodeIDValue] = struct{}{}
+		}
+		qc.appendEdgeIfMissing(api.GraphEdge{
+			ID:      edgeIDFor(subjectNodeID, podNodeIDValue, api.GraphEdgeTypeRunsAs),
+			From:    subjectNodeID,
+			To:      podNodeIDValue,
+			Type:    api.GraphEdgeTypeRunsAs,
+			Explain: explain,
+		})
+
+		if !qc.spec.IncludeWorkloads {
 			continue
 		}
 
-		visiblePods := podRecords
-		if len(visiblePods) > qc.spec.MaxPodsPerSubject {
-			visiblePods = visiblePods[:qc.spec.MaxPodsPerSubject]
+		workloadChain := qc.resolveWorkloadChain(pod)
+		visibleChain := workloadChain
+		if len(visibleChain) > qc.spec.MaxWorkloadsPerPod {
+			visibleChain = visibleChain[:qc.spec.MaxWorkloadsPerPod]
 		}
-		for _, pod := range visiblePods {
-			podNodeIDValue := podNodeID(pod)
+
+		parentID := podNodeIDValue
+		for _, workload := range visibleChain {
+			workloadNodeIDValue := workloadNodeID(workload)
 			if qc.addNodeIfMissing(api.GraphNode{
-				ID:        podNodeIDValue,
-				Type:      api.GraphNodeTypePod,
-				Name:      pod.Name,
-				Namespace: pod.Namespace,
-				PodPhase:  string(pod.Phase),
+				ID:           workloadNodeIDValue,
+				Type:         api.GraphNodeTypeWorkload,
+				Name:         workload.Name,
+				Namespace:    workload.Namespace,
+				WorkloadKind: workload.Kind,
 			}) {
-				qc.podSeen[podNodeIDValue] = struct{}{}
+				qc.workloadSeen[workloadNodeIDValue] = struct{}{}
 			}
 			qc.appendEdgeIfMissing(api.GraphEdge{
-				ID:      edgeIDFor(subject.SubjectNodeID, podNodeIDValue, api.GraphEdgeTypeRunsAs),
-				From:    subject.SubjectNodeID,
-				To:      podNodeIDValue,
-				Type:    api.GraphEdgeTypeRunsAs,
-				Explain: edgeExplainRunsAs,
+				ID:      edgeIDFor(parentID, workloadNodeIDValue, api.GraphEdgeTypeOwnedBy),
+				From:    parentID,
+				To:      workloadNodeIDValue,
+				Type:    api.GraphEdgeTypeOwnedBy,
+				Explain: edgeExplainOwnedBy,
 			})
-
-			if !qc.spec.IncludeWorkloads {
-				continue
-			}
-
-			workloadChain := qc.resolveWorkloadChain(pod)
-			visibleChain := workloadChain
-			if len(visibleChain) > qc.spec.MaxWorkloadsPerPod {
-				visibleChain = visibleChain[:qc.spec.MaxWorkloadsPerPod]
-			}
-
-			parentID := podNodeIDValue
-			for _, workload := range visibleChain {
-				workloadNodeIDValue := workloadNodeID(workload)
-				if qc.addNodeIfMissing(api.GraphNode{
-					ID:           workloadNodeIDValue,
-					Type:         api.GraphNodeTypeWorkload,
-					Name:         workload.Name,
-					Namespace:    workload.Namespace,
-					WorkloadKind: workload.Kind,
-				}) {
-					qc.workloadSeen[workloadNodeIDValue] = struct{}{}
-				}
-				qc.appendEdgeIfMissing(api.GraphEdge{
-					ID:      edgeIDFor(parentID, workloadNodeIDValue, api.GraphEdgeTypeOwnedBy),
-					From:    parentID,
-					To:      workloadNodeIDValue,
-					Type:    api.GraphEdgeTypeOwnedBy,
-					Explain: edgeExplainOwnedBy,
-				})
-				parentID = workloadNodeIDValue
-			}
-
-			hiddenWorkloads := len(workloadChain) - len(visibleChain)
-			if hiddenWorkloads > 0 {
-				overflowID := workloadOverflowNodeID(podNodeIDValue)
-				qc.addNodeIfMissing(api.GraphNode{
-					ID:          overflowID,
-					Type:        api.GraphNodeTypeWorkloadOverflow,
-					Name:        fmt.Sprintf("+%d workloads", hiddenWorkloads),
-					Namespace:   pod.Namespace,
-					Synthetic:   true,
-					HiddenCount: hiddenWorkloads,
-				})
-				qc.appendEdgeIfMissing(api.GraphEdge{
-					ID:      edgeIDFor(parentID, overflowID, api.GraphEdgeTypeOwnedBy),
-					From:    parentID,
-					To:      overflowID,
-					Type:    api.GraphEdgeTypeOwnedBy,
-					Explain: "Workload chain truncated by limit",
-				})
-			}
+			parentID = workloadNodeIDValue
 		}
 
-		hiddenPods := len(podRecords) - len(visiblePods)
-		if hiddenPods > 0 {
-			overflowID := podOverflowNodeID(subject.SubjectNodeID)
+		hiddenWorkloads := len(workloadChain) - len(visibleChain)
+		if hiddenWorkloads > 0 {
+			overflowID := workloadOverflowNodeID(podNodeIDValue)
 			qc.addNodeIfMissing(api.GraphNode{
 				ID:          overflowID,
-				Type:        api.GraphNodeTypePodOverflow,
-				Name:        fmt.Sprintf("+%d pods", hiddenPods),
-				Namespace:   subject.Namespace,
+				Type:        api.GraphNodeTypeWorkloadOverflow,
+				Name:        fmt.Sprintf("+%d workloads", hiddenWorkloads),
+				Namespace:   pod.Namespace,
 				Synthetic:   true,
-				HiddenCount: hiddenPods,
+				HiddenCount: hiddenWorkloads,
 			})
 			qc.appendEdgeIfMissing(api.GraphEdge{
-				ID:      edgeIDFor(subject.SubjectNodeID, overflowID, api.GraphEdgeTypeRunsAs),
-				From:    subject.SubjectNodeID,
+				ID:      edgeIDFor(parentID, overflowID, api.GraphEdgeTypeOwnedBy),
+				From:    parentID,
 				To:      overflowID,
-				Type:    api.GraphEdgeTypeRunsAs,
-				Explain: "Pod list truncated by limit",
+				Type:    api.GraphEdgeTypeOwnedBy,
+				Explain: "Workload chain truncated by limit",
 			})
 		}
 	}
+
+	hiddenPods := len(podRecords) - len(visiblePods)
+	if hiddenPods > 0 {
+		overflowID := podOverflowNodeID(subjectNodeID)
+		qc.addNodeIfMissing(api.GraphNode{
+			ID:          overflowID,
+			Type:        api.GraphNodeTypePodOverflow,
+			Name:        fmt.Sprintf("+%d pods", hiddenPods),
+			Namespace:   namespace,
+			Synthetic:   true,
+			HiddenCount: hiddenPods,
+		})
+		qc.appendEdgeIfMissing(api.GraphEdge{
+			ID:      edgeIDFor(subjectNodeID, overflowID, api.GraphEdgeTypeRunsAs),
+			From:    subjectNodeID,
+			To:      overflowID,
+			Type:    api.GraphEdgeTypeRunsAs,
+			Explain: "Pod list truncated by limit",
+		})
+	}
 }
 
 type subjectServiceAccount struct {
@@ -181,6 +194,74 @@ func sortedServiceAccounts(subjects map[string]subjectServiceAccount) []subjectS
 	return out
 }
 
+// implicitGroupSubject is a group subject whose members are determined by
+// the apiserver rather than an identity provider, so its pods are known.
+// An empty Namespace means ServiceAccounts in all namespaces.
+type implicitGroupSubject struct {
+	SubjectNodeID string
+	Group         string
+	Namespace     string
+}
+
+// trackImplicitGroupSubject records group subjects that ServiceAccounts
+// implicitly belong to: system:serviceaccounts, system:authenticated and
+// system:serviceaccounts:<namespace>.
+func (qc *queryContext) trackImplicitGroupSubject(subjectNodeID string, subject rbacv1.Subject) {
+	if subjectType(subject.Kind) != api.GraphNodeTypeGroup {
+		return
+	}
+	group := strings.TrimSpace(subject.Name)
+	switch {
+	case group == groupServiceAccounts, group == groupAuthenticated:
+		qc.groupSubjects[subjectNodeID] = implicitGroupSubject{SubjectNodeID: subjectNodeID, Group: group}
+	case strings.HasPrefix(group, groupServiceAccounts+":"):
+		namespace := strings.TrimPrefix(group, groupServiceAccounts+":")
+		if namespace == "" {
+			return
+		}
+		qc.groupSubjects[subjectNodeID] = implicitGroupSubject{SubjectNodeID: subjectNodeID, Group: group, Namespace: namespace}
+	}
+}
+
+func sortedImplicitGroups(groups map[string]implicitGroupSubject) []implicitGroupSubject {
+	if len(groups) == 0 {
+		return nil
+	}
+	out := make([]implicitGroupSubject, 0, len(groups))
+	for _, group := range groups {
+		out = append(out, group)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].SubjectNodeID < out[j].SubjectNodeID
+	})
+
+	return out
+}
+
+// podsForImplicitGroup returns the pods of every ServiceAccount in the
+// group, ordered by namespace and name so that overflow is deterministic.
+func (qc *queryContext) podsForImplicitGroup(group implicitGroupSubject) []*indexer.PodRecord {
+	if group.Namespace != "" && !allowNamespace(qc.namespaceFilter, group.Namespace, qc.namespaceStrict) {
+		return nil
+	}
+	var pods []*indexer.PodRecord
+	for key, records := range qc.snapshot.PodsByServiceAccount {
+		if group.Namespace != "" && key.Namespace != group.Namespace {
+			continue
+		}
+		pods = append(pods, records...)
+	}
+	sort.Slice(pods, func(i, j int) bool {
+		if pods[i].Namespace != pods[j].Namespace {
+			return pods[i].Namespace < pods[j].Namespace
+		}
+
+		return pods[i].Name < pods[j].Name
+	})
+
+	return pods
+}
+
 func (qc *queryContext) filterPods(pods []*indexer.PodRecord) []*indexer.PodRecord {
 	if len(pods) == 0 {
 		return nil
@@ -0,0 +1,297 @@
+package engine
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+
+	"k8s-role-graph/internal/indexer"
+	"k8s-role-graph/internal/matcher"
+	api "k8s-role-graph/pkg/apis/rbacgraph"
+)
+
+const (
+	groupAuthenticated   = "system:authenticated"
+	groupUnauthenticated = "system:unauthenticated"
+	groupServiceAccounts = "system:serviceaccounts"
+	userAnonymous        = "system:anonymous"
+	// userServiceAccountPrefix starts the username ServiceAccounts
+	// authenticate as: "system:serviceaccount:<namespace>:<name>".
+	userServiceAccountPrefix = "system:serviceaccount:"
+)
+
+type subjectPermissionKey struct {
+	apiGroup        string
+	resource        string
+	verb            string
+	resourceNames   string
+	nonResourceURLs string
+}
+
+type subjectPermissionAccumulator struct {
+	permission api.SubjectPermission
+	namespaces map[string]struct{}
+	roles      map[string]struct{}
+	bindings   map[string]struct{}
+}
+
+// QuerySubject answers "what can this subject do": it walks the reverse
+// subject index for the subject and all of its effective groups and returns
+// every rule granted through the bindings found. Rules a RoleBinding cannot
+// grant, such as nonResourceURLs or resources discovery reports as
+// cluster-scoped, are left out.
+//
+//nolint:gocognit // walks subject keys -> bindings -> role rules
+func (e *Engine) QuerySubject(snapshot *indexer.Snapshot, spec api.SubjectPermissionReviewSpec, discovery *indexer.APIDiscoveryCache) api.SubjectPermissionReviewStatus {
+	qc := newQueryContext(snapshot, api.RoleGraphReviewSpec{
+		NamespaceScope:      spec.NamespaceScope,
+		IncludeRuleMetadata: spec.IncludeRuleMetadata,
+	}, e.riskCatalog)
+
+	groups := EffectiveGroups(spec.Subject, spec.Groups)
+	keys := make([]indexer.SubjectKey, 0, len(groups)+2)
+	keys = append(keys, subjectKeyFor(spec.Subject))
+	// Bindings may also name a ServiceAccount by the username it
+	// authenticates as.
+	if spec.Subject.Kind == api.SubjectKindServiceAccount {
+		keys = append(keys, indexer.SubjectKey{
+			Kind: indexer.SubjectKindUser,
+			Name: userServiceAccountPrefix + spec.Subject.Namespace + ":" + spec.Subject.Name,
+		})
+	}
+	for _, group := range groups {
+		keys = append(keys, indexer.SubjectKey{Kind: indexer.SubjectKindGroup, Name: group})
+	}
+
+	permissions := make(map[subjectPermissionKey]*subjectPermissionAccumulator)
+	for _, key := range keys {
+		bindings := filterBindingsByNamespace(qc.namespaceFilter, qc.namespaceStrict, snapshot.BindingsBySubject[key])
+		for _, binding := range bindings {
+			roleID := indexer.RecID(binding.RoleRef.Kind, binding.RoleRef.Namespace, binding.RoleRef.Name)
+			role, ok := snapshot.RolesByID[roleID]
+			if !ok {
+				qc.addWarning(fmt.Sprintf(
+					"%s %s references missing %s %q",
+					binding.Kind, bindingDisplayName(binding), binding.RoleRef.Kind, binding.RoleRef.Name,
+				))
+
+				continue
+			}
+
+			roleRefs := expandRoleRules(role, spec.IncludeRuleMetadata)
+			refs := effectiveRefs(roleRefs, binding, discovery)
+			if len(refs) == 0 {
+				continue
+			}
+
+			roleNodeIDValue := qc.upsertRoleNode(role, snapshot.AggregatedRoleSources[roleID], roleRefs)
+			qc.roleSeen[roleID] = struct{}{}
+			bindingNodeIDValue := bindingNodeID(binding)
+			qc.addNodeIfMissing(api.GraphNode{
+				ID:        bindingNodeIDValue,
+				Type:      bindingType(binding),
+				Name:      binding.Name,
+				Namespace: binding.Namespace,
+			})
+			qc.bindingSeen[bindingNodeIDValue] = struct{}{}
+			qc.appendEdgeIfMissing(api.GraphEdge{
+				ID:       edgeIDFor(roleNodeIDValue, bindingNodeIDValue, api.GraphEdgeTypeGrants),
+				From:     roleNodeIDValue,
+				To:       bindingNodeIDValue,
+				Type:     api.GraphEdgeTypeGrants,
+				RuleRefs: refs,
+				Explain:  edgeExplainGrants,
+				Scope:    bindingScope(binding),
+			})
+
+			subject := rbacv1.Subject{Kind: key.Kind, Name: key.Name, Namespace: key.Namespace}
+			subjectNodeIDValue := subjectNodeID(subject)
+			qc.addNodeIfMissing(api.GraphNode{
+				ID:        subjectNodeIDValue,
+				Type:      subjectType(subject.Kind),
+				Name:      subject.Name,
+				Namespace: subject.Namespace,
+			})
+			qc.subjectSeen[subjectNodeIDValue] = struct{}{}
+			qc.appendEdgeIfMissing(api.GraphEdge{
+				ID:      edgeIDFor(bindingNodeIDValue, subjectNodeIDValue, api.GraphEdgeTypeSubjects),
+				From:    bindingNodeIDValue,
+				To:      subjectNodeIDValue,
+				Type:    api.GraphEdgeTypeSubjects,
+				Explain: edgeExplainSubjects,
+				Scope:   bindingScope(binding),
+			})
+
+			accumulateSubjectPermissions(permissions, refs, roleNodeIDValue, bindingNodeIDValue, binding.Namespace)
+		}
+	}
+
+	graphStatus := qc.finalize()
+
+	return api.SubjectPermissionReviewStatus{
+		MatchedRoles:    graphStatus.MatchedRoles,
+		MatchedBindings: graphStatus.MatchedBindings,
+		EffectiveGroups: groups,
+		Warnings:        graphStatus.Warnings,
+		Permissions:     collapseSubjectPermissions(permissions),
+		Graph:           graphStatus.Graph,
+	}
+}
+
+// EffectiveGroups returns the explicit groups of a subject merged with the
+// implicit groups Kubernetes authenticators add: system:authenticated for
+// every authenticated user, plus system:serviceaccounts and
+// system:serviceaccounts:<namespace> for ServiceAccounts. Group subjects
+// have no groups of their own.
+func EffectiveGroups(subject api.SubjectRef, explicit []string) []string {
+	if subject.Kind == api.SubjectKindGroup {
+		return []string{}
+	}
+
+	groups := make([]string, 0, len(explicit)+3)
+	seen := make(map[string]struct{}, len(explicit)+3)
+	for _, group := range explicit {
+		appendUniqueString(&groups, seen, group)
+	}
+	switch {
+	case subject.Kind == api.SubjectKindServiceAccount:
+		appendUniqueString(&groups, seen, groupServiceAccounts)
+		appendUniqueString(&groups, seen, groupServiceAccounts+":"+subject.Namespace)
+		appendUniqueString(&groups, seen, groupAuthenticated)
+	case subject.Name == userAnonymous:
+		appendUniqueString(&groups, seen, groupUnauthenticated)
+	default:
+		appendUniqueString(&groups, seen, groupAuthenticated)
+	}
+	sort.Strings(groups)
+
+	return groups
+}
+
+func subjectKeyFor(subject api.SubjectRef) indexer.SubjectKey {
+	return indexer.NewSubjectKey(rbacv1.Subject{
+		Kind:      subject.Kind,
+		Name:      subject.Name,
+		Namespace: subject.Namespace,
+	}, "")
+}
+
+func expandRoleRules(role *indexer.RoleRecord, includeRuleMetadata bool) []api.RuleRef {
+	refs := make([]api.RuleRef, 0)
+	for idx, rule := range role.Rules {
+		refs = append(refs, matcher.ExpandRule(rule, string(role.UID), idx)...)
+	}
+	if !includeRuleMetadata {
+		for i := range refs {
+			refs[i].SourceObjectUID = ""
+			refs[i].SourceRuleIndex = 0
+		}
+	}
+
+	return refs
+}
+
+func bindingDisplayName(binding *indexer.BindingRecord) string {
+	if binding.Namespace == "" {
+		return binding.Name
+	}
+
+	return binding.Namespace + "/" + binding.Name
+}
+
+func accumulateSubjectPermissions(
+	permissions map[subjectPermissionKey]*subjectPermissionAccumulator,
+	refs []api.RuleRef, roleID, bindingID, bindingNamespace string,
+) {
+	for i := range refs {
+		ref := &refs[i]
+		resource := ref.Resource
+		if ref.Subresource != "" {
+			resource = ref.Resource + "/" + ref.Subresource
+		}
+		key := subjectPermissionKey{
+			apiGroup:        ref.APIGroup,
+			resource:        resource,
+			verb:            ref.Verb,
+			resourceNames:   strings.Join(ref.ResourceNames, ","),
+			nonResourceURLs: strings.Join(ref.NonResourceURLs, ","),
+		}
+		acc, ok := permissions[key]
+		if !ok {
+			acc = &subjectPermissionAccumulator{
+				permission: api.SubjectPermission{
+					APIGroup:        ref.APIGroup,
+					Resource:        resource,
+					Verb:            ref.Verb,
+					ResourceNames:   copyStringSlice(ref.ResourceNames),
+					NonResourceURLs: copyStringSlice(ref.NonResourceURLs),
+				},
+				namespaces: make(map[string]struct{}),
+				roles:      make(map[string]struct{}),
+				bindings:   make(map[string]struct{}),
+			}
+			permissions[key] = acc
+		}
+		if bindingNamespace == "" {
+			acc.permission.ClusterWide = true
+		} else {
+			acc.namespaces[bindingNamespace] = struct{}{}
+		}
+		acc.roles[roleID] = struct{}{}
+		acc.bindings[bindingID] = struct{}{}
+	}
+}
+
+func collapseSubjectPermissions(permissions map[subjectPermissionKey]*subjectPermissionAccumulator) []api.SubjectPermission {
+	out := make([]api.SubjectPermission, 0, len(permissions))
+	for _, acc := range permissions {
+		permission := acc.permission
+		// A cluster-wide grant already covers every namespace.
+		if !permission.ClusterWide {
+			permission.Namespaces = sortedKeys(acc.namespaces)
+		}
+		permission.Roles = sortedKeys(acc.roles)
+		permission.Bindings = sortedKeys(acc.bindings)
+		out = append(out, permission)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		left, right := out[i], out[j]
+		if left.APIGroup != right.APIGroup {
+			return left.APIGroup < right.APIGroup
+		}
+		if left.Resource != right.Resource {
+			return left.Resource < right.Resource
+		}
+		if left.Verb != right.Verb {
+			return left.Verb < right.Verb
+		}
+		leftURLs, rightURLs := strings.Join(left.NonResourceURLs, ","), strings.Join(right.NonResourceURLs, ",")
+		if leftURLs != rightURLs {
+			return leftURLs < rightURLs
+		}
+
+		return strings.Join(left.ResourceNames, ",") < strings.Join(right.ResourceNames, ",")
+	})
+
+	return out
+}
+
+func sortedKeys(set map[string]struct{}) []string {
+	out := make([]string, 0, len(set))
+	for value := range set {
+		out = append(out, value)
+	}
+	sort.Strings(out)
+
+	return out
+}
+
+func copyStringSlice(values []string) []string {
+	if len(values) == 0 {
+		return nil
+	}
+
+	return append([]string(nil), values...)
+}
@@ -1,6 +1,7 @@
 package engine
 
 import (
+	"slices"
 	"sort"
 	"strings"
 
@@ -9,12 +10,15 @@ import (
 )
 
 type resourceAccumulator struct {
-	APIGroup string
-	Resource string
-	Verb     string
-	roles    map[indexer.RoleID]struct{}
-	bindings map[string]struct{}
-	subjects map[string]struct{}
+	APIGroup    string
+	Resource    string
+	Verb        string
+	Namespace   string
+	roles       map[indexer.RoleID]struct{}
+	bindings    map[string]struct{}
+	subjects    map[string]struct{}
+	clusterWide bool
+	namespaces  map[string]struct{}
 }
 
 type resourceRowKey struct {
@@ -23,13 +27,68 @@ type resourceRowKey struct {
 	subresource       string
 	verb              string
 	nonResourceJoined string
+	namespace         string
 }
 
-func (qc *queryContext) accumulateResourceRows(refs []api.RuleRef, roleID indexer.RoleID, bindingID, subjectID string) {
-	accumulateResourceRowsInto(qc.resourceRows, refs, roleID, bindingID, subjectID)
+// bindingScope returns where a binding makes its role effective. A
+// RoleBinding is always limited to its own namespace, even when it
+// references a ClusterRole.
+func bindingScope(binding *indexer.BindingRecord) *api.EffectiveScope {
+	if binding.Namespace == "" {
+		return &api.EffectiveScope{ClusterWide: true}
+	}
+
+	return &api.EffectiveScope{Namespaces: []string{binding.Namespace}}
+}
+
+// effectiveRefs returns the refs a binding actually grants. A RoleBinding
+// grants namespaced resources in its namespace only, so nonResourceURLs and
+// the cluster-scoped resources of a referenced ClusterRole are dropped; the
+// latter only when discovery knows the scope of the resource.
+func effectiveRefs(refs []api.RuleRef, binding *indexer.BindingRecord, discovery *indexer.APIDiscoveryCache) []api.RuleRef {
+	if binding.Namespace == "" {
+		return refs
+	}
+	notGranted := func(ref api.RuleRef) bool { return !grantableInNamespace(ref, discovery) }
+	out := make([]api.RuleRef, 0, len(refs))
+	for _, ref := range refs {
+		if notGranted(ref) {
+			continue
+		}
+		if slices.ContainsFunc(ref.ExpandedRefs, notGranted) {
+			ref.ExpandedRefs = slices.DeleteFunc(slices.Clone(ref.ExpandedRefs), notGranted)
+		}
+		out = append(out, ref)
+	}
+
+	return out
+}
+
+func grantableInNamespace(ref api.RuleRef, discovery *indexer.APIDiscoveryCache) bool {
+	if len(ref.NonResourceURLs) > 0 {
+		return false
+	}
+	resource := ref.Resource
+	if ref.Subresource != "" {
+		resource = ref.Resource + "/" + ref.Subresource
+	}
+
+	return !discovery.ClusterScoped(ref.APIGroup, resource)
+}
+
+func (qc *queryContext) accumulateResourceRows(refs []api.RuleRef, roleID indexer.RoleID, bindingID, subjectID string, scope *api.EffectiveScope) {
+	rowNamespace := ""
+	if qc.spec.ResourceMapByNamespace && scope != nil && !scope.ClusterWide && len(scope.Namespaces) > 0 {
+		rowNamespace = scope.Namespaces[0]
+	}
+	accumulateResourceRowsInto(qc.resourceRows, refs, rowNamespace, roleID, bindingID, subjectID, scope)
 }
 
-func accumulateResourceRowsInto(rows map[resourceRowKey]*resourceAccumulator, refs []api.RuleRef, roleID indexer.RoleID, bindingID, subjectID string) {
+//nolint:gocognit // per-ref accumulation of roles, bindings, subjects and scope
+func accumulateResourceRowsInto(
+	rows map[resourceRowKey]*resourceAccumulator, refs []api.RuleRef, rowNamespace string,
+	roleID indexer.RoleID, bindingID, subjectID string, scope *api.EffectiveScope,
+) {
 	for i := range refs {
 		ref := &refs[i]
 		key := resourceRowKey{
@@ -37,6 +96,7 @@ func accumulateResourceRowsInto(rows map[resourceRowKey]*resourceAccumulator, re
 			resource:    ref.Resource,
 			subresource: ref.Subresource,
 			verb:        ref.Verb,
+			namespace:   rowNamespace,
 		}
 		if len(ref.NonResourceURLs) > 0 {
 			key.nonResourceJoined = strings.Join(ref.NonResourceURLs, ",")
@@ -51,12 +111,14 @@ func accumulateResourceRowsInto(rows map[resourceRowKey]*resourceAccumulator, re
 				resource = strings.Join(ref.NonResourceURLs, ",")
 			}
 			acc = &resourceAccumulator{
-				APIGroup: ref.APIGroup,
-				Resource: resource,
-				Verb:     ref.Verb,
-				roles:    make(map[indexer.RoleID]struct{}),
-				bindings: make(map[string]struct{}),
-				subjects: make(map[string]struct{}),
+				APIGroup:   ref.APIGroup,
+				Resource:   resource,
+				Verb:       ref.Verb,
+				Namespace:  rowNamespace,
+				roles:      make(map[indexer.RoleID]struct{}),
+				bindings:   make(map[string]struct{}),
+				subjects:   make(map[string]struct{}),
+				namespaces: make(map[string]struct{}),
 			}
 			rows[key] = acc
 		}
@@ -69,6 +131,25 @@ func accumulateResourceRowsInto(rows map[resourceRowKey]*resourceAccumulator, re
 		if subjectID != "" {
 			acc.subjects[subjectID] = struct{}{}
 		}
+		if scope != nil {
+			acc.clusterWide = acc.clusterWide || scope.ClusterWide
+			for _, ns := range scope.Namespaces {
+				acc.namespaces[ns] = struct{}{}
+			}
+		}
+	}
+}
+
+// scope collapses the accumulated binding scopes into a single effective
+// scope. A cluster-wide grant already covers every namespace.
+func (acc *resourceAccumulator) scope() *api.EffectiveScope {
+	switch {
+	case acc.clusterWide:
+		return &api.EffectiveScope{ClusterWide: true}
+	case len(acc.namespaces) > 0:
+		return &api.EffectiveScope{Namespaces: sortedKeys(acc.namespaces)}
+	default:
+		return nil
 	}
 }
 
@@ -79,9 +160,11 @@ func collapseResourceRows(rows map[resourceRowKey]*resourceAccumulator) []api.Re
 			APIGroup:     row.APIGroup,
 			Resource:     row.Resource,
 			Verb:         row.Verb,
+			Namespace:    row.Namespace,
 			RoleCount:    len(row.roles),
 			BindingCount: len(row.bindings),
 			SubjectCount: len(row.subjects),
+			Scope:        row.scope(),
 		})
 	}
 	sort.Slice(out, func(i, j int) bool {
@@ -91,8 +174,11 @@ func collapseResourceRows(rows map[resourceRowKey]*resourceAccumulator) []api.Re
 		if out[i].Resource != out[j].Resource {
 			return out[i].Resource < out[j].Resource
 		}
+		if out[i].Verb != out[j].Verb {
+			return out[i].Verb < out[j].Verb
+		}
 
-		return out[i].Verb < out[j].Verb
+		return out[i].Namespace < out[j].Namespace
 	})
 
 	return out
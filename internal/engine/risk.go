@@ -0,0 +1,73 @@
+package engine
+
+import (
+	"k8s-role-graph/internal/indexer"
+	"k8s-role-graph/internal/risk"
+	api "k8s-role-graph/pkg/apis/rbacgraph"
+)
+
+// riskPropagatingEdges are the edges along which a node inherits the risk
+// reasons of its source: bindings from roles, subjects from bindings, and
+// pods and workloads from the ServiceAccount they run as. Pods whose token
+// is not exposed to their containers inherit nothing.
+var riskPropagatingEdges = map[api.GraphEdgeType]struct{}{
+	api.GraphEdgeTypeGrants:   {},
+	api.GraphEdgeTypeSubjects: {},
+	api.GraphEdgeTypeRunsAs:   {},
+	api.GraphEdgeTypeOwnedBy:  {},
+}
+
+// roleRiskReasons evaluates the full rule set of a role, plus the wildcard
+// expansions of the matched refs, against the risk catalog. Results are
+// cached per role for the lifetime of the query.
+func (qc *queryContext) roleRiskReasons(role *indexer.RoleRecord, matchedRefs []api.RuleRef) []api.RiskReason {
+	if qc.riskCatalog == nil {
+		return nil
+	}
+	roleID := indexer.RecID(role.Kind, role.Namespace, role.Name)
+	if reasons, ok := qc.roleRisk[roleID]; ok {
+		return reasons
+	}
+	reasons := qc.riskCatalog.EvaluatePolicyRules(role.Rules, matchedRefs)
+	qc.roleRisk[roleID] = reasons
+
+	return reasons
+}
+
+// propagateRisk pushes risk reasons from roles down the access chain until
+// no node changes. Ownership chains are acyclic, so this terminates after at
+// most one pass per graph layer.
+func (qc *queryContext) propagateRisk() {
+	if qc.riskCatalog == nil {
+		return
+	}
+	nodes := qc.status.Graph.Nodes
+	index := make(map[string]int, len(nodes))
+	for i := range nodes {
+		index[nodes[i].ID] = i
+	}
+
+	for changed := true; changed; {
+		changed = false
+		for _, edge := range qc.status.Graph.Edges {
+			if _, ok := riskPropagatingEdges[edge.Type]; !ok {
+				continue
+			}
+			from, okFrom := index[edge.From]
+			to, okTo := index[edge.To]
+			if !okFrom || !okTo || len(nodes[from].RiskReasons) == 0 {
+				continue
+			}
+			if exposure := nodes[to].TokenExposure; exposure != nil && !exposure.Exposed {
+				continue
+			}
+			merged := risk.Merge(nodes[to].RiskReasons, nodes[from].RiskReasons)
+			if len(merged) == len(nodes[to].RiskReasons) {
+				continue
+			}
+			nodes[to].RiskReasons = merged
+			nodes[to].RiskScore = risk.Score(merged)
+			changed = true
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package engine
+
+import (
+	"maps"
+
+	"k8s-role-graph/internal/indexer"
+	api "k8s-role-graph/pkg/apis/rbacgraph"
+)
+
+// cloudIdentityAnnotations are the ServiceAccount annotations that bind a
+// ServiceAccount to a cloud IAM identity.
+var cloudIdentityAnnotations = []struct {
+	annotation string
+	provider   api.CloudIdentityProvider
+}{
+	{annotation: "eks.amazonaws.com/role-arn", provider: api.CloudIdentityProviderAWS},
+	{annotation: "iam.gke.io/gcp-service-account", provider: api.CloudIdentityProviderGCP},
+	{annotation: "azure.workload.identity/client-id", provider: api.CloudIdentityProviderAzure},
+}
+
+// describeServiceAccounts fills serviceAccount nodes from the indexed
+// ServiceAccounts. Nothing is filled when none are indexed, so a missing
+// ServiceAccount informer does not mark every subject as nonexistent, nor
+// for namespaces whose ServiceAccounts the caller cannot list.
+func (qc *queryContext) describeServiceAccounts() {
+	if len(qc.snapshot.ServiceAccounts) == 0 {
+		return
+	}
+	for i := range qc.status.Graph.Nodes {
+		node := &qc.status.Graph.Nodes[i]
+		if node.Type != api.GraphNodeTypeServiceAccount {
+			continue
+		}
+		key := indexer.ServiceAccountKey{Namespace: qc.nodeNamespace(node), Name: node.Name}
+		if key.Namespace == "" || !qc.snapshot.ServiceAccountVisible(key.Namespace) {
+			continue
+		}
+		sa, ok := qc.snapshot.ServiceAccounts[key]
+		if !ok {
+			node.ServiceAccount = &api.ServiceAccountInfo{}
+
+			continue
+		}
+		node.Annotations = maps.Clone(sa.Annotations)
+		node.ServiceAccount = &api.ServiceAccountInfo{
+			Exists:                       true,
+			AutomountServiceAccountToken: sa.AutomountServiceAccountToken,
+			ImagePullSecrets:             sa.ImagePullSecrets,
+			CloudIdentities:              cloudIdentities(sa.Annotations),
+			PodCount:                     len(qc.snapshot.PodsByServiceAccount[key]),
+		}
+	}
+}
+
+func cloudIdentities(annotations map[string]string) []api.CloudIdentity {
+	var out []api.CloudIdentity
+	for _, known := range cloudIdentityAnnotations {
+		if identity := annotations[known.annotation]; identity != "" {
+			out = append(out, api.CloudIdentity{Provider: known.provider, Annotation: known.annotation, Identity: identity})
+		}
+	}
+
+	return out
+}
@@ -36,3 +36,11 @@ func sortEdges(edges []api.GraphEdge) {
 		return string(e.Type), e.From, e.To, e.ID
 	})
 }
+
+// sortNodesByRisk reorders already sorted nodes by descending risk score,
+// keeping the type/name order among nodes with equal scores.
+func sortNodesByRisk(nodes []api.GraphNode) {
+	sort.SliceStable(nodes, func(i, j int) bool {
+		return nodes[i].RiskScore > nodes[j].RiskScore
+	})
+}
@@ -0,0 +1,120 @@
+package engine
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+
+	"k8s-role-graph/internal/indexer"
+	api "k8s-role-graph/pkg/apis/rbacgraph"
+)
+
+// subjectFilter is the compiled form of api.SubjectSelector. A nil filter
+// allows every subject.
+type subjectFilter struct {
+	kinds      map[string]struct{}
+	names      map[string]struct{}
+	patterns   []string
+	regex      *regexp.Regexp
+	namespaces map[string]struct{}
+	// nothing is set when the selector failed to compile.
+	nothing bool
+}
+
+func makeSubjectFilter(selector *api.SubjectSelector) (*subjectFilter, error) {
+	if selector == nil {
+		return nil, nil //nolint:nilnil // nil filter allows everything
+	}
+	filter := &subjectFilter{
+		kinds:      makeSet(selector.Kinds),
+		names:      makeSet(selector.Names),
+		patterns:   selector.NamePatterns,
+		namespaces: makeNamespaceSet(selector.Namespaces),
+	}
+	if selector.NameRegex != "" {
+		regex, err := regexp.Compile(`^(?:` + selector.NameRegex + `)$`)
+		if err != nil {
+			filter.nothing = true
+
+			return filter, fmt.Errorf("invalid nameRegex: %w", err)
+		}
+		filter.regex = regex
+	}
+
+	return filter, nil
+}
+
+func makeSet(values []string) map[string]struct{} {
+	if len(values) == 0 {
+		return nil
+	}
+	set := make(map[string]struct{}, len(values))
+	for _, value := range values {
+		set[value] = struct{}{}
+	}
+
+	return set
+}
+
+// allows matches a subject by its canonical key, so ServiceAccounts without
+// a namespace match in the namespace of their binding.
+func (f *subjectFilter) allows(key indexer.SubjectKey) bool {
+	if f == nil {
+		return true
+	}
+	if f.nothing {
+		return false
+	}
+	if f.kinds != nil {
+		if _, ok := f.kinds[key.Kind]; !ok {
+			return false
+		}
+	}
+	if f.namespaces != nil {
+		if key.Kind != indexer.SubjectKindServiceAccount {
+			return false
+		}
+		if _, ok := f.namespaces[key.Namespace]; !ok {
+			return false
+		}
+	}
+
+	return f.allowsName(key.Name)
+}
+
+func (f *subjectFilter) allowsName(name string) bool {
+	if f.names == nil && len(f.patterns) == 0 && f.regex == nil {
+		return true
+	}
+	if _, ok := f.names[name]; ok {
+		return true
+	}
+	for _, pattern := range f.patterns {
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+
+	return f.regex != nil && f.regex.MatchString(name)
+}
+
+// filterBindingsBySubjects keeps bindings with at least one allowed subject.
+// The subjects themselves are filtered when the graph is built.
+func filterBindingsBySubjects(filter *subjectFilter, bindings []*indexer.BindingRecord) []*indexer.BindingRecord {
+	if filter == nil || len(bindings) == 0 {
+		return bindings
+	}
+	out := make([]*indexer.BindingRecord, 0, len(bindings))
+	for _, binding := range bindings {
+		if slices.ContainsFunc(binding.Subjects, func(subject rbacv1.Subject) bool {
+			return filter.allows(indexer.NewSubjectKey(subject, binding.Namespace))
+		}) {
+			out = append(out, binding)
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,37 @@
+package engine
+
+import (
+	"k8s-role-graph/internal/indexer"
+	api "k8s-role-graph/pkg/apis/rbacgraph"
+)
+
+// tokenExposure reports whether the containers of pod can use the token of
+// its ServiceAccount against the API server. The pod setting for automount
+// takes precedence over the ServiceAccount setting, which defaults to true.
+// Projected tokens for other audiences are recorded but do not expose the
+// ServiceAccount permissions.
+func (qc *queryContext) tokenExposure(pod *indexer.PodRecord) *api.TokenExposure {
+	exposure := &api.TokenExposure{Automount: true, AutomountSource: api.TokenAutomountSourceDefault}
+	sa := qc.snapshot.ServiceAccounts[indexer.ServiceAccountKey{Namespace: pod.Namespace, Name: pod.ServiceAccountName}]
+	switch {
+	case pod.AutomountServiceAccountToken != nil:
+		exposure.Automount = *pod.AutomountServiceAccountToken
+		exposure.AutomountSource = api.TokenAutomountSourcePod
+	case sa != nil && sa.AutomountServiceAccountToken != nil:
+		exposure.Automount = *sa.AutomountServiceAccountToken
+		exposure.AutomountSource = api.TokenAutomountSourceServiceAccount
+	}
+	exposure.Exposed = exposure.Automount
+	for _, token := range pod.ProjectedTokens {
+		exposure.ProjectedTokens = append(exposure.ProjectedTokens, api.ProjectedToken{
+			Volume:            token.Volume,
+			Audience:          token.Audience,
+			ExpirationSeconds: token.ExpirationSeconds,
+		})
+		if token.Audience == "" {
+			exposure.Exposed = true
+		}
+	}
+
+	return exposure
+}
@@ -1,12 +1,17 @@
 package indexer
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"os"
 	"slices"
 	"sort"
 	"strings"
 	"time"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
 	"k8s.io/client-go/discovery"
 	"k8s.io/klog/v2"
 
@@ -19,6 +24,7 @@ type APIDiscoveryCache struct {
 	Groups               map[string]struct{}            // valid apiGroup names ("", "apps", "batch")
 	ResourcesByGroup     map[string]map[string]struct{} // apiGroup → resource names (incl. subresources "pods/exec")
 	VerbsByGroupResource map[string]map[string][]string // apiGroup → resource → sorted verbs
+	ClusterScopedByGroup map[string]map[string]struct{} // apiGroup → cluster-scoped resource names (incl. subresources)
 	AllResources         map[string]struct{}
 	AllVerbs             map[string]struct{}
 	FetchedAt            time.Time
@@ -30,13 +36,62 @@ func buildDiscoveryCache(client discovery.DiscoveryInterface) (*APIDiscoveryCach
 		if resourceLists == nil {
 			return nil, fmt.Errorf("server groups and resources: %w", err)
 		}
+		discoveryRefreshFailuresMetric.WithLabelValues(discoveryFailurePartial).Inc()
 		klog.Warningf("partial discovery error (continuing with available data): %v", err)
 	}
 
+	return discoveryCacheFromResourceLists(resourceLists), nil
+}
+
+// LoadDiscoveryCache reads a discovery cache from a file holding a stream
+// of APIResourceList documents, in YAML or JSON, such as the concatenated
+// output of `kubectl get --raw /api/v1` and `kubectl get --raw /apis/<group>/<version>`
+// for every served group version. FetchedAt is the file modification time.
+func LoadDiscoveryCache(path string) (*APIDiscoveryCache, error) {
+	f, err := os.Open(path) //nolint:gosec // path is supplied by the operator
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close() //nolint:errcheck // read-only
+
+	var lists []*metav1.APIResourceList
+	decoder := utilyaml.NewYAMLOrJSONDecoder(f, manifestDecodeBufferSize)
+	for doc := 1; ; doc++ {
+		list := &metav1.APIResourceList{}
+		if err := decoder.Decode(list); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			return nil, fmt.Errorf("%s: document %d: %w", path, doc, err)
+		}
+		if list.GroupVersion == "" {
+			if len(list.APIResources) > 0 {
+				return nil, fmt.Errorf("%s: document %d: APIResourceList without groupVersion", path, doc)
+			}
+
+			continue
+		}
+		lists = append(lists, list)
+	}
+	if len(lists) == 0 {
+		return nil, fmt.Errorf("%s: no APIResourceList documents", path)
+	}
+
+	cache := discoveryCacheFromResourceLists(lists)
+	if info, err := f.Stat(); err == nil {
+		cache.FetchedAt = info.ModTime().UTC()
+	}
+
+	return cache, nil
+}
+
+func discoveryCacheFromResourceLists(resourceLists []*metav1.APIResourceList) *APIDiscoveryCache {
 	cache := &APIDiscoveryCache{
 		Groups:               make(map[string]struct{}),
 		ResourcesByGroup:     make(map[string]map[string]struct{}),
 		VerbsByGroupResource: make(map[string]map[string][]string),
+		ClusterScopedByGroup: make(map[string]map[string]struct{}),
 		AllResources:         make(map[string]struct{}),
 		AllVerbs:             make(map[string]struct{}),
 		FetchedAt:            time.Now().UTC(),
@@ -57,6 +112,12 @@ func buildDiscoveryCache(client discovery.DiscoveryInterface) (*APIDiscoveryCach
 			r := &list.APIResources[i]
 			cache.ResourcesByGroup[group][r.Name] = struct{}{}
 			cache.AllResources[r.Name] = struct{}{}
+			if !r.Namespaced {
+				if _, ok := cache.ClusterScopedByGroup[group]; !ok {
+					cache.ClusterScopedByGroup[group] = make(map[string]struct{})
+				}
+				cache.ClusterScopedByGroup[group][r.Name] = struct{}{}
+			}
 
 			verbs := make([]string, len(r.Verbs))
 			for j, v := range r.Verbs {
@@ -69,7 +130,34 @@ func buildDiscoveryCache(client discovery.DiscoveryInterface) (*APIDiscoveryCach
 		}
 	}
 
-	return cache, nil
+	return cache
+}
+
+// ClusterScoped reports whether resource, which may name a subresource, is
+// cluster-scoped in apiGroup. For apiGroup "*" the resource is cluster-scoped
+// when every group serving it is. Unknown resources, "*" and a nil cache
+// report false.
+func (c *APIDiscoveryCache) ClusterScoped(apiGroup, resource string) bool {
+	if c == nil || resource == "*" {
+		return false
+	}
+	if apiGroup != "*" {
+		_, ok := c.ClusterScopedByGroup[apiGroup][resource]
+
+		return ok
+	}
+	served := false
+	for group, resources := range c.ResourcesByGroup {
+		if _, ok := resources[resource]; !ok {
+			continue
+		}
+		if _, ok := c.ClusterScopedByGroup[group][resource]; !ok {
+			return false
+		}
+		served = true
+	}
+
+	return served
 }
 
 func groupFromGroupVersion(gv string) string {
@@ -98,11 +186,13 @@ func (i *Indexer) refreshDiscoveryLoop(stopCh <-chan struct{}, interval time.Dur
 func (i *Indexer) tryRefreshDiscovery(errFmt string) {
 	c, err := buildDiscoveryCache(i.discoveryClient)
 	if err != nil {
+		discoveryRefreshFailuresMetric.WithLabelValues(discoveryFailureError).Inc()
 		klog.Warningf(errFmt, err)
 
 		return
 	}
 	i.discoveryCache.Store(c)
+	recordDiscoveryFetched(c)
 }
 
 // ValidateSelector checks selector values against the cluster's API discovery data.
@@ -154,6 +154,43 @@ func TestBuildDiscoveryCache_Empty(t *testing.T) {
 	}
 }
 
+func TestDiscoveryCache_ClusterScoped(t *testing.T) {
+	cache := discoveryCacheFromResourceLists([]*metav1.APIResourceList{
+		{
+			GroupVersion: "v1",
+			APIResources: []metav1.APIResource{
+				{Name: "pods", Namespaced: true},
+				{Name: "nodes"},
+				{Name: "nodes/proxy"},
+			},
+		},
+		{GroupVersion: "a.example.com/v1", APIResources: []metav1.APIResource{{Name: "widgets"}}},
+		{GroupVersion: "b.example.com/v1", APIResources: []metav1.APIResource{{Name: "widgets", Namespaced: true}}},
+	})
+
+	tests := []struct {
+		apiGroup, resource string
+		expected           bool
+	}{
+		{apiGroup: "", resource: "nodes", expected: true},
+		{apiGroup: "", resource: "nodes/proxy", expected: true},
+		{apiGroup: "", resource: "pods", expected: false},
+		{apiGroup: "*", resource: "nodes", expected: true},
+		{apiGroup: "*", resource: "widgets", expected: false},
+		{apiGroup: "a.example.com", resource: "widgets", expected: true},
+		{apiGroup: "", resource: "*", expected: false},
+		{apiGroup: "", resource: "unknown", expected: false},
+	}
+	for _, tt := range tests {
+		if got := cache.ClusterScoped(tt.apiGroup, tt.resource); got != tt.expected {
+			t.Errorf("ClusterScoped(%q, %q) = %v, expected %v", tt.apiGroup, tt.resource, got, tt.expected)
+		}
+	}
+	if (*APIDiscoveryCache)(nil).ClusterScoped("", "nodes") {
+		t.Error("expected a nil cache to report nothing as cluster-scoped")
+	}
+}
+
 func TestContainsWildcard(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -4,6 +4,8 @@ import (
 	"maps"
 	"slices"
 	"strings"
+
+	rbacv1 "k8s.io/api/rbac/v1"
 )
 
 func RecID(kind, namespace, name string) RoleID {
@@ -32,10 +34,37 @@ func cloneSlice[T any](in []T) []T {
 	return slices.Clone(in)
 }
 
+func clonePtr[T any](in *T) *T {
+	if in == nil {
+		return nil
+	}
+	out := *in
+
+	return &out
+}
+
 func serviceAccountKey(namespace, name string) ServiceAccountKey {
 	return ServiceAccountKey{Namespace: namespace, Name: name}
 }
 
+// NewSubjectKey builds the canonical key for a binding subject. ServiceAccount
+// subjects without an explicit namespace default to the binding's namespace.
+func NewSubjectKey(subject rbacv1.Subject, bindingNamespace string) SubjectKey {
+	switch strings.ToLower(subject.Kind) {
+	case strings.ToLower(SubjectKindServiceAccount):
+		namespace := strings.TrimSpace(subject.Namespace)
+		if namespace == "" {
+			namespace = strings.TrimSpace(bindingNamespace)
+		}
+
+		return SubjectKey{Kind: SubjectKindServiceAccount, Namespace: namespace, Name: subject.Name}
+	case strings.ToLower(SubjectKindGroup):
+		return SubjectKey{Kind: SubjectKindGroup, Name: subject.Name}
+	default:
+		return SubjectKey{Kind: SubjectKindUser, Name: subject.Name}
+	}
+}
+
 func normalizeServiceAccountName(name string) string {
 	normalized := strings.TrimSpace(name)
 	if normalized == "" {
@@ -0,0 +1,445 @@
+package indexer
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+const (
+	historyFilePrefix     = "snapshot-"
+	historyFileSuffix     = ".json.gz"
+	historyGenerationFile = "generation"
+	// historyFileVersion 2 dropped pods and workloads; version 1 files are
+	// still read, without their runtime state.
+	historyFileVersion = 2
+)
+
+// RuntimeIndexNotPersisted is reported in Snapshot.RuntimeUnavailable of
+// snapshots loaded from a persisted history.
+const RuntimeIndexNotPersisted = "runtime index is not persisted in snapshot history; pods and workloads are missing"
+
+// ErrSnapshotNotRetained is returned when no retained snapshot covers the
+// requested time or generation.
+var ErrSnapshotNotRetained = errors.New("snapshot not retained in history")
+
+// History keeps a bounded, ordered list of past snapshots. The newest entry
+// always mirrors the current snapshot. A rebuild replaces it instead of
+// appending while it is less than minInterval newer than its predecessor and
+// only differs from it in runtime state, so every RBAC change keeps its
+// entry and asOf sees each RBAC state; only runtime states are thinned out.
+// A replaced generation resolves to the entry that replaced it, which has
+// the same RBAC part.
+//
+// When dir is set, an entry is written to disk by a background writer once an
+// append makes it final, and reloaded on start. Only the RBAC part is
+// written. The newest entry is not persisted: after a restart the current
+// snapshot is rebuilt from the cluster.
+type History struct {
+	mu          sync.RWMutex
+	entries     []*Snapshot
+	size        int
+	minInterval time.Duration
+	dir         string
+	// lastGeneration is the newest generation recorded or, after a reload,
+	// persisted; it may be newer than every retained entry.
+	lastGeneration int64
+
+	pendingMu sync.Mutex
+	pending   []historyOp
+	wake      chan struct{}
+}
+
+// historyOp is a change to the history dir. The writer applies ops in order.
+type historyOp struct {
+	write      *Snapshot
+	remove     []int64
+	generation int64
+	done       chan struct{}
+}
+
+// HistoryEntry describes a retained snapshot.
+type HistoryEntry struct {
+	Generation int64
+	BuiltAt    time.Time
+}
+
+// NewHistory returns a history retaining up to size snapshots. A non-empty
+// dir is created if needed and any snapshots persisted there are loaded.
+func NewHistory(size int, minInterval time.Duration, dir string) (*History, error) {
+	if size <= 0 {
+		return nil, fmt.Errorf("history size must be positive, got %d", size)
+	}
+	h := &History{size: size, minInterval: minInterval, dir: dir}
+	if dir == "" {
+		return h, nil
+	}
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("create history dir: %w", err)
+	}
+	if err := h.load(); err != nil {
+		return nil, err
+	}
+	h.wake = make(chan struct{}, 1)
+	go h.persistLoop()
+
+	return h, nil
+}
+
+// Record adds a snapshot. Snapshots must be recorded in generation order.
+// Persisting happens in the background, so Record does not block on disk.
+func (h *History) Record(s *Snapshot) {
+	h.mu.Lock()
+	op := historyOp{generation: s.Generation}
+	if n := len(h.entries); n > 1 && h.entries[n-1].RBACGeneration == h.entries[n-2].RBACGeneration &&
+		h.entries[n-1].BuiltAt.Sub(h.entries[n-2].BuiltAt) < h.minInterval {
+		h.entries[n-1] = s
+	} else {
+		if n > 0 {
+			op.write = h.entries[n-1]
+		}
+		h.entries = append(h.entries, s)
+	}
+	if overflow := len(h.entries) - h.size; overflow > 0 {
+		for _, old := range h.entries[:overflow] {
+			if old == op.write {
+				op.write = nil
+			}
+			op.remove = append(op.remove, old.Generation)
+		}
+		h.entries = append([]*Snapshot(nil), h.entries[overflow:]...)
+	}
+	h.lastGeneration = s.Generation
+	h.mu.Unlock()
+
+	if h.dir != "" {
+		h.enqueue(op)
+	}
+}
+
+func (h *History) enqueue(op historyOp) {
+	h.pendingMu.Lock()
+	h.pending = append(h.pending, op)
+	h.pendingMu.Unlock()
+	select {
+	case h.wake <- struct{}{}:
+	default:
+	}
+}
+
+// flush waits until every op enqueued so far is applied.
+func (h *History) flush() {
+	if h.dir == "" {
+		return
+	}
+	done := make(chan struct{})
+	h.enqueue(historyOp{done: done})
+	<-done
+}
+
+// persistLoop applies enqueued ops for the life of the process. Only the
+// newest generation marker of a batch is written.
+func (h *History) persistLoop() {
+	for range h.wake {
+		h.pendingMu.Lock()
+		ops := h.pending
+		h.pending = nil
+		h.pendingMu.Unlock()
+
+		var generation int64
+		for _, op := range ops {
+			h.apply(op)
+			generation = max(generation, op.generation)
+		}
+		if generation > 0 {
+			if err := writeFileAtomic(filepath.Join(h.dir, historyGenerationFile), func(w io.Writer) error {
+				_, err := fmt.Fprintf(w, "%d\n", generation)
+
+				return err
+			}); err != nil {
+				klog.Warningf("persist snapshot generation marker: %v", err)
+			}
+		}
+		for _, op := range ops {
+			if op.done != nil {
+				close(op.done)
+			}
+		}
+	}
+}
+
+func (h *History) apply(op historyOp) {
+	if op.write != nil {
+		// Entries are final once written, and reloaded entries are on disk.
+		path := h.path(op.write.Generation)
+		if _, err := os.Stat(path); err != nil {
+			if err := writeSnapshotFile(path, op.write); err != nil {
+				klog.Warningf("persist snapshot generation %d: %v", op.write.Generation, err)
+			}
+		}
+	}
+	for _, generation := range op.remove {
+		if err := os.Remove(h.path(generation)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			klog.Warningf("remove snapshot generation %d: %v", generation, err)
+		}
+	}
+}
+
+// At returns the newest snapshot built at or before t.
+func (h *History) At(t time.Time) (*Snapshot, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	idx := sort.Search(len(h.entries), func(i int) bool {
+		return h.entries[i].BuiltAt.After(t)
+	})
+	if idx == 0 {
+		return nil, fmt.Errorf("%w: no snapshot at or before %s%s", ErrSnapshotNotRetained, t.UTC().Format(time.RFC3339), h.oldestSuffix())
+	}
+
+	return h.entries[idx-1], nil
+}
+
+// Generation returns the snapshot with the given generation. A generation
+// that was replaced resolves to the retained entry that replaced it, which
+// has a newer generation.
+func (h *History) Generation(generation int64) (*Snapshot, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	idx := sort.Search(len(h.entries), func(i int) bool {
+		return h.entries[i].Generation >= generation
+	})
+	if idx == len(h.entries) || (idx == 0 && h.entries[0].Generation != generation) {
+		return nil, fmt.Errorf("%w: generation %d%s", ErrSnapshotNotRetained, generation, h.oldestSuffix())
+	}
+
+	return h.entries[idx], nil
+}
+
+// Entries lists the retained snapshots, oldest first.
+func (h *History) Entries() []HistoryEntry {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	out := make([]HistoryEntry, 0, len(h.entries))
+	for _, s := range h.entries {
+		out = append(out, HistoryEntry{Generation: s.Generation, BuiltAt: s.BuiltAt})
+	}
+
+	return out
+}
+
+// LastGeneration returns the newest recorded generation, or 0. After a
+// reload it is the newest generation recorded before the restart, which may
+// not be retained.
+func (h *History) LastGeneration() int64 {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return h.lastGeneration
+}
+
+// oldestSuffix must be called with h.mu held.
+func (h *History) oldestSuffix() string {
+	if len(h.entries) == 0 {
+		return " (history is empty)"
+	}
+	oldest := h.entries[0]
+
+	return fmt.Sprintf(" (oldest retained: generation %d built at %s)", oldest.Generation, oldest.BuiltAt.UTC().Format(time.RFC3339))
+}
+
+func (h *History) path(generation int64) string {
+	return filepath.Join(h.dir, fmt.Sprintf("%s%020d%s", historyFilePrefix, generation, historyFileSuffix))
+}
+
+func (h *History) load() error {
+	matches, err := filepath.Glob(filepath.Join(h.dir, historyFilePrefix+"*"+historyFileSuffix))
+	if err != nil {
+		return fmt.Errorf("list history dir: %w", err)
+	}
+	sort.Strings(matches)
+	if len(matches) > h.size {
+		for _, stale := range matches[:len(matches)-h.size] {
+			if err := os.Remove(stale); err != nil {
+				klog.Warningf("remove stale snapshot %s: %v", stale, err)
+			}
+		}
+		matches = matches[len(matches)-h.size:]
+	}
+	for _, path := range matches {
+		s, err := readSnapshotFile(path)
+		if err != nil {
+			klog.Warningf("skip unreadable snapshot %s: %v", path, err)
+
+			continue
+		}
+		h.entries = append(h.entries, s)
+	}
+	sort.Slice(h.entries, func(i, j int) bool {
+		return h.entries[i].Generation < h.entries[j].Generation
+	})
+	if n := len(h.entries); n > 0 {
+		h.lastGeneration = h.entries[n-1].Generation
+	}
+	data, err := os.ReadFile(filepath.Join(h.dir, historyGenerationFile))
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		klog.Warningf("read snapshot generation marker: %v", err)
+	default:
+		generation, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+		if err != nil {
+			klog.Warningf("parse snapshot generation marker: %v", err)
+		}
+		h.lastGeneration = max(h.lastGeneration, generation)
+	}
+
+	return nil
+}
+
+// persistedSnapshot is the on-disk form of a Snapshot. Only the source
+// records of the RBAC part are stored; the lookup indexes are rebuilt on
+// load.
+type persistedSnapshot struct {
+	Version               int
+	Generation            int64
+	RBACGeneration        int64
+	BuiltAt               time.Time
+	Roles                 []*RoleRecord
+	Bindings              []*BindingRecord
+	AggregatedRoleSources map[RoleID][]RoleID
+	AggregationMatches    map[RoleID][]AggregationMatch
+	ServiceAccounts       []*ServiceAccountRecord
+	Namespaces            []*NamespaceRecord
+	KnownGaps             []string
+	Warnings              []string
+}
+
+func writeSnapshotFile(path string, s *Snapshot) error {
+	return writeFileAtomic(path, func(w io.Writer) error {
+		zw := gzip.NewWriter(w)
+		if err := json.NewEncoder(zw).Encode(toPersisted(s)); err != nil {
+			return err
+		}
+
+		return zw.Close()
+	})
+}
+
+// writeFileAtomic writes path through a temporary file in the same dir, so
+// readers never see a partial file.
+func writeFileAtomic(path string, write func(io.Writer) error) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-"+filepath.Base(path))
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) //nolint:errcheck // best-effort cleanup after rename
+
+	if err := write(tmp); err != nil {
+		tmp.Close() //nolint:errcheck,gosec // already failing
+
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+func readSnapshotFile(path string) (*Snapshot, error) {
+	f, err := os.Open(path) //nolint:gosec // path comes from the configured history dir
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close() //nolint:errcheck // read-only
+
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, err
+	}
+	var p persistedSnapshot
+	if err := json.NewDecoder(zr).Decode(&p); err != nil {
+		return nil, err
+	}
+	if p.Version < 1 || p.Version > historyFileVersion {
+		return nil, fmt.Errorf("unsupported snapshot file version %d", p.Version)
+	}
+
+	return fromPersisted(&p), nil
+}
+
+func toPersisted(s *Snapshot) *persistedSnapshot {
+	p := &persistedSnapshot{
+		Version:               historyFileVersion,
+		Generation:            s.Generation,
+		RBACGeneration:        s.RBACGeneration,
+		BuiltAt:               s.BuiltAt,
+		AggregatedRoleSources: s.AggregatedRoleSources,
+		AggregationMatches:    s.AggregationMatches,
+		KnownGaps:             s.KnownGaps,
+		Warnings:              s.Warnings,
+	}
+	for _, id := range s.AllRoleIDs {
+		p.Roles = append(p.Roles, s.RolesByID[id])
+	}
+	for _, bindings := range s.BindingsByRoleRef {
+		p.Bindings = append(p.Bindings, bindings...)
+	}
+	for _, sa := range s.ServiceAccounts {
+		p.ServiceAccounts = append(p.ServiceAccounts, sa)
+	}
+	for _, ns := range s.Namespaces {
+		p.Namespaces = append(p.Namespaces, ns)
+	}
+
+	return p
+}
+
+func fromPersisted(p *persistedSnapshot) *Snapshot {
+	s := newEmptySnapshot()
+	s.Generation = p.Generation
+	s.RBACGeneration = p.RBACGeneration
+	s.BuiltAt = p.BuiltAt
+	s.RuntimeUnavailable = RuntimeIndexNotPersisted
+	s.KnownGaps = p.KnownGaps
+	s.Warnings = p.Warnings
+	for _, role := range p.Roles {
+		id := RecID(role.Kind, role.Namespace, role.Name)
+		s.RolesByID[id] = role
+		s.AllRoleIDs = append(s.AllRoleIDs, id)
+		indexRoleTokens(s, id, role.Rules)
+	}
+	for _, binding := range p.Bindings {
+		s.BindingsByRoleRef[binding.RoleRef] = append(s.BindingsByRoleRef[binding.RoleRef], binding)
+		indexBindingSubjects(s.BindingsBySubject, binding)
+	}
+	for target, sources := range p.AggregatedRoleSources {
+		s.AggregatedRoleSources[target] = sources
+	}
+	for target, matches := range p.AggregationMatches {
+		s.AggregationMatches[target] = matches
+	}
+	for _, sa := range p.ServiceAccounts {
+		s.ServiceAccounts[serviceAccountKey(sa.Namespace, sa.Name)] = sa
+	}
+	for _, ns := range p.Namespaces {
+		s.Namespaces[ns.Name] = ns
+	}
+	sortSnapshot(s)
+
+	return s
+}
@@ -0,0 +1,230 @@
+package indexer
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+func historySnapshot(generation int64, builtAt time.Time) *Snapshot {
+	s := newEmptySnapshot()
+	s.Generation = generation
+	s.BuiltAt = builtAt
+
+	return s
+}
+
+func TestHistory_RecordAtAndGeneration(t *testing.T) {
+	h, err := NewHistory(3, time.Minute, "")
+	if err != nil {
+		t.Fatalf("NewHistory: %v", err)
+	}
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	for gen := int64(1); gen <= 4; gen++ {
+		h.Record(historySnapshot(gen, base.Add(time.Duration(gen)*time.Hour)))
+	}
+
+	entries := h.Entries()
+	if len(entries) != 3 || entries[0].Generation != 2 || entries[2].Generation != 4 {
+		t.Fatalf("expected generations 2..4 retained, got %#v", entries)
+	}
+	if h.LastGeneration() != 4 {
+		t.Fatalf("expected last generation 4, got %d", h.LastGeneration())
+	}
+
+	got, err := h.At(base.Add(3*time.Hour + 30*time.Minute))
+	if err != nil || got.Generation != 3 {
+		t.Fatalf("expected generation 3 at 03:30, got %v, err=%v", got, err)
+	}
+	got, err = h.At(base.Add(10 * time.Hour))
+	if err != nil || got.Generation != 4 {
+		t.Fatalf("expected newest generation for future time, got %v, err=%v", got, err)
+	}
+	if _, err := h.At(base.Add(time.Hour)); !errors.Is(err, ErrSnapshotNotRetained) {
+		t.Fatalf("expected ErrSnapshotNotRetained for evicted time, got %v", err)
+	}
+
+	got, err = h.Generation(2)
+	if err != nil || got.Generation != 2 {
+		t.Fatalf("expected generation 2, got %v, err=%v", got, err)
+	}
+	if _, err := h.Generation(1); !errors.Is(err, ErrSnapshotNotRetained) {
+		t.Fatalf("expected ErrSnapshotNotRetained for evicted generation, got %v", err)
+	}
+}
+
+func TestHistory_ReplacesNewestWithinInterval(t *testing.T) {
+	h, err := NewHistory(5, time.Minute, "")
+	if err != nil {
+		t.Fatalf("NewHistory: %v", err)
+	}
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	h.Record(historySnapshot(1, base))
+	h.Record(historySnapshot(2, base.Add(10*time.Second)))
+	h.Record(historySnapshot(3, base.Add(20*time.Second)))
+	h.Record(historySnapshot(4, base.Add(70*time.Second)))
+	h.Record(historySnapshot(5, base.Add(80*time.Second)))
+
+	entries := h.Entries()
+	var gens []int64
+	for _, entry := range entries {
+		gens = append(gens, entry.Generation)
+	}
+	if len(gens) != 3 || gens[0] != 1 || gens[1] != 4 || gens[2] != 5 {
+		t.Fatalf("expected generations [1 4 5], got %v", gens)
+	}
+	for _, replaced := range []int64{2, 3} {
+		got, err := h.Generation(replaced)
+		if err != nil || got.Generation != 4 {
+			t.Fatalf("expected replaced generation %d to resolve to 4, got %v, err=%v", replaced, got, err)
+		}
+	}
+	if _, err := h.Generation(6); !errors.Is(err, ErrSnapshotNotRetained) {
+		t.Fatalf("expected ErrSnapshotNotRetained for a future generation, got %v", err)
+	}
+}
+
+func TestHistory_KeepsRBACChangesWithinInterval(t *testing.T) {
+	h, err := NewHistory(5, time.Minute, "")
+	if err != nil {
+		t.Fatalf("NewHistory: %v", err)
+	}
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	record := func(generation, rbacGeneration int64, offset time.Duration) {
+		s := historySnapshot(generation, base.Add(offset))
+		s.RBACGeneration = rbacGeneration
+		h.Record(s)
+	}
+	record(1, 1, 0)
+	record(2, 2, 10*time.Second) // grant
+	record(3, 3, 65*time.Second) // revoke
+	record(4, 3, 70*time.Second) // runtime only
+	record(5, 3, 75*time.Second) // runtime only, replaces generation 4
+
+	got, err := h.At(base.Add(30 * time.Second))
+	if err != nil || got.Generation != 2 {
+		t.Fatalf("expected the grant at generation 2 between grant and revoke, got %v, err=%v", got, err)
+	}
+	var gens []int64
+	for _, entry := range h.Entries() {
+		gens = append(gens, entry.Generation)
+	}
+	if len(gens) != 4 || gens[0] != 1 || gens[1] != 2 || gens[2] != 3 || gens[3] != 5 {
+		t.Fatalf("expected generations [1 2 3 5], got %v", gens)
+	}
+}
+
+func TestHistory_PersistsOnlyFinalEntries(t *testing.T) {
+	dir := t.TempDir()
+	h, err := NewHistory(5, time.Minute, dir)
+	if err != nil {
+		t.Fatalf("NewHistory: %v", err)
+	}
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	h.Record(historySnapshot(1, base))
+	h.Record(historySnapshot(2, base.Add(10*time.Second)))
+	h.Record(historySnapshot(3, base.Add(20*time.Second)))
+	h.flush()
+
+	files, err := filepath.Glob(filepath.Join(dir, historyFilePrefix+"*"))
+	if err != nil {
+		t.Fatalf("glob: %v", err)
+	}
+	if len(files) != 1 || filepath.Base(files[0]) != filepath.Base(h.path(1)) {
+		t.Fatalf("expected only generation 1 to be persisted, got %v", files)
+	}
+}
+
+func TestHistory_PersistAndReload(t *testing.T) {
+	dir := t.TempDir()
+	h, err := NewHistory(2, 0, dir)
+	if err != nil {
+		t.Fatalf("NewHistory: %v", err)
+	}
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	for gen := int64(1); gen <= 3; gen++ {
+		s := historySnapshot(gen, base.Add(time.Duration(gen)*time.Hour))
+		roleID := RecID(KindClusterRole, "", "reader")
+		role := &RoleRecord{
+			Kind: KindClusterRole,
+			Name: "reader",
+			Rules: []rbacv1.PolicyRule{{
+				APIGroups: []string{""},
+				Resources: []string{"pods"},
+				Verbs:     []string{"get"},
+			}},
+		}
+		s.RolesByID[roleID] = role
+		s.AllRoleIDs = append(s.AllRoleIDs, roleID)
+		indexRoleTokens(s, roleID, role.Rules)
+		ref := RoleRefKey{Kind: KindClusterRole, Name: "reader"}
+		s.BindingsByRoleRef[ref] = []*BindingRecord{{
+			Kind:     KindClusterRoleBinding,
+			Name:     "reader",
+			RoleRef:  ref,
+			Subjects: []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "alice"}},
+		}}
+		appKey := ServiceAccountKey{Namespace: "team", Name: "app"}
+		s.PodsByServiceAccount[appKey] = []*PodRecord{{UID: "pod-1", Namespace: "team", Name: "app-1", ServiceAccountName: "app"}}
+		h.Record(s)
+	}
+	h.flush()
+
+	files, err := filepath.Glob(filepath.Join(dir, historyFilePrefix+"*"))
+	if err != nil {
+		t.Fatalf("glob: %v", err)
+	}
+	if len(files) != 1 {
+		t.Fatalf("expected the final, retained generation 2 to be persisted, got %v", files)
+	}
+
+	reloaded, err := NewHistory(2, 0, dir)
+	if err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	if reloaded.LastGeneration() != 3 {
+		t.Fatalf("expected last generation 3 after reload, got %d", reloaded.LastGeneration())
+	}
+	s, err := reloaded.Generation(2)
+	if err != nil {
+		t.Fatalf("Generation(2): %v", err)
+	}
+	if !s.BuiltAt.Equal(base.Add(2 * time.Hour)) {
+		t.Fatalf("unexpected BuiltAt %s", s.BuiltAt)
+	}
+	if len(s.RoleIDsByVerb["get"]) != 1 {
+		t.Fatalf("expected verb index to be rebuilt, got %#v", s.RoleIDsByVerb)
+	}
+	subject := SubjectKey{Kind: rbacv1.UserKind, Name: "alice"}
+	if len(s.BindingsBySubject[subject]) != 1 {
+		t.Fatalf("expected subject index to be rebuilt, got %#v", s.BindingsBySubject)
+	}
+	if len(s.PodsByServiceAccount) != 0 || s.RuntimeUnavailable != RuntimeIndexNotPersisted {
+		t.Fatalf("expected runtime state not to be persisted, got pods=%#v runtimeUnavailable=%q", s.PodsByServiceAccount, s.RuntimeUnavailable)
+	}
+}
+
+func TestHistory_SkipsUnreadableFiles(t *testing.T) {
+	dir := t.TempDir()
+	bad := filepath.Join(dir, historyFilePrefix+"00000000000000000001"+historyFileSuffix)
+	if err := os.WriteFile(bad, []byte("not gzip"), 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	h, err := NewHistory(2, 0, dir)
+	if err != nil {
+		t.Fatalf("NewHistory: %v", err)
+	}
+	if len(h.Entries()) != 0 {
+		t.Fatalf("expected unreadable snapshot to be skipped, got %#v", h.Entries())
+	}
+}
+
+func TestNewHistory_RejectsNonPositiveSize(t *testing.T) {
+	if _, err := NewHistory(0, 0, ""); err == nil {
+		t.Fatal("expected error for zero size")
+	}
+}
@@ -3,153 +3,361 @@ package indexer
 import (
 	"context"
 	"errors"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"time"
 
-	appslisters "k8s.io/client-go/listers/apps/v1"
-	batchlisters "k8s.io/client-go/listers/batch/v1"
-	corelisters "k8s.io/client-go/listers/core/v1"
-	rbaclisters "k8s.io/client-go/listers/rbac/v1"
-
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/metadata"
 	"k8s.io/client-go/tools/cache"
 )
 
-const rebuildDebounceInterval = 500 * time.Millisecond
+const (
+	rebuildDebounceInterval = 500 * time.Millisecond
+	// DefaultMaxStaleness bounds how long informer events may wait for the
+	// debounce interval to pass without new events.
+	DefaultMaxStaleness = 5 * time.Second
+)
+
+// Parts of a snapshot. Each part has its own informers, event queue and
+// readiness, so churn in one part never delays rebuilds of the other.
+const (
+	partRBAC    = "rbac"
+	partRuntime = "runtime"
+	// partAll labels rebuild metrics of a build that covers every part.
+	partAll = "all"
+)
+
+// Reasons reported in Snapshot.RuntimeUnavailable.
+const (
+	RuntimeIndexDisabled  = "runtime index is disabled; pods and workloads are not indexed"
+	RuntimeIndexNotSynced = "runtime index has not synced yet; pods and workloads may be missing"
+)
 
 type Indexer struct {
 	factory informers.SharedInformerFactory
 
-	rolesInformer               cache.SharedIndexInformer
-	clusterRolesInformer        cache.SharedIndexInformer
-	roleBindingsInformer        cache.SharedIndexInformer
-	clusterRoleBindingsInformer cache.SharedIndexInformer
-	podsInformer                cache.SharedIndexInformer
-	deploymentsInformer         cache.SharedIndexInformer
-	replicaSetsInformer         cache.SharedIndexInformer
-	statefulSetsInformer        cache.SharedIndexInformer
-	daemonSetsInformer          cache.SharedIndexInformer
-	jobsInformer                cache.SharedIndexInformer
-	cronJobsInformer            cache.SharedIndexInformer
-
-	rolesLister               rbaclisters.RoleLister
-	clusterRolesLister        rbaclisters.ClusterRoleLister
-	roleBindingsLister        rbaclisters.RoleBindingLister
-	clusterRoleBindingsLister rbaclisters.ClusterRoleBindingLister
-	podsLister                corelisters.PodLister
-	deploymentsLister         appslisters.DeploymentLister
-	replicaSetsLister         appslisters.ReplicaSetLister
-	statefulSetsLister        appslisters.StatefulSetLister
-	daemonSetsLister          appslisters.DaemonSetLister
-	jobsLister                batchlisters.JobLister
-	cronJobsLister            batchlisters.CronJobLister
-	snapshot                  atomic.Pointer[Snapshot]
-	synced                    atomic.Bool
-	rebuildMu                 sync.Mutex
-	rebuildTimer              *time.Timer
-	timerMu                   sync.Mutex
+	rbac    *part
+	runtime *part // nil when the runtime index is disabled
+	owners  *ownerInformers
+
+	source     Source
+	snapshot   atomic.Pointer[Snapshot]
+	generation atomic.Int64
+	history    *History
+	handlers   []func(*Snapshot)
+	// publishMu serializes publishing, so parts rebuilt concurrently are
+	// combined with the latest version of each other.
+	publishMu    sync.Mutex
+	maxStaleness time.Duration
 
 	discoveryClient discovery.DiscoveryInterface
 	discoveryCache  atomic.Pointer[APIDiscoveryCache]
 }
 
-func New(client kubernetes.Interface, resyncPeriod time.Duration) *Indexer {
+// part is the rebuild state of one snapshot part.
+type part struct {
+	name      string
+	informers []cache.SharedIndexInformer
+	// build indexes the part from the source into next.
+	build func(next *Snapshot, src Source)
+
+	// watching is set once the informers have synced; events before that
+	// are covered by the first build. ready is set once the part is built.
+	watching atomic.Bool
+	ready    atomic.Bool
+	// warnings are the list failures of the last build, guarded by publishMu.
+	warnings []string
+
+	rebuildMu sync.Mutex
+
+	// Informer events not yet in the published snapshot, guarded by timerMu.
+	// pendingFull requests a rebuild from the source instead of deltas.
+	timerMu      sync.Mutex
+	timer        *time.Timer
+	pending      []delta
+	pendingFull  bool
+	pendingSince time.Time
+}
+
+func (p *part) hasSynced() []cache.InformerSynced {
+	out := make([]cache.InformerSynced, 0, len(p.informers))
+	for _, informer := range p.informers {
+		out = append(out, informer.HasSynced)
+	}
+
+	return out
+}
+
+// Option configures an informer-backed Indexer.
+type Option func(*options)
+
+type options struct {
+	disableRuntime bool
+	ownerClient    metadata.Interface
+	ownerKinds     []string
+}
+
+// WithOwnerKinds follows owner references to kinds beyond the built-in
+// workloads. Kinds matching patterns (see OwnerKindPattern) are watched with
+// metadata informers once a pod or workload references them. It has no
+// effect when the runtime index is disabled.
+func WithOwnerKinds(client metadata.Interface, patterns ...string) Option {
+	return func(o *options) {
+		o.ownerClient = client
+		o.ownerKinds = patterns
+	}
+}
+
+// WithoutRuntimeIndex skips the pod and workload informers. Snapshots have
+// an empty RuntimeIndex and report RuntimeIndexDisabled.
+func WithoutRuntimeIndex() Option {
+	return func(o *options) { o.disableRuntime = true }
+}
+
+func New(client kubernetes.Interface, resyncPeriod time.Duration, opts ...Option) *Indexer {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
 	factory := informers.NewSharedInformerFactory(client, resyncPeriod)
 	roles := factory.Rbac().V1().Roles()
 	clusterRoles := factory.Rbac().V1().ClusterRoles()
 	roleBindings := factory.Rbac().V1().RoleBindings()
 	clusterRoleBindings := factory.Rbac().V1().ClusterRoleBindings()
-	pods := factory.Core().V1().Pods()
-	deployments := factory.Apps().V1().Deployments()
-	replicaSets := factory.Apps().V1().ReplicaSets()
-	statefulSets := factory.Apps().V1().StatefulSets()
-	daemonSets := factory.Apps().V1().DaemonSets()
-	jobs := factory.Batch().V1().Jobs()
-	cronJobs := factory.Batch().V1().CronJobs()
+	serviceAccounts := factory.Core().V1().ServiceAccounts()
+	namespaces := factory.Core().V1().Namespaces()
+	src := &listerSource{
+		roles:               roles.Lister(),
+		clusterRoles:        clusterRoles.Lister(),
+		roleBindings:        roleBindings.Lister(),
+		clusterRoleBindings: clusterRoleBindings.Lister(),
+		serviceAccounts:     serviceAccounts.Lister(),
+		namespaces:          namespaces.Lister(),
+	}
 
 	i := &Indexer{
-		factory:                     factory,
-		discoveryClient:             client.Discovery(),
-		rolesInformer:               roles.Informer(),
-		clusterRolesInformer:        clusterRoles.Informer(),
-		roleBindingsInformer:        roleBindings.Informer(),
-		clusterRoleBindingsInformer: clusterRoleBindings.Informer(),
-		podsInformer:                pods.Informer(),
-		deploymentsInformer:         deployments.Informer(),
-		replicaSetsInformer:         replicaSets.Informer(),
-		statefulSetsInformer:        statefulSets.Informer(),
-		daemonSetsInformer:          daemonSets.Informer(),
-		jobsInformer:                jobs.Informer(),
-		cronJobsInformer:            cronJobs.Informer(),
-		rolesLister:                 roles.Lister(),
-		clusterRolesLister:          clusterRoles.Lister(),
-		roleBindingsLister:          roleBindings.Lister(),
-		clusterRoleBindingsLister:   clusterRoleBindings.Lister(),
-		podsLister:                  pods.Lister(),
-		deploymentsLister:           deployments.Lister(),
-		replicaSetsLister:           replicaSets.Lister(),
-		statefulSetsLister:          statefulSets.Lister(),
-		daemonSetsLister:            daemonSets.Lister(),
-		jobsLister:                  jobs.Lister(),
-		cronJobsLister:              cronJobs.Lister(),
-	}
-
-	handler := cache.ResourceEventHandlerFuncs{
-		AddFunc:    func(any) { i.scheduleRebuild() },
-		UpdateFunc: func(any, any) { i.scheduleRebuild() },
-		DeleteFunc: func(any) { i.scheduleRebuild() },
+		factory:         factory,
+		discoveryClient: client.Discovery(),
+		source:          src,
+		maxStaleness:    DefaultMaxStaleness,
+	}
+	i.rbac = &part{
+		name:  partRBAC,
+		build: buildRBACIndex,
+		informers: []cache.SharedIndexInformer{
+			roles.Informer(),
+			clusterRoles.Informer(),
+			roleBindings.Informer(),
+			clusterRoleBindings.Informer(),
+			serviceAccounts.Informer(),
+			namespaces.Informer(),
+		},
 	}
+	//nolint:errcheck,gosec // SetTransform only errors once the informer has started
+	serviceAccounts.Informer().SetTransform(transformServiceAccount)
+	// A ClusterRole change can alter aggregation of other ClusterRoles, so
+	// it always rebuilds from the listers.
 	//nolint:errcheck,gosec // AddEventHandler only errors when the informer is stopped
-	i.rolesInformer.AddEventHandler(handler)
-	i.clusterRolesInformer.AddEventHandler(handler)        //nolint:errcheck,gosec // informer is running
-	i.roleBindingsInformer.AddEventHandler(handler)        //nolint:errcheck,gosec // informer is running
-	i.clusterRoleBindingsInformer.AddEventHandler(handler) //nolint:errcheck,gosec // informer is running
-	i.podsInformer.AddEventHandler(handler)                //nolint:errcheck,gosec // informer is running
-	i.deploymentsInformer.AddEventHandler(handler)         //nolint:errcheck,gosec // informer is running
-	i.replicaSetsInformer.AddEventHandler(handler)         //nolint:errcheck,gosec // informer is running
-	i.statefulSetsInformer.AddEventHandler(handler)        //nolint:errcheck,gosec // informer is running
-	i.daemonSetsInformer.AddEventHandler(handler)          //nolint:errcheck,gosec // informer is running
-	i.jobsInformer.AddEventHandler(handler)                //nolint:errcheck,gosec // informer is running
-	i.cronJobsInformer.AddEventHandler(handler)            //nolint:errcheck,gosec // informer is running
-
-	i.snapshot.Store(newEmptySnapshot())
+	clusterRoles.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(any) { i.scheduleRebuild(i.rbac) },
+		UpdateFunc: func(any, any) { i.scheduleRebuild(i.rbac) },
+		DeleteFunc: func(any) { i.scheduleRebuild(i.rbac) },
+	})
+	for _, informer := range []cache.SharedIndexInformer{
+		roles.Informer(), roleBindings.Informer(), clusterRoleBindings.Informer(), serviceAccounts.Informer(), namespaces.Informer(),
+	} {
+		informer.AddEventHandler(i.deltaHandler(i.rbac)) //nolint:errcheck,gosec // informer is running
+	}
+
+	if !o.disableRuntime {
+		pods := factory.Core().V1().Pods()
+		deployments := factory.Apps().V1().Deployments()
+		replicaSets := factory.Apps().V1().ReplicaSets()
+		statefulSets := factory.Apps().V1().StatefulSets()
+		daemonSets := factory.Apps().V1().DaemonSets()
+		jobs := factory.Batch().V1().Jobs()
+		cronJobs := factory.Batch().V1().CronJobs()
+		src.pods = pods.Lister()
+		src.deployments = deployments.Lister()
+		src.replicaSets = replicaSets.Lister()
+		src.statefulSets = statefulSets.Lister()
+		src.daemonSets = daemonSets.Lister()
+		src.jobs = jobs.Lister()
+		src.cronJobs = cronJobs.Lister()
+		i.runtime = &part{
+			name:  partRuntime,
+			build: buildRuntimeIndex,
+			informers: []cache.SharedIndexInformer{
+				pods.Informer(),
+				deployments.Informer(),
+				replicaSets.Informer(),
+				statefulSets.Informer(),
+				daemonSets.Informer(),
+				jobs.Informer(),
+				cronJobs.Informer(),
+			},
+		}
+		// Strip runtime objects before they are cached; see transform.go.
+		//nolint:errcheck,gosec // SetTransform only errors once the informer has started
+		pods.Informer().SetTransform(transformPod)
+		for _, informer := range i.runtime.informers[1:] { // workloads
+			informer.SetTransform(transformWorkload) //nolint:errcheck,gosec // informer is not started
+		}
+		for _, informer := range i.runtime.informers {
+			informer.AddEventHandler(i.deltaHandler(i.runtime)) //nolint:errcheck,gosec // informer is running
+		}
+
+		if o.ownerClient != nil && len(o.ownerKinds) > 0 {
+			i.owners = newOwnerInformers(o.ownerClient, client.Discovery(), o.ownerKinds, resyncPeriod)
+			i.owners.enqueue = func(deltas ...delta) { i.enqueue(i.runtime, deltas...) }
+			i.owners.synced = func() { i.scheduleRebuild(i.runtime) }
+			i.runtime.build = func(next *Snapshot, src Source) {
+				buildRuntimeIndex(next, src)
+				i.owners.index(next)
+			}
+			for _, informer := range i.runtime.informers {
+				//nolint:errcheck,gosec // AddEventHandler only errors when the informer is stopped
+				informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+					AddFunc:    i.owners.observeObject,
+					UpdateFunc: func(_, obj any) { i.owners.observeObject(obj) },
+				})
+			}
+		}
+	}
+
+	empty := newEmptySnapshot()
+	empty.RuntimeUnavailable = i.runtimeUnavailable()
+	i.snapshot.Store(empty)
+
+	return i
+}
+
+// deltaHandler queues informer events for incremental application to p.
+func (i *Indexer) deltaHandler(p *part) cache.ResourceEventHandlerFuncs {
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj any) { i.enqueue(p, delta{obj: obj}) },
+		UpdateFunc: func(oldObj, newObj any) { i.enqueue(p, delta{remove: true, obj: oldObj}, delta{obj: newObj}) },
+		DeleteFunc: func(obj any) { i.enqueue(p, delta{remove: true, obj: obj}) },
+	}
+}
+
+// NewOffline returns an indexer over a fixed source, such as parsed
+// manifests, with no informers or discovery client. The snapshot is built
+// immediately and the indexer is ready without Start. discoveryCache may be
+// nil, which disables phantom API checks.
+func NewOffline(src Source, discoveryCache *APIDiscoveryCache) *Indexer {
+	i := &Indexer{
+		source:  src,
+		rbac:    &part{name: partRBAC, build: buildRBACIndex},
+		runtime: &part{name: partRuntime, build: buildRuntimeIndex},
+	}
+	if discoveryCache != nil {
+		i.discoveryCache.Store(discoveryCache)
+		recordDiscoveryFetched(discoveryCache)
+	}
+	i.rbac.watching.Store(true)
+	i.runtime.watching.Store(true)
+	i.rebuild()
 
 	return i
 }
 
 func (i *Indexer) Start(ctx context.Context) error {
-	i.factory.Start(ctx.Done())
+	if i.factory == nil {
+		<-ctx.Done()
 
-	if !cache.WaitForCacheSync(
-		ctx.Done(),
-		i.rolesInformer.HasSynced,
-		i.clusterRolesInformer.HasSynced,
-		i.roleBindingsInformer.HasSynced,
-		i.clusterRoleBindingsInformer.HasSynced,
-		i.podsInformer.HasSynced,
-		i.deploymentsInformer.HasSynced,
-		i.replicaSetsInformer.HasSynced,
-		i.statefulSetsInformer.HasSynced,
-		i.daemonSetsInformer.HasSynced,
-		i.jobsInformer.HasSynced,
-		i.cronJobsInformer.HasSynced,
-	) {
-		return errors.New("failed to sync informer caches")
+		return nil
 	}
+	i.factory.Start(ctx.Done())
 
-	i.rebuild()
-	i.synced.Store(true)
+	if !cache.WaitForCacheSync(ctx.Done(), i.rbac.hasSynced()...) {
+		return errors.New("failed to sync RBAC informer caches")
+	}
+	i.rbac.watching.Store(true)
+	i.rebuild(i.rbac)
 	go i.refreshDiscoveryLoop(ctx.Done(), 5*time.Minute)
+
+	if i.runtime != nil {
+		if i.owners != nil {
+			i.owners.start(ctx.Done())
+		}
+		if !cache.WaitForCacheSync(ctx.Done(), i.runtime.hasSynced()...) {
+			return errors.New("failed to sync runtime informer caches")
+		}
+		i.runtime.watching.Store(true)
+		i.rebuild(i.runtime)
+	}
 	<-ctx.Done()
 
 	return nil
 }
 
+// SetHistory enables snapshot history. It must be called before Start.
+// Generations continue after the newest snapshot loaded from a persisted
+// history.
+func (i *Indexer) SetHistory(h *History) {
+	i.history = h
+	if h != nil && h.LastGeneration() > i.generation.Load() {
+		i.generation.Store(h.LastGeneration())
+	}
+}
+
+// SetMaxStaleness bounds the delay between an informer event and the
+// snapshot that includes it. Without a bound, a steady stream of events
+// keeps resetting the debounce timer. Zero disables the bound. It must be
+// called before Start.
+func (i *Indexer) SetMaxStaleness(d time.Duration) {
+	i.maxStaleness = d
+}
+
+// AddRebuildHandler registers fn to be called with every snapshot the
+// indexer publishes. It must be called before Start. Handlers run in order
+// while the snapshot is published, so a slow handler delays the next
+// publish; handlers with real work should hand the snapshot off to their
+// own goroutine.
+func (i *Indexer) AddRebuildHandler(fn func(*Snapshot)) {
+	i.handlers = append(i.handlers, fn)
+}
+
+// SnapshotAt returns the snapshot that was current at t. Times after the
+// newest rebuild resolve to the current snapshot.
+func (i *Indexer) SnapshotAt(t time.Time) (*Snapshot, error) {
+	if i.history == nil {
+		return nil, errors.New("snapshot history is disabled")
+	}
+
+	return i.history.At(t)
+}
+
+// SnapshotGeneration returns the snapshot with the given generation. The
+// current generation is always available, even with history disabled. A
+// generation replaced in the history resolves to the snapshot that replaced
+// it; see History.
+func (i *Indexer) SnapshotGeneration(generation int64) (*Snapshot, error) {
+	if current := i.Snapshot(); current.Generation == generation {
+		return current, nil
+	}
+	if i.history == nil {
+		return nil, errors.New("snapshot history is disabled")
+	}
+
+	return i.history.Generation(generation)
+}
+
+// IsReady reports whether the RBAC part of the snapshot is built. Queries
+// can be served before the runtime part is ready; see RuntimeReady.
 func (i *Indexer) IsReady() bool {
-	return i.synced.Load()
+	return i.rbac.ready.Load()
+}
+
+// RuntimeReady reports whether the runtime part of the snapshot is built.
+// It is always false when the runtime index is disabled.
+func (i *Indexer) RuntimeReady() bool {
+	return i.runtime != nil && i.runtime.ready.Load()
 }
 
 func (i *Indexer) DiscoveryCache() *APIDiscoveryCache {
@@ -165,60 +373,162 @@ func (i *Indexer) Snapshot() *Snapshot {
 	return s
 }
 
-func (i *Indexer) rebuild() {
-	i.rebuildMu.Lock()
-	defer i.rebuildMu.Unlock()
+// rebuild builds parts from the source and publishes them together; with
+// no arguments every enabled part is rebuilt. Pending deltas are dropped:
+// the listers already reflect them, and events that arrive while listing
+// are queued again and reapplied idempotently.
+func (i *Indexer) rebuild(parts ...*part) {
+	if len(parts) == 0 {
+		parts = i.parts()
+	}
+	for _, p := range parts {
+		p.rebuildMu.Lock()
+		defer p.rebuildMu.Unlock()
+		p.timerMu.Lock()
+		p.pending, p.pendingFull, p.pendingSince = nil, false, time.Time{}
+		p.timerMu.Unlock()
+	}
 
-	next := newEmptySnapshot()
-	next.BuiltAt = time.Now().UTC()
+	start := time.Now()
+	built := newEmptySnapshot()
+	for _, p := range parts {
+		p.build(built, i.source)
+	}
+	i.publish(built, rebuildModeFull, time.Since(start), parts...)
+}
 
-	roles := listWithWarning(i.rolesLister.List, "roles", &next.Warnings)
-	clusterRoles := listWithWarning(i.clusterRolesLister.List, "clusterroles", &next.Warnings)
-	roleBindings := listWithWarning(i.roleBindingsLister.List, "rolebindings", &next.Warnings)
-	clusterRoleBindings := listWithWarning(i.clusterRoleBindingsLister.List, "clusterrolebindings", &next.Warnings)
-	pods := listWithWarning(i.podsLister.List, "pods", &next.Warnings)
-	deployments := listWithWarning(i.deploymentsLister.List, "deployments", &next.Warnings)
-	replicaSets := listWithWarning(i.replicaSetsLister.List, "replicasets", &next.Warnings)
-	statefulSets := listWithWarning(i.statefulSetsLister.List, "statefulsets", &next.Warnings)
-	daemonSets := listWithWarning(i.daemonSetsLister.List, "daemonsets", &next.Warnings)
-	jobs := listWithWarning(i.jobsLister.List, "jobs", &next.Warnings)
-	cronJobs := listWithWarning(i.cronJobsLister.List, "cronjobs", &next.Warnings)
+// applyPending publishes p with deltas applied, or rebuilds p from the
+// source when a delta cannot be applied incrementally.
+func (i *Indexer) applyPending(p *part, deltas []delta) {
+	p.rebuildMu.Lock()
+	start := time.Now()
+	// Only p's part of the result is published, and only this goroutine
+	// writes it, so a concurrent publish of the other part is harmless.
+	built, ok := applyDeltas(i.Snapshot(), deltas)
+	if !ok {
+		p.rebuildMu.Unlock()
+		i.rebuild(p)
 
-	indexRoles(next, roles)
-	indexClusterRoles(next, clusterRoles)
-	indexAggregatedClusterRoles(next, clusterRoles)
-	indexRoleBindings(next, roleBindings)
-	indexClusterRoleBindings(next, clusterRoleBindings)
+		return
+	}
+	defer p.rebuildMu.Unlock()
+	i.publish(built, rebuildModeDelta, time.Since(start), p)
+}
+
+// publish combines the parts of built with the other parts of the current
+// snapshot and makes the result current.
+func (i *Indexer) publish(built *Snapshot, mode string, duration time.Duration, parts ...*part) {
+	i.publishMu.Lock()
+	defer i.publishMu.Unlock()
 
-	indexPods(next, pods)
-	for _, deployment := range deployments {
-		indexWorkload(next, "apps/v1", "Deployment", deployment.ObjectMeta)
+	next := *i.Snapshot()
+	next.BuiltAt = built.BuiltAt
+	for _, p := range parts {
+		switch p {
+		case i.rbac:
+			next.RBACIndex = built.RBACIndex
+		case i.runtime:
+			next.RuntimeIndex = built.RuntimeIndex
+		}
+		if mode == rebuildModeFull {
+			p.warnings = built.Warnings
+		}
+		p.ready.Store(true)
 	}
-	for _, replicaSet := range replicaSets {
-		indexWorkload(next, "apps/v1", "ReplicaSet", replicaSet.ObjectMeta)
+	next.RuntimeUnavailable = i.runtimeUnavailable()
+	next.Warnings = nil
+	for _, p := range i.parts() {
+		next.Warnings = append(next.Warnings, p.warnings...)
 	}
-	for _, statefulSet := range statefulSets {
-		indexWorkload(next, "apps/v1", "StatefulSet", statefulSet.ObjectMeta)
+	next.Generation = i.generation.Add(1)
+	if slices.Contains(parts, i.rbac) {
+		next.RBACGeneration = next.Generation
 	}
-	for _, daemonSet := range daemonSets {
-		indexWorkload(next, "apps/v1", "DaemonSet", daemonSet.ObjectMeta)
+	i.snapshot.Store(&next)
+
+	label := partAll
+	if len(parts) == 1 {
+		label = parts[0].name
+	}
+	recordRebuildMetrics(&next, label, mode, duration)
+	if i.history != nil {
+		i.history.Record(&next)
 	}
-	for _, job := range jobs {
-		indexWorkload(next, "batch/v1", "Job", job.ObjectMeta)
+	for _, fn := range i.handlers {
+		fn(&next)
 	}
-	for _, cronJob := range cronJobs {
-		indexWorkload(next, "batch/v1", "CronJob", cronJob.ObjectMeta)
+}
+
+func (i *Indexer) parts() []*part {
+	if i.runtime == nil {
+		return []*part{i.rbac}
 	}
 
-	sortSnapshot(next)
-	i.snapshot.Store(next)
+	return []*part{i.rbac, i.runtime}
+}
+
+func (i *Indexer) runtimeUnavailable() string {
+	switch {
+	case i.runtime == nil:
+		return RuntimeIndexDisabled
+	case !i.runtime.ready.Load():
+		return RuntimeIndexNotSynced
+	default:
+		return ""
+	}
 }
 
-func (i *Indexer) scheduleRebuild() {
-	i.timerMu.Lock()
-	defer i.timerMu.Unlock()
-	if i.rebuildTimer != nil {
-		i.rebuildTimer.Stop()
+// enqueue queues informer events for incremental application to p. Events
+// before p's informers sync are dropped: the first build lists them.
+func (i *Indexer) enqueue(p *part, deltas ...delta) {
+	if !p.watching.Load() {
+		return
+	}
+	p.timerMu.Lock()
+	defer p.timerMu.Unlock()
+	if !p.pendingFull {
+		p.pending = append(p.pending, deltas...)
+	}
+	i.scheduleFlushLocked(p)
+}
+
+func (i *Indexer) scheduleRebuild(p *part) {
+	if !p.watching.Load() {
+		return
+	}
+	p.timerMu.Lock()
+	defer p.timerMu.Unlock()
+	p.pending, p.pendingFull = nil, true
+	i.scheduleFlushLocked(p)
+}
+
+// scheduleFlushLocked (re)starts the debounce timer of p, firing no later
+// than maxStaleness after the oldest pending event. p.timerMu must be held.
+func (i *Indexer) scheduleFlushLocked(p *part) {
+	now := time.Now()
+	if p.pendingSince.IsZero() {
+		p.pendingSince = now
+	}
+	delay := rebuildDebounceInterval
+	if i.maxStaleness > 0 {
+		delay = min(delay, max(i.maxStaleness-now.Sub(p.pendingSince), 0))
+	}
+	if p.timer != nil {
+		p.timer.Stop()
+	}
+	p.timer = time.AfterFunc(delay, func() { i.flush(p) })
+}
+
+func (i *Indexer) flush(p *part) {
+	p.timerMu.Lock()
+	deltas, full := p.pending, p.pendingFull
+	p.pending, p.pendingFull, p.pendingSince = nil, false, time.Time{}
+	p.timerMu.Unlock()
+
+	switch {
+	case full:
+		i.rebuild(p)
+	case len(deltas) > 0:
+		i.applyPending(p, deltas)
 	}
-	i.rebuildTimer = time.AfterFunc(rebuildDebounceInterval, i.rebuild)
 }
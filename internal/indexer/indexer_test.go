@@ -1,10 +1,15 @@
 package indexer
 
 import (
+	"context"
+	"reflect"
 	"testing"
 
+	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/component-base/metrics/testutil"
 )
 
 func TestNormalizeServiceAccountName(t *testing.T) {
@@ -95,3 +100,101 @@ func TestIndexWorkloadStoresRecord(t *testing.T) {
 		t.Fatalf("expected owner references to be preserved: %#v", got.OwnerReferences)
 	}
 }
+
+func TestIndexBindingRecordBuildsSubjectIndex(t *testing.T) {
+	snapshot := newEmptySnapshot()
+	indexBindingRecord(
+		snapshot,
+		types.UID("rb-1"),
+		KindRoleBinding,
+		"team-a",
+		"deployers",
+		nil,
+		rbacv1.RoleRef{Kind: KindClusterRole, Name: "edit"},
+		[]rbacv1.Subject{
+			{Kind: SubjectKindServiceAccount, Name: "builder"},
+			{Kind: SubjectKindServiceAccount, Name: "builder", Namespace: "team-a"},
+			{Kind: SubjectKindGroup, Name: "devs"},
+			{Kind: SubjectKindUser, Name: "alice"},
+		},
+	)
+
+	saKey := SubjectKey{Kind: SubjectKindServiceAccount, Namespace: "team-a", Name: "builder"}
+	if got := snapshot.BindingsBySubject[saKey]; len(got) != 1 || got[0].Name != "deployers" {
+		t.Fatalf("expected single binding for %s (namespace defaulted, duplicates collapsed), got %#v", saKey, got)
+	}
+	if got := snapshot.BindingsBySubject[SubjectKey{Kind: SubjectKindGroup, Name: "devs"}]; len(got) != 1 {
+		t.Fatalf("expected group subject to be indexed, got %#v", got)
+	}
+	if got := snapshot.BindingsBySubject[SubjectKey{Kind: SubjectKindUser, Name: "alice"}]; len(got) != 1 {
+		t.Fatalf("expected user subject to be indexed, got %#v", got)
+	}
+	if saKey.String() != "ServiceAccount:team-a/builder" {
+		t.Fatalf("unexpected SubjectKey.String(): %q", saKey.String())
+	}
+}
+
+func TestIndexer_WithoutRuntimeIndex(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client := fake.NewSimpleClientset(deltaTestRole("reader", "get"), deltaTestPod("app-1", "app"))
+	idx := New(client, 0, WithoutRuntimeIndex())
+	go idx.Start(ctx) //nolint:errcheck // stopped by cancel
+	waitFor(t, idx.IsReady)
+
+	s := idx.Snapshot()
+	if _, ok := s.RolesByID[RecID(KindRole, "team-a", "reader")]; !ok {
+		t.Fatalf("expected Role team-a/reader, got %v", s.AllRoleIDs)
+	}
+	if len(s.PodsByServiceAccount) != 0 {
+		t.Fatalf("expected no pods with the runtime index disabled, got %v", s.PodsByServiceAccount)
+	}
+	if s.RuntimeUnavailable != RuntimeIndexDisabled {
+		t.Fatalf("expected RuntimeUnavailable %q, got %q", RuntimeIndexDisabled, s.RuntimeUnavailable)
+	}
+	if idx.RuntimeReady() {
+		t.Fatal("expected runtime index not to be ready when disabled")
+	}
+}
+
+func TestIndexer_PodEventsDoNotRebuildRBAC(t *testing.T) {
+	RegisterMetrics()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client := fake.NewSimpleClientset(deltaTestRole("reader", "get"))
+	idx := New(client, 0)
+	go idx.Start(ctx) //nolint:errcheck // stopped by cancel
+	waitFor(t, idx.RuntimeReady)
+
+	before := idx.Snapshot()
+	if before.RuntimeUnavailable != "" {
+		t.Fatalf("expected runtime index to be available, got %q", before.RuntimeUnavailable)
+	}
+	rbacBefore := rbacRebuilds(t)
+	if _, err := client.CoreV1().Pods("team-a").Create(ctx, deltaTestPod("app-1", "app"), metav1.CreateOptions{}); err != nil {
+		t.Fatalf("create pod: %v", err)
+	}
+	waitFor(t, func() bool { return len(idx.Snapshot().PodsByServiceAccount) == 1 })
+
+	after := idx.Snapshot()
+	if reflect.ValueOf(after.RolesByID).Pointer() != reflect.ValueOf(before.RolesByID).Pointer() {
+		t.Fatal("expected the RBAC part to be shared across a runtime rebuild")
+	}
+	if got := rbacRebuilds(t); got != rbacBefore {
+		t.Fatalf("expected no RBAC rebuild for a pod event, got %v", got-rbacBefore)
+	}
+}
+
+func rbacRebuilds(t *testing.T) float64 {
+	t.Helper()
+	var total float64
+	for _, mode := range []string{rebuildModeFull, rebuildModeDelta} {
+		value, err := testutil.GetCounterMetricValue(rebuildsMetric.WithLabelValues(partRBAC, mode))
+		if err != nil {
+			t.Fatalf("read rebuild counter: %v", err)
+		}
+		total += value
+	}
+
+	return total
+}
@@ -0,0 +1,365 @@
+package indexer
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+)
+
+const manifestDecodeBufferSize = 4096
+
+// manifestExtensions are the file extensions read when walking a directory.
+var manifestExtensions = map[string]struct{}{".yaml": {}, ".yml": {}, ".json": {}}
+
+// ManifestSource is a Source over objects parsed from YAML or JSON
+// manifests: rendered Helm charts, kustomize output or a
+// `kubectl get -o yaml` dump. Objects of kinds the indexer does not use are
+// ignored. Namespaced objects without a namespace are placed in
+// DefaultNamespace, and objects without a UID get a stable synthetic one so
+// rule and owner references stay resolvable.
+type ManifestSource struct {
+	DefaultNamespace string
+
+	origins map[ManifestKey]string
+
+	roles               []*rbacv1.Role
+	clusterRoles        []*rbacv1.ClusterRole
+	roleBindings        []*rbacv1.RoleBinding
+	clusterRoleBindings []*rbacv1.ClusterRoleBinding
+	pods                []*corev1.Pod
+	serviceAccounts     []*corev1.ServiceAccount
+	namespaces          []*corev1.Namespace
+	deployments         []*appsv1.Deployment
+	replicaSets         []*appsv1.ReplicaSet
+	statefulSets        []*appsv1.StatefulSet
+	daemonSets          []*appsv1.DaemonSet
+	jobs                []*batchv1.Job
+	cronJobs            []*batchv1.CronJob
+}
+
+var _ Source = (*ManifestSource)(nil)
+
+// ManifestKey identifies an object read from a manifest.
+type ManifestKey struct {
+	Kind      string
+	Namespace string
+	Name      string
+}
+
+// NewManifestSource returns an empty source. An empty defaultNamespace
+// means "default".
+func NewManifestSource(defaultNamespace string) *ManifestSource {
+	if defaultNamespace == "" {
+		defaultNamespace = metav1.NamespaceDefault
+	}
+
+	return &ManifestSource{DefaultNamespace: defaultNamespace, origins: make(map[ManifestKey]string)}
+}
+
+// Origin returns the name of the file or stream the object was read from,
+// or "" when it was not read from a manifest.
+func (m *ManifestSource) Origin(kind, namespace, name string) string {
+	return m.origins[ManifestKey{Kind: kind, Namespace: namespace, Name: name}]
+}
+
+// LoadManifests reads every path into a new source. Directories are walked
+// recursively and only .yaml, .yml and .json files are read; files named
+// explicitly are read regardless of extension.
+func LoadManifests(defaultNamespace string, paths ...string) (*ManifestSource, error) {
+	src := NewManifestSource(defaultNamespace)
+	for _, path := range paths {
+		if err := src.AddPath(path); err != nil {
+			return nil, err
+		}
+	}
+
+	return src, nil
+}
+
+// AddPath reads a manifest file or a directory of manifests.
+func (m *ManifestSource) AddPath(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return m.addFile(path)
+	}
+
+	return filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		if _, ok := manifestExtensions[strings.ToLower(filepath.Ext(p))]; !ok {
+			return nil
+		}
+
+		return m.addFile(p)
+	})
+}
+
+func (m *ManifestSource) addFile(path string) error {
+	f, err := os.Open(path) //nolint:gosec // path is supplied by the operator
+	if err != nil {
+		return err
+	}
+	defer f.Close() //nolint:errcheck // read-only
+
+	return m.Add(f, path)
+}
+
+// Add decodes a stream of YAML documents or JSON objects. List objects,
+// as produced by `kubectl get -o yaml`, are expanded into their items. name
+// is used in error messages only.
+func (m *ManifestSource) Add(r io.Reader, name string) error {
+	decoder := utilyaml.NewYAMLOrJSONDecoder(r, manifestDecodeBufferSize)
+	for doc := 1; ; doc++ {
+		var obj map[string]any
+		if err := decoder.Decode(&obj); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+
+			return fmt.Errorf("%s: document %d: %w", name, doc, err)
+		}
+		if len(obj) == 0 {
+			continue
+		}
+		u := &unstructured.Unstructured{Object: obj}
+		if !u.IsList() {
+			if err := m.addObject(u, name); err != nil {
+				return fmt.Errorf("%s: document %d: %w", name, doc, err)
+			}
+
+			continue
+		}
+		err := u.EachListItem(func(item runtime.Object) error {
+			itemU, ok := item.(*unstructured.Unstructured)
+			if !ok {
+				return fmt.Errorf("unexpected list item type %T", item)
+			}
+
+			return m.addObject(itemU, name)
+		})
+		if err != nil {
+			return fmt.Errorf("%s: document %d: %w", name, doc, err)
+		}
+	}
+}
+
+//nolint:gocyclo // one case per indexed kind
+func (m *ManifestSource) addObject(u *unstructured.Unstructured, origin string) error {
+	gvk := u.GroupVersionKind()
+	switch gvk.Group + "/" + gvk.Kind {
+	case rbacv1.GroupName + "/" + KindRole:
+		return decodeManifest(u, m, origin, true, &m.roles)
+	case rbacv1.GroupName + "/" + KindClusterRole:
+		return decodeManifest(u, m, origin, false, &m.clusterRoles)
+	case rbacv1.GroupName + "/" + KindRoleBinding:
+		return decodeManifest(u, m, origin, true, &m.roleBindings)
+	case rbacv1.GroupName + "/" + KindClusterRoleBinding:
+		return decodeManifest(u, m, origin, false, &m.clusterRoleBindings)
+	case "/Pod":
+		return decodeManifest(u, m, origin, true, &m.pods)
+	case "/ServiceAccount":
+		return decodeManifest(u, m, origin, true, &m.serviceAccounts)
+	case "/Namespace":
+		return decodeManifest(u, m, origin, false, &m.namespaces)
+	case "apps/Deployment":
+		return decodeManifest(u, m, origin, true, &m.deployments)
+	case "apps/ReplicaSet":
+		return decodeManifest(u, m, origin, true, &m.replicaSets)
+	case "apps/StatefulSet":
+		return decodeManifest(u, m, origin, true, &m.statefulSets)
+	case "apps/DaemonSet":
+		return decodeManifest(u, m, origin, true, &m.daemonSets)
+	case "batch/Job":
+		return decodeManifest(u, m, origin, true, &m.jobs)
+	case "batch/CronJob":
+		return decodeManifest(u, m, origin, true, &m.cronJobs)
+	default:
+		return nil
+	}
+}
+
+// decodeManifest converts u into a typed object, fills in the namespace and
+// UID, appends it to items and records where it was read from.
+func decodeManifest[T any, PT interface {
+	*T
+	metav1.Object
+}](u *unstructured.Unstructured, m *ManifestSource, origin string, namespaced bool, items *[]PT) error {
+	obj := PT(new(T))
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, obj); err != nil {
+		return fmt.Errorf("decode %s %q: %w", u.GetKind(), u.GetName(), err)
+	}
+	if obj.GetName() == "" {
+		return fmt.Errorf("%s without metadata.name", u.GetKind())
+	}
+	if !namespaced {
+		obj.SetNamespace("")
+	} else if obj.GetNamespace() == "" {
+		obj.SetNamespace(m.DefaultNamespace)
+	}
+	if obj.GetUID() == "" {
+		obj.SetUID(types.UID(fmt.Sprintf("manifest:%s:%s/%s", u.GetKind(), obj.GetNamespace(), obj.GetName())))
+	}
+	*items = append(*items, obj)
+	m.origins[ManifestKey{Kind: u.GetKind(), Namespace: obj.GetNamespace(), Name: obj.GetName()}] = origin
+
+	return nil
+}
+
+// filterManifests returns the items whose labels match sel.
+func filterManifests[T metav1.Object](items []T, sel labels.Selector) []T {
+	if sel == nil || sel.Empty() {
+		return items
+	}
+	out := make([]T, 0, len(items))
+	for _, item := range items {
+		if sel.Matches(labels.Set(item.GetLabels())) {
+			out = append(out, item)
+		}
+	}
+
+	return out
+}
+
+func (m *ManifestSource) Roles(sel labels.Selector) ([]*rbacv1.Role, error) {
+	return filterManifests(m.roles, sel), nil
+}
+
+// ClusterRoles returns the ClusterRoles with aggregation rules resolved the
+// way the clusterrole-aggregation controller does in a live cluster: the
+// rules of an aggregating ClusterRole are replaced by the rules of every
+// ClusterRole its selectors match.
+func (m *ManifestSource) ClusterRoles(sel labels.Selector) ([]*rbacv1.ClusterRole, error) {
+	resolved, err := aggregateManifestClusterRoles(m.clusterRoles)
+
+	return filterManifests(resolved, sel), err
+}
+
+func (m *ManifestSource) RoleBindings(sel labels.Selector) ([]*rbacv1.RoleBinding, error) {
+	return filterManifests(m.roleBindings, sel), nil
+}
+
+func (m *ManifestSource) ClusterRoleBindings(sel labels.Selector) ([]*rbacv1.ClusterRoleBinding, error) {
+	return filterManifests(m.clusterRoleBindings, sel), nil
+}
+
+func (m *ManifestSource) Pods(sel labels.Selector) ([]*corev1.Pod, error) {
+	return filterManifests(m.pods, sel), nil
+}
+
+func (m *ManifestSource) ServiceAccounts(sel labels.Selector) ([]*corev1.ServiceAccount, error) {
+	return filterManifests(m.serviceAccounts, sel), nil
+}
+
+func (m *ManifestSource) Namespaces(sel labels.Selector) ([]*corev1.Namespace, error) {
+	return filterManifests(m.namespaces, sel), nil
+}
+
+func (m *ManifestSource) Deployments(sel labels.Selector) ([]*appsv1.Deployment, error) {
+	return filterManifests(m.deployments, sel), nil
+}
+
+func (m *ManifestSource) ReplicaSets(sel labels.Selector) ([]*appsv1.ReplicaSet, error) {
+	return filterManifests(m.replicaSets, sel), nil
+}
+
+func (m *ManifestSource) StatefulSets(sel labels.Selector) ([]*appsv1.StatefulSet, error) {
+	return filterManifests(m.statefulSets, sel), nil
+}
+
+func (m *ManifestSource) DaemonSets(sel labels.Selector) ([]*appsv1.DaemonSet, error) {
+	return filterManifests(m.daemonSets, sel), nil
+}
+
+func (m *ManifestSource) Jobs(sel labels.Selector) ([]*batchv1.Job, error) {
+	return filterManifests(m.jobs, sel), nil
+}
+
+func (m *ManifestSource) CronJobs(sel labels.Selector) ([]*batchv1.CronJob, error) {
+	return filterManifests(m.cronJobs, sel), nil
+}
+
+// aggregateManifestClusterRoles fills in the rules of aggregated ClusterRoles
+// the way the aggregation controller would. Sources that are aggregated
+// themselves are resolved first, so chains of any depth resolve; a role on a
+// cycle contributes its own manifest rules.
+func aggregateManifestClusterRoles(clusterRoles []*rbacv1.ClusterRole) ([]*rbacv1.ClusterRole, error) {
+	resolved := make(map[string][]rbacv1.PolicyRule)
+	resolving := make(map[string]bool)
+	var errs []error
+	var resolve func(target *rbacv1.ClusterRole) []rbacv1.PolicyRule
+	resolve = func(target *rbacv1.ClusterRole) []rbacv1.PolicyRule {
+		if target.AggregationRule == nil || len(target.AggregationRule.ClusterRoleSelectors) == 0 {
+			return target.Rules
+		}
+		if rules, ok := resolved[target.Name]; ok {
+			return rules
+		}
+		if resolving[target.Name] {
+			return target.Rules
+		}
+		resolving[target.Name] = true
+		var rules []rbacv1.PolicyRule
+		for _, selector := range target.AggregationRule.ClusterRoleSelectors {
+			labelSelector, err := metav1.LabelSelectorAsSelector(&selector)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("clusterrole/%s: %w", target.Name, err))
+
+				continue
+			}
+			for _, source := range clusterRoles {
+				if source.Name == target.Name || !labelSelector.Matches(labels.Set(source.Labels)) {
+					continue
+				}
+				for _, rule := range resolve(source) {
+					if !slices.ContainsFunc(rules, func(existing rbacv1.PolicyRule) bool {
+						return equality.Semantic.DeepEqual(existing, rule)
+					}) {
+						rules = append(rules, rule)
+					}
+				}
+			}
+		}
+		resolving[target.Name] = false
+		resolved[target.Name] = rules
+
+		return rules
+	}
+
+	out := make([]*rbacv1.ClusterRole, 0, len(clusterRoles))
+	for _, target := range clusterRoles {
+		if target.AggregationRule == nil || len(target.AggregationRule.ClusterRoleSelectors) == 0 {
+			out = append(out, target)
+
+			continue
+		}
+		aggregated := target.DeepCopy()
+		aggregated.Rules = resolve(target)
+		out = append(out, aggregated)
+	}
+
+	return out, errors.Join(errs...)
+}
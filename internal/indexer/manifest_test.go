@@ -0,0 +1,215 @@
+package indexer
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func TestLoadManifests_BuildsSnapshot(t *testing.T) {
+	src, err := LoadManifests("team-a", "testdata/manifests")
+	if err != nil {
+		t.Fatalf("LoadManifests: %v", err)
+	}
+	snapshot := BuildSnapshot(src)
+
+	role, ok := snapshot.RolesByID[RecID(KindRole, "team-a", "read-secrets")]
+	if !ok {
+		t.Fatalf("expected Role team-a/read-secrets, got %v", snapshot.AllRoleIDs)
+	}
+	if role.UID == "" {
+		t.Fatal("expected synthetic UID for manifest without uid")
+	}
+	ref := RoleRefKey{Kind: KindRole, Namespace: "team-a", Name: "read-secrets"}
+	if len(snapshot.BindingsByRoleRef[ref]) != 1 {
+		t.Fatalf("expected RoleBinding in default namespace, got %#v", snapshot.BindingsByRoleRef)
+	}
+	if _, ok := snapshot.ServiceAccounts[serviceAccountKey("team-a", "app")]; !ok {
+		t.Fatalf("expected ServiceAccount team-a/app, got %#v", snapshot.ServiceAccounts)
+	}
+	if len(snapshot.WorkloadsByUID) != 1 {
+		t.Fatalf("expected one Deployment workload, got %#v", snapshot.WorkloadsByUID)
+	}
+
+	monitoring := snapshot.RolesByID[RecID(KindClusterRole, "", "monitoring")]
+	if monitoring == nil || monitoring.UID != "7a1c" {
+		t.Fatalf("expected ClusterRole monitoring from List to keep its uid, got %#v", monitoring)
+	}
+	aggregated := snapshot.RolesByID[RecID(KindClusterRole, "", "monitoring-aggregate")]
+	if aggregated == nil || len(aggregated.Rules) != 1 || !slices.Contains(aggregated.Rules[0].Resources, "pods") {
+		t.Fatalf("expected aggregated rules resolved from monitoring, got %#v", aggregated)
+	}
+	sources := snapshot.AggregatedRoleSources[RecID(KindClusterRole, "", "monitoring-aggregate")]
+	if !slices.Equal(sources, []RoleID{RecID(KindClusterRole, "", "monitoring")}) {
+		t.Fatalf("unexpected aggregation sources %v", sources)
+	}
+	if len(snapshot.KnownGaps) != 0 {
+		t.Fatalf("expected no empty aggregation known gap, got %v", snapshot.KnownGaps)
+	}
+	if origin := src.Origin(KindRoleBinding, "team-a", "app-read-secrets"); origin != "testdata/manifests/chart/rbac.yaml" {
+		t.Fatalf("unexpected RoleBinding origin %q", origin)
+	}
+	if origin := src.Origin(KindClusterRole, "", "monitoring"); origin != "testdata/manifests/dump.yaml" {
+		t.Fatalf("expected List item origin dump.yaml, got %q", origin)
+	}
+}
+
+func TestManifestSource_AddRejectsInvalidDocuments(t *testing.T) {
+	src := NewManifestSource("")
+	err := src.Add(strings.NewReader("apiVersion: rbac.authorization.k8s.io/v1\nkind: Role\nmetadata: {}\n"), "inline")
+	if err == nil || !strings.Contains(err.Error(), "inline: document 1") {
+		t.Fatalf("expected document error for Role without name, got %v", err)
+	}
+}
+
+func TestManifestSource_FiltersBySelector(t *testing.T) {
+	src := NewManifestSource("")
+	manifest := `apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: labelled
+  labels: {team: a}
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: other
+`
+	if err := src.Add(strings.NewReader(manifest), "inline"); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	roles, err := src.ClusterRoles(labels.SelectorFromSet(labels.Set{"team": "a"}))
+	if err != nil {
+		t.Fatalf("ClusterRoles: %v", err)
+	}
+	if len(roles) != 1 || roles[0].Name != "labelled" {
+		t.Fatalf("expected only labelled ClusterRole, got %v", roles)
+	}
+}
+
+func TestBuildSnapshot_ResolvesAggregationChains(t *testing.T) {
+	src := NewManifestSource("")
+	manifest := `apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: admin
+aggregationRule:
+  clusterRoleSelectors:
+  - matchLabels: {aggregate-to-admin: "true"}
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: edit
+  labels: {aggregate-to-admin: "true"}
+aggregationRule:
+  clusterRoleSelectors:
+  - matchLabels: {aggregate-to-edit: "true"}
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: pods-exec
+  labels: {aggregate-to-edit: "true", team: a}
+rules:
+- apiGroups: [""]
+  resources: [pods/exec]
+  verbs: [create]
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: loop-a
+  labels: {loop: a}
+aggregationRule:
+  clusterRoleSelectors:
+  - matchLabels: {loop: b}
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: loop-b
+  labels: {loop: b}
+aggregationRule:
+  clusterRoleSelectors:
+  - matchLabels: {loop: a}
+`
+	if err := src.Add(strings.NewReader(manifest), "inline"); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	snapshot := BuildSnapshot(src)
+
+	admin := snapshot.RolesByID[RecID(KindClusterRole, "", "admin")]
+	if admin == nil || len(admin.Rules) != 1 || !slices.Contains(admin.Rules[0].Resources, "pods/exec") {
+		t.Fatalf("expected admin to resolve rules through edit, got %#v", admin)
+	}
+	matches := snapshot.AggregationMatches[RecID(KindClusterRole, "", "edit")]
+	if len(matches) != 1 {
+		t.Fatalf("expected one aggregation match for edit, got %#v", matches)
+	}
+	match := matches[0]
+	if match.Source != RecID(KindClusterRole, "", "pods-exec") || match.SelectorIndex != 0 ||
+		match.Selector != "aggregate-to-edit=true" || len(match.MatchedLabels) != 1 || match.MatchedLabels["aggregate-to-edit"] != "true" {
+		t.Fatalf("unexpected aggregation match %#v", match)
+	}
+	if !slices.Contains(snapshot.Warnings, "clusterrole aggregation cycle: loop-a -> loop-b -> loop-a") {
+		t.Fatalf("expected aggregation cycle warning, got %v", snapshot.Warnings)
+	}
+}
+
+func TestLoadDiscoveryCache(t *testing.T) {
+	cache, err := LoadDiscoveryCache("testdata/discovery.json")
+	if err != nil {
+		t.Fatalf("LoadDiscoveryCache: %v", err)
+	}
+	if _, ok := cache.Groups["apps"]; !ok {
+		t.Fatalf("expected apps group, got %v", cache.Groups)
+	}
+	if verbs := cache.VerbsByGroupResource[""]["pods/exec"]; !slices.Equal(verbs, []string{"create", "get"}) {
+		t.Fatalf("unexpected pods/exec verbs %v", verbs)
+	}
+	if cache.FetchedAt.IsZero() {
+		t.Fatal("expected FetchedAt from file modification time")
+	}
+}
+
+func TestNewOffline_IsReadyWithSnapshot(t *testing.T) {
+	src, err := LoadManifests("", "testdata/manifests/chart/rbac.yaml")
+	if err != nil {
+		t.Fatalf("LoadManifests: %v", err)
+	}
+	cache, err := LoadDiscoveryCache("testdata/discovery.json")
+	if err != nil {
+		t.Fatalf("LoadDiscoveryCache: %v", err)
+	}
+	idx := NewOffline(src, cache)
+
+	if !idx.IsReady() {
+		t.Fatal("expected offline indexer to be ready")
+	}
+	if idx.Snapshot().Generation != 1 {
+		t.Fatalf("expected generation 1, got %d", idx.Snapshot().Generation)
+	}
+	if _, ok := idx.Snapshot().RolesByID[RecID(KindRole, "default", "read-secrets")]; !ok {
+		t.Fatalf("expected Role default/read-secrets, got %v", idx.Snapshot().AllRoleIDs)
+	}
+	if idx.DiscoveryCache() != cache {
+		t.Fatal("expected discovery cache from file")
+	}
+}
+
+func TestAddRebuildHandler_ReceivesPublishedSnapshots(t *testing.T) {
+	idx := NewOffline(NewManifestSource(""), nil)
+	var generations []int64
+	idx.AddRebuildHandler(func(s *Snapshot) {
+		generations = append(generations, s.Generation)
+	})
+
+	idx.rebuild()
+
+	if !slices.Equal(generations, []int64{2}) {
+		t.Fatalf("expected handler call for generation 2, got %v", generations)
+	}
+}
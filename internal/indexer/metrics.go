@@ -0,0 +1,177 @@
+package indexer
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"k8s.io/component-base/metrics"
+	"k8s.io/component-base/metrics/legacyregistry"
+)
+
+const metricsSubsystem = "rbacgraph_indexer"
+
+// Reasons reported by the discovery_refresh_failures_total metric.
+const (
+	discoveryFailureError   = "error"
+	discoveryFailurePartial = "partial"
+)
+
+// Modes reported by the rebuild metrics.
+const (
+	rebuildModeFull  = "full"
+	rebuildModeDelta = "delta"
+)
+
+// Record types reported by the snapshot_records metric.
+const (
+	recordTypeRole               = "role"
+	recordTypeClusterRole        = "clusterRole"
+	recordTypeRoleBinding        = "roleBinding"
+	recordTypeClusterRoleBinding = "clusterRoleBinding"
+	recordTypePod                = "pod"
+	recordTypeServiceAccount     = "serviceAccount"
+	recordTypeWorkload           = "workload"
+)
+
+var (
+	rebuildDurationMetric = metrics.NewHistogramVec(
+		&metrics.HistogramOpts{
+			Subsystem: metricsSubsystem,
+			Name:      "rebuild_duration_seconds",
+			Help: "Time to build a snapshot part, either from the source (\"full\") " +
+				"or by applying informer events to the previous one (\"delta\").",
+			Buckets:        metrics.ExponentialBuckets(0.0005, 2, 15),
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"part", "mode"},
+	)
+	rebuildsMetric = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Subsystem:      metricsSubsystem,
+			Name:           "rebuilds_total",
+			Help:           "Number of snapshots built, by part and mode.",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"part", "mode"},
+	)
+	snapshotRecordsMetric = metrics.NewGaugeVec(
+		&metrics.GaugeOpts{
+			Subsystem:      metricsSubsystem,
+			Name:           "snapshot_records",
+			Help:           "Number of records of each type in the current snapshot.",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"type"},
+	)
+	snapshotGenerationMetric = metrics.NewGauge(
+		&metrics.GaugeOpts{
+			Subsystem:      metricsSubsystem,
+			Name:           "snapshot_generation",
+			Help:           "Generation of the current snapshot.",
+			StabilityLevel: metrics.ALPHA,
+		},
+	)
+	discoveryRefreshFailuresMetric = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Subsystem: metricsSubsystem,
+			Name:      "discovery_refresh_failures_total",
+			Help: "Number of failed discovery refreshes. On \"error\" the previous cache is kept; " +
+				"on \"partial\" the cache is built from the groups that were available.",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"reason"},
+	)
+	discoveryCacheAgeDesc = metrics.NewDesc(
+		metricsSubsystem+"_discovery_cache_age_seconds",
+		"Seconds since the discovery cache in use was fetched.",
+		nil, nil, metrics.ALPHA, "",
+	)
+
+	// discoveryFetchedAt holds the FetchedAt of the discovery cache last
+	// stored, as Unix nanoseconds, for the age collector.
+	discoveryFetchedAt atomic.Int64
+
+	registerMetricsOnce sync.Once
+)
+
+// RegisterMetrics registers the indexer metrics with the legacy registry
+// served on the apiserver /metrics endpoint. It is safe to call repeatedly.
+func RegisterMetrics() {
+	registerMetricsOnce.Do(func() {
+		legacyregistry.MustRegister(
+			rebuildDurationMetric,
+			rebuildsMetric,
+			snapshotRecordsMetric,
+			snapshotGenerationMetric,
+			discoveryRefreshFailuresMetric,
+		)
+		legacyregistry.CustomMustRegister(&discoveryAgeCollector{})
+	})
+}
+
+func recordRebuildMetrics(s *Snapshot, part, mode string, duration time.Duration) {
+	rebuildDurationMetric.WithLabelValues(part, mode).Observe(duration.Seconds())
+	rebuildsMetric.WithLabelValues(part, mode).Inc()
+	snapshotGenerationMetric.Set(float64(s.Generation))
+	for recordType, count := range snapshotRecordCounts(s) {
+		snapshotRecordsMetric.WithLabelValues(recordType).Set(float64(count))
+	}
+}
+
+func recordDiscoveryFetched(cache *APIDiscoveryCache) {
+	discoveryFetchedAt.Store(cache.FetchedAt.UnixNano())
+}
+
+// snapshotRecordCounts counts the records of each type in s.
+func snapshotRecordCounts(s *Snapshot) map[string]int {
+	counts := map[string]int{
+		recordTypeRole:               0,
+		recordTypeClusterRole:        0,
+		recordTypeRoleBinding:        0,
+		recordTypeClusterRoleBinding: 0,
+		recordTypePod:                0,
+		recordTypeServiceAccount:     len(s.ServiceAccounts),
+		recordTypeWorkload:           len(s.WorkloadsByUID),
+	}
+	for _, role := range s.RolesByID {
+		if role.Kind == KindClusterRole {
+			counts[recordTypeClusterRole]++
+		} else {
+			counts[recordTypeRole]++
+		}
+	}
+	// Every binding is indexed under exactly one role reference.
+	for _, bindings := range s.BindingsByRoleRef {
+		for _, binding := range bindings {
+			if binding.Kind == KindClusterRoleBinding {
+				counts[recordTypeClusterRoleBinding]++
+			} else {
+				counts[recordTypeRoleBinding]++
+			}
+		}
+	}
+	for _, pods := range s.PodsByServiceAccount {
+		counts[recordTypePod] += len(pods)
+	}
+
+	return counts
+}
+
+// discoveryAgeCollector reports the discovery cache age at scrape time.
+type discoveryAgeCollector struct {
+	metrics.BaseStableCollector
+}
+
+func (c *discoveryAgeCollector) DescribeWithStability(ch chan<- *metrics.Desc) {
+	ch <- discoveryCacheAgeDesc
+}
+
+func (c *discoveryAgeCollector) CollectWithStability(ch chan<- metrics.Metric) {
+	fetchedAt := discoveryFetchedAt.Load()
+	if fetchedAt == 0 {
+		return
+	}
+	age := time.Since(time.Unix(0, fetchedAt)).Seconds()
+	ch <- metrics.NewLazyConstMetric(discoveryCacheAgeDesc, metrics.GaugeValue, age)
+}
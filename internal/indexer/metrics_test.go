@@ -0,0 +1,68 @@
+package indexer
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/component-base/metrics/legacyregistry"
+	"k8s.io/component-base/metrics/testutil"
+)
+
+func TestRebuild_RecordsSnapshotMetrics(t *testing.T) {
+	RegisterMetrics()
+	src, err := LoadManifests("team-a", "testdata/manifests")
+	if err != nil {
+		t.Fatalf("LoadManifests: %v", err)
+	}
+	rebuildsBefore, err := testutil.GetCounterMetricValue(rebuildsMetric.WithLabelValues(partAll, rebuildModeFull))
+	if err != nil {
+		t.Fatalf("read rebuild counter: %v", err)
+	}
+
+	NewOffline(src, nil)
+
+	rebuildsAfter, err := testutil.GetCounterMetricValue(rebuildsMetric.WithLabelValues(partAll, rebuildModeFull))
+	if err != nil {
+		t.Fatalf("read rebuild counter: %v", err)
+	}
+	if rebuildsAfter-rebuildsBefore != 1 {
+		t.Fatalf("expected one rebuild counted, got %v", rebuildsAfter-rebuildsBefore)
+	}
+	want := `
+# HELP rbacgraph_indexer_snapshot_records [ALPHA] Number of records of each type in the current snapshot.
+# TYPE rbacgraph_indexer_snapshot_records gauge
+rbacgraph_indexer_snapshot_records{type="clusterRole"} 2
+rbacgraph_indexer_snapshot_records{type="clusterRoleBinding"} 1
+rbacgraph_indexer_snapshot_records{type="pod"} 0
+rbacgraph_indexer_snapshot_records{type="role"} 1
+rbacgraph_indexer_snapshot_records{type="roleBinding"} 1
+rbacgraph_indexer_snapshot_records{type="serviceAccount"} 1
+rbacgraph_indexer_snapshot_records{type="workload"} 1
+`
+	if err := testutil.GatherAndCompare(legacyregistry.DefaultGatherer, strings.NewReader(want),
+		"rbacgraph_indexer_snapshot_records"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDiscoveryAgeCollector(t *testing.T) {
+	RegisterMetrics()
+	recordDiscoveryFetched(&APIDiscoveryCache{FetchedAt: time.Now().Add(-time.Hour)})
+
+	families, err := legacyregistry.DefaultGatherer.Gather()
+	if err != nil {
+		t.Fatalf("Gather: %v", err)
+	}
+	for _, family := range families {
+		if family.GetName() != "rbacgraph_indexer_discovery_cache_age_seconds" {
+			continue
+		}
+		if age := family.GetMetric()[0].GetGauge().GetValue(); age < time.Hour.Seconds() {
+			t.Fatalf("expected age of at least an hour, got %vs", age)
+		}
+
+		return
+	}
+	t.Fatal("expected discovery cache age metric")
+}
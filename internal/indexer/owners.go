@@ -0,0 +1,280 @@
+package indexer
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/metadata"
+	"k8s.io/client-go/metadata/metadatainformer"
+	"k8s.io/client-go/restmapper"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+)
+
+// ownerSyncTimeout bounds the wait for an owner informer to sync before the
+// kind is reported as unavailable, typically because list/watch is denied.
+const ownerSyncTimeout = time.Minute
+
+// builtinWorkloadKinds are the owner kinds indexed by typed informers.
+var builtinWorkloadKinds = map[schema.GroupKind]struct{}{
+	{Group: "apps", Kind: "Deployment"}:  {},
+	{Group: "apps", Kind: "ReplicaSet"}:  {},
+	{Group: "apps", Kind: "StatefulSet"}: {},
+	{Group: "apps", Kind: "DaemonSet"}:   {},
+	{Group: "batch", Kind: "Job"}:        {},
+	{Group: "batch", Kind: "CronJob"}:    {},
+}
+
+// ownerObject is the metadata of an owner watched through a metadata
+// informer, with the kind the informer was started for: metadata lists do
+// not carry the kind of their items.
+type ownerObject struct {
+	APIVersion string
+	Kind       string
+	*metav1.PartialObjectMetadata
+}
+
+// OwnerKindPattern reports whether pattern is a valid owner kind pattern:
+// a path.Match glob over "Kind.group", or "Kind" for the core group.
+func OwnerKindPattern(pattern string) error {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return fmt.Errorf("invalid owner kind pattern %q: %w", pattern, err)
+	}
+
+	return nil
+}
+
+func ownerKindName(gk schema.GroupKind) string {
+	if gk.Group == "" {
+		return gk.Kind
+	}
+
+	return gk.Kind + "." + gk.Group
+}
+
+// ownerInformers watches owner kinds beyond the built-in workloads. A kind
+// is watched once an owner reference of an indexed pod or workload points at
+// it and it matches an allow-list pattern; its resource is resolved through
+// discovery and only object metadata is cached. Owner references in any
+// version of a kind share one informer for the preferred version.
+type ownerInformers struct {
+	client   metadata.Interface
+	mapper   *restmapper.DeferredDiscoveryRESTMapper
+	patterns []string
+	resync   time.Duration
+	// enqueue queues owner events as runtime deltas; synced requests a
+	// runtime rebuild once a kind is listed.
+	enqueue func(deltas ...delta)
+	synced  func()
+
+	mu    sync.Mutex
+	stop  <-chan struct{}
+	kinds map[schema.GroupKind]*ownerKind
+}
+
+type ownerKind struct {
+	gk      schema.GroupKind
+	allowed bool
+	// gvk is the preferred version of the kind, set once it is resolved.
+	gvk      schema.GroupVersionKind
+	informer cache.SharedIndexInformer
+	// err is set when the kind cannot be watched.
+	err error
+}
+
+func newOwnerInformers(client metadata.Interface, discoveryClient discovery.DiscoveryInterface,
+	patterns []string, resync time.Duration,
+) *ownerInformers {
+	return &ownerInformers{
+		client:   client,
+		mapper:   restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(discoveryClient)),
+		patterns: patterns,
+		resync:   resync,
+		kinds:    make(map[schema.GroupKind]*ownerKind),
+	}
+}
+
+// start starts informers for kinds observed so far and for every kind
+// observed later, until stop is closed.
+func (o *ownerInformers) start(stop <-chan struct{}) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.stop = stop
+	for _, kind := range o.kinds {
+		if kind.allowed {
+			go o.run(kind)
+		}
+	}
+}
+
+func (o *ownerInformers) allows(gk schema.GroupKind) bool {
+	name := ownerKindName(gk)
+	for _, pattern := range o.patterns {
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+// observe records the kinds refs point at and starts informers for new
+// allowed kinds.
+func (o *ownerInformers) observe(refs []metav1.OwnerReference) {
+	for _, ref := range refs {
+		gv, err := schema.ParseGroupVersion(ref.APIVersion)
+		if err != nil {
+			continue
+		}
+		gk := schema.GroupKind{Group: gv.Group, Kind: ref.Kind}
+		if _, ok := builtinWorkloadKinds[gk]; ok {
+			continue
+		}
+		o.mu.Lock()
+		if _, seen := o.kinds[gk]; !seen {
+			kind := &ownerKind{gk: gk, allowed: o.allows(gk)}
+			o.kinds[gk] = kind
+			if kind.allowed && o.stop != nil {
+				go o.run(kind)
+			}
+		}
+		o.mu.Unlock()
+	}
+}
+
+// observeObject observes the owner references of an informer object.
+func (o *ownerInformers) observeObject(obj any) {
+	if accessor, err := meta.Accessor(obj); err == nil {
+		o.observe(accessor.GetOwnerReferences())
+	}
+}
+
+// run resolves the preferred resource of kind, runs its metadata informer
+// and requests a rebuild once it has synced or failed to.
+func (o *ownerInformers) run(kind *ownerKind) {
+	mapping, err := o.mapper.RESTMapping(kind.gk)
+	if meta.IsNoMatchError(err) {
+		// The kind may belong to a CRD installed after discovery was cached.
+		o.mapper.Reset()
+		mapping, err = o.mapper.RESTMapping(kind.gk)
+	}
+	if err != nil {
+		o.fail(kind, fmt.Errorf("resolve resource: %w", err))
+
+		return
+	}
+
+	informer := metadatainformer.NewFilteredMetadataInformer(o.client, mapping.Resource, metav1.NamespaceAll, o.resync, nil, nil).Informer()
+	//nolint:errcheck,gosec // SetTransform only errors once the informer has started
+	informer.SetTransform(transformOwner)
+	//nolint:errcheck,gosec // AddEventHandler only errors when the informer is stopped
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj any) { o.enqueue(delta{obj: o.wrap(kind, obj)}) },
+		UpdateFunc: func(oldObj, newObj any) {
+			o.enqueue(delta{remove: true, obj: o.wrap(kind, oldObj)}, delta{obj: o.wrap(kind, newObj)})
+		},
+		DeleteFunc: func(obj any) { o.enqueue(delta{remove: true, obj: o.wrap(kind, obj)}) },
+	})
+	o.mu.Lock()
+	kind.gvk = mapping.GroupVersionKind
+	kind.informer = informer
+	o.mu.Unlock()
+	klog.Infof("watching owner kind %s (%s)", ownerKindName(kind.gk), mapping.Resource)
+	go informer.Run(o.stop)
+
+	ctx, cancel := context.WithTimeout(context.Background(), ownerSyncTimeout)
+	defer cancel()
+	go func() {
+		select {
+		case <-o.stop:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	if !cache.WaitForCacheSync(ctx.Done(), informer.HasSynced) {
+		select {
+		case <-o.stop:
+			return
+		default:
+		}
+		// The informer keeps retrying; the kind is indexed once it lists.
+		klog.Warningf("owner kind %s did not sync within %s; check list/watch permissions", ownerKindName(kind.gk), ownerSyncTimeout)
+	}
+	o.synced()
+}
+
+func (o *ownerInformers) fail(kind *ownerKind, err error) {
+	klog.Warningf("owner kind %s cannot be watched: %v", ownerKindName(kind.gk), err)
+	o.mu.Lock()
+	kind.err = err
+	o.mu.Unlock()
+	o.synced()
+}
+
+func (o *ownerInformers) wrap(kind *ownerKind, obj any) any {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	partial, ok := obj.(*metav1.PartialObjectMetadata)
+	if !ok {
+		return obj
+	}
+	o.observe(partial.OwnerReferences)
+
+	return &ownerObject{APIVersion: kind.gvk.GroupVersion().String(), Kind: kind.gvk.Kind, PartialObjectMetadata: partial}
+}
+
+// index adds the cached owners to next and observes the owner references
+// of its pods and workloads. Kinds that cannot be watched are reported as
+// warnings.
+func (o *ownerInformers) index(next *Snapshot) {
+	for _, pods := range next.PodsByServiceAccount {
+		for _, pod := range pods {
+			o.observe(pod.OwnerReferences)
+		}
+	}
+	for _, workload := range next.WorkloadsByUID {
+		o.observe(workload.OwnerReferences)
+	}
+
+	o.mu.Lock()
+	kinds := make([]ownerKind, 0, len(o.kinds))
+	for _, kind := range o.kinds {
+		if kind.allowed {
+			kinds = append(kinds, *kind)
+		}
+	}
+	o.mu.Unlock()
+	sort.Slice(kinds, func(i, j int) bool { return ownerKindName(kinds[i].gk) < ownerKindName(kinds[j].gk) })
+
+	for _, kind := range kinds {
+		name := ownerKindName(kind.gk)
+		switch {
+		case kind.err != nil:
+			next.Warnings = append(next.Warnings, fmt.Sprintf("owner kind %s cannot be watched: %v", name, kind.err))
+
+			continue
+		case kind.informer == nil:
+			continue
+		case !kind.informer.HasSynced():
+			next.Warnings = append(next.Warnings, fmt.Sprintf("owner kind %s has not synced; check list/watch permissions", name))
+		}
+		for _, obj := range kind.informer.GetStore().List() {
+			partial, ok := obj.(*metav1.PartialObjectMetadata)
+			if !ok {
+				continue
+			}
+			indexWorkload(next, kind.gvk.GroupVersion().String(), kind.gvk.Kind, partial.ObjectMeta)
+			o.observe(partial.OwnerReferences)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package indexer
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	metadatafake "k8s.io/client-go/metadata/fake"
+)
+
+func TestOwnerKindPattern(t *testing.T) {
+	for _, pattern := range []string{"Rollout.argoproj.io", "*.kubevirt.io", "Widget"} {
+		if err := OwnerKindPattern(pattern); err != nil {
+			t.Fatalf("expected %q to be valid, got %v", pattern, err)
+		}
+	}
+	if err := OwnerKindPattern("Rollout.[argoproj.io"); err == nil {
+		t.Fatal("expected malformed pattern to be rejected")
+	}
+}
+
+func TestIndexer_ResolvesAllowedOwnerKinds(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	rolloutRef := metav1.OwnerReference{APIVersion: "argoproj.io/v1alpha1", Kind: "Rollout", Name: "app", UID: "uid-rollout"}
+	vmRef := metav1.OwnerReference{APIVersion: "kubevirt.io/v1", Kind: "VirtualMachineInstance", Name: "vm", UID: "uid-vm"}
+	rs := &appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+		Namespace: "team-a", Name: "app-7c9f", UID: "uid-rs", OwnerReferences: []metav1.OwnerReference{rolloutRef},
+	}}
+	pod := deltaTestPod("app-7c9f-x1", "app")
+	pod.OwnerReferences = []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: rs.Name, UID: rs.UID}}
+	vmPod := deltaTestPod("virt-launcher-vm", "default")
+	vmPod.OwnerReferences = []metav1.OwnerReference{vmRef}
+	// An owner reference in a version discovery does not serve resolves to
+	// the same informer for the preferred version.
+	otherVersionPod := deltaTestPod("app-canary", "app")
+	otherVersionPod.OwnerReferences = []metav1.OwnerReference{{APIVersion: "argoproj.io/v1beta1", Kind: "Rollout", Name: "app", UID: rolloutRef.UID}}
+
+	client := fake.NewSimpleClientset(rs, pod, vmPod, otherVersionPod)
+	client.Resources = []*metav1.APIResourceList{
+		{GroupVersion: "argoproj.io/v1alpha1", APIResources: []metav1.APIResource{{Name: "rollouts", Kind: "Rollout", Namespaced: true}}},
+		{GroupVersion: "kubevirt.io/v1", APIResources: []metav1.APIResource{{Name: "virtualmachineinstances", Kind: "VirtualMachineInstance", Namespaced: true}}},
+	}
+	scheme := metadatafake.NewTestScheme()
+	if err := metav1.AddMetaToScheme(scheme); err != nil {
+		t.Fatalf("add meta to scheme: %v", err)
+	}
+	metadataClient := metadatafake.NewSimpleMetadataClient(scheme, &metav1.PartialObjectMetadata{
+		TypeMeta:   metav1.TypeMeta{APIVersion: rolloutRef.APIVersion, Kind: rolloutRef.Kind},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: rolloutRef.Name, UID: rolloutRef.UID},
+	})
+
+	idx := New(client, 0, WithOwnerKinds(metadataClient, "Rollout.argoproj.io"))
+	go idx.Start(ctx) //nolint:errcheck // stopped by cancel
+	waitFor(t, func() bool {
+		_, ok := idx.Snapshot().WorkloadsByUID[rolloutRef.UID]
+
+		return ok
+	})
+
+	rollout := idx.Snapshot().WorkloadsByUID[rolloutRef.UID]
+	if rollout.Kind != "Rollout" || rollout.APIVersion != rolloutRef.APIVersion || rollout.Name != "app" {
+		t.Fatalf("unexpected rollout record %#v", rollout)
+	}
+	if _, ok := idx.Snapshot().WorkloadsByUID[vmRef.UID]; ok {
+		t.Fatal("expected owner kind outside the allow-list not to be indexed")
+	}
+	idx.owners.mu.Lock()
+	vm := idx.owners.kinds[schema.GroupKind{Group: "kubevirt.io", Kind: vmRef.Kind}]
+	kinds := len(idx.owners.kinds)
+	idx.owners.mu.Unlock()
+	if vm == nil || vm.allowed || vm.informer != nil {
+		t.Fatalf("expected VirtualMachineInstance to be observed but not watched, got %#v", vm)
+	}
+	if kinds != 2 {
+		t.Fatalf("expected one owner kind per group and kind, got %d", kinds)
+	}
+	for _, warning := range idx.Snapshot().Warnings {
+		if strings.Contains(warning, "Rollout") {
+			t.Fatalf("expected Rollout references in every version to resolve, got warning %q", warning)
+		}
+	}
+}
+
+func TestApplyDeltas_OwnerObject(t *testing.T) {
+	owner := &ownerObject{
+		APIVersion: "argoproj.io/v1alpha1",
+		Kind:       "Rollout",
+		PartialObjectMetadata: &metav1.PartialObjectMetadata{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "app", UID: "uid-rollout"},
+		},
+	}
+
+	next, ok := applyDeltas(deltaTestSnapshot(), []delta{{obj: owner}})
+	if !ok {
+		t.Fatal("expected owner deltas to apply incrementally")
+	}
+	if got := next.WorkloadsByUID["uid-rollout"]; got.Kind != "Rollout" || got.Name != "app" {
+		t.Fatalf("unexpected owner record %#v", got)
+	}
+
+	next, ok = applyDeltas(next, []delta{{remove: true, obj: owner}})
+	if !ok {
+		t.Fatal("expected owner deltas to apply incrementally")
+	}
+	if _, found := next.WorkloadsByUID["uid-rollout"]; found {
+		t.Fatal("expected owner record to be removed")
+	}
+}
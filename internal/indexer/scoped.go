@@ -17,17 +17,29 @@ func Scoped(s *Snapshot, scope Scope) *Snapshot {
 	}
 
 	out := &Snapshot{
-		BuiltAt:               s.BuiltAt,
-		RolesByID:             make(map[RoleID]*RoleRecord, len(s.RolesByID)),
-		BindingsByRoleRef:     make(map[RoleRefKey][]*BindingRecord, len(s.BindingsByRoleRef)),
-		AggregatedRoleSources: make(map[RoleID][]RoleID, len(s.AggregatedRoleSources)),
-		PodsByServiceAccount:  make(map[ServiceAccountKey][]*PodRecord, len(s.PodsByServiceAccount)),
-		WorkloadsByUID:        make(map[types.UID]*WorkloadRecord, len(s.WorkloadsByUID)),
-		RoleIDsByVerb:         make(map[string]map[RoleID]struct{}),
-		RoleIDsByResource:     make(map[string]map[RoleID]struct{}),
-		RoleIDsByAPIGroup:     make(map[string]map[RoleID]struct{}),
-		KnownGaps:             s.CloneKnownGaps(),
-		Warnings:              s.CloneWarnings(),
+		Generation:     s.Generation,
+		RBACGeneration: s.RBACGeneration,
+		BuiltAt:        s.BuiltAt,
+		RBACIndex: RBACIndex{
+			RolesByID:             make(map[RoleID]*RoleRecord, len(s.RolesByID)),
+			BindingsByRoleRef:     make(map[RoleRefKey][]*BindingRecord, len(s.BindingsByRoleRef)),
+			BindingsBySubject:     make(map[SubjectKey][]*BindingRecord, len(s.BindingsBySubject)),
+			AggregatedRoleSources: make(map[RoleID][]RoleID, len(s.AggregatedRoleSources)),
+			AggregationMatches:    make(map[RoleID][]AggregationMatch, len(s.AggregationMatches)),
+			ServiceAccounts:       make(map[ServiceAccountKey]*ServiceAccountRecord, len(s.ServiceAccounts)),
+			Namespaces:            make(map[string]*NamespaceRecord, len(s.Namespaces)),
+			RoleIDsByVerb:         make(map[string]map[RoleID]struct{}),
+			RoleIDsByResource:     make(map[string]map[RoleID]struct{}),
+			RoleIDsByAPIGroup:     make(map[string]map[RoleID]struct{}),
+			KnownGaps:             s.CloneKnownGaps(),
+		},
+		RuntimeIndex: RuntimeIndex{
+			PodsByServiceAccount: make(map[ServiceAccountKey][]*PodRecord, len(s.PodsByServiceAccount)),
+			WorkloadsByUID:       make(map[types.UID]*WorkloadRecord, len(s.WorkloadsByUID)),
+		},
+		RuntimeUnavailable: s.RuntimeUnavailable,
+		Warnings:           s.CloneWarnings(),
+		Scope:              scope,
 	}
 
 	for id, rec := range s.RolesByID {
@@ -51,6 +63,18 @@ func Scoped(s *Snapshot, scope Scope) *Snapshot {
 		}
 	}
 
+	for key, bindings := range s.BindingsBySubject {
+		var kept []*BindingRecord
+		for _, b := range bindings {
+			if scope.AllowBinding(b.Namespace) {
+				kept = append(kept, b)
+			}
+		}
+		if len(kept) > 0 {
+			out.BindingsBySubject[key] = kept
+		}
+	}
+
 	// Filter aggregated role sources — keep only if target role survived.
 	for targetID, sources := range s.AggregatedRoleSources {
 		if _, ok := out.RolesByID[targetID]; !ok {
@@ -65,6 +89,15 @@ func Scoped(s *Snapshot, scope Scope) *Snapshot {
 		if len(kept) > 0 {
 			out.AggregatedRoleSources[targetID] = kept
 		}
+		var keptMatches []AggregationMatch
+		for _, match := range s.AggregationMatches[targetID] {
+			if _, ok := out.RolesByID[match.Source]; ok {
+				keptMatches = append(keptMatches, match)
+			}
+		}
+		if len(keptMatches) > 0 {
+			out.AggregationMatches[targetID] = keptMatches
+		}
 	}
 
 	for key, pods := range s.PodsByServiceAccount {
@@ -79,6 +112,20 @@ func Scoped(s *Snapshot, scope Scope) *Snapshot {
 		}
 	}
 
+	for key, sa := range s.ServiceAccounts {
+		if scope.AllowServiceAccount(sa.Namespace) {
+			out.ServiceAccounts[key] = sa
+		}
+	}
+
+	for name, ns := range s.Namespaces {
+		if scope.AllowNamespace(name) {
+			out.Namespaces[name] = ns
+		} else {
+			out.NamespacesHidden = true
+		}
+	}
+
 	for uid, w := range s.WorkloadsByUID {
 		if scope.AllowWorkload(w.Namespace) {
 			out.WorkloadsByUID[uid] = w
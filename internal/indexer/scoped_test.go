@@ -3,6 +3,7 @@ package indexer_test
 import (
 	"testing"
 
+	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/types"
 
@@ -110,6 +111,18 @@ func buildTestSnapshot() *indexer.Snapshot {
 		{UID: types.UID("pod-b-1"), Namespace: "ns-b", Name: "pod-b"},
 	}
 
+	// ServiceAccounts
+	for _, key := range []indexer.ServiceAccountKey{{Namespace: "ns-a", Name: "sa-1"}, {Namespace: "ns-b", Name: "sa-2"}} {
+		s.ServiceAccounts[key] = &indexer.ServiceAccountRecord{Namespace: key.Namespace, Name: key.Name}
+	}
+
+	// Namespaces
+	for _, name := range []string{"ns-a", "ns-b"} {
+		s.Namespaces[name] = &indexer.NamespaceRecord{
+			UID: types.UID("ns-" + name), Name: name, Labels: map[string]string{"team": name}, Phase: corev1.NamespaceActive,
+		}
+	}
+
 	// Workloads
 	s.WorkloadsByUID[types.UID("wl-a")] = &indexer.WorkloadRecord{
 		UID: types.UID("wl-a"), Kind: "Deployment", Namespace: "ns-a", Name: "deploy-a",
@@ -150,6 +163,7 @@ func TestScoped_Unrestricted(t *testing.T) {
 		CanListRoleBindings:        true,
 		CanListPods:                true,
 		CanListWorkloads:           true,
+		CanListServiceAccounts:     true,
 	}
 
 	result := indexer.Scoped(s, scope)
@@ -167,6 +181,7 @@ func TestScoped_NoClusterRoles(t *testing.T) {
 		CanListRoleBindings:        true,
 		CanListPods:                true,
 		CanListWorkloads:           true,
+		CanListServiceAccounts:     true,
 	}
 
 	result := indexer.Scoped(s, scope)
@@ -191,12 +206,13 @@ func TestScoped_NoClusterRoles(t *testing.T) {
 func TestScoped_OnlyNamespaceA(t *testing.T) {
 	s := buildTestSnapshot()
 	scope := &authz.AccessScope{
-		CanListClusterRoles:        true,
-		CanListClusterRoleBindings: true,
-		AllowedRoleNamespaces:      map[string]struct{}{"ns-a": {}},
-		AllowedBindingNamespaces:   map[string]struct{}{"ns-a": {}},
-		AllowedPodNamespaces:       map[string]struct{}{"ns-a": {}},
-		AllowedWorkloadNamespaces:  map[string]struct{}{"ns-a": {}},
+		CanListClusterRoles:             true,
+		CanListClusterRoleBindings:      true,
+		AllowedRoleNamespaces:           map[string]struct{}{"ns-a": {}},
+		AllowedBindingNamespaces:        map[string]struct{}{"ns-a": {}},
+		AllowedPodNamespaces:            map[string]struct{}{"ns-a": {}},
+		AllowedWorkloadNamespaces:       map[string]struct{}{"ns-a": {}},
+		AllowedServiceAccountNamespaces: map[string]struct{}{"ns-a": {}},
 	}
 
 	result := indexer.Scoped(s, scope)
@@ -234,6 +250,40 @@ func TestScoped_OnlyNamespaceA(t *testing.T) {
 			t.Errorf("expected no ns-b workloads, found %s", w.Name)
 		}
 	}
+
+	// Only ns-a service accounts should remain.
+	if len(result.ServiceAccounts) != 1 || result.ServiceAccounts[indexer.ServiceAccountKey{Namespace: "ns-a", Name: "sa-1"}] == nil {
+		t.Errorf("expected only ns-a service account, got %v", result.ServiceAccounts)
+	}
+
+	// Only ns-a is visible; ns-b is dropped without listing namespaces.
+	if ns := result.Namespaces["ns-a"]; ns == nil || ns.Labels["team"] != "ns-a" {
+		t.Errorf("expected ns-a namespace with labels, got %#v", ns)
+	}
+	if ns, ok := result.Namespaces["ns-b"]; ok {
+		t.Errorf("expected ns-b namespace to be filtered out, got %#v", ns)
+	}
+	if !result.NamespacesHidden {
+		t.Error("expected NamespacesHidden to be set")
+	}
+}
+
+func TestScoped_ListNamespacesKeepsAllNamespaces(t *testing.T) {
+	s := buildTestSnapshot()
+	scope := &authz.AccessScope{
+		AllowedRoleNamespaces:    map[string]struct{}{"ns-a": {}},
+		AllowedBindingNamespaces: map[string]struct{}{"ns-a": {}},
+		CanListNamespaces:        true,
+	}
+
+	result := indexer.Scoped(s, scope)
+
+	if ns := result.Namespaces["ns-b"]; ns == nil || ns.Labels["team"] != "ns-b" || ns.Phase != corev1.NamespaceActive {
+		t.Errorf("expected ns-b namespace with labels, got %#v", ns)
+	}
+	if result.NamespacesHidden {
+		t.Error("expected NamespacesHidden to be unset")
+	}
 }
 
 func TestScoped_TokenIndexes(t *testing.T) {
@@ -245,6 +295,7 @@ func TestScoped_TokenIndexes(t *testing.T) {
 		AllowedBindingNamespaces:   map[string]struct{}{"ns-a": {}},
 		CanListPods:                true,
 		CanListWorkloads:           true,
+		CanListServiceAccounts:     true,
 	}
 
 	result := indexer.Scoped(s, scope)
@@ -292,6 +343,7 @@ func TestScoped_AggregatedSources(t *testing.T) {
 		CanListRoleBindings:        true,
 		CanListPods:                true,
 		CanListWorkloads:           true,
+		CanListServiceAccounts:     true,
 	}
 
 	result := indexer.Scoped(s, scope)
@@ -310,6 +362,7 @@ func TestScoped_WarningsAndGaps(t *testing.T) {
 		CanListRoleBindings:        true,
 		CanListPods:                true,
 		CanListWorkloads:           true,
+		CanListServiceAccounts:     true,
 	}
 
 	result := indexer.Scoped(s, scope)
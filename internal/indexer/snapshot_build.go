@@ -1,9 +1,9 @@
 package indexer
 
 import (
+	"cmp"
 	"fmt"
 	"slices"
-	"sort"
 	"strings"
 	"time"
 
@@ -16,12 +16,21 @@ import (
 
 func newEmptySnapshot() *Snapshot {
 	return &Snapshot{
-		BuiltAt:               time.Now().UTC(),
+		BuiltAt:      time.Now().UTC(),
+		RBACIndex:    newRBACIndex(),
+		RuntimeIndex: newRuntimeIndex(),
+	}
+}
+
+func newRBACIndex() RBACIndex {
+	return RBACIndex{
 		RolesByID:             make(map[RoleID]*RoleRecord),
 		BindingsByRoleRef:     make(map[RoleRefKey][]*BindingRecord),
+		BindingsBySubject:     make(map[SubjectKey][]*BindingRecord),
 		AggregatedRoleSources: make(map[RoleID][]RoleID),
-		PodsByServiceAccount:  make(map[ServiceAccountKey][]*PodRecord),
-		WorkloadsByUID:        make(map[types.UID]*WorkloadRecord),
+		AggregationMatches:    make(map[RoleID][]AggregationMatch),
+		ServiceAccounts:       make(map[ServiceAccountKey]*ServiceAccountRecord),
+		Namespaces:            make(map[string]*NamespaceRecord),
 		RoleIDsByVerb:         make(map[string]map[RoleID]struct{}),
 		RoleIDsByResource:     make(map[string]map[RoleID]struct{}),
 		RoleIDsByAPIGroup:     make(map[string]map[RoleID]struct{}),
@@ -29,6 +38,79 @@ func newEmptySnapshot() *Snapshot {
 	}
 }
 
+func newRuntimeIndex() RuntimeIndex {
+	return RuntimeIndex{
+		PodsByServiceAccount: make(map[ServiceAccountKey][]*PodRecord),
+		WorkloadsByUID:       make(map[types.UID]*WorkloadRecord),
+	}
+}
+
+// BuildSnapshot lists every object from src and indexes it into a new
+// snapshot. List failures are recorded as snapshot warnings. The caller
+// assigns the generation.
+func BuildSnapshot(src Source) *Snapshot {
+	next := newEmptySnapshot()
+	buildRBACIndex(next, src)
+	buildRuntimeIndex(next, src)
+
+	return next
+}
+
+// buildRBACIndex lists roles, bindings and service accounts from src into
+// next.RBACIndex. List failures are appended to next.Warnings.
+func buildRBACIndex(next *Snapshot, src Source) {
+	roles := listWithWarning(src.Roles, "roles", &next.Warnings)
+	clusterRoles := listWithWarning(src.ClusterRoles, "clusterroles", &next.Warnings)
+	roleBindings := listWithWarning(src.RoleBindings, "rolebindings", &next.Warnings)
+	clusterRoleBindings := listWithWarning(src.ClusterRoleBindings, "clusterrolebindings", &next.Warnings)
+	serviceAccounts := listWithWarning(src.ServiceAccounts, "serviceaccounts", &next.Warnings)
+	namespaces := listWithWarning(src.Namespaces, "namespaces", &next.Warnings)
+
+	indexRoles(next, roles)
+	indexClusterRoles(next, clusterRoles)
+	indexAggregatedClusterRoles(next, clusterRoles)
+	indexRoleBindings(next, roleBindings)
+	indexClusterRoleBindings(next, clusterRoleBindings)
+	indexServiceAccounts(next, serviceAccounts)
+	indexNamespaces(next, namespaces)
+
+	sortRBACIndex(&next.RBACIndex)
+}
+
+// buildRuntimeIndex lists pods and workloads from src into
+// next.RuntimeIndex. List failures are appended to next.Warnings.
+func buildRuntimeIndex(next *Snapshot, src Source) {
+	pods := listWithWarning(src.Pods, "pods", &next.Warnings)
+	deployments := listWithWarning(src.Deployments, "deployments", &next.Warnings)
+	replicaSets := listWithWarning(src.ReplicaSets, "replicasets", &next.Warnings)
+	statefulSets := listWithWarning(src.StatefulSets, "statefulsets", &next.Warnings)
+	daemonSets := listWithWarning(src.DaemonSets, "daemonsets", &next.Warnings)
+	jobs := listWithWarning(src.Jobs, "jobs", &next.Warnings)
+	cronJobs := listWithWarning(src.CronJobs, "cronjobs", &next.Warnings)
+
+	indexPods(next, pods)
+	for _, deployment := range deployments {
+		indexWorkload(next, "apps/v1", "Deployment", deployment.ObjectMeta)
+	}
+	for _, replicaSet := range replicaSets {
+		indexWorkload(next, "apps/v1", "ReplicaSet", replicaSet.ObjectMeta)
+	}
+	for _, statefulSet := range statefulSets {
+		indexWorkload(next, "apps/v1", "StatefulSet", statefulSet.ObjectMeta)
+	}
+	for _, daemonSet := range daemonSets {
+		indexWorkload(next, "apps/v1", "DaemonSet", daemonSet.ObjectMeta)
+	}
+	for _, job := range jobs {
+		indexWorkload(next, "batch/v1", "Job", job.ObjectMeta)
+	}
+	for _, cronJob := range cronJobs {
+		indexWorkload(next, "batch/v1", "CronJob", cronJob.ObjectMeta)
+	}
+
+	sortRuntimeIndex(&next.RuntimeIndex)
+}
+
 func listWithWarning[T any](
 	listFn func(labels.Selector) ([]*T, error),
 	resourceName string,
@@ -76,18 +158,19 @@ func indexWorkload(snapshot *Snapshot, apiVersion, kind string, meta metav1.Obje
 	}
 }
 
-func indexRoleRecord(next *Snapshot, uid types.UID, kind, namespace, name string,
+func indexRoleRecord(next *Snapshot, uid types.UID, resourceVersion, kind, namespace, name string,
 	lbls, annotations map[string]string, rules []rbacv1.PolicyRule,
 ) {
 	rec := &RoleRecord{
-		UID:         uid,
-		Kind:        kind,
-		Namespace:   namespace,
-		Name:        name,
-		Labels:      cloneMap(lbls),
-		Annotations: cloneMap(annotations),
-		Rules:       cloneSlice(rules),
-		RuleCount:   len(rules),
+		UID:             uid,
+		ResourceVersion: resourceVersion,
+		Kind:            kind,
+		Namespace:       namespace,
+		Name:            name,
+		Labels:          cloneMap(lbls),
+		Annotations:     cloneMap(annotations),
+		Rules:           cloneSlice(rules),
+		RuleCount:       len(rules),
 	}
 	id := RecID(kind, namespace, name)
 	next.RolesByID[id] = rec
@@ -97,14 +180,14 @@ func indexRoleRecord(next *Snapshot, uid types.UID, kind, namespace, name string
 
 func indexRoles(next *Snapshot, roles []*rbacv1.Role) {
 	for _, role := range roles {
-		indexRoleRecord(next, role.UID, KindRole, role.Namespace, role.Name,
+		indexRoleRecord(next, role.UID, role.ResourceVersion, KindRole, role.Namespace, role.Name,
 			role.Labels, role.Annotations, role.Rules)
 	}
 }
 
 func indexClusterRoles(next *Snapshot, clusterRoles []*rbacv1.ClusterRole) {
 	for _, role := range clusterRoles {
-		indexRoleRecord(next, role.UID, KindClusterRole, "", role.Name,
+		indexRoleRecord(next, role.UID, role.ResourceVersion, KindClusterRole, "", role.Name,
 			role.Labels, role.Annotations, role.Rules)
 		if role.AggregationRule != nil && len(role.Rules) == 0 {
 			next.KnownGaps = append(next.KnownGaps, fmt.Sprintf("clusterrole/%s has aggregationRule but resolved rules are empty", role.Name))
@@ -136,8 +219,9 @@ func indexAggregatedClusterRoles(snapshot *Snapshot, clusterRoles []*rbacv1.Clus
 		}
 		targetID := RecID(KindClusterRole, "", target.Name)
 		sourceSet := make(map[RoleID]struct{})
+		var matches []AggregationMatch
 
-		for _, selector := range target.AggregationRule.ClusterRoleSelectors {
+		for selectorIndex, selector := range target.AggregationRule.ClusterRoleSelectors {
 			candidates, err := matchAggregationSelector(selector, labelIndex, clusterRoles)
 			if err != nil {
 				snapshot.Warnings = append(snapshot.Warnings, fmt.Sprintf("clusterrole/%s has invalid aggregation selector: %v", target.Name, err))
@@ -148,7 +232,14 @@ func indexAggregatedClusterRoles(snapshot *Snapshot, clusterRoles []*rbacv1.Clus
 				if c.Name == target.Name {
 					continue
 				}
-				sourceSet[RecID(KindClusterRole, "", c.Name)] = struct{}{}
+				sourceID := RecID(KindClusterRole, "", c.Name)
+				sourceSet[sourceID] = struct{}{}
+				matches = append(matches, AggregationMatch{
+					Source:        sourceID,
+					SelectorIndex: selectorIndex,
+					Selector:      metav1.FormatLabelSelector(&selector),
+					MatchedLabels: selectedLabels(selector, c.Labels),
+				})
 			}
 		}
 
@@ -161,7 +252,87 @@ func indexAggregatedClusterRoles(snapshot *Snapshot, clusterRoles []*rbacv1.Clus
 		}
 		slices.Sort(sources)
 		snapshot.AggregatedRoleSources[targetID] = sources
+		sortAggregationMatches(matches)
+		snapshot.AggregationMatches[targetID] = matches
 	}
+
+	for _, cycle := range aggregationCycles(snapshot.AggregatedRoleSources) {
+		names := make([]string, len(cycle))
+		for i, id := range cycle {
+			names[i] = strings.TrimPrefix(string(id), strings.ToLower(KindClusterRole)+":")
+		}
+		snapshot.Warnings = append(snapshot.Warnings, "clusterrole aggregation cycle: "+strings.Join(names, " -> "))
+	}
+}
+
+// selectedLabels returns the labels of a source that selector constrains.
+func selectedLabels(selector metav1.LabelSelector, lbls map[string]string) map[string]string {
+	out := make(map[string]string)
+	for key := range selector.MatchLabels {
+		if value, ok := lbls[key]; ok {
+			out[key] = value
+		}
+	}
+	for _, expr := range selector.MatchExpressions {
+		if value, ok := lbls[expr.Key]; ok {
+			out[expr.Key] = value
+		}
+	}
+	if len(out) == 0 {
+		return nil
+	}
+
+	return out
+}
+
+func sortAggregationMatches(matches []AggregationMatch) {
+	slices.SortFunc(matches, func(a, b AggregationMatch) int {
+		return cmp.Or(cmp.Compare(a.Source, b.Source), cmp.Compare(a.SelectorIndex, b.SelectorIndex))
+	})
+}
+
+// aggregationCycles returns the cycles of the aggregation graph, each as the
+// chain of roles that starts and ends with the same role. Every back edge
+// found by a depth-first walk yields one cycle.
+func aggregationCycles(sources map[RoleID][]RoleID) [][]RoleID {
+	const (
+		unvisited = iota
+		onStack
+		done
+	)
+	state := make(map[RoleID]int, len(sources))
+	var stack []RoleID
+	var cycles [][]RoleID
+	var visit func(id RoleID)
+	visit = func(id RoleID) {
+		state[id] = onStack
+		stack = append(stack, id)
+		for _, source := range sources[id] {
+			switch state[source] {
+			case onStack:
+				start := slices.Index(stack, source)
+				cycle := append(slices.Clone(stack[start:]), source)
+				cycles = append(cycles, cycle)
+			case unvisited:
+				visit(source)
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[id] = done
+	}
+
+	targets := make([]RoleID, 0, len(sources))
+	for target := range sources {
+		targets = append(targets, target)
+	}
+	slices.Sort(targets)
+	for _, target := range targets {
+		if state[target] == unvisited {
+			visit(target)
+		}
+	}
+
+	return cycles
 }
 
 // matchAggregationSelector returns ClusterRoles matching a single aggregation selector.
@@ -222,34 +393,58 @@ func intersectByName(base, filter []*rbacv1.ClusterRole) []*rbacv1.ClusterRole {
 }
 
 func indexBindingRecord(next *Snapshot, uid types.UID, kind, namespace, name string,
-	roleRef rbacv1.RoleRef, subjects []rbacv1.Subject,
+	lbls map[string]string, roleRef rbacv1.RoleRef, subjects []rbacv1.Subject,
 ) {
-	key := RoleRefKey{Kind: roleRef.Kind, Namespace: "", Name: roleRef.Name}
-	if namespace != "" && strings.EqualFold(roleRef.Kind, KindRole) {
-		key.Namespace = namespace
-	}
+	key := bindingRoleRefKey(namespace, roleRef)
 	bindRec := &BindingRecord{
 		UID:       uid,
 		Kind:      kind,
 		Namespace: namespace,
 		Name:      name,
+		Labels:    cloneMap(lbls),
 		RoleRef:   key,
 		Subjects:  append([]rbacv1.Subject(nil), subjects...),
 	}
 	next.BindingsByRoleRef[key] = append(next.BindingsByRoleRef[key], bindRec)
+	indexBindingSubjects(next.BindingsBySubject, bindRec)
+}
+
+// bindingRoleRefKey returns the key of the role a binding in namespace
+// refers to. Only Role references are namespaced.
+func bindingRoleRefKey(namespace string, roleRef rbacv1.RoleRef) RoleRefKey {
+	key := RoleRefKey{Kind: roleRef.Kind, Namespace: "", Name: roleRef.Name}
+	if namespace != "" && strings.EqualFold(roleRef.Kind, KindRole) {
+		key.Namespace = namespace
+	}
+
+	return key
+}
+
+// indexBindingSubjects adds the binding to the reverse subject index once per
+// distinct subject, so duplicate subject entries do not produce duplicate bindings.
+func indexBindingSubjects(idx map[SubjectKey][]*BindingRecord, binding *BindingRecord) {
+	seen := make(map[SubjectKey]struct{}, len(binding.Subjects))
+	for _, subject := range binding.Subjects {
+		key := NewSubjectKey(subject, binding.Namespace)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		idx[key] = append(idx[key], binding)
+	}
 }
 
 func indexRoleBindings(next *Snapshot, roleBindings []*rbacv1.RoleBinding) {
 	for _, binding := range roleBindings {
 		indexBindingRecord(next, binding.UID, KindRoleBinding, binding.Namespace, binding.Name,
-			binding.RoleRef, binding.Subjects)
+			binding.Labels, binding.RoleRef, binding.Subjects)
 	}
 }
 
 func indexClusterRoleBindings(next *Snapshot, clusterRoleBindings []*rbacv1.ClusterRoleBinding) {
 	for _, binding := range clusterRoleBindings {
 		indexBindingRecord(next, binding.UID, KindClusterRoleBinding, "", binding.Name,
-			binding.RoleRef, binding.Subjects)
+			binding.Labels, binding.RoleRef, binding.Subjects)
 	}
 }
 
@@ -258,31 +453,98 @@ func indexPods(next *Snapshot, pods []*corev1.Pod) {
 		sa := normalizeServiceAccountName(pod.Spec.ServiceAccountName)
 		key := serviceAccountKey(pod.Namespace, sa)
 		next.PodsByServiceAccount[key] = append(next.PodsByServiceAccount[key], &PodRecord{
-			UID:                pod.UID,
-			Namespace:          pod.Namespace,
-			Name:               pod.Name,
-			ServiceAccountName: sa,
-			Phase:              pod.Status.Phase,
-			OwnerReferences:    cloneSlice(pod.OwnerReferences),
+			UID:                          pod.UID,
+			Namespace:                    pod.Namespace,
+			Name:                         pod.Name,
+			ServiceAccountName:           sa,
+			Phase:                        pod.Status.Phase,
+			OwnerReferences:              cloneSlice(pod.OwnerReferences),
+			AutomountServiceAccountToken: clonePtr(pod.Spec.AutomountServiceAccountToken),
+			ProjectedTokens:              projectedTokens(&pod.Spec),
 		})
 	}
 }
 
-func sortSnapshot(next *Snapshot) {
-	for key := range next.PodsByServiceAccount {
-		sort.Slice(next.PodsByServiceAccount[key], func(i, j int) bool {
-			left := next.PodsByServiceAccount[key][i]
-			right := next.PodsByServiceAccount[key][j]
-			if left.Namespace != right.Namespace {
-				return left.Namespace < right.Namespace
+// projectedTokens returns the serviceAccountToken sources of projected
+// volumes that a container or init container mounts.
+func projectedTokens(spec *corev1.PodSpec) []ProjectedToken {
+	var out []ProjectedToken
+	for i := range spec.Volumes {
+		volume := &spec.Volumes[i]
+		if volume.Projected == nil || !mountsVolume(spec, volume.Name) {
+			continue
+		}
+		for _, source := range volume.Projected.Sources {
+			if source.ServiceAccountToken == nil {
+				continue
 			}
-			if left.Name != right.Name {
-				return left.Name < right.Name
+			token := ProjectedToken{Volume: volume.Name, Audience: source.ServiceAccountToken.Audience}
+			if source.ServiceAccountToken.ExpirationSeconds != nil {
+				token.ExpirationSeconds = *source.ServiceAccountToken.ExpirationSeconds
 			}
+			out = append(out, token)
+		}
+	}
 
-			return string(left.UID) < string(right.UID)
-		})
+	return out
+}
+
+func mountsVolume(spec *corev1.PodSpec, volume string) bool {
+	for _, containers := range [][]corev1.Container{spec.InitContainers, spec.Containers} {
+		for i := range containers {
+			for _, mount := range containers[i].VolumeMounts {
+				if mount.Name == volume {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+func indexServiceAccounts(next *Snapshot, serviceAccounts []*corev1.ServiceAccount) {
+	for _, sa := range serviceAccounts {
+		var pullSecrets []string
+		for _, ref := range sa.ImagePullSecrets {
+			pullSecrets = append(pullSecrets, ref.Name)
+		}
+		next.ServiceAccounts[serviceAccountKey(sa.Namespace, sa.Name)] = &ServiceAccountRecord{
+			UID:                          sa.UID,
+			Namespace:                    sa.Namespace,
+			Name:                         sa.Name,
+			Annotations:                  cloneMap(sa.Annotations),
+			AutomountServiceAccountToken: clonePtr(sa.AutomountServiceAccountToken),
+			ImagePullSecrets:             pullSecrets,
+		}
+	}
+}
+
+func indexNamespaces(next *Snapshot, namespaces []*corev1.Namespace) {
+	for _, ns := range namespaces {
+		next.Namespaces[ns.Name] = &NamespaceRecord{
+			UID:    ns.UID,
+			Name:   ns.Name,
+			Labels: cloneMap(ns.Labels),
+			Phase:  ns.Status.Phase,
+		}
 	}
+}
 
-	slices.Sort(next.AllRoleIDs)
+func sortSnapshot(next *Snapshot) {
+	sortRBACIndex(&next.RBACIndex)
+	sortRuntimeIndex(&next.RuntimeIndex)
+}
+
+func sortRBACIndex(idx *RBACIndex) {
+	for key := range idx.BindingsBySubject {
+		sortBindings(idx.BindingsBySubject[key])
+	}
+	slices.Sort(idx.AllRoleIDs)
+}
+
+func sortRuntimeIndex(idx *RuntimeIndex) {
+	for key := range idx.PodsByServiceAccount {
+		sortPods(idx.PodsByServiceAccount[key])
+	}
 }
@@ -0,0 +1,358 @@
+package indexer
+
+import (
+	"maps"
+	"slices"
+	"sort"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+)
+
+// delta is a single informer event queued for incremental application.
+// Updates are queued as a removal of the old object followed by an upsert
+// of the new one, so applying a delta twice is harmless.
+type delta struct {
+	remove bool
+	obj    any
+}
+
+// applyDeltas returns a copy of base with the queued objects added or
+// removed. Maps and index buckets that are not touched are shared with
+// base, which is never modified. ok is false when a delta cannot be applied
+// incrementally, such as a ClusterRole change that may alter aggregation,
+// and the caller must rebuild from the source instead.
+func applyDeltas(base *Snapshot, deltas []delta) (next *Snapshot, ok bool) {
+	w := newDeltaWriter(base)
+	for _, d := range deltas {
+		if !w.apply(d) {
+			return nil, false
+		}
+	}
+
+	return w.finish(), true
+}
+
+// deltaWriter copies each snapshot map, index bucket or slice the first
+// time a delta modifies it.
+type deltaWriter struct {
+	s *Snapshot
+
+	rolesCloned        bool
+	tokenBuckets       map[*map[string]map[RoleID]struct{}]map[string]bool
+	roleRefsCloned     map[RoleRefKey]bool
+	subjectsCloned     map[SubjectKey]bool
+	podsCloned         map[ServiceAccountKey]bool
+	serviceAccountsCow bool
+	namespacesCow      bool
+	workloadsCow       bool
+}
+
+func newDeltaWriter(base *Snapshot) *deltaWriter {
+	next := *base
+	next.BuiltAt = time.Now().UTC()
+
+	return &deltaWriter{
+		s:              &next,
+		tokenBuckets:   make(map[*map[string]map[RoleID]struct{}]map[string]bool),
+		roleRefsCloned: make(map[RoleRefKey]bool),
+		subjectsCloned: make(map[SubjectKey]bool),
+		podsCloned:     make(map[ServiceAccountKey]bool),
+	}
+}
+
+//nolint:gocyclo // one case per indexed kind
+func (w *deltaWriter) apply(d delta) bool {
+	obj := d.obj
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	switch o := obj.(type) {
+	case *rbacv1.ClusterRole:
+		return false
+	case *rbacv1.Role:
+		w.removeRole(RecID(KindRole, o.Namespace, o.Name))
+		if !d.remove {
+			w.cloneRoles()
+			w.forEachToken(o.Rules, func(idx *map[string]map[RoleID]struct{}, token string) {
+				w.ownBucket(idx, token)
+			})
+			indexRoles(w.s, []*rbacv1.Role{o})
+		}
+	case *rbacv1.RoleBinding:
+		w.removeBinding(KindRoleBinding, o.Namespace, o.Name, o.RoleRef)
+		if !d.remove {
+			w.addBinding(o.UID, KindRoleBinding, o.Namespace, o.Name, o.Labels, o.RoleRef, o.Subjects)
+		}
+	case *rbacv1.ClusterRoleBinding:
+		w.removeBinding(KindClusterRoleBinding, "", o.Name, o.RoleRef)
+		if !d.remove {
+			w.addBinding(o.UID, KindClusterRoleBinding, "", o.Name, o.Labels, o.RoleRef, o.Subjects)
+		}
+	case *corev1.Pod:
+		w.applyPod(o, d.remove)
+	case *corev1.ServiceAccount:
+		w.cloneServiceAccounts()
+		delete(w.s.ServiceAccounts, serviceAccountKey(o.Namespace, o.Name))
+		if !d.remove {
+			indexServiceAccounts(w.s, []*corev1.ServiceAccount{o})
+		}
+	case *corev1.Namespace:
+		w.cloneNamespaces()
+		delete(w.s.Namespaces, o.Name)
+		if !d.remove {
+			indexNamespaces(w.s, []*corev1.Namespace{o})
+		}
+	case *appsv1.Deployment:
+		w.applyWorkload(o.UID, d.remove, func() { indexWorkload(w.s, "apps/v1", "Deployment", o.ObjectMeta) })
+	case *appsv1.ReplicaSet:
+		w.applyWorkload(o.UID, d.remove, func() { indexWorkload(w.s, "apps/v1", "ReplicaSet", o.ObjectMeta) })
+	case *appsv1.StatefulSet:
+		w.applyWorkload(o.UID, d.remove, func() { indexWorkload(w.s, "apps/v1", "StatefulSet", o.ObjectMeta) })
+	case *appsv1.DaemonSet:
+		w.applyWorkload(o.UID, d.remove, func() { indexWorkload(w.s, "apps/v1", "DaemonSet", o.ObjectMeta) })
+	case *batchv1.Job:
+		w.applyWorkload(o.UID, d.remove, func() { indexWorkload(w.s, "batch/v1", "Job", o.ObjectMeta) })
+	case *batchv1.CronJob:
+		w.applyWorkload(o.UID, d.remove, func() { indexWorkload(w.s, "batch/v1", "CronJob", o.ObjectMeta) })
+	case *ownerObject:
+		w.applyWorkload(o.UID, d.remove, func() { indexWorkload(w.s, o.APIVersion, o.Kind, o.ObjectMeta) })
+	default:
+		return false
+	}
+
+	return true
+}
+
+func (w *deltaWriter) finish() *Snapshot {
+	for key := range w.subjectsCloned {
+		if len(w.s.BindingsBySubject[key]) == 0 {
+			delete(w.s.BindingsBySubject, key)
+
+			continue
+		}
+		sortBindings(w.s.BindingsBySubject[key])
+	}
+	for key := range w.podsCloned {
+		if len(w.s.PodsByServiceAccount[key]) == 0 {
+			delete(w.s.PodsByServiceAccount, key)
+
+			continue
+		}
+		sortPods(w.s.PodsByServiceAccount[key])
+	}
+	if w.rolesCloned {
+		slices.Sort(w.s.AllRoleIDs)
+	}
+
+	return w.s
+}
+
+// cloneRoles copies the role map, the ID list and the token index maps.
+func (w *deltaWriter) cloneRoles() {
+	if w.rolesCloned {
+		return
+	}
+	w.rolesCloned = true
+	w.s.RolesByID = maps.Clone(w.s.RolesByID)
+	w.s.AllRoleIDs = slices.Clone(w.s.AllRoleIDs)
+	// Buckets inside the token indexes are copied by ownBucket.
+	for _, idx := range []*map[string]map[RoleID]struct{}{&w.s.RoleIDsByVerb, &w.s.RoleIDsByResource, &w.s.RoleIDsByAPIGroup} {
+		*idx = maps.Clone(*idx)
+	}
+}
+
+// ownBucket returns the bucket for token in *idx, copying it on first use.
+func (w *deltaWriter) ownBucket(idx *map[string]map[RoleID]struct{}, token string) map[RoleID]struct{} {
+	owned := w.tokenBuckets[idx]
+	if owned == nil {
+		owned = make(map[string]bool)
+		w.tokenBuckets[idx] = owned
+	}
+	bucket := (*idx)[token]
+	if !owned[token] {
+		bucket = maps.Clone(bucket)
+		if bucket == nil {
+			bucket = make(map[RoleID]struct{})
+		}
+		(*idx)[token] = bucket
+		owned[token] = true
+	}
+
+	return bucket
+}
+
+func (w *deltaWriter) removeRole(id RoleID) {
+	rec, ok := w.s.RolesByID[id]
+	if !ok {
+		return
+	}
+	w.cloneRoles()
+	delete(w.s.RolesByID, id)
+	w.s.AllRoleIDs = slices.DeleteFunc(w.s.AllRoleIDs, func(existing RoleID) bool { return existing == id })
+	w.forEachToken(rec.Rules, func(idx *map[string]map[RoleID]struct{}, token string) {
+		bucket := w.ownBucket(idx, token)
+		delete(bucket, id)
+		if len(bucket) == 0 {
+			delete(*idx, token)
+		}
+	})
+}
+
+// forEachToken calls fn for every index token of rules, the same tokens
+// indexRoleTokens inserts.
+func (w *deltaWriter) forEachToken(rules []rbacv1.PolicyRule, fn func(idx *map[string]map[RoleID]struct{}, token string)) {
+	for _, rule := range rules {
+		for _, group := range normalizedSlice(rule.APIGroups) {
+			fn(&w.s.RoleIDsByAPIGroup, group)
+		}
+		for _, resource := range normalizedSlice(rule.Resources) {
+			if resource != "" {
+				fn(&w.s.RoleIDsByResource, resource)
+			}
+		}
+		for _, verb := range normalizedSlice(rule.Verbs) {
+			if verb != "" {
+				fn(&w.s.RoleIDsByVerb, verb)
+			}
+		}
+	}
+}
+
+// removeBinding removes a binding by identity. roleRef is immutable, so the
+// binding can only be indexed under the key derived from it.
+func (w *deltaWriter) removeBinding(kind, namespace, name string, roleRef rbacv1.RoleRef) {
+	matches := func(b *BindingRecord) bool {
+		return b.Kind == kind && b.Namespace == namespace && b.Name == name
+	}
+	key := bindingRoleRefKey(namespace, roleRef)
+	idx := slices.IndexFunc(w.s.BindingsByRoleRef[key], matches)
+	if idx < 0 {
+		return
+	}
+	removed := w.s.BindingsByRoleRef[key][idx]
+	w.ownRoleRef(key)
+	w.s.BindingsByRoleRef[key] = slices.DeleteFunc(w.s.BindingsByRoleRef[key], matches)
+	if len(w.s.BindingsByRoleRef[key]) == 0 {
+		delete(w.s.BindingsByRoleRef, key)
+	}
+	for _, subject := range removed.Subjects {
+		subjectKey := NewSubjectKey(subject, removed.Namespace)
+		w.ownSubject(subjectKey)
+		w.s.BindingsBySubject[subjectKey] = slices.DeleteFunc(w.s.BindingsBySubject[subjectKey], matches)
+	}
+}
+
+func (w *deltaWriter) addBinding(uid types.UID, kind, namespace, name string,
+	lbls map[string]string, roleRef rbacv1.RoleRef, subjects []rbacv1.Subject,
+) {
+	key := bindingRoleRefKey(namespace, roleRef)
+	w.ownRoleRef(key)
+	for _, subject := range subjects {
+		w.ownSubject(NewSubjectKey(subject, namespace))
+	}
+	indexBindingRecord(w.s, uid, kind, namespace, name, lbls, roleRef, subjects)
+}
+
+// ownRoleRef copies the BindingsByRoleRef map and the slice under key on
+// first use.
+func (w *deltaWriter) ownRoleRef(key RoleRefKey) {
+	if len(w.roleRefsCloned) == 0 {
+		w.s.BindingsByRoleRef = maps.Clone(w.s.BindingsByRoleRef)
+	}
+	if !w.roleRefsCloned[key] {
+		w.s.BindingsByRoleRef[key] = slices.Clone(w.s.BindingsByRoleRef[key])
+		w.roleRefsCloned[key] = true
+	}
+}
+
+func (w *deltaWriter) ownSubject(key SubjectKey) {
+	if len(w.subjectsCloned) == 0 {
+		w.s.BindingsBySubject = maps.Clone(w.s.BindingsBySubject)
+	}
+	if !w.subjectsCloned[key] {
+		w.s.BindingsBySubject[key] = slices.Clone(w.s.BindingsBySubject[key])
+		w.subjectsCloned[key] = true
+	}
+}
+
+// applyPod replaces or removes a pod by UID. The service account of a pod
+// is immutable, so the pod can only be indexed under the key derived from it.
+func (w *deltaWriter) applyPod(pod *corev1.Pod, remove bool) {
+	key := serviceAccountKey(pod.Namespace, normalizeServiceAccountName(pod.Spec.ServiceAccountName))
+	w.ownPods(key)
+	w.s.PodsByServiceAccount[key] = slices.DeleteFunc(w.s.PodsByServiceAccount[key], func(p *PodRecord) bool {
+		return p.UID == pod.UID
+	})
+	if !remove {
+		indexPods(w.s, []*corev1.Pod{pod})
+	}
+}
+
+func (w *deltaWriter) ownPods(key ServiceAccountKey) {
+	if len(w.podsCloned) == 0 {
+		w.s.PodsByServiceAccount = maps.Clone(w.s.PodsByServiceAccount)
+	}
+	if !w.podsCloned[key] {
+		w.s.PodsByServiceAccount[key] = slices.Clone(w.s.PodsByServiceAccount[key])
+		w.podsCloned[key] = true
+	}
+}
+
+func (w *deltaWriter) cloneNamespaces() {
+	if !w.namespacesCow {
+		w.s.Namespaces = maps.Clone(w.s.Namespaces)
+		w.namespacesCow = true
+	}
+}
+
+func (w *deltaWriter) cloneServiceAccounts() {
+	if !w.serviceAccountsCow {
+		w.s.ServiceAccounts = maps.Clone(w.s.ServiceAccounts)
+		w.serviceAccountsCow = true
+	}
+}
+
+func (w *deltaWriter) applyWorkload(uid types.UID, remove bool, index func()) {
+	if !w.workloadsCow {
+		w.s.WorkloadsByUID = maps.Clone(w.s.WorkloadsByUID)
+		w.workloadsCow = true
+	}
+	delete(w.s.WorkloadsByUID, uid)
+	if !remove {
+		index()
+	}
+}
+
+func sortPods(pods []*PodRecord) {
+	sort.Slice(pods, func(i, j int) bool {
+		left, right := pods[i], pods[j]
+		if left.Namespace != right.Namespace {
+			return left.Namespace < right.Namespace
+		}
+		if left.Name != right.Name {
+			return left.Name < right.Name
+		}
+
+		return string(left.UID) < string(right.UID)
+	})
+}
+
+func sortBindings(bindings []*BindingRecord) {
+	sort.Slice(bindings, func(i, j int) bool {
+		left, right := bindings[i], bindings[j]
+		if left.Kind != right.Kind {
+			return left.Kind < right.Kind
+		}
+		if left.Namespace != right.Namespace {
+			return left.Namespace < right.Namespace
+		}
+
+		return left.Name < right.Name
+	})
+}
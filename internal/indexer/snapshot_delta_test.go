@@ -0,0 +1,208 @@
+package indexer
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/component-base/metrics/testutil"
+)
+
+func deltaTestRole(name string, verbs ...string) *rbacv1.Role {
+	return &rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: name, UID: types.UID("uid-" + name)},
+		Rules:      []rbacv1.PolicyRule{{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: verbs}},
+	}
+}
+
+func deltaTestBinding(name, role string, subjects ...string) *rbacv1.RoleBinding {
+	binding := &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: name, UID: types.UID("uid-" + name)},
+		RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: KindRole, Name: role},
+	}
+	for _, subject := range subjects {
+		binding.Subjects = append(binding.Subjects, rbacv1.Subject{Kind: SubjectKindServiceAccount, Name: subject})
+	}
+
+	return binding
+}
+
+func deltaTestPod(name, serviceAccount string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: name, UID: types.UID("uid-" + name)},
+		Spec:       corev1.PodSpec{ServiceAccountName: serviceAccount},
+	}
+}
+
+func deltaTestSnapshot() *Snapshot {
+	s := newEmptySnapshot()
+	indexRoles(s, []*rbacv1.Role{deltaTestRole("reader", "get", "list"), deltaTestRole("writer", "create")})
+	indexRoleBindings(s, []*rbacv1.RoleBinding{
+		deltaTestBinding("read", "reader", "app", "worker"),
+		deltaTestBinding("write", "writer", "app"),
+	})
+	indexPods(s, []*corev1.Pod{deltaTestPod("app-1", "app"), deltaTestPod("worker-1", "worker")})
+	sortSnapshot(s)
+
+	return s
+}
+
+// sameSnapshot compares snapshot contents, ignoring build metadata.
+func sameSnapshot(a, b *Snapshot) bool {
+	left, right := *a, *b
+	left.BuiltAt, right.BuiltAt = time.Time{}, time.Time{}
+	left.Generation, right.Generation = 0, 0
+	left.RBACGeneration, right.RBACGeneration = 0, 0
+
+	return reflect.DeepEqual(left, right)
+}
+
+func TestApplyDeltas_MatchesFullBuild(t *testing.T) {
+	base := deltaTestSnapshot()
+
+	next, ok := applyDeltas(base, []delta{
+		{remove: true, obj: deltaTestRole("reader", "get", "list")},
+		{obj: deltaTestRole("reader", "get", "watch")},
+		{remove: true, obj: deltaTestBinding("write", "writer", "app")},
+		{remove: true, obj: deltaTestBinding("read", "reader", "app", "worker")},
+		{obj: deltaTestBinding("read", "reader", "worker")},
+		{remove: true, obj: deltaTestPod("worker-1", "worker")},
+		{obj: deltaTestPod("app-2", "app")},
+	})
+	if !ok {
+		t.Fatal("expected deltas to apply incrementally")
+	}
+
+	want := newEmptySnapshot()
+	indexRoles(want, []*rbacv1.Role{deltaTestRole("reader", "get", "watch"), deltaTestRole("writer", "create")})
+	indexRoleBindings(want, []*rbacv1.RoleBinding{deltaTestBinding("read", "reader", "worker")})
+	indexPods(want, []*corev1.Pod{deltaTestPod("app-1", "app"), deltaTestPod("app-2", "app")})
+	sortSnapshot(want)
+	if !sameSnapshot(next, want) {
+		t.Fatalf("delta snapshot differs from full build:\ngot  %#v\nwant %#v", next, want)
+	}
+}
+
+func TestApplyDeltas_DoesNotModifyBase(t *testing.T) {
+	base := deltaTestSnapshot()
+
+	_, ok := applyDeltas(base, []delta{
+		{obj: deltaTestRole("reader", "delete")},
+		{obj: deltaTestRole("admin", "*")},
+		{remove: true, obj: deltaTestRole("writer", "create")},
+		{obj: deltaTestBinding("admin", "admin", "app")},
+		{remove: true, obj: deltaTestBinding("read", "reader", "app", "worker")},
+		{obj: deltaTestPod("app-2", "app")},
+		{remove: true, obj: deltaTestPod("worker-1", "worker")},
+		{obj: &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "app"}}},
+		{obj: &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}}},
+		{obj: &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "app", UID: "uid-deploy"}}},
+	})
+	if !ok {
+		t.Fatal("expected deltas to apply incrementally")
+	}
+
+	if !sameSnapshot(base, deltaTestSnapshot()) {
+		t.Fatal("expected base snapshot to be unchanged")
+	}
+}
+
+func TestApplyDeltas_Namespace(t *testing.T) {
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a", UID: "uid-ns", Labels: map[string]string{"team": "a"}}}
+	relabelled := ns.DeepCopy()
+	relabelled.Labels = map[string]string{"team": "payments"}
+
+	next, ok := applyDeltas(deltaTestSnapshot(), []delta{{obj: ns}, {remove: true, obj: ns}, {obj: relabelled}})
+	if !ok {
+		t.Fatal("expected namespace deltas to apply incrementally")
+	}
+	if got := next.Namespaces["team-a"]; got == nil || got.Labels["team"] != "payments" {
+		t.Fatalf("expected relabelled namespace record, got %#v", got)
+	}
+
+	next, ok = applyDeltas(next, []delta{{remove: true, obj: relabelled}})
+	if !ok {
+		t.Fatal("expected namespace deltas to apply incrementally")
+	}
+	if _, found := next.Namespaces["team-a"]; found {
+		t.Fatal("expected namespace record to be removed")
+	}
+}
+
+func TestApplyDeltas_ClusterRoleRequiresRebuild(t *testing.T) {
+	_, ok := applyDeltas(deltaTestSnapshot(), []delta{
+		{obj: deltaTestPod("app-2", "app")},
+		{obj: &rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "view"}}},
+	})
+	if ok {
+		t.Fatal("expected a ClusterRole delta to require a full rebuild")
+	}
+}
+
+func TestIndexer_AppliesInformerEventsAsDeltas(t *testing.T) {
+	RegisterMetrics()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client := fake.NewSimpleClientset(deltaTestRole("reader", "get"), deltaTestBinding("read", "reader", "app"))
+	idx := New(client, 0)
+	go idx.Start(ctx) //nolint:errcheck // stopped by cancel
+	waitFor(t, idx.RuntimeReady)
+
+	fullBefore, err := testutil.GetCounterMetricValue(rebuildsMetric.WithLabelValues(partRuntime, rebuildModeFull))
+	if err != nil {
+		t.Fatalf("read rebuild counter: %v", err)
+	}
+	pods := client.CoreV1().Pods("team-a")
+	if _, err := pods.Create(ctx, deltaTestPod("app-1", "app"), metav1.CreateOptions{}); err != nil {
+		t.Fatalf("create pod: %v", err)
+	}
+	waitFor(t, func() bool {
+		return len(idx.Snapshot().PodsByServiceAccount[serviceAccountKey("team-a", "app")]) == 1
+	})
+
+	if !sameSnapshot(idx.Snapshot(), BuildSnapshot(idx.source)) {
+		t.Fatal("expected delta snapshot to match a full build of the listers")
+	}
+	fullAfter, err := testutil.GetCounterMetricValue(rebuildsMetric.WithLabelValues(partRuntime, rebuildModeFull))
+	if err != nil {
+		t.Fatalf("read rebuild counter: %v", err)
+	}
+	if fullAfter != fullBefore {
+		t.Fatalf("expected no full rebuild for a pod event, got %v", fullAfter-fullBefore)
+	}
+}
+
+func TestIndexer_MaxStalenessBoundsDebounce(t *testing.T) {
+	idx := NewOffline(NewManifestSource(""), nil)
+	idx.SetMaxStaleness(200 * time.Millisecond)
+
+	// Events arrive faster than the debounce interval, so only the staleness
+	// bound can publish a snapshot while they keep coming.
+	deadline := time.Now().Add(3 * rebuildDebounceInterval)
+	for time.Now().Before(deadline) {
+		idx.enqueue(idx.runtime, delta{obj: deltaTestPod("churn", "app")})
+		time.Sleep(rebuildDebounceInterval / 5)
+	}
+
+	if generation := idx.Snapshot().Generation; generation < 2 {
+		t.Fatalf("expected a snapshot published under continuous events, got generation %d", generation)
+	}
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for condition")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,103 @@
+package indexer
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	appslisters "k8s.io/client-go/listers/apps/v1"
+	batchlisters "k8s.io/client-go/listers/batch/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	rbaclisters "k8s.io/client-go/listers/rbac/v1"
+)
+
+// Source supplies the objects a Snapshot is built from. The methods share
+// the signature of the generated listers, so informer listers satisfy it
+// directly; offline sources filter their in-memory objects by selector.
+type Source interface {
+	Roles(selector labels.Selector) ([]*rbacv1.Role, error)
+	ClusterRoles(selector labels.Selector) ([]*rbacv1.ClusterRole, error)
+	RoleBindings(selector labels.Selector) ([]*rbacv1.RoleBinding, error)
+	ClusterRoleBindings(selector labels.Selector) ([]*rbacv1.ClusterRoleBinding, error)
+	Pods(selector labels.Selector) ([]*corev1.Pod, error)
+	ServiceAccounts(selector labels.Selector) ([]*corev1.ServiceAccount, error)
+	Namespaces(selector labels.Selector) ([]*corev1.Namespace, error)
+	Deployments(selector labels.Selector) ([]*appsv1.Deployment, error)
+	ReplicaSets(selector labels.Selector) ([]*appsv1.ReplicaSet, error)
+	StatefulSets(selector labels.Selector) ([]*appsv1.StatefulSet, error)
+	DaemonSets(selector labels.Selector) ([]*appsv1.DaemonSet, error)
+	Jobs(selector labels.Selector) ([]*batchv1.Job, error)
+	CronJobs(selector labels.Selector) ([]*batchv1.CronJob, error)
+}
+
+// listerSource reads objects from informer listers.
+type listerSource struct {
+	roles               rbaclisters.RoleLister
+	clusterRoles        rbaclisters.ClusterRoleLister
+	roleBindings        rbaclisters.RoleBindingLister
+	clusterRoleBindings rbaclisters.ClusterRoleBindingLister
+	pods                corelisters.PodLister
+	serviceAccounts     corelisters.ServiceAccountLister
+	namespaces          corelisters.NamespaceLister
+	deployments         appslisters.DeploymentLister
+	replicaSets         appslisters.ReplicaSetLister
+	statefulSets        appslisters.StatefulSetLister
+	daemonSets          appslisters.DaemonSetLister
+	jobs                batchlisters.JobLister
+	cronJobs            batchlisters.CronJobLister
+}
+
+var _ Source = (*listerSource)(nil)
+
+func (s *listerSource) Roles(sel labels.Selector) ([]*rbacv1.Role, error) {
+	return s.roles.List(sel)
+}
+
+func (s *listerSource) ClusterRoles(sel labels.Selector) ([]*rbacv1.ClusterRole, error) {
+	return s.clusterRoles.List(sel)
+}
+
+func (s *listerSource) RoleBindings(sel labels.Selector) ([]*rbacv1.RoleBinding, error) {
+	return s.roleBindings.List(sel)
+}
+
+func (s *listerSource) ClusterRoleBindings(sel labels.Selector) ([]*rbacv1.ClusterRoleBinding, error) {
+	return s.clusterRoleBindings.List(sel)
+}
+
+func (s *listerSource) Pods(sel labels.Selector) ([]*corev1.Pod, error) {
+	return s.pods.List(sel)
+}
+
+func (s *listerSource) ServiceAccounts(sel labels.Selector) ([]*corev1.ServiceAccount, error) {
+	return s.serviceAccounts.List(sel)
+}
+
+func (s *listerSource) Namespaces(sel labels.Selector) ([]*corev1.Namespace, error) {
+	return s.namespaces.List(sel)
+}
+
+func (s *listerSource) Deployments(sel labels.Selector) ([]*appsv1.Deployment, error) {
+	return s.deployments.List(sel)
+}
+
+func (s *listerSource) ReplicaSets(sel labels.Selector) ([]*appsv1.ReplicaSet, error) {
+	return s.replicaSets.List(sel)
+}
+
+func (s *listerSource) StatefulSets(sel labels.Selector) ([]*appsv1.StatefulSet, error) {
+	return s.statefulSets.List(sel)
+}
+
+func (s *listerSource) DaemonSets(sel labels.Selector) ([]*appsv1.DaemonSet, error) {
+	return s.daemonSets.List(sel)
+}
+
+func (s *listerSource) Jobs(sel labels.Selector) ([]*batchv1.Job, error) {
+	return s.jobs.List(sel)
+}
+
+func (s *listerSource) CronJobs(sel labels.Selector) ([]*batchv1.CronJob, error) {
+	return s.cronJobs.List(sel)
+}
@@ -0,0 +1,146 @@
+package indexer
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Informer transforms strip runtime objects down to the fields the snapshot
+// reads before they are stored in the informer caches. Specs, statuses,
+// managed fields and annotations such as last-applied-configuration
+// dominate the size of pods and workloads on large clusters.
+//
+// Transforms must be idempotent: informers may apply them to objects that
+// were already transformed. Objects of other types are returned unchanged.
+
+// transformPod keeps the metadata, service account, token automount setting
+// and phase of a pod, and its projected ServiceAccount token volumes with the
+// container mounts that reference them.
+func transformPod(obj any) (any, error) {
+	pod, ok := obj.(*corev1.Pod)
+	if !ok {
+		return obj, nil
+	}
+
+	spec := corev1.PodSpec{
+		ServiceAccountName:           pod.Spec.ServiceAccountName,
+		AutomountServiceAccountToken: pod.Spec.AutomountServiceAccountToken,
+	}
+	tokenVolumes := make(map[string]struct{})
+	for _, volume := range pod.Spec.Volumes {
+		if volume.Projected == nil {
+			continue
+		}
+		var sources []corev1.VolumeProjection
+		for _, source := range volume.Projected.Sources {
+			if source.ServiceAccountToken != nil {
+				sources = append(sources, corev1.VolumeProjection{ServiceAccountToken: source.ServiceAccountToken})
+			}
+		}
+		if len(sources) == 0 {
+			continue
+		}
+		tokenVolumes[volume.Name] = struct{}{}
+		spec.Volumes = append(spec.Volumes, corev1.Volume{
+			Name:         volume.Name,
+			VolumeSource: corev1.VolumeSource{Projected: &corev1.ProjectedVolumeSource{Sources: sources}},
+		})
+	}
+	spec.InitContainers = tokenMounts(pod.Spec.InitContainers, tokenVolumes)
+	spec.Containers = tokenMounts(pod.Spec.Containers, tokenVolumes)
+
+	return &corev1.Pod{
+		TypeMeta:   pod.TypeMeta,
+		ObjectMeta: stripObjectMeta(pod.ObjectMeta),
+		Spec:       spec,
+		Status:     corev1.PodStatus{Phase: pod.Status.Phase},
+	}, nil
+}
+
+// tokenMounts keeps the containers that mount one of volumes, with only those
+// mounts.
+func tokenMounts(containers []corev1.Container, volumes map[string]struct{}) []corev1.Container {
+	var out []corev1.Container
+	for i := range containers {
+		var mounts []corev1.VolumeMount
+		for _, mount := range containers[i].VolumeMounts {
+			if _, ok := volumes[mount.Name]; ok {
+				mounts = append(mounts, corev1.VolumeMount{Name: mount.Name})
+			}
+		}
+		if len(mounts) > 0 {
+			out = append(out, corev1.Container{Name: containers[i].Name, VolumeMounts: mounts})
+		}
+	}
+
+	return out
+}
+
+// transformServiceAccount keeps the labels, annotations, token automount
+// setting and image pull secrets of a ServiceAccount. Token secret references
+// and managed fields are dropped.
+func transformServiceAccount(obj any) (any, error) {
+	sa, ok := obj.(*corev1.ServiceAccount)
+	if !ok {
+		return obj, nil
+	}
+	meta := stripObjectMeta(sa.ObjectMeta)
+	meta.Labels = sa.Labels
+	meta.Annotations = sa.Annotations
+
+	return &corev1.ServiceAccount{
+		TypeMeta:                     sa.TypeMeta,
+		ObjectMeta:                   meta,
+		AutomountServiceAccountToken: sa.AutomountServiceAccountToken,
+		ImagePullSecrets:             sa.ImagePullSecrets,
+	}, nil
+}
+
+// transformWorkload keeps the metadata of a workload; owner references are
+// all the runtime chain needs.
+func transformWorkload(obj any) (any, error) {
+	switch o := obj.(type) {
+	case *appsv1.Deployment:
+		return &appsv1.Deployment{TypeMeta: o.TypeMeta, ObjectMeta: stripObjectMeta(o.ObjectMeta)}, nil
+	case *appsv1.ReplicaSet:
+		return &appsv1.ReplicaSet{TypeMeta: o.TypeMeta, ObjectMeta: stripObjectMeta(o.ObjectMeta)}, nil
+	case *appsv1.StatefulSet:
+		return &appsv1.StatefulSet{TypeMeta: o.TypeMeta, ObjectMeta: stripObjectMeta(o.ObjectMeta)}, nil
+	case *appsv1.DaemonSet:
+		return &appsv1.DaemonSet{TypeMeta: o.TypeMeta, ObjectMeta: stripObjectMeta(o.ObjectMeta)}, nil
+	case *batchv1.Job:
+		return &batchv1.Job{TypeMeta: o.TypeMeta, ObjectMeta: stripObjectMeta(o.ObjectMeta)}, nil
+	case *batchv1.CronJob:
+		return &batchv1.CronJob{TypeMeta: o.TypeMeta, ObjectMeta: stripObjectMeta(o.ObjectMeta)}, nil
+	default:
+		return obj, nil
+	}
+}
+
+// transformOwner keeps the metadata of an owner watched through a metadata
+// informer.
+func transformOwner(obj any) (any, error) {
+	partial, ok := obj.(*metav1.PartialObjectMetadata)
+	if !ok {
+		return obj, nil
+	}
+
+	return &metav1.PartialObjectMetadata{TypeMeta: partial.TypeMeta, ObjectMeta: stripObjectMeta(partial.ObjectMeta)}, nil
+}
+
+// stripObjectMeta keeps identity, owner references and the fields informers
+// use to order and compare versions. Labels and annotations are dropped.
+func stripObjectMeta(meta metav1.ObjectMeta) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:              meta.Name,
+		Namespace:         meta.Namespace,
+		UID:               meta.UID,
+		ResourceVersion:   meta.ResourceVersion,
+		Generation:        meta.Generation,
+		CreationTimestamp: meta.CreationTimestamp,
+		DeletionTimestamp: meta.DeletionTimestamp,
+		OwnerReferences:   meta.OwnerReferences,
+	}
+}
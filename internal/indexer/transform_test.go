@@ -0,0 +1,212 @@
+package indexer
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/cache"
+)
+
+// benchmarkPod returns a pod shaped like a typical Deployment replica, with
+// managed fields, two containers, env, probes and container statuses.
+func benchmarkPod(n int) *corev1.Pod {
+	name := fmt.Sprintf("app-%d-7c9f8d6b5-x%04d", n/10, n%10000)
+	env := make([]corev1.EnvVar, 0, 12)
+	for e := range 12 {
+		env = append(env, corev1.EnvVar{Name: fmt.Sprintf("APP_SETTING_%d", e), Value: strings.Repeat("v", 24)})
+	}
+	container := func(image string) corev1.Container {
+		return corev1.Container{
+			Name:  image,
+			Image: "registry.example.com/team/" + image + ":1.2.3",
+			Env:   env,
+			Ports: []corev1.ContainerPort{{Name: "http", ContainerPort: 8080, Protocol: corev1.ProtocolTCP}},
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m"), corev1.ResourceMemory: resource.MustParse("128Mi")},
+				Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("256Mi")},
+			},
+			VolumeMounts: []corev1.VolumeMount{{Name: "kube-api-access", MountPath: "/var/run/secrets/kubernetes.io/serviceaccount", ReadOnly: true}},
+			ReadinessProbe: &corev1.Probe{ProbeHandler: corev1.ProbeHandler{
+				HTTPGet: &corev1.HTTPGetAction{Path: "/healthz", Port: intstr.FromString("http")},
+			}},
+		}
+	}
+
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       fmt.Sprintf("team-%d", n%50),
+			Name:            name,
+			UID:             types.UID(fmt.Sprintf("00000000-0000-0000-0000-%012d", n)),
+			ResourceVersion: fmt.Sprint(100000 + n),
+			Labels:          map[string]string{"app": "app", "pod-template-hash": "7c9f8d6b5", "team": "team", "tier": "backend"},
+			Annotations:     map[string]string{"kubectl.kubernetes.io/restartedAt": "2026-01-01T00:00:00Z"},
+			OwnerReferences: []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "app-7c9f8d6b5", UID: "rs-uid"}},
+			ManagedFields: []metav1.ManagedFieldsEntry{
+				{Manager: "kube-controller-manager", Operation: metav1.ManagedFieldsOperationUpdate, FieldsType: "FieldsV1",
+					FieldsV1: &metav1.FieldsV1{Raw: []byte(strings.Repeat(`{"f:spec":{"f:containers":{}}}`, 60))}},
+				{Manager: "kubelet", Operation: metav1.ManagedFieldsOperationUpdate, FieldsType: "FieldsV1", Subresource: "status",
+					FieldsV1: &metav1.FieldsV1{Raw: []byte(strings.Repeat(`{"f:status":{"f:conditions":{}}}`, 40))}},
+			},
+		},
+		Spec: corev1.PodSpec{
+			ServiceAccountName: "app",
+			NodeName:           fmt.Sprintf("node-%d", n%200),
+			Containers:         []corev1.Container{container("app"), container("sidecar")},
+			Volumes: []corev1.Volume{{Name: "kube-api-access", VolumeSource: corev1.VolumeSource{Projected: &corev1.ProjectedVolumeSource{
+				Sources: []corev1.VolumeProjection{{ServiceAccountToken: &corev1.ServiceAccountTokenProjection{Path: "token"}}},
+			}}}},
+		},
+		Status: corev1.PodStatus{
+			Phase:  corev1.PodRunning,
+			PodIP:  "10.0.0.1",
+			HostIP: "192.168.0.1",
+			Conditions: []corev1.PodCondition{
+				{Type: corev1.PodReady, Status: corev1.ConditionTrue},
+				{Type: corev1.ContainersReady, Status: corev1.ConditionTrue},
+				{Type: corev1.PodScheduled, Status: corev1.ConditionTrue},
+			},
+			ContainerStatuses: []corev1.ContainerStatus{
+				{Name: "app", Ready: true, Image: "registry.example.com/team/app:1.2.3", ImageID: "sha256:" + strings.Repeat("a", 64)},
+				{Name: "sidecar", Ready: true, Image: "registry.example.com/team/sidecar:1.2.3", ImageID: "sha256:" + strings.Repeat("b", 64)},
+			},
+		},
+	}
+}
+
+func TestTransformPod_KeepsIndexedFields(t *testing.T) {
+	pod := benchmarkPod(1)
+	transformed, err := transformPod(pod)
+	if err != nil {
+		t.Fatalf("transformPod: %v", err)
+	}
+
+	full, lean := newEmptySnapshot(), newEmptySnapshot()
+	indexPods(full, []*corev1.Pod{pod})
+	indexPods(lean, []*corev1.Pod{transformed.(*corev1.Pod)})
+	if !reflect.DeepEqual(full.PodsByServiceAccount, lean.PodsByServiceAccount) {
+		t.Fatalf("expected identical pod records, got %#v and %#v", full.PodsByServiceAccount, lean.PodsByServiceAccount)
+	}
+	stripped := transformed.(*corev1.Pod)
+	if len(stripped.ManagedFields) != 0 || len(stripped.Labels) != 0 {
+		t.Fatalf("expected managed fields and labels to be stripped, got %#v", stripped)
+	}
+	for _, container := range stripped.Spec.Containers {
+		if container.Image != "" || len(container.Env) != 0 || container.ReadinessProbe != nil || len(container.VolumeMounts) != 1 {
+			t.Fatalf("expected containers to keep only token mounts, got %#v", container)
+		}
+	}
+	if tokens := lean.PodsByServiceAccount[serviceAccountKey(pod.Namespace, "app")][0].ProjectedTokens; len(tokens) != 1 || tokens[0].Volume != "kube-api-access" {
+		t.Fatalf("expected the kube-api-access token to be indexed, got %#v", tokens)
+	}
+	again, _ := transformPod(stripped)
+	if !reflect.DeepEqual(again, stripped) {
+		t.Fatal("expected transformPod to be idempotent")
+	}
+}
+
+func TestTransformWorkload_KeepsOwnerReferences(t *testing.T) {
+	rs := &appsv1.ReplicaSet{
+		ObjectMeta: benchmarkPod(1).ObjectMeta,
+		Spec:       appsv1.ReplicaSetSpec{Template: corev1.PodTemplateSpec{Spec: benchmarkPod(1).Spec}},
+	}
+	transformed, err := transformWorkload(rs)
+	if err != nil {
+		t.Fatalf("transformWorkload: %v", err)
+	}
+
+	full, lean := newEmptySnapshot(), newEmptySnapshot()
+	indexWorkload(full, "apps/v1", "ReplicaSet", rs.ObjectMeta)
+	indexWorkload(lean, "apps/v1", "ReplicaSet", transformed.(*appsv1.ReplicaSet).ObjectMeta)
+	if !reflect.DeepEqual(full.WorkloadsByUID, lean.WorkloadsByUID) {
+		t.Fatalf("expected identical workload records, got %#v and %#v", full.WorkloadsByUID, lean.WorkloadsByUID)
+	}
+	if len(transformed.(*appsv1.ReplicaSet).Spec.Template.Spec.Containers) != 0 {
+		t.Fatal("expected pod template to be stripped")
+	}
+	if other, _ := transformWorkload(benchmarkPod(1)); other == nil {
+		t.Fatal("expected objects of other types to pass through")
+	}
+}
+
+func TestTransformServiceAccount_KeepsIndexedFields(t *testing.T) {
+	automount := false
+	sa := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:     "team-a",
+			Name:          "app",
+			UID:           "sa-uid",
+			Annotations:   map[string]string{"eks.amazonaws.com/role-arn": "arn:aws:iam::123456789012:role/app"},
+			ManagedFields: benchmarkPod(1).ManagedFields,
+		},
+		Secrets:                      []corev1.ObjectReference{{Name: "app-token-x1"}},
+		ImagePullSecrets:             []corev1.LocalObjectReference{{Name: "registry"}},
+		AutomountServiceAccountToken: &automount,
+	}
+	transformed, err := transformServiceAccount(sa)
+	if err != nil {
+		t.Fatalf("transformServiceAccount: %v", err)
+	}
+
+	full, lean := newEmptySnapshot(), newEmptySnapshot()
+	indexServiceAccounts(full, []*corev1.ServiceAccount{sa})
+	indexServiceAccounts(lean, []*corev1.ServiceAccount{transformed.(*corev1.ServiceAccount)})
+	if !reflect.DeepEqual(full.ServiceAccounts, lean.ServiceAccounts) {
+		t.Fatalf("expected identical service account records, got %#v and %#v", full.ServiceAccounts, lean.ServiceAccounts)
+	}
+	stripped := transformed.(*corev1.ServiceAccount)
+	if len(stripped.ManagedFields) != 0 || len(stripped.Secrets) != 0 {
+		t.Fatalf("expected managed fields and token secrets to be stripped, got %#v", stripped)
+	}
+	again, _ := transformServiceAccount(stripped)
+	if !reflect.DeepEqual(again, stripped) {
+		t.Fatal("expected transformServiceAccount to be idempotent")
+	}
+}
+
+// BenchmarkPodCacheMemory reports the heap retained by an informer store
+// holding 10k pods, with and without the pod transform.
+func BenchmarkPodCacheMemory(b *testing.B) {
+	const pods = 10000
+	for _, tc := range []struct {
+		name      string
+		transform cache.TransformFunc
+	}{
+		{name: "full"},
+		{name: "transformed", transform: transformPod},
+	} {
+		b.Run(tc.name, func(b *testing.B) {
+			for b.Loop() {
+				store := cache.NewStore(cache.MetaNamespaceKeyFunc)
+				before := heapAlloc()
+				for n := range pods {
+					var obj any = benchmarkPod(n)
+					if tc.transform != nil {
+						obj, _ = tc.transform(obj)
+					}
+					if err := store.Add(obj); err != nil {
+						b.Fatalf("add: %v", err)
+					}
+				}
+				b.ReportMetric(float64(heapAlloc()-before), "B/10k-pods")
+				runtime.KeepAlive(store)
+			}
+		})
+	}
+}
+
+func heapAlloc() int64 {
+	runtime.GC()
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+
+	return int64(stats.HeapAlloc) //nolint:gosec // heap size fits in int64
+}
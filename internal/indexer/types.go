@@ -31,6 +31,22 @@ func (k ServiceAccountKey) String() string {
 	return k.Namespace + "/" + k.Name
 }
 
+// SubjectKey identifies a binding subject. Kind is always one of the
+// canonical SubjectKind* constants; Namespace is only set for ServiceAccounts.
+type SubjectKey struct {
+	Kind      string
+	Namespace string
+	Name      string
+}
+
+func (k SubjectKey) String() string {
+	if k.Namespace == "" {
+		return k.Kind + ":" + k.Name
+	}
+
+	return k.Kind + ":" + k.Namespace + "/" + k.Name
+}
+
 const (
 	KindRole               = "Role"
 	KindClusterRole        = "ClusterRole"
@@ -45,14 +61,16 @@ const (
 )
 
 type RoleRecord struct {
-	UID         types.UID
-	Kind        string
-	Namespace   string
-	Name        string
-	Labels      map[string]string
-	Annotations map[string]string
-	Rules       []rbacv1.PolicyRule
-	RuleCount   int
+	UID types.UID
+	// ResourceVersion is empty for roles loaded from manifests without one.
+	ResourceVersion string
+	Kind            string
+	Namespace       string
+	Name            string
+	Labels          map[string]string
+	Annotations     map[string]string
+	Rules           []rbacv1.PolicyRule
+	RuleCount       int
 }
 
 type BindingRecord struct {
@@ -60,6 +78,7 @@ type BindingRecord struct {
 	Kind      string
 	Namespace string
 	Name      string
+	Labels    map[string]string
 	RoleRef   RoleRefKey
 	Subjects  []rbacv1.Subject
 }
@@ -71,6 +90,40 @@ type PodRecord struct {
 	ServiceAccountName string
 	Phase              corev1.PodPhase
 	OwnerReferences    []metav1.OwnerReference
+	// AutomountServiceAccountToken is the pod setting; nil defers to the
+	// ServiceAccount.
+	AutomountServiceAccountToken *bool
+	// ProjectedTokens are the projected ServiceAccount token volumes mounted
+	// into at least one container.
+	ProjectedTokens []ProjectedToken
+}
+
+// ProjectedToken is a serviceAccountToken source of a projected volume. An
+// empty Audience defaults to the API server audience.
+type ProjectedToken struct {
+	Volume            string
+	Audience          string
+	ExpirationSeconds int64
+}
+
+// NamespaceRecord holds the labels namespace scopes select on and the phase
+// grants in the namespace are checked against.
+type NamespaceRecord struct {
+	UID    types.UID
+	Name   string
+	Labels map[string]string
+	Phase  corev1.NamespacePhase
+}
+
+type ServiceAccountRecord struct {
+	UID         types.UID
+	Namespace   string
+	Name        string
+	Annotations map[string]string
+	// AutomountServiceAccountToken is nil when the ServiceAccount leaves the
+	// decision to its pods.
+	AutomountServiceAccountToken *bool
+	ImagePullSecrets             []string
 }
 
 type WorkloadRecord struct {
@@ -89,22 +142,95 @@ type Scope interface {
 	AllowRole(namespace string) bool
 	AllowBinding(namespace string) bool
 	AllowPod(namespace string) bool
+	AllowServiceAccount(namespace string) bool
 	AllowWorkload(namespace string) bool
+	// AllowNamespace reports whether the Namespace object name is visible.
+	AllowNamespace(name string) bool
 }
 
+// Snapshot is an immutable view of the indexed cluster state. The RBAC and
+// runtime parts are rebuilt independently by the indexer, so a snapshot may
+// combine parts built at different times.
 type Snapshot struct {
-	BuiltAt               time.Time
+	// Generation increases with every rebuild of the indexer. It is zero for
+	// snapshots that were not produced by an indexer.
+	Generation int64
+	// RBACGeneration is the generation that last published RBACIndex; it
+	// is unchanged by runtime-only publishes.
+	RBACGeneration int64
+	BuiltAt        time.Time
+	RBACIndex
+	RuntimeIndex
+	// RuntimeUnavailable explains why RuntimeIndex is empty: the runtime
+	// index is disabled or its informers have not synced yet.
+	RuntimeUnavailable string
+	Warnings           []string
+	// Scope is the access scope Scoped narrowed the snapshot to. It is nil
+	// for snapshots that show everything.
+	Scope Scope
+	// NamespacesHidden is set when Scoped dropped Namespace objects the
+	// caller may not see, so Namespaces is not the full list.
+	NamespacesHidden bool
+}
+
+// RoleVisible reports whether the snapshot shows the roles of namespace, or
+// the ClusterRoles for an empty namespace, so that a role missing from it
+// does not exist.
+func (s *Snapshot) RoleVisible(namespace string) bool {
+	return s.Scope == nil || s.Scope.AllowRole(namespace)
+}
+
+// BindingVisible reports whether the snapshot shows the bindings of
+// namespace, or the ClusterRoleBindings for an empty namespace.
+func (s *Snapshot) BindingVisible(namespace string) bool {
+	return s.Scope == nil || s.Scope.AllowBinding(namespace)
+}
+
+// ServiceAccountVisible reports whether the snapshot shows the
+// ServiceAccounts of namespace, so that a ServiceAccount missing from it
+// does not exist.
+func (s *Snapshot) ServiceAccountVisible(namespace string) bool {
+	return s.Scope == nil || s.Scope.AllowServiceAccount(namespace)
+}
+
+// NamespaceVisible reports whether the snapshot shows the Namespace object
+// name, so that a namespace missing from it does not exist.
+func (s *Snapshot) NamespaceVisible(name string) bool {
+	return s.Scope == nil || s.Scope.AllowNamespace(name)
+}
+
+// RBACIndex holds roles, bindings and service accounts with the lookup
+// indexes over them.
+type RBACIndex struct {
 	RolesByID             map[RoleID]*RoleRecord
 	BindingsByRoleRef     map[RoleRefKey][]*BindingRecord
+	BindingsBySubject     map[SubjectKey][]*BindingRecord
 	AggregatedRoleSources map[RoleID][]RoleID
-	PodsByServiceAccount  map[ServiceAccountKey][]*PodRecord
-	WorkloadsByUID        map[types.UID]*WorkloadRecord
-	RoleIDsByVerb         map[string]map[RoleID]struct{}
-	RoleIDsByResource     map[string]map[RoleID]struct{}
-	RoleIDsByAPIGroup     map[string]map[RoleID]struct{}
-	AllRoleIDs            []RoleID
-	KnownGaps             []string
-	Warnings              []string
+	// AggregationMatches explains AggregatedRoleSources: for each target,
+	// the selectors that select each source.
+	AggregationMatches map[RoleID][]AggregationMatch
+	ServiceAccounts    map[ServiceAccountKey]*ServiceAccountRecord
+	Namespaces         map[string]*NamespaceRecord
+	RoleIDsByVerb      map[string]map[RoleID]struct{}
+	RoleIDsByResource  map[string]map[RoleID]struct{}
+	RoleIDsByAPIGroup  map[string]map[RoleID]struct{}
+	AllRoleIDs         []RoleID
+	KnownGaps          []string
+}
+
+// AggregationMatch records that an aggregationRule selector of a ClusterRole
+// selects Source, and the source labels it matched on.
+type AggregationMatch struct {
+	Source        RoleID
+	SelectorIndex int
+	Selector      string
+	MatchedLabels map[string]string
+}
+
+// RuntimeIndex holds pods and the workloads that own them.
+type RuntimeIndex struct {
+	PodsByServiceAccount map[ServiceAccountKey][]*PodRecord
+	WorkloadsByUID       map[types.UID]*WorkloadRecord
 }
 
 func (s *Snapshot) CloneKnownGaps() []string {
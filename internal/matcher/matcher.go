@@ -20,6 +20,10 @@ type MatchInput struct {
 	WildcardMode api.WildcardMode
 	SourceUID    string
 	RuleIndex    int
+	// ExactResourceNames makes a rule's resourceNames binding: such a rule only
+	// matches when every requested name is listed, and never matches a request
+	// without names (list, create, deletecollection cannot be name-restricted).
+	ExactResourceNames bool
 }
 
 //nolint:gocyclo // matching logic handles resource + non-resource + combined cases
@@ -97,7 +101,7 @@ func matchResourceRule(in MatchInput) ([]api.RuleRef, bool) {
 		return nil, false
 	}
 
-	if !matchResourceNames(in.Selector.ResourceNames, in.Rule.ResourceNames, in.Mode) {
+	if !matchResourceNames(in.Selector.ResourceNames, in.Rule.ResourceNames, in.Mode, in.ExactResourceNames) {
 		return nil, false
 	}
 
@@ -173,6 +177,41 @@ func matchNonResourceRule(in MatchInput) ([]api.RuleRef, bool) {
 	return refs, true
 }
 
+// ExpandRule flattens a policy rule into one RuleRef per (apiGroup, resource,
+// verb) or (nonResourceURL, verb) combination without any selector filtering.
+// Unlike MatchRule, the rule's own resourceNames are preserved on every ref.
+func ExpandRule(rule rbacv1.PolicyRule, sourceUID string, ruleIndex int) []api.RuleRef {
+	refs := make([]api.RuleRef, 0, len(rule.APIGroups)*len(rule.Resources)*len(rule.Verbs)+len(rule.NonResourceURLs)*len(rule.Verbs))
+	for _, g := range rule.APIGroups {
+		for _, r := range rule.Resources {
+			resource, subresource := splitResource(r)
+			for _, v := range rule.Verbs {
+				refs = append(refs, api.RuleRef{
+					APIGroup:        g,
+					Resource:        resource,
+					Subresource:     subresource,
+					Verb:            v,
+					ResourceNames:   copyStrings(rule.ResourceNames),
+					SourceObjectUID: sourceUID,
+					SourceRuleIndex: ruleIndex,
+				})
+			}
+		}
+	}
+	for _, url := range rule.NonResourceURLs {
+		for _, v := range rule.Verbs {
+			refs = append(refs, api.RuleRef{
+				NonResourceURLs: []string{url},
+				Verb:            v,
+				SourceObjectUID: sourceUID,
+				SourceRuleIndex: ruleIndex,
+			})
+		}
+	}
+
+	return refs
+}
+
 func matchRequested(requested, allowed []string, mode api.MatchMode, fn func(string, string) bool) []string {
 	if len(requested) == 0 {
 		requested = []string{"*"}
@@ -255,18 +294,18 @@ func nonResourceWildcardMatch(requested, allowed string) bool {
 	return false
 }
 
-func matchResourceNames(requested, allowed []string, mode api.MatchMode) bool {
-	if len(requested) == 0 {
-		return true
-	}
+func matchResourceNames(requested, allowed []string, mode api.MatchMode, exact bool) bool {
 	if len(allowed) == 0 {
 		return true
 	}
+	if len(requested) == 0 {
+		return !exact
+	}
 	lookup := make(map[string]struct{}, len(allowed))
 	for _, name := range allowed {
 		lookup[name] = struct{}{}
 	}
-	if mode == api.MatchModeAll {
+	if mode == api.MatchModeAll || exact {
 		for _, name := range requested {
 			if _, ok := lookup[name]; !ok {
 				return false
@@ -102,6 +102,44 @@ func TestMatchRule_ResourceNames(t *testing.T) {
 	}
 }
 
+func TestMatchRule_ExactResourceNames(t *testing.T) {
+	rule := rbacv1.PolicyRule{
+		APIGroups:     []string{""},
+		Resources:     []string{"secrets"},
+		ResourceNames: []string{"db-creds", "tls"},
+		Verbs:         []string{"get", "list"},
+	}
+
+	tests := []struct {
+		name      string
+		verb      string
+		names     []string
+		exact     bool
+		wantMatch bool
+	}{
+		{name: "loose without names", verb: "list", exact: false, wantMatch: true},
+		{name: "exact without names", verb: "list", exact: true, wantMatch: false},
+		{name: "exact listed name", verb: "get", names: []string{"db-creds"}, exact: true, wantMatch: true},
+		{name: "exact partially listed names", verb: "get", names: []string{"db-creds", "other"}, exact: true, wantMatch: false},
+		{name: "loose partially listed names", verb: "get", names: []string{"db-creds", "other"}, exact: false, wantMatch: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sel := api.Selector{
+				APIGroups:     []string{""},
+				Resources:     []string{"secrets"},
+				Verbs:         []string{tt.verb},
+				ResourceNames: tt.names,
+			}
+			result := MatchRule(MatchInput{Rule: rule, Selector: sel, Mode: api.MatchModeAny, ExactResourceNames: tt.exact})
+			if result.Matched != tt.wantMatch {
+				t.Fatalf("expected matched=%v, got %v", tt.wantMatch, result.Matched)
+			}
+		})
+	}
+}
+
 func TestRuleRef_ResourceNames_NoRuleRestriction(t *testing.T) {
 	// When the rule has no resourceNames restriction (= all names allowed),
 	// the RuleRef should NOT echo the selector's resourceNames.
@@ -313,3 +351,43 @@ func TestMatchRule_ExactMode_WildcardAPIGroupDoesNotMatchConcrete(t *testing.T)
 		t.Fatalf("exact mode: wildcard apiGroup rule should NOT match concrete selector apiGroup")
 	}
 }
+
+func TestExpandRule_PreservesResourceNames(t *testing.T) {
+	rule := rbacv1.PolicyRule{
+		APIGroups:     []string{""},
+		Resources:     []string{"secrets", "pods/log"},
+		Verbs:         []string{"get", "list"},
+		ResourceNames: []string{"db-creds"},
+	}
+
+	refs := ExpandRule(rule, "uid-1", 3)
+	if len(refs) != 4 {
+		t.Fatalf("expected 4 refs, got %d", len(refs))
+	}
+	for _, ref := range refs {
+		if len(ref.ResourceNames) != 1 || ref.ResourceNames[0] != "db-creds" {
+			t.Fatalf("expected resourceNames=[db-creds], got %v", ref.ResourceNames)
+		}
+		if ref.SourceObjectUID != "uid-1" || ref.SourceRuleIndex != 3 {
+			t.Fatalf("unexpected source metadata: %+v", ref)
+		}
+	}
+	if refs[2].Resource != "pods" || refs[2].Subresource != "log" {
+		t.Fatalf("expected pods/log to be split, got %+v", refs[2])
+	}
+}
+
+func TestExpandRule_NonResourceURLs(t *testing.T) {
+	rule := rbacv1.PolicyRule{
+		NonResourceURLs: []string{"/metrics", "/healthz"},
+		Verbs:           []string{"get"},
+	}
+
+	refs := ExpandRule(rule, "uid-2", 0)
+	if len(refs) != 2 {
+		t.Fatalf("expected 2 refs, got %d", len(refs))
+	}
+	if refs[0].NonResourceURLs[0] != "/metrics" || refs[0].Verb != "get" {
+		t.Fatalf("unexpected ref: %+v", refs[0])
+	}
+}
@@ -0,0 +1,216 @@
+package policy
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"k8s-role-graph/internal/engine"
+	"k8s-role-graph/internal/indexer"
+	api "k8s-role-graph/pkg/apis/rbacgraph"
+)
+
+// Violation is a subject granted a policy's permission without being allowed.
+type Violation struct {
+	Policy   string
+	Severity Severity
+	Subject  api.SubjectRef
+	// ClusterWide is set when any grant applies cluster-wide; Namespaces
+	// lists the namespaces of the remaining grants.
+	ClusterWide bool
+	Namespaces  []string
+	Bindings    []api.ObjectRef
+	Roles       []api.ObjectRef
+}
+
+// Message describes the violation in one line.
+func (v Violation) Message() string {
+	where := "in " + strings.Join(v.Namespaces, ", ")
+	if v.ClusterWide {
+		where = "cluster-wide"
+	}
+	bindings := make([]string, 0, len(v.Bindings))
+	for _, ref := range v.Bindings {
+		bindings = append(bindings, objectRefString(ref))
+	}
+
+	return fmt.Sprintf("%s is granted access %s via %s", subjectString(v.Subject), where, strings.Join(bindings, ", "))
+}
+
+// Result holds the violations of one policy.
+type Result struct {
+	Policy     Policy
+	Violations []Violation
+	Warnings   []string
+}
+
+// Failed reports whether the result has violations of error severity.
+func (r Result) Failed() bool {
+	return len(r.Violations) > 0 && r.Policy.EffectiveSeverity() == SeverityError
+}
+
+// Evaluate runs each policy query against snapshot. Policies must have been
+// validated.
+func Evaluate(eng *engine.Engine, snapshot *indexer.Snapshot, discovery *indexer.APIDiscoveryCache, policies []Policy) []Result {
+	results := make([]Result, 0, len(policies))
+	for _, p := range policies {
+		result := Result{Policy: p}
+		spec, err := p.reviewSpec()
+		if err != nil {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("convert query: %v", err))
+			results = append(results, result)
+
+			continue
+		}
+		status := eng.Query(snapshot, spec, discovery)
+		result.Warnings = status.Warnings
+		result.Violations = violations(p, status.Graph)
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// grantedSubject accumulates the grants reaching one subject node.
+type grantedSubject struct {
+	violation  Violation
+	namespaces map[string]struct{}
+	bindings   map[api.ObjectRef]struct{}
+	roles      map[api.ObjectRef]struct{}
+}
+
+var subjectNodeKinds = map[api.GraphNodeType]string{
+	api.GraphNodeTypeUser:           api.SubjectKindUser,
+	api.GraphNodeTypeGroup:          api.SubjectKindGroup,
+	api.GraphNodeTypeServiceAccount: api.SubjectKindServiceAccount,
+}
+
+var objectNodeKinds = map[api.GraphNodeType]string{
+	api.GraphNodeTypeRole:               indexer.KindRole,
+	api.GraphNodeTypeClusterRole:        indexer.KindClusterRole,
+	api.GraphNodeTypeRoleBinding:        indexer.KindRoleBinding,
+	api.GraphNodeTypeClusterRoleBinding: indexer.KindClusterRoleBinding,
+}
+
+// violations walks role -> binding -> subject edges of the query graph and
+// returns the subjects that no Allow pattern matches.
+func violations(p Policy, graph api.Graph) []Violation {
+	nodes := make(map[string]api.GraphNode, len(graph.Nodes))
+	for _, node := range graph.Nodes {
+		nodes[node.ID] = node
+	}
+	rolesByBinding := make(map[string][]api.ObjectRef)
+	for _, edge := range graph.Edges {
+		if edge.Type != api.GraphEdgeTypeGrants {
+			continue
+		}
+		if role, ok := nodes[edge.From]; ok {
+			rolesByBinding[edge.To] = append(rolesByBinding[edge.To], objectRef(role))
+		}
+	}
+
+	granted := make(map[string]*grantedSubject)
+	for _, edge := range graph.Edges {
+		if edge.Type != api.GraphEdgeTypeSubjects || edge.Scope == nil {
+			continue
+		}
+		if p.ClusterWideOnly && !edge.Scope.ClusterWide {
+			continue
+		}
+		node, ok := nodes[edge.To]
+		if !ok {
+			continue
+		}
+		kind, ok := subjectNodeKinds[node.Type]
+		if !ok {
+			continue
+		}
+		subject := api.SubjectRef{Kind: kind, Namespace: node.Namespace, Name: node.Name}
+		binding, hasBinding := nodes[edge.From]
+		if kind == api.SubjectKindServiceAccount && subject.Namespace == "" && hasBinding {
+			// RoleBinding subjects may omit the namespace of the binding.
+			subject.Namespace = binding.Namespace
+		}
+		if p.allows(subject) {
+			continue
+		}
+		entry, ok := granted[edge.To]
+		if !ok {
+			entry = &grantedSubject{
+				violation:  Violation{Policy: p.Name, Severity: p.EffectiveSeverity(), Subject: subject},
+				namespaces: make(map[string]struct{}),
+				bindings:   make(map[api.ObjectRef]struct{}),
+				roles:      make(map[api.ObjectRef]struct{}),
+			}
+			granted[edge.To] = entry
+		}
+		if edge.Scope.ClusterWide {
+			entry.violation.ClusterWide = true
+		}
+		for _, ns := range edge.Scope.Namespaces {
+			entry.namespaces[ns] = struct{}{}
+		}
+		if hasBinding {
+			entry.bindings[objectRef(binding)] = struct{}{}
+		}
+		for _, role := range rolesByBinding[edge.From] {
+			entry.roles[role] = struct{}{}
+		}
+	}
+
+	out := make([]Violation, 0, len(granted))
+	for _, entry := range granted {
+		v := entry.violation
+		v.Namespaces = sortedKeys(entry.namespaces)
+		v.Bindings = sortedRefs(entry.bindings)
+		v.Roles = sortedRefs(entry.roles)
+		out = append(out, v)
+	}
+	slices.SortFunc(out, func(a, b Violation) int {
+		return strings.Compare(subjectString(a.Subject), subjectString(b.Subject))
+	})
+
+	return out
+}
+
+func objectRef(node api.GraphNode) api.ObjectRef {
+	return api.ObjectRef{Kind: objectNodeKinds[node.Type], Namespace: node.Namespace, Name: node.Name}
+}
+
+func objectRefString(ref api.ObjectRef) string {
+	if ref.Namespace == "" {
+		return ref.Kind + "/" + ref.Name
+	}
+
+	return ref.Kind + "/" + ref.Namespace + "/" + ref.Name
+}
+
+func subjectString(ref api.SubjectRef) string {
+	if ref.Namespace == "" {
+		return ref.Kind + " " + ref.Name
+	}
+
+	return ref.Kind + " " + ref.Namespace + "/" + ref.Name
+}
+
+func sortedKeys(set map[string]struct{}) []string {
+	out := make([]string, 0, len(set))
+	for key := range set {
+		out = append(out, key)
+	}
+	slices.Sort(out)
+
+	return out
+}
+
+func sortedRefs(set map[api.ObjectRef]struct{}) []api.ObjectRef {
+	out := make([]api.ObjectRef, 0, len(set))
+	for ref := range set {
+		out = append(out, ref)
+	}
+	slices.SortFunc(out, func(a, b api.ObjectRef) int {
+		return strings.Compare(objectRefString(a), objectRefString(b))
+	})
+
+	return out
+}
@@ -0,0 +1,80 @@
+package policy
+
+import (
+	"sync"
+
+	"k8s.io/component-base/metrics"
+	"k8s.io/component-base/metrics/legacyregistry"
+)
+
+const metricsSubsystem = "rbacgraph_policy"
+
+var (
+	violationsMetric = metrics.NewGaugeVec(
+		&metrics.GaugeOpts{
+			Subsystem:      metricsSubsystem,
+			Name:           "violations",
+			Help:           "Number of subjects violating each policy in the latest evaluated snapshot.",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"policy", "severity"},
+	)
+	violatingSubjectMetric = metrics.NewGaugeVec(
+		&metrics.GaugeOpts{
+			Subsystem:      metricsSubsystem,
+			Name:           "violating_subject",
+			Help:           "Set to 1 for every subject violating a policy in the latest evaluated snapshot.",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"policy", "severity", "subject_kind", "subject_namespace", "subject_name"},
+	)
+	evaluatedGenerationMetric = metrics.NewGauge(
+		&metrics.GaugeOpts{
+			Subsystem:      metricsSubsystem,
+			Name:           "evaluated_snapshot_generation",
+			Help:           "Generation of the snapshot the policies were last evaluated against.",
+			StabilityLevel: metrics.ALPHA,
+		},
+	)
+	evaluationDurationMetric = metrics.NewHistogram(
+		&metrics.HistogramOpts{
+			Subsystem:      metricsSubsystem,
+			Name:           "evaluation_duration_seconds",
+			Help:           "Time to evaluate all policies against a snapshot.",
+			Buckets:        metrics.ExponentialBuckets(0.001, 4, 8),
+			StabilityLevel: metrics.ALPHA,
+		},
+	)
+
+	registerMetricsOnce sync.Once
+)
+
+// RegisterMetrics registers the policy metrics with the legacy registry
+// served on the apiserver /metrics endpoint. It is safe to call repeatedly.
+func RegisterMetrics() {
+	registerMetricsOnce.Do(func() {
+		legacyregistry.MustRegister(
+			violationsMetric,
+			violatingSubjectMetric,
+			evaluatedGenerationMetric,
+			evaluationDurationMetric,
+		)
+	})
+}
+
+// recordMetrics replaces the violation series with those of results, so
+// subjects that no longer violate a policy disappear from the export.
+func recordMetrics(generation int64, results []Result) {
+	violationsMetric.Reset()
+	violatingSubjectMetric.Reset()
+	for _, result := range results {
+		severity := string(result.Policy.EffectiveSeverity())
+		violationsMetric.WithLabelValues(result.Policy.Name, severity).Set(float64(len(result.Violations)))
+		for _, v := range result.Violations {
+			violatingSubjectMetric.WithLabelValues(
+				result.Policy.Name, severity, v.Subject.Kind, v.Subject.Namespace, v.Subject.Name,
+			).Set(1)
+		}
+	}
+	evaluatedGenerationMetric.Set(float64(generation))
+}
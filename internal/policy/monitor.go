@@ -0,0 +1,88 @@
+package policy
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"k8s-role-graph/internal/engine"
+	"k8s-role-graph/internal/indexer"
+)
+
+// Monitor re-evaluates policies whenever the indexer publishes a snapshot
+// with a changed RBAC part and exports the violations as metrics.
+type Monitor struct {
+	engine    *engine.Engine
+	policies  []Policy
+	discovery func() *indexer.APIDiscoveryCache
+
+	// latest holds the newest snapshot Notify queued for Run.
+	latest  chan *indexer.Snapshot
+	results atomic.Pointer[[]Result]
+}
+
+// NewMonitor returns a monitor for validated policies. discovery returns the
+// cache used for wildcard expansion and may return nil.
+func NewMonitor(eng *engine.Engine, policies []Policy, discovery func() *indexer.APIDiscoveryCache) *Monitor {
+	RegisterMetrics()
+
+	return &Monitor{engine: eng, policies: policies, discovery: discovery, latest: make(chan *indexer.Snapshot, 1)}
+}
+
+// Notify queues snapshot for evaluation by Run, replacing a snapshot that
+// is still queued. It has the signature of an indexer rebuild handler and
+// does not block; calls must not be concurrent.
+func (m *Monitor) Notify(snapshot *indexer.Snapshot) {
+	select {
+	case <-m.latest:
+	default:
+	}
+	m.latest <- snapshot
+}
+
+// Run evaluates the snapshots queued by Notify until ctx is done. A
+// snapshot whose RBAC part was already evaluated is skipped, since pod and
+// workload changes do not affect policy results.
+func (m *Monitor) Run(ctx context.Context) {
+	var evaluated int64
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case snapshot := <-m.latest:
+			if snapshot.RBACGeneration != 0 && snapshot.RBACGeneration == evaluated {
+				continue
+			}
+			m.Evaluate(snapshot)
+			evaluated = snapshot.RBACGeneration
+		}
+	}
+}
+
+// Evaluate runs all policies against snapshot and updates the metrics.
+func (m *Monitor) Evaluate(snapshot *indexer.Snapshot) {
+	start := time.Now()
+	results := Evaluate(m.engine, snapshot, m.discovery(), m.policies)
+	evaluationDurationMetric.Observe(time.Since(start).Seconds())
+	recordMetrics(snapshot.Generation, results)
+	m.results.Store(&results)
+
+	for _, result := range results {
+		if len(result.Violations) > 0 {
+			klog.V(2).Infof("policy %q: %d violations in snapshot generation %d",
+				result.Policy.Name, len(result.Violations), snapshot.Generation)
+		}
+	}
+}
+
+// Results returns the results of the latest evaluation, or nil before the
+// first one.
+func (m *Monitor) Results() []Result {
+	if results := m.results.Load(); results != nil {
+		return *results
+	}
+
+	return nil
+}
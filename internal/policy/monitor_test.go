@@ -0,0 +1,125 @@
+package policy
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/component-base/metrics/legacyregistry"
+	"k8s.io/component-base/metrics/testutil"
+
+	"k8s-role-graph/internal/engine"
+	"k8s-role-graph/internal/indexer"
+)
+
+func TestMonitor_ExportsViolationsPerSubject(t *testing.T) {
+	policies, err := LoadFile("testdata/policies.yaml")
+	if err != nil {
+		t.Fatalf("LoadFile: %v", err)
+	}
+	src, err := indexer.LoadManifests("", "testdata/manifests")
+	if err != nil {
+		t.Fatalf("LoadManifests: %v", err)
+	}
+	snapshot := indexer.BuildSnapshot(src)
+	snapshot.Generation = 7
+	monitor := NewMonitor(engine.New(), policies, func() *indexer.APIDiscoveryCache { return nil })
+
+	monitor.Evaluate(snapshot)
+
+	if len(monitor.Results()) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(monitor.Results()))
+	}
+	want := `
+# HELP rbacgraph_policy_evaluated_snapshot_generation [ALPHA] Generation of the snapshot the policies were last evaluated against.
+# TYPE rbacgraph_policy_evaluated_snapshot_generation gauge
+rbacgraph_policy_evaluated_snapshot_generation 7
+# HELP rbacgraph_policy_violating_subject [ALPHA] Set to 1 for every subject violating a policy in the latest evaluated snapshot.
+# TYPE rbacgraph_policy_violating_subject gauge
+rbacgraph_policy_violating_subject{policy="no-cluster-wide-secrets",severity="error",subject_kind="ServiceAccount",subject_name="app",subject_namespace="team-a"} 1
+rbacgraph_policy_violating_subject{policy="no-pod-exec",severity="warning",subject_kind="User",subject_name="alice",subject_namespace=""} 1
+# HELP rbacgraph_policy_violations [ALPHA] Number of subjects violating each policy in the latest evaluated snapshot.
+# TYPE rbacgraph_policy_violations gauge
+rbacgraph_policy_violations{policy="no-cluster-wide-secrets",severity="error"} 1
+rbacgraph_policy_violations{policy="no-pod-exec",severity="warning"} 1
+`
+	names := []string{
+		"rbacgraph_policy_evaluated_snapshot_generation",
+		"rbacgraph_policy_violating_subject",
+		"rbacgraph_policy_violations",
+	}
+	if err := testutil.GatherAndCompare(legacyregistry.DefaultGatherer, strings.NewReader(want), names...); err != nil {
+		t.Fatal(err)
+	}
+
+	// A snapshot without the violating bindings drops the subject series.
+	empty := indexer.BuildSnapshot(indexer.NewManifestSource(""))
+	empty.Generation = 8
+	monitor.Evaluate(empty)
+	want = `
+# HELP rbacgraph_policy_violations [ALPHA] Number of subjects violating each policy in the latest evaluated snapshot.
+# TYPE rbacgraph_policy_violations gauge
+rbacgraph_policy_violations{policy="no-cluster-wide-secrets",severity="error"} 0
+rbacgraph_policy_violations{policy="no-pod-exec",severity="warning"} 0
+`
+	if err := testutil.GatherAndCompare(legacyregistry.DefaultGatherer, strings.NewReader(want),
+		"rbacgraph_policy_violating_subject", "rbacgraph_policy_violations"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMonitor_RunSkipsUnchangedRBAC(t *testing.T) {
+	policies, err := LoadFile("testdata/policies.yaml")
+	if err != nil {
+		t.Fatalf("LoadFile: %v", err)
+	}
+	src, err := indexer.LoadManifests("", "testdata/manifests")
+	if err != nil {
+		t.Fatalf("LoadManifests: %v", err)
+	}
+	monitor := NewMonitor(engine.New(), policies, func() *indexer.APIDiscoveryCache { return nil })
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go monitor.Run(ctx)
+
+	evaluations := func() uint64 {
+		vec, err := testutil.GetHistogramVecFromGatherer(legacyregistry.DefaultGatherer, "rbacgraph_policy_evaluation_duration_seconds", nil)
+		if err != nil {
+			t.Fatalf("gather evaluation duration: %v", err)
+		}
+
+		return vec.GetAggregatedSampleCount()
+	}
+	before := evaluations()
+	notify := func(generation, rbacGeneration int64) {
+		snapshot := indexer.BuildSnapshot(src)
+		snapshot.Generation = generation
+		snapshot.RBACGeneration = rbacGeneration
+		monitor.Notify(snapshot)
+	}
+	waitForGeneration := func(generation float64) {
+		deadline := time.Now().Add(5 * time.Second)
+		for {
+			got, err := testutil.GetGaugeMetricValue(evaluatedGenerationMetric)
+			if err == nil && got == generation {
+				return
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("expected evaluated generation %v, got %v (err=%v)", generation, got, err)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	notify(1, 1)
+	waitForGeneration(1)
+	// A runtime-only publish keeps the RBAC generation and is not evaluated.
+	notify(2, 1)
+	notify(3, 3)
+	waitForGeneration(3)
+
+	if got := evaluations() - before; got != 2 {
+		t.Fatalf("expected 2 evaluations, got %d", got)
+	}
+}
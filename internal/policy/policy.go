@@ -0,0 +1,180 @@
+// Package policy evaluates declared RBAC assertions against a snapshot. A
+// policy is a review query plus the subjects allowed to match it; every other
+// subject the query reaches is a violation.
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+
+	"sigs.k8s.io/yaml"
+
+	api "k8s-role-graph/pkg/apis/rbacgraph"
+	"k8s-role-graph/pkg/apis/rbacgraph/v1alpha1"
+)
+
+// Severity controls whether a violation fails a lint run.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Set is the policy file format.
+type Set struct {
+	Policies []Policy `json:"policies"`
+}
+
+// Policy is a named assertion. Query selects the permission, for example
+// get on secrets; every subject granted it must match one of Allow.
+type Policy struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Severity    Severity `json:"severity,omitempty"`
+	// Query uses the RoleGraphReview spec format.
+	Query v1alpha1.RoleGraphReviewSpec `json:"query"`
+	// ClusterWideOnly ignores grants that apply in single namespaces.
+	ClusterWideOnly bool             `json:"clusterWideOnly,omitempty"`
+	Allow           []SubjectPattern `json:"allow,omitempty"`
+}
+
+// SubjectPattern matches subjects by kind, name and namespace. Name and
+// Namespace are path.Match globs; empty fields match anything. A pattern
+// with a namespace only matches ServiceAccounts.
+type SubjectPattern struct {
+	Kind      string `json:"kind,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// LoadFile reads and validates a policy file. Unknown fields are rejected so
+// a typo does not silently disable an assertion.
+func LoadFile(file string) ([]Policy, error) {
+	data, err := os.ReadFile(file) //nolint:gosec // path is supplied by the operator
+	if err != nil {
+		return nil, err
+	}
+	var set Set
+	if err := yaml.UnmarshalStrict(data, &set); err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+	if err := Validate(set.Policies); err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+
+	return set.Policies, nil
+}
+
+// LoadFiles reads several policy files. Names must be unique across files.
+func LoadFiles(files ...string) ([]Policy, error) {
+	var policies []Policy
+	for _, file := range files {
+		loaded, err := LoadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, loaded...)
+	}
+	if err := Validate(policies); err != nil {
+		return nil, err
+	}
+
+	return policies, nil
+}
+
+// Validate checks policy names, severities, subject patterns and queries.
+func Validate(policies []Policy) error {
+	var errs []error
+	seen := make(map[string]struct{}, len(policies))
+	for i, p := range policies {
+		if p.Name == "" {
+			errs = append(errs, fmt.Errorf("policies[%d]: name is required", i))
+
+			continue
+		}
+		if _, ok := seen[p.Name]; ok {
+			errs = append(errs, fmt.Errorf("policy %q: duplicate name", p.Name))
+		}
+		seen[p.Name] = struct{}{}
+		if p.Severity != "" && p.Severity != SeverityError && p.Severity != SeverityWarning {
+			errs = append(errs, fmt.Errorf("policy %q: severity must be %q or %q", p.Name, SeverityError, SeverityWarning))
+		}
+		for j, pattern := range p.Allow {
+			if err := pattern.validate(); err != nil {
+				errs = append(errs, fmt.Errorf("policy %q: allow[%d]: %w", p.Name, j, err))
+			}
+		}
+		query := p.Query
+		query.EnsureDefaults()
+		if query.Selector.IsEmpty() && query.Object == nil {
+			errs = append(errs, fmt.Errorf("policy %q: query.selector or query.object is required", p.Name))
+		} else if err := query.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("policy %q: query: %w", p.Name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// EffectiveSeverity returns the severity with the default applied.
+func (p Policy) EffectiveSeverity() Severity {
+	if p.Severity == "" {
+		return SeverityError
+	}
+
+	return p.Severity
+}
+
+// reviewSpec returns the query as an internal spec with defaults applied.
+func (p Policy) reviewSpec() (api.RoleGraphReviewSpec, error) {
+	query := p.Query
+	query.EnsureDefaults()
+	var spec api.RoleGraphReviewSpec
+	if err := v1alpha1.Convert_v1alpha1_RoleGraphReviewSpec_To_rbacgraph_RoleGraphReviewSpec(&query, &spec, nil); err != nil {
+		return spec, err
+	}
+	spec.EnsureDefaults()
+
+	return spec, nil
+}
+
+// allows reports whether any Allow pattern matches subject.
+func (p Policy) allows(subject api.SubjectRef) bool {
+	for _, pattern := range p.Allow {
+		if pattern.matches(subject) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s SubjectPattern) validate() error {
+	for _, pattern := range []string{s.Name, s.Namespace} {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+	}
+
+	return nil
+}
+
+func (s SubjectPattern) matches(subject api.SubjectRef) bool {
+	if s.Kind != "" && s.Kind != subject.Kind {
+		return false
+	}
+	if s.Namespace != "" && (subject.Kind != api.SubjectKindServiceAccount || !globMatch(s.Namespace, subject.Namespace)) {
+		return false
+	}
+
+	return s.Name == "" || globMatch(s.Name, subject.Name)
+}
+
+func globMatch(pattern, value string) bool {
+	ok, err := path.Match(pattern, value)
+
+	return err == nil && ok
+}
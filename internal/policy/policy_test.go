@@ -0,0 +1,142 @@
+package policy
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"k8s-role-graph/internal/engine"
+	"k8s-role-graph/internal/indexer"
+	api "k8s-role-graph/pkg/apis/rbacgraph"
+	"k8s-role-graph/pkg/apis/rbacgraph/v1alpha1"
+)
+
+func evaluateQuery(resource, verb string) v1alpha1.RoleGraphReviewSpec {
+	return v1alpha1.RoleGraphReviewSpec{
+		Selector: v1alpha1.Selector{Resources: []string{resource}, Verbs: []string{verb}},
+	}
+}
+
+func evaluateTestdata(t *testing.T) ([]Result, *indexer.ManifestSource) {
+	t.Helper()
+	policies, err := LoadFile("testdata/policies.yaml")
+	if err != nil {
+		t.Fatalf("LoadFile: %v", err)
+	}
+	src, err := indexer.LoadManifests("", "testdata/manifests")
+	if err != nil {
+		t.Fatalf("LoadManifests: %v", err)
+	}
+
+	return Evaluate(engine.New(), indexer.BuildSnapshot(src), nil, policies), src
+}
+
+func TestEvaluate_ClusterWideSecrets(t *testing.T) {
+	results, _ := evaluateTestdata(t)
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+
+	secrets := results[0]
+	if !secrets.Failed() {
+		t.Fatal("expected no-cluster-wide-secrets to fail")
+	}
+	if len(secrets.Violations) != 1 {
+		t.Fatalf("expected only team-a/app to violate, got %#v", secrets.Violations)
+	}
+	v := secrets.Violations[0]
+	want := api.SubjectRef{Kind: api.SubjectKindServiceAccount, Namespace: "team-a", Name: "app"}
+	if v.Subject != want || !v.ClusterWide {
+		t.Fatalf("unexpected violation %#v", v)
+	}
+	if len(v.Bindings) != 1 || v.Bindings[0].Name != "app-secrets" {
+		t.Fatalf("expected binding app-secrets, got %v", v.Bindings)
+	}
+	if len(v.Roles) != 1 || v.Roles[0] != (api.ObjectRef{Kind: indexer.KindClusterRole, Name: "secret-reader"}) {
+		t.Fatalf("expected role secret-reader, got %v", v.Roles)
+	}
+	if !strings.Contains(v.Message(), "cluster-wide via ClusterRoleBinding/app-secrets") {
+		t.Fatalf("unexpected message %q", v.Message())
+	}
+}
+
+func TestEvaluate_WarningSeverityDoesNotFail(t *testing.T) {
+	results, _ := evaluateTestdata(t)
+
+	exec := results[1]
+	if exec.Failed() {
+		t.Fatal("expected warning policy not to fail")
+	}
+	if len(exec.Violations) != 1 || exec.Violations[0].Subject.Name != "alice" {
+		t.Fatalf("expected only User alice to violate, got %#v", exec.Violations)
+	}
+	if got := exec.Violations[0].Namespaces; len(got) != 1 || got[0] != "team-b" {
+		t.Fatalf("expected namespace team-b, got %v", got)
+	}
+}
+
+func TestSubjectPattern_ServiceAccountNamespaceFromBinding(t *testing.T) {
+	policies := []Policy{{
+		Name:  "local",
+		Query: evaluateQuery("secrets", "get"),
+		Allow: []SubjectPattern{{Namespace: "team-b"}},
+	}}
+	src, err := indexer.LoadManifests("", "testdata/manifests")
+	if err != nil {
+		t.Fatalf("LoadManifests: %v", err)
+	}
+	results := Evaluate(engine.New(), indexer.BuildSnapshot(src), nil, policies)
+
+	for _, v := range results[0].Violations {
+		if v.Subject.Name == "worker" {
+			t.Fatalf("expected team-b/worker without subject namespace to be allowed, got %#v", v)
+		}
+	}
+}
+
+func TestLoadFile_RejectsInvalidPolicies(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name:    "unknown field",
+			content: "policies:\n- name: a\n  alow: []\n  query: {selector: {resources: [secrets]}}\n",
+			wantErr: "unknown field",
+		},
+		{
+			name:    "duplicate name",
+			content: "policies:\n- name: a\n  query: {selector: {resources: [secrets]}}\n- name: a\n  query: {selector: {resources: [pods]}}\n",
+			wantErr: "duplicate name",
+		},
+		{
+			name:    "empty query",
+			content: "policies:\n- name: a\n  query: {}\n",
+			wantErr: "query.selector or query.object is required",
+		},
+		{
+			name:    "severity",
+			content: "policies:\n- name: a\n  severity: fatal\n  query: {selector: {resources: [secrets]}}\n",
+			wantErr: "severity must be",
+		},
+		{
+			name:    "pattern",
+			content: "policies:\n- name: a\n  query: {selector: {resources: [secrets]}}\n  allow: [{name: \"[\"}]\n",
+			wantErr: "invalid pattern",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "policies.yaml")
+			if err := os.WriteFile(file, []byte(tt.content), 0o600); err != nil {
+				t.Fatalf("write: %v", err)
+			}
+			_, err := LoadFile(file)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
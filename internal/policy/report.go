@@ -0,0 +1,227 @@
+package policy
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// OriginFunc returns the file an object was read from, or "" when unknown.
+// ManifestSource.Origin satisfies it.
+type OriginFunc func(kind, namespace, name string) string
+
+// WriteText writes one line per violation and a summary.
+func WriteText(w io.Writer, results []Result) error {
+	var violations, failed int
+	var b strings.Builder
+	for _, result := range results {
+		for _, warning := range result.Warnings {
+			fmt.Fprintf(&b, "WARN  %s: %s\n", result.Policy.Name, warning)
+		}
+		for _, v := range result.Violations {
+			fmt.Fprintf(&b, "%-5s %s: %s\n", textLabel(v.Severity), v.Policy, v.Message())
+		}
+		violations += len(result.Violations)
+		if result.Failed() {
+			failed++
+		}
+	}
+	fmt.Fprintf(&b, "%d policies checked, %d violations, %d policies failed\n", len(results), violations, failed)
+	_, err := io.WriteString(w, b.String())
+
+	return err
+}
+
+func textLabel(severity Severity) string {
+	if severity == SeverityWarning {
+		return "WARN"
+	}
+
+	return "ERROR"
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name       `xml:"testsuites"`
+	Name     string         `xml:"name,attr"`
+	Tests    int            `xml:"tests,attr"`
+	Failures int            `xml:"failures,attr"`
+	Suites   []junitTestSet `xml:"testsuite"`
+}
+
+type junitTestSet struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// WriteJUnit writes one test case per policy. Policies with error-severity
+// violations fail; warning-severity violations are reported as output.
+func WriteJUnit(w io.Writer, results []Result) error {
+	suite := junitTestSet{Name: "rbacgraph lint", Tests: len(results)}
+	for _, result := range results {
+		tc := junitTestCase{Name: result.Policy.Name, ClassName: "rbacgraph.policy"}
+		lines := make([]string, 0, len(result.Violations))
+		for _, v := range result.Violations {
+			lines = append(lines, v.Message())
+		}
+		switch {
+		case result.Failed():
+			suite.Failures++
+			tc.Failure = &junitFailure{
+				Message: fmt.Sprintf("%d violations", len(result.Violations)),
+				Type:    string(SeverityError),
+				Text:    strings.Join(lines, "\n"),
+			}
+		case len(lines) > 0:
+			tc.SystemOut = strings.Join(lines, "\n")
+		}
+		suite.Cases = append(suite.Cases, tc)
+	}
+	doc := junitTestSuites{Name: suite.Name, Tests: suite.Tests, Failures: suite.Failures, Suites: []junitTestSet{suite}}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+
+	return err
+}
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+)
+
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name  string      `json:"name"`
+	Rules []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+	LogicalLocations []sarifLogical        `json:"logicalLocations,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifact `json:"artifactLocation"`
+}
+
+type sarifArtifact struct {
+	URI string `json:"uri"`
+}
+
+type sarifLogical struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+// WriteSARIF writes a SARIF 2.1.0 log with one rule per policy and one
+// result per violation. Results point at the manifests of the granting
+// bindings when origin knows them; origin may be nil.
+func WriteSARIF(w io.Writer, results []Result, origin OriginFunc) error {
+	run := sarifRun{
+		Tool:    sarifTool{Driver: sarifDriver{Name: "rbacgraph", Rules: make([]sarifRule, 0, len(results))}},
+		Results: []sarifResult{},
+	}
+	for _, result := range results {
+		description := result.Policy.Description
+		if description == "" {
+			description = result.Policy.Name
+		}
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{
+			ID:               result.Policy.Name,
+			ShortDescription: sarifMessage{Text: description},
+		})
+		for _, v := range result.Violations {
+			run.Results = append(run.Results, sarifResult{
+				RuleID:    v.Policy,
+				Level:     sarifLevel(v.Severity),
+				Message:   sarifMessage{Text: v.Message()},
+				Locations: sarifLocations(v, origin),
+			})
+		}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(sarifLog{Version: sarifVersion, Schema: sarifSchema, Runs: []sarifRun{run}})
+}
+
+func sarifLevel(severity Severity) string {
+	if severity == SeverityWarning {
+		return "warning"
+	}
+
+	return "error"
+}
+
+func sarifLocations(v Violation, origin OriginFunc) []sarifLocation {
+	if origin == nil {
+		return nil
+	}
+	var out []sarifLocation
+	for _, ref := range v.Bindings {
+		file := origin(ref.Kind, ref.Namespace, ref.Name)
+		if file == "" {
+			continue
+		}
+		out = append(out, sarifLocation{
+			PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifact{URI: file}},
+			LogicalLocations: []sarifLogical{{FullyQualifiedName: objectRefString(ref), Kind: "resource"}},
+		})
+	}
+
+	return out
+}
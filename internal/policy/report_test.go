@@ -0,0 +1,72 @@
+package policy
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func TestWriteText(t *testing.T) {
+	results, _ := evaluateTestdata(t)
+	var buf bytes.Buffer
+	if err := WriteText(&buf, results); err != nil {
+		t.Fatalf("WriteText: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"ERROR no-cluster-wide-secrets: ServiceAccount team-a/app is granted access cluster-wide",
+		"WARN  no-pod-exec: User alice is granted access in team-b via RoleBinding/team-b/debug",
+		"2 policies checked, 2 violations, 1 policies failed",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in output:\n%s", want, out)
+		}
+	}
+}
+
+func TestWriteJUnit(t *testing.T) {
+	results, _ := evaluateTestdata(t)
+	var buf bytes.Buffer
+	if err := WriteJUnit(&buf, results); err != nil {
+		t.Fatalf("WriteJUnit: %v", err)
+	}
+	var doc junitTestSuites
+	if err := xml.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("unmarshal: %v\n%s", err, buf.String())
+	}
+	if doc.Tests != 2 || doc.Failures != 1 {
+		t.Fatalf("expected 2 tests and 1 failure, got %d/%d", doc.Tests, doc.Failures)
+	}
+	cases := doc.Suites[0].Cases
+	if cases[0].Failure == nil || cases[1].Failure != nil || cases[1].SystemOut == "" {
+		t.Fatalf("expected only the error policy to fail, got %#v", cases)
+	}
+}
+
+func TestWriteSARIF_LocatesBindingManifest(t *testing.T) {
+	results, src := evaluateTestdata(t)
+	var buf bytes.Buffer
+	if err := WriteSARIF(&buf, results, src.Origin); err != nil {
+		t.Fatalf("WriteSARIF: %v", err)
+	}
+	var log sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	run := log.Runs[0]
+	if log.Version != sarifVersion || len(run.Tool.Driver.Rules) != 2 || len(run.Results) != 2 {
+		t.Fatalf("unexpected SARIF log %s", buf.String())
+	}
+	first := run.Results[0]
+	if first.RuleID != "no-cluster-wide-secrets" || first.Level != "error" {
+		t.Fatalf("unexpected result %#v", first)
+	}
+	if len(first.Locations) != 1 || first.Locations[0].PhysicalLocation.ArtifactLocation.URI != "testdata/manifests/rbac.yaml" {
+		t.Fatalf("expected location in rbac.yaml, got %#v", first.Locations)
+	}
+	if run.Results[1].Level != "warning" {
+		t.Fatalf("expected warning level, got %q", run.Results[1].Level)
+	}
+}
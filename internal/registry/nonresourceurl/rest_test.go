@@ -14,7 +14,9 @@ import (
 func newTestREST(roles map[indexer.RoleID]*indexer.RoleRecord) *REST {
 	idx := indexer.New(fake.NewSimpleClientset(), 0)
 	idx.SetSnapshotForTest(&indexer.Snapshot{
-		RolesByID: roles,
+		RBACIndex: indexer.RBACIndex{
+			RolesByID: roles,
+		},
 	})
 
 	return NewREST(idx)
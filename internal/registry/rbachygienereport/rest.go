@@ -0,0 +1,78 @@
+package rbachygienereport
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/registry/rest"
+
+	"k8s-role-graph/internal/authz"
+	"k8s-role-graph/internal/engine"
+	"k8s-role-graph/internal/indexer"
+	"k8s-role-graph/pkg/apis/rbacgraph"
+	"k8s-role-graph/pkg/apis/rbacgraph/v1alpha1"
+)
+
+type REST struct {
+	engine        *engine.Engine
+	indexer       *indexer.Indexer
+	authzResolver authz.ScopeResolver // nil when --enforce-caller-scope is disabled
+}
+
+var _ rest.Storage = &REST{}
+var _ rest.Creater = &REST{}
+var _ rest.SingularNameProvider = &REST{}
+
+func NewREST(eng *engine.Engine, idx *indexer.Indexer, resolver authz.ScopeResolver) *REST {
+	return &REST{
+		engine:        eng,
+		indexer:       idx,
+		authzResolver: resolver,
+	}
+}
+
+func (r *REST) New() runtime.Object {
+	return &rbacgraph.RBACHygieneReport{}
+}
+
+func (r *REST) Destroy() {}
+
+func (r *REST) NamespaceScoped() bool {
+	return false
+}
+
+func (r *REST) GetSingularName() string {
+	return "rbachygienereport"
+}
+
+func (r *REST) Create(ctx context.Context, obj runtime.Object, _ rest.ValidateObjectFunc, _ *metav1.CreateOptions) (runtime.Object, error) {
+	defer engine.ObserveQuery(v1alpha1.RBACHygieneReportResource, time.Now())
+
+	report, ok := obj.(*rbacgraph.RBACHygieneReport)
+	if !ok {
+		return nil, fmt.Errorf("unexpected object type: %T", obj)
+	}
+
+	if err := report.Spec.Validate(); err != nil {
+		return nil, apierrors.NewBadRequest(err.Error())
+	}
+
+	snapshot, scopeWarnings, err := authz.ScopeSnapshot(ctx, r.authzResolver, r.indexer.Snapshot(), report.Spec.NamespaceScope.RequestedNamespaces())
+	if err != nil {
+		return nil, err
+	}
+
+	report.Status = r.engine.QueryHygiene(snapshot, report.Spec)
+
+	if len(scopeWarnings) > 0 {
+		report.Status.Warnings = append(report.Status.Warnings, scopeWarnings...)
+	}
+
+	report.CreationTimestamp = metav1.Now()
+
+	return report, nil
+}
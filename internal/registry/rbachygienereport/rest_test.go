@@ -0,0 +1,86 @@
+package rbachygienereport
+
+import (
+	"context"
+	"testing"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fake "k8s.io/client-go/kubernetes/fake"
+
+	"k8s-role-graph/internal/authz"
+	"k8s-role-graph/internal/engine"
+	"k8s-role-graph/internal/indexer"
+	"k8s-role-graph/pkg/apis/rbacgraph"
+)
+
+func newTestREST(resolver authz.ScopeResolver) (*REST, *indexer.Indexer) {
+	client := fake.NewSimpleClientset()
+	idx := indexer.New(client, 0)
+
+	return NewREST(engine.New(), idx, resolver), idx
+}
+
+func TestCreate_ReportsDanglingBinding(t *testing.T) {
+	r, idx := newTestREST(nil)
+	key := indexer.RoleRefKey{Kind: indexer.KindClusterRole, Name: "deleted"}
+	idx.SetSnapshotForTest(&indexer.Snapshot{
+		RBACIndex: indexer.RBACIndex{
+			RolesByID: map[indexer.RoleID]*indexer.RoleRecord{},
+			BindingsByRoleRef: map[indexer.RoleRefKey][]*indexer.BindingRecord{
+				key: {{
+					Kind:     indexer.KindClusterRoleBinding,
+					Name:     "leftover",
+					RoleRef:  key,
+					Subjects: []rbacv1.Subject{{Kind: indexer.SubjectKindUser, Name: "alice"}},
+				}},
+			},
+		},
+	})
+
+	report := &rbacgraph.RBACHygieneReport{ObjectMeta: metav1.ObjectMeta{Name: "test"}}
+	result, err := r.Create(context.Background(), report, nil, &metav1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resultReport, ok := result.(*rbacgraph.RBACHygieneReport)
+	if !ok {
+		t.Fatalf("expected *rbacgraph.RBACHygieneReport, got %T", result)
+	}
+	if len(resultReport.Status.Findings) != 1 || resultReport.Status.Findings[0].Check != rbacgraph.HygieneCheckMissingRole {
+		t.Fatalf("expected one missingRole finding, got %+v", resultReport.Status.Findings)
+	}
+}
+
+func TestCreate_InvalidCheck(t *testing.T) {
+	r, _ := newTestREST(nil)
+	report := &rbacgraph.RBACHygieneReport{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Spec:       rbacgraph.RBACHygieneReportSpec{Checks: []rbacgraph.HygieneCheck{"orphanedPods"}},
+	}
+
+	_, err := r.Create(context.Background(), report, nil, &metav1.CreateOptions{})
+	if !apierrors.IsBadRequest(err) {
+		t.Fatalf("expected BadRequest for unknown check, got %v", err)
+	}
+}
+
+func TestCreate_WithScopeNoUserInfo(t *testing.T) {
+	resolver := authz.NewLocalResolver(func() *indexer.Snapshot {
+		return nil
+	})
+	r, _ := newTestREST(resolver)
+	report := &rbacgraph.RBACHygieneReport{ObjectMeta: metav1.ObjectMeta{Name: "test"}}
+
+	if _, err := r.Create(context.Background(), report, nil, &metav1.CreateOptions{}); err == nil {
+		t.Fatal("expected error when no user info in context")
+	}
+}
+
+func TestCreate_WrongObjectType(t *testing.T) {
+	r, _ := newTestREST(nil)
+	if _, err := r.Create(context.Background(), &metav1.Status{}, nil, &metav1.CreateOptions{}); err == nil {
+		t.Fatal("expected error for wrong object type")
+	}
+}
@@ -0,0 +1,117 @@
+package rolegraphdiff
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/registry/rest"
+
+	"k8s-role-graph/internal/authz"
+	"k8s-role-graph/internal/engine"
+	"k8s-role-graph/internal/indexer"
+	"k8s-role-graph/pkg/apis/rbacgraph"
+	"k8s-role-graph/pkg/apis/rbacgraph/v1alpha1"
+)
+
+type REST struct {
+	engine        *engine.Engine
+	indexer       *indexer.Indexer
+	authzResolver authz.ScopeResolver // nil when --enforce-caller-scope is disabled
+}
+
+var _ rest.Storage = &REST{}
+var _ rest.Creater = &REST{}
+var _ rest.SingularNameProvider = &REST{}
+
+func NewREST(eng *engine.Engine, idx *indexer.Indexer, resolver authz.ScopeResolver) *REST {
+	return &REST{
+		engine:        eng,
+		indexer:       idx,
+		authzResolver: resolver,
+	}
+}
+
+func (r *REST) New() runtime.Object {
+	return &rbacgraph.RoleGraphDiff{}
+}
+
+func (r *REST) Destroy() {}
+
+func (r *REST) NamespaceScoped() bool {
+	return false
+}
+
+func (r *REST) GetSingularName() string {
+	return "rolegraphdiff"
+}
+
+func (r *REST) Create(ctx context.Context, obj runtime.Object, _ rest.ValidateObjectFunc, _ *metav1.CreateOptions) (runtime.Object, error) {
+	defer engine.ObserveQuery(v1alpha1.RoleGraphDiffResource, time.Now())
+
+	diff, ok := obj.(*rbacgraph.RoleGraphDiff)
+	if !ok {
+		return nil, fmt.Errorf("unexpected object type: %T", obj)
+	}
+
+	diff.Spec.EnsureDefaults()
+	if err := diff.Spec.Validate(); err != nil {
+		return nil, apierrors.NewBadRequest(err.Error())
+	}
+	if err := r.indexer.ValidateSelector(diff.Spec.ReviewSpec().Selector); err != nil {
+		return nil, apierrors.NewBadRequest(err.Error())
+	}
+
+	from, err := r.resolve(diff.Spec.From)
+	if err != nil {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("from: %v", err))
+	}
+	to, err := r.resolve(diff.Spec.To)
+	if err != nil {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("to: %v", err))
+	}
+
+	namespaces := diff.Spec.NamespaceScope.RequestedNamespaces()
+	scopedFrom, scopeWarnings, err := authz.ScopeSnapshot(ctx, r.authzResolver, from, namespaces)
+	if err != nil {
+		return nil, err
+	}
+	scopedTo, toWarnings, err := authz.ScopeSnapshot(ctx, r.authzResolver, to, namespaces)
+	if err != nil {
+		return nil, err
+	}
+
+	diff.Status = r.engine.Diff(scopedFrom, scopedTo, diff.Spec, r.indexer.DiscoveryCache())
+
+	seen := make(map[string]struct{}, len(diff.Status.Warnings))
+	for _, warning := range diff.Status.Warnings {
+		seen[warning] = struct{}{}
+	}
+	for _, warning := range append(scopeWarnings, toWarnings...) {
+		if _, ok := seen[warning]; ok {
+			continue
+		}
+		seen[warning] = struct{}{}
+		diff.Status.Warnings = append(diff.Status.Warnings, warning)
+	}
+
+	diff.CreationTimestamp = metav1.Now()
+
+	return diff, nil
+}
+
+// resolve returns the snapshot selected by point; an empty point selects
+// the current snapshot.
+func (r *REST) resolve(point rbacgraph.SnapshotPoint) (*indexer.Snapshot, error) {
+	switch {
+	case point.Time != nil:
+		return r.indexer.SnapshotAt(point.Time.Time)
+	case point.Generation != 0:
+		return r.indexer.SnapshotGeneration(point.Generation)
+	default:
+		return r.indexer.Snapshot(), nil
+	}
+}
@@ -0,0 +1,78 @@
+package rolegraphdiff
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fake "k8s.io/client-go/kubernetes/fake"
+
+	"k8s-role-graph/internal/authz"
+	"k8s-role-graph/internal/engine"
+	"k8s-role-graph/internal/indexer"
+	"k8s-role-graph/pkg/apis/rbacgraph"
+)
+
+func newTestREST(resolver authz.ScopeResolver) (*REST, *indexer.Indexer) {
+	client := fake.NewSimpleClientset()
+	idx := indexer.New(client, 0)
+
+	return NewREST(engine.New(), idx, resolver), idx
+}
+
+func TestCreate_RequiresFrom(t *testing.T) {
+	r, _ := newTestREST(nil)
+	diff := &rbacgraph.RoleGraphDiff{ObjectMeta: metav1.ObjectMeta{Name: "test"}}
+
+	_, err := r.Create(context.Background(), diff, nil, &metav1.CreateOptions{})
+	if !apierrors.IsBadRequest(err) {
+		t.Fatalf("expected BadRequest without from, got %v", err)
+	}
+}
+
+func TestCreate_UnretainedGeneration(t *testing.T) {
+	r, idx := newTestREST(nil)
+	idx.SetSnapshotForTest(&indexer.Snapshot{Generation: 5})
+	diff := &rbacgraph.RoleGraphDiff{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Spec:       rbacgraph.RoleGraphDiffSpec{From: rbacgraph.SnapshotPoint{Generation: 3}},
+	}
+
+	_, err := r.Create(context.Background(), diff, nil, &metav1.CreateOptions{})
+	if !apierrors.IsBadRequest(err) {
+		t.Fatalf("expected BadRequest for unretained generation, got %v", err)
+	}
+}
+
+func TestCreate_ComparesHistoryWithCurrent(t *testing.T) {
+	r, idx := newTestREST(nil)
+	history, err := indexer.NewHistory(4, 0, "")
+	if err != nil {
+		t.Fatalf("NewHistory: %v", err)
+	}
+	idx.SetHistory(history)
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	history.Record(&indexer.Snapshot{Generation: 1, BuiltAt: base})
+	current := &indexer.Snapshot{Generation: 2, BuiltAt: base.Add(time.Hour)}
+	history.Record(current)
+	idx.SetSnapshotForTest(current)
+
+	from := metav1.NewTime(base.Add(time.Minute))
+	diff := &rbacgraph.RoleGraphDiff{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Spec:       rbacgraph.RoleGraphDiffSpec{From: rbacgraph.SnapshotPoint{Time: &from}},
+	}
+	result, err := r.Create(context.Background(), diff, nil, &metav1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	status := result.(*rbacgraph.RoleGraphDiff).Status
+	if status.From.Generation != 1 || status.To.Generation != 2 {
+		t.Fatalf("unexpected generations from=%d to=%d", status.From.Generation, status.To.Generation)
+	}
+	if status.To.BuiltAt == nil || !status.To.BuiltAt.Time.Equal(base.Add(time.Hour)) {
+		t.Fatalf("unexpected to.builtAt %v", status.To.BuiltAt)
+	}
+}
@@ -2,19 +2,19 @@ package rolegraphreview
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"time"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/apiserver/pkg/endpoints/request"
 	"k8s.io/apiserver/pkg/registry/rest"
 
 	"k8s-role-graph/internal/authz"
 	"k8s-role-graph/internal/engine"
 	"k8s-role-graph/internal/indexer"
 	"k8s-role-graph/pkg/apis/rbacgraph"
+	"k8s-role-graph/pkg/apis/rbacgraph/v1alpha1"
 )
 
 type REST struct {
@@ -52,6 +52,8 @@ func (r *REST) GetSingularName() string {
 }
 
 func (r *REST) Create(ctx context.Context, obj runtime.Object, _ rest.ValidateObjectFunc, _ *metav1.CreateOptions) (runtime.Object, error) {
+	defer engine.ObserveQuery(v1alpha1.Resource, time.Now())
+
 	review, ok := obj.(*rbacgraph.RoleGraphReview)
 	if !ok {
 		return nil, fmt.Errorf("unexpected object type: %T", obj)
@@ -62,28 +64,35 @@ func (r *REST) Create(ctx context.Context, obj runtime.Object, _ rest.ValidateOb
 		return nil, err
 	}
 
-	if err := r.indexer.ValidateSelector(review.Spec.Selector); err != nil {
+	selector := review.Spec.Selector
+	if review.Spec.Object != nil {
+		selector = review.Spec.Object.Selector()
+	}
+	if err := r.indexer.ValidateSelector(selector); err != nil {
 		return nil, apierrors.NewBadRequest(err.Error())
 	}
 
-	snapshot := r.indexer.Snapshot()
-
-	var scopeWarnings []string
-	if r.authzResolver != nil {
-		userInfo, ok := request.UserFrom(ctx)
-		if !ok {
-			return nil, errors.New("cannot enforce caller scope: no user info in request context")
-		}
-		namespacesToCheck := collectNamespacesFromSnapshot(snapshot, review.Spec.NamespaceScope)
-		scope, err := r.authzResolver.Resolve(ctx, userInfo, namespacesToCheck)
+	base := r.indexer.Snapshot()
+	if review.Spec.AsOf != nil {
+		historical, err := r.indexer.SnapshotAt(review.Spec.AsOf.Time)
 		if err != nil {
-			return nil, fmt.Errorf("resolve caller access scope: %w", err)
+			return nil, apierrors.NewBadRequest(fmt.Sprintf("asOf: %v", err))
 		}
-		snapshot = indexer.Scoped(snapshot, scope)
-		scopeWarnings = scope.Warnings
+		base = historical
+	}
+
+	snapshot, scopeWarnings, err := authz.ScopeSnapshot(ctx, r.authzResolver, base, review.Spec.NamespaceScope.RequestedNamespaces())
+	if err != nil {
+		return nil, err
 	}
 
 	review.Status = r.engine.Query(snapshot, review.Spec, r.indexer.DiscoveryCache())
+	engine.ObserveGraph(review.Status.Graph)
+	review.Status.SnapshotGeneration = base.Generation
+	if !base.BuiltAt.IsZero() {
+		builtAt := metav1.NewTime(base.BuiltAt)
+		review.Status.SnapshotBuiltAt = &builtAt
+	}
 
 	if len(scopeWarnings) > 0 {
 		review.Status.Warnings = append(review.Status.Warnings, scopeWarnings...)
@@ -93,48 +102,3 @@ func (r *REST) Create(ctx context.Context, obj runtime.Object, _ rest.ValidateOb
 
 	return review, nil
 }
-
-// collectNamespacesFromSnapshot extracts unique namespaces from the snapshot,
-// optionally filtered by the NamespaceScope from the request spec.
-func collectNamespacesFromSnapshot(s *indexer.Snapshot, nsScope rbacgraph.NamespaceScope) []string {
-	nsSet := make(map[string]struct{})
-	addNS := func(ns string) {
-		if ns != "" {
-			nsSet[ns] = struct{}{}
-		}
-	}
-	for _, rec := range s.RolesByID {
-		addNS(rec.Namespace)
-	}
-	for _, bindings := range s.BindingsByRoleRef {
-		for _, b := range bindings {
-			addNS(b.Namespace)
-		}
-	}
-	for key := range s.PodsByServiceAccount {
-		addNS(key.Namespace)
-	}
-	for _, w := range s.WorkloadsByUID {
-		addNS(w.Namespace)
-	}
-
-	// If the caller specified explicit namespaces, intersect.
-	if len(nsScope.Namespaces) > 0 {
-		allowed := make(map[string]struct{}, len(nsScope.Namespaces))
-		for _, ns := range nsScope.Namespaces {
-			allowed[ns] = struct{}{}
-		}
-		for ns := range nsSet {
-			if _, ok := allowed[ns]; !ok {
-				delete(nsSet, ns)
-			}
-		}
-	}
-
-	out := make([]string, 0, len(nsSet))
-	for ns := range nsSet {
-		out = append(out, ns)
-	}
-
-	return out
-}
@@ -3,9 +3,10 @@ package rolegraphreview
 import (
 	"context"
 	"testing"
+	"time"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apiserver/pkg/authentication/user"
 	"k8s.io/apiserver/pkg/endpoints/request"
 	fake "k8s.io/client-go/kubernetes/fake"
@@ -127,47 +128,50 @@ func TestCreate_WrongObjectType(t *testing.T) {
 	}
 }
 
-func TestCollectNamespacesFromSnapshot(t *testing.T) {
-	snap := &indexer.Snapshot{
-		RolesByID: map[indexer.RoleID]*indexer.RoleRecord{
-			"Role/ns-a/read": {Namespace: "ns-a", Name: "read"},
-			"Role/ns-b/edit": {Namespace: "ns-b", Name: "edit"},
-		},
-		BindingsByRoleRef:     make(map[indexer.RoleRefKey][]*indexer.BindingRecord),
-		PodsByServiceAccount:  make(map[indexer.ServiceAccountKey][]*indexer.PodRecord),
-		WorkloadsByUID:        make(map[types.UID]*indexer.WorkloadRecord),
-		AggregatedRoleSources: make(map[indexer.RoleID][]indexer.RoleID),
+func TestCreate_AsOfWithoutHistory(t *testing.T) {
+	r := newTestREST(nil)
+	asOf := metav1.Now()
+	review := &rbacgraph.RoleGraphReview{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Spec:       rbacgraph.RoleGraphReviewSpec{AsOf: &asOf},
 	}
 
-	namespaces := collectNamespacesFromSnapshot(snap, rbacgraph.NamespaceScope{})
-	nsSet := make(map[string]struct{})
-	for _, ns := range namespaces {
-		nsSet[ns] = struct{}{}
-	}
-	if _, ok := nsSet["ns-a"]; !ok {
-		t.Error("expected ns-a in namespaces")
-	}
-	if _, ok := nsSet["ns-b"]; !ok {
-		t.Error("expected ns-b in namespaces")
+	_, err := r.Create(context.Background(), review, nil, &metav1.CreateOptions{})
+	if !apierrors.IsBadRequest(err) {
+		t.Fatalf("expected BadRequest when history is disabled, got %v", err)
 	}
 }
 
-func TestCollectNamespacesFromSnapshot_WithFilter(t *testing.T) {
-	snap := &indexer.Snapshot{
-		RolesByID: map[indexer.RoleID]*indexer.RoleRecord{
-			"Role/ns-a/read": {Namespace: "ns-a", Name: "read"},
-			"Role/ns-b/edit": {Namespace: "ns-b", Name: "edit"},
-		},
-		BindingsByRoleRef:     make(map[indexer.RoleRefKey][]*indexer.BindingRecord),
-		PodsByServiceAccount:  make(map[indexer.ServiceAccountKey][]*indexer.PodRecord),
-		WorkloadsByUID:        make(map[types.UID]*indexer.WorkloadRecord),
-		AggregatedRoleSources: make(map[indexer.RoleID][]indexer.RoleID),
+func TestCreate_AsOfResolvesHistoricalSnapshot(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	idx := indexer.New(client, 0)
+	history, err := indexer.NewHistory(4, 0, "")
+	if err != nil {
+		t.Fatalf("NewHistory: %v", err)
 	}
+	idx.SetHistory(history)
+	r := NewREST(engine.New(), idx, nil, nil)
 
-	namespaces := collectNamespacesFromSnapshot(snap, rbacgraph.NamespaceScope{
-		Namespaces: []string{"ns-a"},
-	})
-	if len(namespaces) != 1 || namespaces[0] != "ns-a" {
-		t.Errorf("expected [ns-a], got %v", namespaces)
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	history.Record(&indexer.Snapshot{Generation: 1, BuiltAt: base})
+	current := &indexer.Snapshot{Generation: 2, BuiltAt: base.Add(time.Hour)}
+	history.Record(current)
+	idx.SetSnapshotForTest(current)
+
+	asOf := metav1.NewTime(base.Add(30 * time.Minute))
+	review := &rbacgraph.RoleGraphReview{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Spec:       rbacgraph.RoleGraphReviewSpec{AsOf: &asOf},
+	}
+	result, err := r.Create(context.Background(), review, nil, &metav1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	status := result.(*rbacgraph.RoleGraphReview).Status
+	if status.SnapshotGeneration != 1 {
+		t.Fatalf("expected generation 1, got %d", status.SnapshotGeneration)
+	}
+	if status.SnapshotBuiltAt == nil || !status.SnapshotBuiltAt.Time.Equal(base) {
+		t.Fatalf("unexpected snapshotBuiltAt %v", status.SnapshotBuiltAt)
 	}
 }
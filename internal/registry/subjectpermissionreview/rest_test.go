@@ -0,0 +1,86 @@
+package subjectpermissionreview
+
+import (
+	"context"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fake "k8s.io/client-go/kubernetes/fake"
+
+	"k8s-role-graph/internal/authz"
+	"k8s-role-graph/internal/engine"
+	"k8s-role-graph/internal/indexer"
+	"k8s-role-graph/pkg/apis/rbacgraph"
+)
+
+func newTestREST(resolver authz.ScopeResolver) *REST {
+	client := fake.NewSimpleClientset()
+	idx := indexer.New(client, 0)
+
+	return NewREST(engine.New(), idx, resolver)
+}
+
+func TestCreate_ServiceAccount(t *testing.T) {
+	r := newTestREST(nil)
+	review := &rbacgraph.SubjectPermissionReview{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Spec: rbacgraph.SubjectPermissionReviewSpec{
+			Subject: rbacgraph.SubjectRef{Kind: rbacgraph.SubjectKindServiceAccount, Namespace: "default", Name: "builder"},
+		},
+	}
+
+	result, err := r.Create(context.Background(), review, nil, &metav1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resultReview, ok := result.(*rbacgraph.SubjectPermissionReview)
+	if !ok {
+		t.Fatalf("expected *rbacgraph.SubjectPermissionReview, got %T", result)
+	}
+	if len(resultReview.Status.Permissions) != 0 {
+		t.Errorf("expected no permissions from empty snapshot, got %d", len(resultReview.Status.Permissions))
+	}
+	if len(resultReview.Status.EffectiveGroups) != 3 {
+		t.Errorf("expected implicit service account groups, got %v", resultReview.Status.EffectiveGroups)
+	}
+}
+
+func TestCreate_InvalidSubject(t *testing.T) {
+	r := newTestREST(nil)
+	review := &rbacgraph.SubjectPermissionReview{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Spec: rbacgraph.SubjectPermissionReviewSpec{
+			Subject: rbacgraph.SubjectRef{Kind: rbacgraph.SubjectKindServiceAccount, Name: "builder"},
+		},
+	}
+
+	_, err := r.Create(context.Background(), review, nil, &metav1.CreateOptions{})
+	if !apierrors.IsBadRequest(err) {
+		t.Fatalf("expected BadRequest for ServiceAccount without namespace, got %v", err)
+	}
+}
+
+func TestCreate_WithScopeNoUserInfo(t *testing.T) {
+	resolver := authz.NewLocalResolver(func() *indexer.Snapshot {
+		return nil
+	})
+	r := newTestREST(resolver)
+	review := &rbacgraph.SubjectPermissionReview{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Spec: rbacgraph.SubjectPermissionReviewSpec{
+			Subject: rbacgraph.SubjectRef{Kind: rbacgraph.SubjectKindUser, Name: "alice"},
+		},
+	}
+
+	if _, err := r.Create(context.Background(), review, nil, &metav1.CreateOptions{}); err == nil {
+		t.Fatal("expected error when no user info in context")
+	}
+}
+
+func TestCreate_WrongObjectType(t *testing.T) {
+	r := newTestREST(nil)
+	if _, err := r.Create(context.Background(), &metav1.Status{}, nil, &metav1.CreateOptions{}); err == nil {
+		t.Fatal("expected error for wrong object type")
+	}
+}
@@ -0,0 +1,153 @@
+package risk
+
+import (
+	"slices"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+
+	api "k8s-role-graph/pkg/apis/rbacgraph"
+)
+
+// Built-in rule IDs.
+const (
+	RuleClusterAdmin        = "cluster-admin-equivalent"
+	RuleWildcardVerbs       = "wildcard-verbs"
+	RuleWildcardResources   = "wildcard-resources"
+	RuleSecretsRead         = "secrets-read"
+	RulePodsExec            = "pods-exec"
+	RuleNodesProxy          = "nodes-proxy"
+	RuleRBACWrite           = "rbac-write"
+	RuleImpersonate         = "impersonate"
+	RuleServiceAccountToken = "serviceaccount-token-create"
+	RulePodsCreate          = "pods-create"
+)
+
+var (
+	readVerbs = []string{"get", "list", "watch"}
+	// Exec and attach are authorized as create over websockets and as get
+	// by older clients.
+	execVerbs      = []string{"create", "get"}
+	rbacWriteVerbs = []string{"create", "update", "patch", "bind", "escalate"}
+	rbacResources  = []string{"roles", "clusterroles", "rolebindings", "clusterrolebindings"}
+
+	impersonateResources = []string{"users", "groups", "serviceaccounts"}
+)
+
+// BuiltinRules returns the default risk catalog entries.
+func BuiltinRules() []Rule {
+	return []Rule{
+		{
+			ID:          RuleClusterAdmin,
+			Description: "all verbs on all resources in all API groups",
+			Score:       100,
+			Matches: func(ref api.RuleRef) bool {
+				return ref.APIGroup == "*" && ref.Resource == "*" && ref.Subresource == "" &&
+					ref.Verb == "*" && len(ref.ResourceNames) == 0
+			},
+		},
+		{
+			ID:          RuleWildcardVerbs,
+			Description: "wildcard verb",
+			Score:       20,
+			Matches: func(ref api.RuleRef) bool {
+				return ref.Verb == "*"
+			},
+		},
+		{
+			ID:          RuleWildcardResources,
+			Description: "wildcard resource",
+			Score:       20,
+			Matches: func(ref api.RuleRef) bool {
+				return ref.Resource == "*"
+			},
+		},
+		{
+			ID:          RuleSecretsRead,
+			Description: "reads secrets",
+			Score:       40,
+			Matches: func(ref api.RuleRef) bool {
+				return coversGroup(ref, "") && coversResource(ref, "secrets", "") && coversVerb(ref, readVerbs...)
+			},
+		},
+		{
+			ID:          RulePodsExec,
+			Description: "executes commands in or attaches to pods",
+			Score:       40,
+			Matches: func(ref api.RuleRef) bool {
+				return coversGroup(ref, "") &&
+					(coversResource(ref, "pods", "exec") || coversResource(ref, "pods", "attach")) &&
+					coversVerb(ref, execVerbs...)
+			},
+		},
+		{
+			ID:          RuleNodesProxy,
+			Description: "proxies to the kubelet API",
+			Score:       50,
+			Matches: func(ref api.RuleRef) bool {
+				return coversGroup(ref, "") && coversResource(ref, "nodes", "proxy")
+			},
+		},
+		{
+			ID:          RuleRBACWrite,
+			Description: "writes, binds or escalates RBAC roles and bindings",
+			Score:       50,
+			Matches: func(ref api.RuleRef) bool {
+				if !coversGroup(ref, rbacv1.GroupName) || !coversVerb(ref, rbacWriteVerbs...) {
+					return false
+				}
+
+				return slices.ContainsFunc(rbacResources, func(resource string) bool {
+					return coversResource(ref, resource, "")
+				})
+			},
+		},
+		{
+			ID:          RuleImpersonate,
+			Description: "impersonates users, groups or service accounts",
+			Score:       50,
+			Matches: func(ref api.RuleRef) bool {
+				if !coversGroup(ref, "") || !coversVerb(ref, "impersonate") {
+					return false
+				}
+
+				return slices.ContainsFunc(impersonateResources, func(resource string) bool {
+					return coversResource(ref, resource, "")
+				})
+			},
+		},
+		{
+			ID:          RuleServiceAccountToken,
+			Description: "creates service account tokens",
+			Score:       30,
+			Matches: func(ref api.RuleRef) bool {
+				return coversGroup(ref, "") && coversResource(ref, "serviceaccounts", "token") && coversVerb(ref, "create")
+			},
+		},
+		{
+			ID:          RulePodsCreate,
+			Description: "creates pods and can run them as any service account in the namespace",
+			Score:       20,
+			Matches: func(ref api.RuleRef) bool {
+				return coversGroup(ref, "") && coversResource(ref, "pods", "") && coversVerb(ref, "create")
+			},
+		},
+	}
+}
+
+func coversGroup(ref api.RuleRef, group string) bool {
+	return ref.APIGroup == "*" || ref.APIGroup == group
+}
+
+func coversVerb(ref api.RuleRef, verbs ...string) bool {
+	return ref.Verb == "*" || slices.Contains(verbs, ref.Verb)
+}
+
+// coversResource reports whether the reference grants resource/subresource.
+// A bare "*" covers every resource and subresource.
+func coversResource(ref api.RuleRef, resource, subresource string) bool {
+	if ref.Resource == "*" && ref.Subresource == "" {
+		return true
+	}
+
+	return (ref.Resource == "*" || ref.Resource == resource) && ref.Subresource == subresource
+}
@@ -0,0 +1,153 @@
+// Package risk scores RBAC permissions against a catalog of known-dangerous
+// patterns such as wildcard verbs, secret reads or RBAC writes.
+package risk
+
+import (
+	"sort"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+
+	"k8s-role-graph/internal/matcher"
+	api "k8s-role-graph/pkg/apis/rbacgraph"
+)
+
+// Rule is a single catalog entry. Matches is evaluated against every expanded
+// rule reference; a rule contributes its score at most once per node.
+type Rule struct {
+	ID          string
+	Description string
+	Score       int
+	Matches     func(ref api.RuleRef) bool
+}
+
+// Catalog is an ordered set of risk rules. The zero value and a nil
+// *Catalog are valid and produce no reasons.
+type Catalog struct {
+	rules []Rule
+}
+
+// NewCatalog returns a catalog with the given rules.
+func NewCatalog(rules ...Rule) *Catalog {
+	return &Catalog{rules: append([]Rule(nil), rules...)}
+}
+
+// DefaultCatalog returns a fresh catalog with the built-in rules. Callers may
+// Register additional rules without affecting other catalogs.
+func DefaultCatalog() *Catalog {
+	return NewCatalog(BuiltinRules()...)
+}
+
+// Register appends rules to the catalog. A rule with an ID that is already
+// present replaces the existing one.
+func (c *Catalog) Register(rules ...Rule) {
+	for _, rule := range rules {
+		replaced := false
+		for i := range c.rules {
+			if c.rules[i].ID == rule.ID {
+				c.rules[i] = rule
+				replaced = true
+
+				break
+			}
+		}
+		if !replaced {
+			c.rules = append(c.rules, rule)
+		}
+	}
+}
+
+// Rules returns a copy of the catalog entries.
+func (c *Catalog) Rules() []Rule {
+	if c == nil {
+		return nil
+	}
+
+	return append([]Rule(nil), c.rules...)
+}
+
+// EvaluatePolicyRules expands the policy rules of a role and evaluates them
+// together with extra references, typically the matched refs of a query with
+// their discovery-based wildcard expansions.
+func (c *Catalog) EvaluatePolicyRules(rules []rbacv1.PolicyRule, extra []api.RuleRef) []api.RiskReason {
+	refs := make([]api.RuleRef, 0, len(extra))
+	for idx, rule := range rules {
+		refs = append(refs, matcher.ExpandRule(rule, "", idx)...)
+	}
+	refs = append(refs, extra...)
+
+	return c.Evaluate(refs)
+}
+
+// Evaluate returns the catalog rules matched by any of the references or
+// their ExpandedRefs, ordered by descending score.
+func (c *Catalog) Evaluate(refs []api.RuleRef) []api.RiskReason {
+	if c == nil || len(c.rules) == 0 {
+		return nil
+	}
+	matched := make(map[string]api.RiskReason)
+	var visit func(refs []api.RuleRef)
+	visit = func(refs []api.RuleRef) {
+		for i := range refs {
+			for _, rule := range c.rules {
+				if _, ok := matched[rule.ID]; ok {
+					continue
+				}
+				if rule.Matches(refs[i]) {
+					matched[rule.ID] = api.RiskReason{ID: rule.ID, Description: rule.Description, Score: rule.Score}
+				}
+			}
+			visit(refs[i].ExpandedRefs)
+		}
+	}
+	visit(refs)
+
+	reasons := make([]api.RiskReason, 0, len(matched))
+	for _, reason := range matched {
+		reasons = append(reasons, reason)
+	}
+	sortReasons(reasons)
+
+	return reasons
+}
+
+// Score sums the scores of distinct reasons, capped at api.MaxRiskScore.
+func Score(reasons []api.RiskReason) int {
+	total := 0
+	for _, reason := range reasons {
+		total += reason.Score
+	}
+
+	return min(total, api.MaxRiskScore)
+}
+
+// Merge returns the union of two reason lists keyed by ID, ordered like
+// Evaluate.
+func Merge(existing, incoming []api.RiskReason) []api.RiskReason {
+	if len(incoming) == 0 {
+		return existing
+	}
+	seen := make(map[string]struct{}, len(existing)+len(incoming))
+	merged := make([]api.RiskReason, 0, len(existing)+len(incoming))
+	for _, list := range [][]api.RiskReason{existing, incoming} {
+		for _, reason := range list {
+			if _, ok := seen[reason.ID]; ok {
+				continue
+			}
+			seen[reason.ID] = struct{}{}
+			merged = append(merged, reason)
+		}
+	}
+	sortReasons(merged)
+
+	return merged
+}
+
+func sortReasons(reasons []api.RiskReason) {
+	sort.Slice(reasons, func(i, j int) bool {
+		if reasons[i].Score != reasons[j].Score {
+			return reasons[i].Score > reasons[j].Score
+		}
+
+		return reasons[i].ID < reasons[j].ID
+	})
+}
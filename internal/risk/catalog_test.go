@@ -0,0 +1,119 @@
+package risk
+
+import (
+	"slices"
+	"testing"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+
+	api "k8s-role-graph/pkg/apis/rbacgraph"
+)
+
+func reasonIDs(reasons []api.RiskReason) []string {
+	ids := make([]string, 0, len(reasons))
+	for _, reason := range reasons {
+		ids = append(ids, reason.ID)
+	}
+
+	return ids
+}
+
+func TestEvaluatePolicyRules_BuiltinCatalog(t *testing.T) {
+	testCases := []struct {
+		name     string
+		rule     rbacv1.PolicyRule
+		expected []string
+	}{
+		{
+			name:     "cluster-admin",
+			rule:     rbacv1.PolicyRule{APIGroups: []string{"*"}, Resources: []string{"*"}, Verbs: []string{"*"}},
+			expected: []string{RuleClusterAdmin, RuleImpersonate, RuleNodesProxy, RuleRBACWrite, RulePodsExec, RuleSecretsRead, RuleServiceAccountToken, RulePodsCreate, RuleWildcardResources, RuleWildcardVerbs},
+		},
+		{
+			name:     "secret reader",
+			rule:     rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"secrets"}, Verbs: []string{"list"}},
+			expected: []string{RuleSecretsRead},
+		},
+		{
+			name:     "pods exec",
+			rule:     rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"pods/exec"}, Verbs: []string{"create"}},
+			expected: []string{RulePodsExec},
+		},
+		{
+			name:     "rbac bind",
+			rule:     rbacv1.PolicyRule{APIGroups: []string{rbacv1.GroupName}, Resources: []string{"clusterroles"}, Verbs: []string{"bind"}},
+			expected: []string{RuleRBACWrite},
+		},
+		{
+			name:     "configmap reader is harmless",
+			rule:     rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"configmaps"}, Verbs: []string{"get"}},
+			expected: []string{},
+		},
+		{
+			name:     "pods status is not exec",
+			rule:     rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"pods/status"}, Verbs: []string{"*"}},
+			expected: []string{RuleWildcardVerbs},
+		},
+	}
+
+	catalog := DefaultCatalog()
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := reasonIDs(catalog.EvaluatePolicyRules([]rbacv1.PolicyRule{tc.rule}, nil))
+			if !slices.Equal(got, tc.expected) {
+				t.Fatalf("expected reasons %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestEvaluate_UsesExpandedRefs(t *testing.T) {
+	ref := api.RuleRef{
+		APIGroup: "", Resource: "*", Verb: "get",
+		ExpandedRefs: []api.RuleRef{{APIGroup: "", Resource: "nodes", Subresource: "proxy", Verb: "get"}},
+	}
+	catalog := NewCatalog(BuiltinRules()...)
+	got := reasonIDs(catalog.Evaluate([]api.RuleRef{ref}))
+	if len(got) == 0 || got[0] != RuleNodesProxy {
+		t.Fatalf("expected nodes-proxy from expanded refs first, got %v", got)
+	}
+}
+
+func TestCatalogRegisterAndScore(t *testing.T) {
+	catalog := NewCatalog()
+	catalog.Register(Rule{
+		ID: "configmaps", Score: 70,
+		Matches: func(ref api.RuleRef) bool { return ref.Resource == "configmaps" },
+	})
+	catalog.Register(Rule{
+		ID: "configmaps", Score: 80,
+		Matches: func(ref api.RuleRef) bool { return ref.Resource == "configmaps" },
+	})
+	catalog.Register(Rule{
+		ID: "get", Score: 50,
+		Matches: func(ref api.RuleRef) bool { return ref.Verb == "get" },
+	})
+
+	if len(catalog.Rules()) != 2 {
+		t.Fatalf("expected re-registered rule to replace the original, got %d rules", len(catalog.Rules()))
+	}
+	reasons := catalog.Evaluate([]api.RuleRef{{Resource: "configmaps", Verb: "get"}})
+	if score := Score(reasons); score != api.MaxRiskScore {
+		t.Fatalf("expected score to be capped at %d, got %d", api.MaxRiskScore, score)
+	}
+
+	var nilCatalog *Catalog
+	if reasons := nilCatalog.Evaluate([]api.RuleRef{{Resource: "secrets", Verb: "get"}}); reasons != nil {
+		t.Fatalf("expected nil catalog to produce no reasons, got %v", reasons)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	merged := Merge(
+		[]api.RiskReason{{ID: "a", Score: 10}},
+		[]api.RiskReason{{ID: "b", Score: 30}, {ID: "a", Score: 10}},
+	)
+	if got := reasonIDs(merged); !slices.Equal(got, []string{"b", "a"}) {
+		t.Fatalf("expected merged reasons [b a], got %v", got)
+	}
+}
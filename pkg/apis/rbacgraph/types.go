@@ -1,7 +1,11 @@
 package rbacgraph
 
 import (
+	"errors"
 	"fmt"
+	"path"
+	"regexp"
+	"slices"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -39,9 +43,45 @@ const (
 	PodPhaseModeActive  PodPhaseMode = "active"
 	PodPhaseModeAll     PodPhaseMode = "all"
 	PodPhaseModeRunning PodPhaseMode = "running"
+)
+
+type PolicyRulesMode string
+
+const (
+	PolicyRulesModeNone    PolicyRulesMode = "none"
+	PolicyRulesModeMatched PolicyRulesMode = "matched"
+	PolicyRulesModeAll     PolicyRulesMode = "all"
+)
+
+type NamespaceState string
+
+const (
+	NamespaceStateMissing     NamespaceState = "missing"
+	NamespaceStateTerminating NamespaceState = "terminating"
+)
+
+type CloudIdentityProvider string
+
+const (
+	CloudIdentityProviderAWS   CloudIdentityProvider = "aws"
+	CloudIdentityProviderGCP   CloudIdentityProvider = "gcp"
+	CloudIdentityProviderAzure CloudIdentityProvider = "azure"
+)
+
+type TokenAutomountSource string
+
+const (
+	TokenAutomountSourcePod            TokenAutomountSource = "pod"
+	TokenAutomountSourceServiceAccount TokenAutomountSource = "serviceAccount"
+	TokenAutomountSourceDefault        TokenAutomountSource = "default"
+)
 
+const (
 	DefaultMaxPodsPerSubject  = 20
 	DefaultMaxWorkloadsPerPod = 10
+
+	// MaxRiskScore is the upper bound of GraphNode.RiskScore.
+	MaxRiskScore = 100
 )
 
 type GraphNodeType string
@@ -68,6 +108,16 @@ const (
 	GraphEdgeTypeSubjects   GraphEdgeType = "subjects"
 	GraphEdgeTypeRunsAs     GraphEdgeType = "runsAs"
 	GraphEdgeTypeOwnedBy    GraphEdgeType = "ownedBy"
+
+	// Escalation edges are only emitted with IncludeEscalationPaths. They
+	// point from a subject to a subject it can become, or to a role it can
+	// grant to itself.
+	GraphEdgeTypeCanImpersonate GraphEdgeType = "canImpersonate"
+	GraphEdgeTypeCanRunAs       GraphEdgeType = "canRunAs"
+	GraphEdgeTypeCanReadToken   GraphEdgeType = "canReadToken"
+	GraphEdgeTypeCanMintToken   GraphEdgeType = "canMintToken"
+	GraphEdgeTypeCanBind        GraphEdgeType = "canBind"
+	GraphEdgeTypeCanEscalate    GraphEdgeType = "canEscalate"
 )
 
 // ---------- spec / status types ----------
@@ -84,11 +134,62 @@ type RoleGraphReviewSpec struct {
 	MaxPodsPerSubject   int
 	MaxWorkloadsPerPod  int
 	FilterPhantomAPIs   bool
+	Object              *ObjectTarget
+	// ResourceMapByNamespace splits resource map rows by the namespace in
+	// which the granting binding applies.
+	ResourceMapByNamespace bool
+	// IncludeEscalationPaths adds transitive "can become" edges for matched
+	// subjects.
+	IncludeEscalationPaths bool
+	// MinRiskScore drops roles whose risk score is below the threshold.
+	MinRiskScore int
+	// SortByRiskScore orders graph nodes by descending risk score.
+	SortByRiskScore bool
+	// RoleLabelSelector and RoleNamePatterns (path.Match globs) restrict
+	// the candidate roles.
+	RoleLabelSelector *metav1.LabelSelector
+	RoleNamePatterns  []string
+	// BindingLabelSelector and BindingNamePatterns restrict the bindings;
+	// roles left without a matching binding are dropped.
+	BindingLabelSelector *metav1.LabelSelector
+	BindingNamePatterns  []string
+	// AsOf selects the historical snapshot current at the given time.
+	AsOf *metav1.Time
+	// IncludePolicyRules embeds role rules in role nodes.
+	IncludePolicyRules PolicyRulesMode
+}
+
+// ObjectTarget names a concrete object and the verb to check against it.
+// When set on a RoleGraphReviewSpec it replaces the selector and narrows
+// bindings to those that actually apply to the object.
+type ObjectTarget struct {
+	APIGroup  string
+	Resource  string
+	Namespace string
+	Name      string
+	Verb      string
+}
+
+// Selector returns the permission selector equivalent to the object target.
+func (o ObjectTarget) Selector() Selector {
+	sel := Selector{
+		APIGroups: []string{o.APIGroup},
+		Resources: []string{o.Resource},
+		Verbs:     []string{o.Verb},
+	}
+	if o.Name != "" {
+		sel.ResourceNames = []string{o.Name}
+	}
+
+	return sel
 }
 
 type NamespaceScope struct {
 	Namespaces []string
 	Strict     bool
+	Selector   *metav1.LabelSelector
+	Patterns   []string
+	Exclude    []string
 }
 
 type Selector struct {
@@ -97,6 +198,17 @@ type Selector struct {
 	Verbs           []string
 	ResourceNames   []string
 	NonResourceURLs []string
+	// Subjects keeps only matching binding subjects.
+	Subjects *SubjectSelector
+}
+
+// SubjectSelector matches binding subjects; see the v1alpha1 type.
+type SubjectSelector struct {
+	Kinds        []string
+	Names        []string
+	NamePatterns []string
+	NameRegex    string
+	Namespaces   []string
 }
 
 type RoleGraphReviewStatus struct {
@@ -109,6 +221,9 @@ type RoleGraphReviewStatus struct {
 	KnownGaps        []string
 	Graph            Graph
 	ResourceMap      []ResourceMapRow
+	// SnapshotGeneration and SnapshotBuiltAt identify the evaluated snapshot.
+	SnapshotGeneration int64
+	SnapshotBuiltAt    *metav1.Time
 }
 
 type Graph struct {
@@ -130,6 +245,65 @@ type GraphNode struct {
 	WorkloadKind       string
 	Synthetic          bool
 	HiddenCount        int
+	RiskScore          int
+	RiskReasons        []RiskReason
+	PolicyRules        []PolicyRule
+	RuleCount          int
+	ResourceVersion    string
+	NamespaceState     NamespaceState
+	ServiceAccount     *ServiceAccountInfo
+	TokenExposure      *TokenExposure
+}
+
+// PolicyRule is a rule of a role with its index in the role.
+type PolicyRule struct {
+	Index           int
+	Verbs           []string
+	APIGroups       []string
+	Resources       []string
+	ResourceNames   []string
+	NonResourceURLs []string
+}
+
+// ServiceAccountInfo describes the ServiceAccount behind a serviceAccount
+// node.
+type ServiceAccountInfo struct {
+	Exists                       bool
+	AutomountServiceAccountToken *bool
+	ImagePullSecrets             []string
+	CloudIdentities              []CloudIdentity
+	PodCount                     int
+}
+
+// CloudIdentity is a cloud IAM identity a ServiceAccount is bound to through
+// an annotation.
+type CloudIdentity struct {
+	Provider   CloudIdentityProvider
+	Annotation string
+	Identity   string
+}
+
+// TokenExposure describes whether the ServiceAccount token of a pod is
+// mounted where its containers can use it against the API server.
+type TokenExposure struct {
+	Exposed         bool
+	Automount       bool
+	AutomountSource TokenAutomountSource
+	ProjectedTokens []ProjectedToken
+}
+
+// ProjectedToken is a ServiceAccount token projected into a pod volume.
+type ProjectedToken struct {
+	Volume            string
+	Audience          string
+	ExpirationSeconds int64
+}
+
+// RiskReason is one risk catalog entry that contributed to a node's score.
+type RiskReason struct {
+	ID          string
+	Description string
+	Score       int
 }
 
 type GraphEdge struct {
@@ -139,6 +313,24 @@ type GraphEdge struct {
 	Type     GraphEdgeType
 	RuleRefs []RuleRef
 	Explain  string
+	Scope    *EffectiveScope
+	// AggregationMatches is set on aggregates edges.
+	AggregationMatches []AggregationMatch
+}
+
+// AggregationMatch is an aggregationRule selector of the target ClusterRole
+// that selects the source, with the source labels it matched on.
+type AggregationMatch struct {
+	SelectorIndex int
+	Selector      string
+	MatchedLabels map[string]string
+}
+
+// EffectiveScope describes where a grant applies: cluster-wide for
+// ClusterRoleBindings, or only in the listed namespaces for RoleBindings.
+type EffectiveScope struct {
+	ClusterWide bool
+	Namespaces  []string
 }
 
 type RuleRef struct {
@@ -160,9 +352,11 @@ type ResourceMapRow struct {
 	APIGroup     string
 	Resource     string
 	Verb         string
+	Namespace    string
 	RoleCount    int
 	BindingCount int
 	SubjectCount int
+	Scope        *EffectiveScope
 }
 
 // ---------- NonResourceURL types ----------
@@ -184,6 +378,169 @@ type NonResourceURLEntry struct {
 	Roles []string
 }
 
+// ---------- SubjectPermissionReview types ----------
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// SubjectPermissionReview is the internal (hub) representation of a subject-centric
+// permission query: given a User, Group or ServiceAccount, what can it do.
+type SubjectPermissionReview struct {
+	metav1.TypeMeta
+	metav1.ObjectMeta
+
+	Spec   SubjectPermissionReviewSpec
+	Status SubjectPermissionReviewStatus
+}
+
+const (
+	SubjectKindUser           = "User"
+	SubjectKindGroup          = "Group"
+	SubjectKindServiceAccount = "ServiceAccount"
+)
+
+type SubjectPermissionReviewSpec struct {
+	Subject             SubjectRef
+	Groups              []string
+	NamespaceScope      NamespaceScope
+	IncludeRuleMetadata bool
+}
+
+type SubjectRef struct {
+	Kind      string
+	Name      string
+	Namespace string
+}
+
+type SubjectPermissionReviewStatus struct {
+	MatchedRoles    int
+	MatchedBindings int
+	EffectiveGroups []string
+	Warnings        []string
+	Permissions     []SubjectPermission
+	Graph           Graph
+}
+
+type SubjectPermission struct {
+	APIGroup        string
+	Resource        string
+	Verb            string
+	ResourceNames   []string
+	NonResourceURLs []string
+	ClusterWide     bool
+	Namespaces      []string
+	Roles           []string
+	Bindings        []string
+}
+
+// ---------- RBACHygieneReport types ----------
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RBACHygieneReport is the internal (hub) representation of a report on RBAC
+// objects that grant nothing or reference objects that do not exist.
+type RBACHygieneReport struct {
+	metav1.TypeMeta
+	metav1.ObjectMeta
+
+	Spec   RBACHygieneReportSpec
+	Status RBACHygieneReportStatus
+}
+
+type HygieneCheck string
+
+const (
+	HygieneCheckMissingRole           HygieneCheck = "missingRole"
+	HygieneCheckMissingServiceAccount HygieneCheck = "missingServiceAccount"
+	HygieneCheckUnboundRole           HygieneCheck = "unboundRole"
+	HygieneCheckEmptySubjects         HygieneCheck = "emptySubjects"
+)
+
+type RBACHygieneReportSpec struct {
+	NamespaceScope       NamespaceScope
+	Checks               []HygieneCheck
+	IncludeSystemObjects bool
+}
+
+type RBACHygieneReportStatus struct {
+	Findings []HygieneFinding
+	Warnings []string
+}
+
+type HygieneFinding struct {
+	Check       HygieneCheck
+	Kind        string
+	Namespace   string
+	Name        string
+	RoleRefKind string
+	RoleRefName string
+	Subject     *SubjectRef
+	Unresolved  bool
+	Message     string
+}
+
+// ---------- RoleGraphDiff types ----------
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RoleGraphDiff is the internal (hub) representation of a comparison of the
+// same query against two snapshots.
+type RoleGraphDiff struct {
+	metav1.TypeMeta
+	metav1.ObjectMeta
+
+	Spec   RoleGraphDiffSpec
+	Status RoleGraphDiffStatus
+}
+
+type RoleGraphDiffSpec struct {
+	Selector               Selector
+	MatchMode              MatchMode
+	WildcardMode           WildcardMode
+	NamespaceScope         NamespaceScope
+	FilterPhantomAPIs      bool
+	Object                 *ObjectTarget
+	ResourceMapByNamespace bool
+	From                   SnapshotPoint
+	To                     SnapshotPoint
+}
+
+type SnapshotPoint struct {
+	Generation int64
+	Time       *metav1.Time
+}
+
+type SnapshotRef struct {
+	Generation int64
+	BuiltAt    *metav1.Time
+}
+
+type RoleGraphDiffStatus struct {
+	From     SnapshotRef
+	To       SnapshotRef
+	Added    RoleGraphChanges
+	Removed  RoleGraphChanges
+	Warnings []string
+}
+
+type RoleGraphChanges struct {
+	Roles           []ObjectRef
+	Bindings        []ObjectRef
+	Subjects        []SubjectRef
+	BindingSubjects []BindingSubject
+	Permissions     []ResourceMapRow
+}
+
+type ObjectRef struct {
+	Kind      string
+	Namespace string
+	Name      string
+}
+
+type BindingSubject struct {
+	Binding ObjectRef
+	Subject SubjectRef
+}
+
 // ---------- spec methods ----------
 // SYNC: Keep EnsureDefaults/Validate in sync with pkg/apis/rbacgraph/v1alpha1/types.go
 
@@ -219,6 +576,163 @@ func (s RoleGraphReviewSpec) Validate() error {
 	if podPhaseMode != PodPhaseModeActive && podPhaseMode != PodPhaseModeAll && podPhaseMode != PodPhaseModeRunning {
 		return fmt.Errorf("invalid podPhaseMode %q", s.PodPhaseMode)
 	}
+	if s.Object != nil {
+		if !s.Selector.IsEmpty() {
+			return errors.New("selector and object are mutually exclusive")
+		}
+		if s.Object.Resource == "" || s.Object.Verb == "" {
+			return errors.New("object.resource and object.verb are required")
+		}
+	}
+	switch s.IncludePolicyRules {
+	case "", PolicyRulesModeNone, PolicyRulesModeMatched, PolicyRulesModeAll:
+	default:
+		return fmt.Errorf("invalid includePolicyRules %q", s.IncludePolicyRules)
+	}
+	if s.MinRiskScore < 0 || s.MinRiskScore > MaxRiskScore {
+		return fmt.Errorf("minRiskScore must be between 0 and %d", MaxRiskScore)
+	}
+	if err := s.NamespaceScope.Validate(); err != nil {
+		return err
+	}
+	if err := s.Selector.Subjects.Validate(); err != nil {
+		return err
+	}
+	if err := validateObjectFilter("role", s.RoleLabelSelector, s.RoleNamePatterns); err != nil {
+		return err
+	}
+
+	return validateObjectFilter("binding", s.BindingLabelSelector, s.BindingNamePatterns)
+}
+
+// Validate checks subject kinds and name patterns. A nil selector is valid.
+func (s *SubjectSelector) Validate() error {
+	if s == nil {
+		return nil
+	}
+	for _, kind := range s.Kinds {
+		if kind != SubjectKindUser && kind != SubjectKindGroup && kind != SubjectKindServiceAccount {
+			return fmt.Errorf("invalid selector.subjects.kinds entry %q", kind)
+		}
+	}
+	for _, pattern := range s.NamePatterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid selector.subjects.namePatterns entry %q: %w", pattern, err)
+		}
+	}
+	if s.NameRegex != "" {
+		if _, err := regexp.Compile(s.NameRegex); err != nil {
+			return fmt.Errorf("invalid selector.subjects.nameRegex: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Validate checks the namespace selector and globs.
+func (s NamespaceScope) Validate() error {
+	if s.Selector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(s.Selector); err != nil {
+			return fmt.Errorf("invalid namespaceScope.selector: %w", err)
+		}
+	}
+	for _, pattern := range s.Patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid namespaceScope.patterns entry %q: %w", pattern, err)
+		}
+	}
+	for _, pattern := range s.Exclude {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid namespaceScope.exclude entry %q: %w", pattern, err)
+		}
+	}
+
+	return nil
+}
+
+// RequestedNamespaces returns the namespaces known to be requested before
+// the snapshot is consulted, or nil when selectors or patterns may add
+// namespaces beyond Namespaces.
+func (s NamespaceScope) RequestedNamespaces() []string {
+	if s.Selector != nil || len(s.Patterns) > 0 {
+		return nil
+	}
+
+	return s.Namespaces
+}
+
+func validateObjectFilter(prefix string, selector *metav1.LabelSelector, patterns []string) error {
+	if selector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(selector); err != nil {
+			return fmt.Errorf("invalid %sLabelSelector: %w", prefix, err)
+		}
+	}
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid %sNamePatterns entry %q: %w", prefix, pattern, err)
+		}
+	}
+
+	return nil
+}
+
+// IsEmpty reports whether the selector has no rule criteria. Subjects is
+// not a rule criterion.
+func (s Selector) IsEmpty() bool {
+	return len(s.APIGroups) == 0 && len(s.Resources) == 0 && len(s.Verbs) == 0 &&
+		len(s.ResourceNames) == 0 && len(s.NonResourceURLs) == 0
+}
+
+func (s *RoleGraphDiffSpec) EnsureDefaults() {
+	if s.MatchMode == "" {
+		s.MatchMode = MatchModeAny
+	}
+	if s.WildcardMode == "" {
+		s.WildcardMode = WildcardModeExpand
+	}
+}
+
+func (s RoleGraphDiffSpec) Validate() error {
+	if s.From.IsEmpty() {
+		return errors.New("from.generation or from.time is required")
+	}
+	if err := s.From.Validate("from"); err != nil {
+		return err
+	}
+	if err := s.To.Validate("to"); err != nil {
+		return err
+	}
+
+	return s.ReviewSpec().Validate()
+}
+
+// ReviewSpec returns the RoleGraphReview query run against both snapshots.
+func (s RoleGraphDiffSpec) ReviewSpec() RoleGraphReviewSpec {
+	spec := RoleGraphReviewSpec{
+		Selector:               s.Selector,
+		MatchMode:              s.MatchMode,
+		WildcardMode:           s.WildcardMode,
+		NamespaceScope:         s.NamespaceScope,
+		FilterPhantomAPIs:      s.FilterPhantomAPIs,
+		Object:                 s.Object,
+		ResourceMapByNamespace: s.ResourceMapByNamespace,
+	}
+	spec.EnsureDefaults()
+
+	return spec
+}
+
+func (p SnapshotPoint) IsEmpty() bool {
+	return p.Generation == 0 && p.Time == nil
+}
+
+func (p SnapshotPoint) Validate(field string) error {
+	if p.Generation < 0 {
+		return fmt.Errorf("%s.generation must not be negative", field)
+	}
+	if p.Generation != 0 && p.Time != nil {
+		return fmt.Errorf("%s.generation and %s.time are mutually exclusive", field, field)
+	}
 
 	return nil
 }
@@ -232,3 +746,38 @@ func (s *RoleGraphReviewSpec) NormalizeRuntimeFlags() []string {
 
 	return nil
 }
+
+func (s SubjectPermissionReviewSpec) Validate() error {
+	switch s.Subject.Kind {
+	case SubjectKindUser, SubjectKindGroup:
+	case SubjectKindServiceAccount:
+		if s.Subject.Namespace == "" {
+			return errors.New("subject.namespace is required for ServiceAccount subjects")
+		}
+	default:
+		return fmt.Errorf("invalid subject.kind %q", s.Subject.Kind)
+	}
+	if s.Subject.Name == "" {
+		return errors.New("subject.name is required")
+	}
+
+	return s.NamespaceScope.Validate()
+}
+
+// AllHygieneChecks lists every check run when RBACHygieneReportSpec.Checks is empty.
+var AllHygieneChecks = []HygieneCheck{
+	HygieneCheckMissingRole,
+	HygieneCheckMissingServiceAccount,
+	HygieneCheckUnboundRole,
+	HygieneCheckEmptySubjects,
+}
+
+func (s RBACHygieneReportSpec) Validate() error {
+	for _, check := range s.Checks {
+		if !slices.Contains(AllHygieneChecks, check) {
+			return fmt.Errorf("invalid check %q", check)
+		}
+	}
+
+	return s.NamespaceScope.Validate()
+}
@@ -52,10 +52,22 @@ func GetEnumOpenAPIDefinitions(_ common.ReferenceCallback) map[string]common.Ope
 					Enum: []any{
 						string(GraphEdgeTypeAggregates), string(GraphEdgeTypeGrants),
 						string(GraphEdgeTypeSubjects), string(GraphEdgeTypeRunsAs), string(GraphEdgeTypeOwnedBy),
+						string(GraphEdgeTypeCanImpersonate), string(GraphEdgeTypeCanRunAs),
+						string(GraphEdgeTypeCanReadToken), string(GraphEdgeTypeCanMintToken),
+						string(GraphEdgeTypeCanBind), string(GraphEdgeTypeCanEscalate),
 					},
 				},
 			},
 		},
+		prefix + "HygieneCheck": {
+			Schema: spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Description: "RBAC hygiene check: 'missingRole', 'missingServiceAccount', 'unboundRole' or 'emptySubjects'.",
+					Type:        []string{"string"},
+					Enum:        hygieneCheckEnum(),
+				},
+			},
+		},
 		prefix + "WildcardMode": {
 			Schema: spec.Schema{
 				SchemaProps: spec.SchemaProps{
@@ -104,6 +116,7 @@ func injectEnumsIntoStructFields(defs map[string]common.OpenAPIDefinition) {
 	patchField("RoleGraphReviewSpec", "matchMode", []any{string(MatchModeAny), string(MatchModeAll)})
 	patchField("RoleGraphReviewSpec", "podPhaseMode", []any{string(PodPhaseModeActive), string(PodPhaseModeRunning), string(PodPhaseModeAll)})
 	patchField("RoleGraphReviewSpec", "wildcardMode", []any{string(WildcardModeExpand), string(WildcardModeExact)})
+	patchField("HygieneFinding", "check", hygieneCheckEnum())
 	patchField("GraphNode", "type", []any{
 		string(GraphNodeTypeRole), string(GraphNodeTypeClusterRole),
 		string(GraphNodeTypeRoleBinding), string(GraphNodeTypeClusterRoleBinding),
@@ -114,5 +127,17 @@ func injectEnumsIntoStructFields(defs map[string]common.OpenAPIDefinition) {
 	patchField("GraphEdge", "type", []any{
 		string(GraphEdgeTypeAggregates), string(GraphEdgeTypeGrants),
 		string(GraphEdgeTypeSubjects), string(GraphEdgeTypeRunsAs), string(GraphEdgeTypeOwnedBy),
+		string(GraphEdgeTypeCanImpersonate), string(GraphEdgeTypeCanRunAs),
+		string(GraphEdgeTypeCanReadToken), string(GraphEdgeTypeCanMintToken),
+		string(GraphEdgeTypeCanBind), string(GraphEdgeTypeCanEscalate),
 	})
 }
+
+func hygieneCheckEnum() []any {
+	out := make([]any, 0, len(AllHygieneChecks))
+	for _, check := range AllHygieneChecks {
+		out = append(out, string(check))
+	}
+
+	return out
+}
@@ -26,12 +26,39 @@ func TestOpenAPIRefsResolvable(t *testing.T) {
 		RoleGraphReviewSpec{}.OpenAPIModelName(),
 		RoleGraphReviewStatus{}.OpenAPIModelName(),
 		Selector{}.OpenAPIModelName(),
+		SubjectSelector{}.OpenAPIModelName(),
 		NamespaceScope{}.OpenAPIModelName(),
 		Graph{}.OpenAPIModelName(),
 		GraphNode{}.OpenAPIModelName(),
 		GraphEdge{}.OpenAPIModelName(),
 		RuleRef{}.OpenAPIModelName(),
 		ResourceMapRow{}.OpenAPIModelName(),
+		ObjectTarget{}.OpenAPIModelName(),
+		EffectiveScope{}.OpenAPIModelName(),
+		AggregationMatch{}.OpenAPIModelName(),
+		PolicyRule{}.OpenAPIModelName(),
+		ServiceAccountInfo{}.OpenAPIModelName(),
+		CloudIdentity{}.OpenAPIModelName(),
+		TokenExposure{}.OpenAPIModelName(),
+		ProjectedToken{}.OpenAPIModelName(),
+		RiskReason{}.OpenAPIModelName(),
+		SubjectPermissionReview{}.OpenAPIModelName(),
+		SubjectPermissionReviewSpec{}.OpenAPIModelName(),
+		SubjectPermissionReviewStatus{}.OpenAPIModelName(),
+		SubjectRef{}.OpenAPIModelName(),
+		SubjectPermission{}.OpenAPIModelName(),
+		RBACHygieneReport{}.OpenAPIModelName(),
+		RBACHygieneReportSpec{}.OpenAPIModelName(),
+		RBACHygieneReportStatus{}.OpenAPIModelName(),
+		HygieneFinding{}.OpenAPIModelName(),
+		RoleGraphDiff{}.OpenAPIModelName(),
+		RoleGraphDiffSpec{}.OpenAPIModelName(),
+		RoleGraphDiffStatus{}.OpenAPIModelName(),
+		RoleGraphChanges{}.OpenAPIModelName(),
+		SnapshotPoint{}.OpenAPIModelName(),
+		SnapshotRef{}.OpenAPIModelName(),
+		ObjectRef{}.OpenAPIModelName(),
+		BindingSubject{}.OpenAPIModelName(),
 	}
 
 	swagger, err := builder.BuildOpenAPIDefinitionsForResources(config, names...)
@@ -18,6 +18,9 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
 		&RoleGraphReview{},
 		&NonResourceURLList{},
+		&SubjectPermissionReview{},
+		&RBACHygieneReport{},
+		&RoleGraphDiff{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 
@@ -1,7 +1,11 @@
 package v1alpha1
 
 import (
+	"errors"
 	"fmt"
+	"path"
+	"regexp"
+	"slices"
 	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -38,9 +42,49 @@ const (
 	PodPhaseModeActive  PodPhaseMode = "active"
 	PodPhaseModeAll     PodPhaseMode = "all"
 	PodPhaseModeRunning PodPhaseMode = "running"
+)
+
+// +enum
+type PolicyRulesMode string
+
+const (
+	PolicyRulesModeNone    PolicyRulesMode = "none"
+	PolicyRulesModeMatched PolicyRulesMode = "matched"
+	PolicyRulesModeAll     PolicyRulesMode = "all"
+)
+
+// +enum
+type NamespaceState string
+
+const (
+	NamespaceStateMissing     NamespaceState = "missing"
+	NamespaceStateTerminating NamespaceState = "terminating"
+)
 
+// +enum
+type CloudIdentityProvider string
+
+const (
+	CloudIdentityProviderAWS   CloudIdentityProvider = "aws"
+	CloudIdentityProviderGCP   CloudIdentityProvider = "gcp"
+	CloudIdentityProviderAzure CloudIdentityProvider = "azure"
+)
+
+// +enum
+type TokenAutomountSource string
+
+const (
+	TokenAutomountSourcePod            TokenAutomountSource = "pod"
+	TokenAutomountSourceServiceAccount TokenAutomountSource = "serviceAccount"
+	TokenAutomountSourceDefault        TokenAutomountSource = "default"
+)
+
+const (
 	DefaultMaxPodsPerSubject  = 20
 	DefaultMaxWorkloadsPerPod = 10
+
+	// MaxRiskScore is the upper bound of GraphNode.RiskScore.
+	MaxRiskScore = 100
 )
 
 // +enum
@@ -69,6 +113,16 @@ const (
 	GraphEdgeTypeSubjects   GraphEdgeType = "subjects"
 	GraphEdgeTypeRunsAs     GraphEdgeType = "runsAs"
 	GraphEdgeTypeOwnedBy    GraphEdgeType = "ownedBy"
+
+	// Escalation edges are only emitted with IncludeEscalationPaths. They
+	// point from a subject to a subject it can become, or to a role it can
+	// grant to itself.
+	GraphEdgeTypeCanImpersonate GraphEdgeType = "canImpersonate"
+	GraphEdgeTypeCanRunAs       GraphEdgeType = "canRunAs"
+	GraphEdgeTypeCanReadToken   GraphEdgeType = "canReadToken"
+	GraphEdgeTypeCanMintToken   GraphEdgeType = "canMintToken"
+	GraphEdgeTypeCanBind        GraphEdgeType = "canBind"
+	GraphEdgeTypeCanEscalate    GraphEdgeType = "canEscalate"
 )
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -94,11 +148,72 @@ type RoleGraphReviewSpec struct {
 	MaxPodsPerSubject   int            `json:"maxPodsPerSubject,omitempty"`
 	MaxWorkloadsPerPod  int            `json:"maxWorkloadsPerPod,omitempty"`
 	FilterPhantomAPIs   bool           `json:"filterPhantomAPIs,omitempty"`
+	// Object switches the review to "who can <verb> this object" mode. It is
+	// mutually exclusive with Selector.
+	Object *ObjectTarget `json:"object,omitempty"`
+	// ResourceMapByNamespace splits resource map rows by the namespace in
+	// which the granting binding applies. Cluster-wide grants keep an empty
+	// namespace.
+	ResourceMapByNamespace bool `json:"resourceMapByNamespace,omitempty"`
+	// IncludeEscalationPaths adds transitive "can become" edges for matched
+	// subjects: impersonation, pod/workload creation, token secret reads,
+	// token minting and role bind/escalate.
+	IncludeEscalationPaths bool `json:"includeEscalationPaths,omitempty"`
+	// MinRiskScore drops roles whose risk score is below the threshold,
+	// together with the bindings and subjects reached only through them.
+	MinRiskScore int `json:"minRiskScore,omitempty"`
+	// SortByRiskScore orders graph nodes by descending risk score instead of
+	// by type and name.
+	SortByRiskScore bool `json:"sortByRiskScore,omitempty"`
+	// RoleLabelSelector keeps only roles whose labels match, e.g.
+	// "app.kubernetes.io/managed-by=Helm" or
+	// "kubernetes.io/bootstrapping notin (rbac-defaults)".
+	RoleLabelSelector *metav1.LabelSelector `json:"roleLabelSelector,omitempty"`
+	// RoleNamePatterns keeps only roles whose name matches one of the
+	// path.Match globs.
+	RoleNamePatterns []string `json:"roleNamePatterns,omitempty"`
+	// BindingLabelSelector keeps only bindings whose labels match. Roles
+	// left without a matching binding are dropped.
+	BindingLabelSelector *metav1.LabelSelector `json:"bindingLabelSelector,omitempty"`
+	// BindingNamePatterns keeps only bindings whose name matches one of the
+	// path.Match globs. Roles left without a matching binding are dropped.
+	BindingNamePatterns []string `json:"bindingNamePatterns,omitempty"`
+	// AsOf evaluates the query against the snapshot that was current at the
+	// given time instead of the latest one. Requires snapshot history to be
+	// enabled on the server.
+	AsOf *metav1.Time `json:"asOf,omitempty"`
+	// IncludePolicyRules embeds the rules of each role node, together with
+	// its rule count and resourceVersion: "matched" embeds the rules that
+	// matched the selector, "all" every rule of the role. Defaults to "none".
+	IncludePolicyRules PolicyRulesMode `json:"includePolicyRules,omitempty"`
 }
 
+// ObjectTarget names a concrete object and the verb to check against it.
+// Only bindings that apply in the object's namespace and rules whose
+// resourceNames (if any) include the object's name are considered.
+type ObjectTarget struct {
+	APIGroup  string `json:"apiGroup,omitempty"`
+	Resource  string `json:"resource"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Verb      string `json:"verb"`
+}
+
+// NamespaceScope restricts results to namespaces. A namespace is in scope
+// when it is listed in Namespaces, matches one of Patterns or has labels
+// matching Selector, and does not match Exclude. Without Namespaces,
+// Patterns and Selector every namespace is in scope.
 type NamespaceScope struct {
 	Namespaces []string `json:"namespaces,omitempty"`
 	Strict     bool     `json:"strict,omitempty"`
+	// Selector matches namespace labels, e.g. "team=payments". Namespaces
+	// that are not indexed never match.
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+	// Patterns are path.Match globs over namespace names, e.g. "tenant-*".
+	Patterns []string `json:"patterns,omitempty"`
+	// Exclude drops namespaces by name or path.Match glob, even when they
+	// are otherwise in scope.
+	Exclude []string `json:"exclude,omitempty"`
 }
 
 type Selector struct {
@@ -107,6 +222,25 @@ type Selector struct {
 	Verbs           []string `json:"verbs,omitempty"`
 	ResourceNames   []string `json:"resourceNames,omitempty"`
 	NonResourceURLs []string `json:"nonResourceURLs,omitempty"`
+	// Subjects keeps only matching binding subjects. Bindings and roles left
+	// without a matching subject are dropped. It may be combined with Object.
+	Subjects *SubjectSelector `json:"subjects,omitempty"`
+}
+
+// SubjectSelector matches binding subjects. Set fields are ANDed; Names,
+// NamePatterns and NameRegex are alternatives, a subject matches if any of
+// them does.
+type SubjectSelector struct {
+	// Kinds is a list of User, Group and ServiceAccount.
+	Kinds []string `json:"kinds,omitempty"`
+	// Names are exact subject names.
+	Names []string `json:"names,omitempty"`
+	// NamePatterns are path.Match globs, e.g. "ci-*".
+	NamePatterns []string `json:"namePatterns,omitempty"`
+	// NameRegex is an RE2 expression matched against the whole name.
+	NameRegex string `json:"nameRegex,omitempty"`
+	// Namespaces keeps only ServiceAccount subjects in these namespaces.
+	Namespaces []string `json:"namespaces,omitempty"`
 }
 
 type RoleGraphReviewStatus struct {
@@ -119,6 +253,10 @@ type RoleGraphReviewStatus struct {
 	KnownGaps        []string         `json:"knownGaps,omitempty"`
 	Graph            Graph            `json:"graph"`
 	ResourceMap      []ResourceMapRow `json:"resourceMap"`
+	// SnapshotGeneration and SnapshotBuiltAt identify the snapshot the query
+	// was evaluated against.
+	SnapshotGeneration int64        `json:"snapshotGeneration,omitempty"`
+	SnapshotBuiltAt    *metav1.Time `json:"snapshotBuiltAt,omitempty"`
 }
 
 type Graph struct {
@@ -140,6 +278,97 @@ type GraphNode struct {
 	WorkloadKind       string            `json:"workloadKind,omitempty"`
 	Synthetic          bool              `json:"synthetic,omitempty"`
 	HiddenCount        int               `json:"hiddenCount,omitempty"`
+	// RiskScore is the sum of the distinct risk reasons of the node, capped
+	// at 100. Bindings, subjects, pods and workloads inherit the reasons of
+	// everything that grants them access.
+	RiskScore   int          `json:"riskScore,omitempty"`
+	RiskReasons []RiskReason `json:"riskReasons,omitempty"`
+
+	// PolicyRules, RuleCount and ResourceVersion are set on role nodes with
+	// IncludePolicyRules. RuleCount counts every rule of the role, also when
+	// only the matched ones are embedded.
+	PolicyRules     []PolicyRule `json:"policyRules,omitempty"`
+	RuleCount       int          `json:"ruleCount,omitempty"`
+	ResourceVersion string       `json:"resourceVersion,omitempty"`
+
+	// NamespaceState is set on Roles, RoleBindings and ServiceAccounts whose
+	// namespace no longer exists or is terminating.
+	NamespaceState NamespaceState `json:"namespaceState,omitempty"`
+
+	// ServiceAccount is set on serviceAccount nodes once ServiceAccounts are
+	// indexed. The annotations of the ServiceAccount are in Annotations.
+	ServiceAccount *ServiceAccountInfo `json:"serviceAccount,omitempty"`
+
+	// TokenExposure is set on pod nodes.
+	TokenExposure *TokenExposure `json:"tokenExposure,omitempty"`
+}
+
+// PolicyRule is a rule of a role as written in the role, with its position.
+type PolicyRule struct {
+	// Index is the position of the rule in the role, as in
+	// RuleRef.SourceRuleIndex.
+	Index           int      `json:"index"`
+	Verbs           []string `json:"verbs"`
+	APIGroups       []string `json:"apiGroups,omitempty"`
+	Resources       []string `json:"resources,omitempty"`
+	ResourceNames   []string `json:"resourceNames,omitempty"`
+	NonResourceURLs []string `json:"nonResourceURLs,omitempty"`
+}
+
+// ServiceAccountInfo describes the ServiceAccount behind a serviceAccount
+// node.
+type ServiceAccountInfo struct {
+	// Exists is false when the subject refers to a ServiceAccount that is
+	// not indexed; the remaining fields are then empty.
+	Exists bool `json:"exists"`
+	// AutomountServiceAccountToken is the setting of the ServiceAccount; nil
+	// leaves the decision to its pods.
+	AutomountServiceAccountToken *bool    `json:"automountServiceAccountToken,omitempty"`
+	ImagePullSecrets             []string `json:"imagePullSecrets,omitempty"`
+	// CloudIdentities lists the cloud IAM identities the ServiceAccount is
+	// bound to, such as IRSA or Workload Identity.
+	CloudIdentities []CloudIdentity `json:"cloudIdentities,omitempty"`
+	// PodCount is the number of indexed pods running as the ServiceAccount,
+	// in any phase.
+	PodCount int `json:"podCount"`
+}
+
+// CloudIdentity is a cloud IAM identity a ServiceAccount is bound to through
+// an annotation.
+type CloudIdentity struct {
+	Provider   CloudIdentityProvider `json:"provider"`
+	Annotation string                `json:"annotation"`
+	Identity   string                `json:"identity"`
+}
+
+// TokenExposure describes whether the ServiceAccount token of a pod is
+// mounted where its containers can use it against the API server.
+type TokenExposure struct {
+	// Exposed is true when the token is automounted or a projected token
+	// with the API server audience is mounted into a container.
+	Exposed bool `json:"exposed"`
+	// Automount is the effective automountServiceAccountToken of the pod,
+	// taken from AutomountSource.
+	Automount       bool                 `json:"automount"`
+	AutomountSource TokenAutomountSource `json:"automountSource"`
+	// ProjectedTokens are the projected ServiceAccount tokens mounted into
+	// at least one container, including the automounted one.
+	ProjectedTokens []ProjectedToken `json:"projectedTokens,omitempty"`
+}
+
+// ProjectedToken is a ServiceAccount token projected into a pod volume.
+type ProjectedToken struct {
+	Volume string `json:"volume"`
+	// Audience is empty for the API server audience.
+	Audience          string `json:"audience,omitempty"`
+	ExpirationSeconds int64  `json:"expirationSeconds,omitempty"`
+}
+
+// RiskReason is one risk catalog entry that contributed to a node's score.
+type RiskReason struct {
+	ID          string `json:"id"`
+	Description string `json:"description,omitempty"`
+	Score       int    `json:"score"`
 }
 
 type GraphEdge struct {
@@ -149,6 +378,32 @@ type GraphEdge struct {
 	Type     GraphEdgeType `json:"type"`
 	RuleRefs []RuleRef     `json:"ruleRefs,omitempty"`
 	Explain  string        `json:"explain,omitempty"`
+	// Scope is set on grants and subjects edges and tells whether the
+	// binding applies cluster-wide or only in its own namespace.
+	Scope *EffectiveScope `json:"scope,omitempty"`
+	// AggregationMatches is set on aggregates edges and explains why the
+	// target ClusterRole aggregates the source.
+	AggregationMatches []AggregationMatch `json:"aggregationMatches,omitempty"`
+}
+
+// AggregationMatch is an aggregationRule selector of the target ClusterRole
+// that selects the source, with the source labels it matched on.
+type AggregationMatch struct {
+	// SelectorIndex is the position of the selector in
+	// aggregationRule.clusterRoleSelectors.
+	SelectorIndex int `json:"selectorIndex"`
+	// Selector is the selector in label selector syntax.
+	Selector string `json:"selector"`
+	// MatchedLabels are the source labels the selector constrains.
+	MatchedLabels map[string]string `json:"matchedLabels,omitempty"`
+}
+
+// EffectiveScope describes where a grant applies: cluster-wide for
+// ClusterRoleBindings, or only in the listed namespaces for RoleBindings
+// (including RoleBindings that reference a ClusterRole).
+type EffectiveScope struct {
+	ClusterWide bool     `json:"clusterWide,omitempty"`
+	Namespaces  []string `json:"namespaces,omitempty"`
 }
 
 type RuleRef struct {
@@ -167,12 +422,17 @@ type RuleRef struct {
 }
 
 type ResourceMapRow struct {
-	APIGroup     string `json:"apiGroup,omitempty"`
-	Resource     string `json:"resource,omitempty"`
-	Verb         string `json:"verb,omitempty"`
+	APIGroup string `json:"apiGroup,omitempty"`
+	Resource string `json:"resource,omitempty"`
+	Verb     string `json:"verb,omitempty"`
+	// Namespace is only set when ResourceMapByNamespace is requested.
+	Namespace    string `json:"namespace,omitempty"`
 	RoleCount    int    `json:"roleCount"`
 	BindingCount int    `json:"bindingCount"`
 	SubjectCount int    `json:"subjectCount"`
+	// Scope aggregates the effective scope of all bindings contributing to
+	// the row. It is nil when no binding grants the row.
+	Scope *EffectiveScope `json:"scope,omitempty"`
 }
 
 // ---------- NonResourceURL types ----------
@@ -206,6 +466,257 @@ func (NonResourceURLEntry) OpenAPIModelName() string {
 	return openAPIPrefix + "NonResourceURLEntry"
 }
 
+// ---------- SubjectPermissionReview types ----------
+
+const (
+	SubjectPermissionReviewKind     = "SubjectPermissionReview"
+	SubjectPermissionReviewResource = "subjectpermissionreviews"
+
+	SubjectKindUser           = "User"
+	SubjectKindGroup          = "Group"
+	SubjectKindServiceAccount = "ServiceAccount"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// SubjectPermissionReview returns the effective permissions of a single
+// User, Group or ServiceAccount together with the bindings and roles that
+// grant them and the namespaces they apply in.
+type SubjectPermissionReview struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              SubjectPermissionReviewSpec   `json:"spec"`
+	Status            SubjectPermissionReviewStatus `json:"status,omitempty"`
+}
+
+type SubjectPermissionReviewSpec struct {
+	Subject SubjectRef `json:"subject"`
+	// Groups lists additional group memberships of a User subject. Implicit
+	// groups (system:authenticated, system:serviceaccounts, ...) are added automatically.
+	Groups              []string       `json:"groups,omitempty"`
+	NamespaceScope      NamespaceScope `json:"namespaceScope,omitempty"`
+	IncludeRuleMetadata bool           `json:"includeRuleMetadata,omitempty"`
+}
+
+type SubjectRef struct {
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+type SubjectPermissionReviewStatus struct {
+	MatchedRoles    int                 `json:"matchedRoles"`
+	MatchedBindings int                 `json:"matchedBindings"`
+	EffectiveGroups []string            `json:"effectiveGroups,omitempty"`
+	Warnings        []string            `json:"warnings,omitempty"`
+	Permissions     []SubjectPermission `json:"permissions"`
+	Graph           Graph               `json:"graph"`
+}
+
+// SubjectPermission is a single effective permission of the reviewed subject.
+// ClusterWide is set when at least one ClusterRoleBinding grants it; otherwise
+// Namespaces lists where RoleBindings make it effective.
+type SubjectPermission struct {
+	APIGroup        string   `json:"apiGroup,omitempty"`
+	Resource        string   `json:"resource,omitempty"`
+	Verb            string   `json:"verb"`
+	ResourceNames   []string `json:"resourceNames,omitempty"`
+	NonResourceURLs []string `json:"nonResourceURLs,omitempty"`
+	ClusterWide     bool     `json:"clusterWide,omitempty"`
+	Namespaces      []string `json:"namespaces,omitempty"`
+	Roles           []string `json:"roles"`
+	Bindings        []string `json:"bindings"`
+}
+
+// ---------- RBACHygieneReport types ----------
+
+const (
+	RBACHygieneReportKind     = "RBACHygieneReport"
+	RBACHygieneReportResource = "rbachygienereports"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RBACHygieneReport lists RBAC objects that the graph queries skip silently:
+// bindings to roles that do not exist, ServiceAccount subjects that do not
+// exist, roles that nothing binds and bindings without subjects.
+type RBACHygieneReport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              RBACHygieneReportSpec   `json:"spec"`
+	Status            RBACHygieneReportStatus `json:"status,omitempty"`
+}
+
+// HygieneCheck names a single hygiene check.
+type HygieneCheck string
+
+const (
+	// HygieneCheckMissingRole reports bindings whose roleRef does not exist.
+	// Recreating the role silently restores the access the binding grants.
+	HygieneCheckMissingRole HygieneCheck = "missingRole"
+	// HygieneCheckMissingServiceAccount reports ServiceAccount subjects that
+	// do not exist. Creating the ServiceAccount restores the access.
+	HygieneCheckMissingServiceAccount HygieneCheck = "missingServiceAccount"
+	// HygieneCheckUnboundRole reports roles that no binding references and
+	// that are not aggregated into another ClusterRole.
+	HygieneCheckUnboundRole HygieneCheck = "unboundRole"
+	// HygieneCheckEmptySubjects reports bindings with no subjects.
+	HygieneCheckEmptySubjects HygieneCheck = "emptySubjects"
+)
+
+// AllHygieneChecks lists every check run when RBACHygieneReportSpec.Checks is empty.
+var AllHygieneChecks = []HygieneCheck{
+	HygieneCheckMissingRole,
+	HygieneCheckMissingServiceAccount,
+	HygieneCheckUnboundRole,
+	HygieneCheckEmptySubjects,
+}
+
+type RBACHygieneReportSpec struct {
+	NamespaceScope NamespaceScope `json:"namespaceScope,omitempty"`
+	// Checks limits the report to the listed checks. Empty runs all checks.
+	Checks []HygieneCheck `json:"checks,omitempty"`
+	// IncludeSystemObjects reports unbound roles and empty bindings whose
+	// name starts with "system:". They are skipped by default because the
+	// control plane ships many of them.
+	IncludeSystemObjects bool `json:"includeSystemObjects,omitempty"`
+}
+
+type RBACHygieneReportStatus struct {
+	Findings []HygieneFinding `json:"findings"`
+	Warnings []string         `json:"warnings,omitempty"`
+}
+
+// HygieneFinding is a single problem. Kind, Namespace and Name identify the
+// role or binding it was found on; RoleRefKind/RoleRefName and Subject are
+// set for missingRole and missingServiceAccount findings respectively.
+type HygieneFinding struct {
+	Check       HygieneCheck `json:"check"`
+	Kind        string       `json:"kind"`
+	Namespace   string       `json:"namespace,omitempty"`
+	Name        string       `json:"name"`
+	RoleRefKind string       `json:"roleRefKind,omitempty"`
+	RoleRefName string       `json:"roleRefName,omitempty"`
+	Subject     *SubjectRef  `json:"subject,omitempty"`
+	// Unresolved is set when the referenced role, ServiceAccount or the
+	// bindings of the role lie outside the caller's scope, so the finding
+	// may not be a problem.
+	Unresolved bool   `json:"unresolved,omitempty"`
+	Message    string `json:"message"`
+}
+
+// ---------- RoleGraphDiff types ----------
+
+const (
+	RoleGraphDiffKind     = "RoleGraphDiff"
+	RoleGraphDiffResource = "rolegraphdiffs"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RoleGraphDiff runs the same query against two snapshots and returns the
+// roles, bindings, subjects and resource map rows that appear in only one
+// of them.
+type RoleGraphDiff struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              RoleGraphDiffSpec   `json:"spec"`
+	Status            RoleGraphDiffStatus `json:"status,omitempty"`
+}
+
+// RoleGraphDiffSpec carries the RoleGraphReview query fields that decide
+// which roles, bindings and subjects match, plus the two snapshots to
+// compare.
+type RoleGraphDiffSpec struct {
+	Selector               Selector       `json:"selector,omitempty"`
+	MatchMode              MatchMode      `json:"matchMode,omitempty"`
+	WildcardMode           WildcardMode   `json:"wildcardMode,omitempty"`
+	NamespaceScope         NamespaceScope `json:"namespaceScope,omitempty"`
+	FilterPhantomAPIs      bool           `json:"filterPhantomAPIs,omitempty"`
+	Object                 *ObjectTarget  `json:"object,omitempty"`
+	ResourceMapByNamespace bool           `json:"resourceMapByNamespace,omitempty"`
+	// From is the older snapshot. It is required.
+	From SnapshotPoint `json:"from"`
+	// To is the newer snapshot. Empty means the current snapshot.
+	To SnapshotPoint `json:"to,omitempty"`
+}
+
+// SnapshotPoint selects a snapshot by generation or by time. At most one
+// field may be set.
+type SnapshotPoint struct {
+	Generation int64        `json:"generation,omitempty"`
+	Time       *metav1.Time `json:"time,omitempty"`
+}
+
+// SnapshotRef identifies the snapshot a point resolved to.
+type SnapshotRef struct {
+	Generation int64        `json:"generation"`
+	BuiltAt    *metav1.Time `json:"builtAt,omitempty"`
+}
+
+type RoleGraphDiffStatus struct {
+	From     SnapshotRef      `json:"from"`
+	To       SnapshotRef      `json:"to"`
+	Added    RoleGraphChanges `json:"added"`
+	Removed  RoleGraphChanges `json:"removed"`
+	Warnings []string         `json:"warnings,omitempty"`
+}
+
+// RoleGraphChanges lists objects matched in only one of the two snapshots.
+// A role whose rules changed so that it starts matching the selector is
+// reported as added even though the object already existed. Permissions
+// are compared by apiGroup, resource, verb and namespace. BindingSubjects
+// reports subjects added to or removed from a binding, so access gained by a
+// subject that already held other grants is not missed.
+type RoleGraphChanges struct {
+	Roles           []ObjectRef      `json:"roles"`
+	Bindings        []ObjectRef      `json:"bindings"`
+	Subjects        []SubjectRef     `json:"subjects"`
+	BindingSubjects []BindingSubject `json:"bindingSubjects"`
+	Permissions     []ResourceMapRow `json:"permissions"`
+}
+
+// BindingSubject is a subject of a binding.
+type BindingSubject struct {
+	Binding ObjectRef  `json:"binding"`
+	Subject SubjectRef `json:"subject"`
+}
+
+// ObjectRef identifies a role or binding.
+type ObjectRef struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+}
+
+func (r *RoleGraphDiff) EnsureDefaults() {
+	if strings.TrimSpace(r.APIVersion) == "" {
+		r.APIVersion = APIVersionValue
+	}
+	if strings.TrimSpace(r.Kind) == "" {
+		r.Kind = RoleGraphDiffKind
+	}
+	r.Spec.EnsureDefaults()
+}
+
+func (r *RBACHygieneReport) EnsureDefaults() {
+	if strings.TrimSpace(r.APIVersion) == "" {
+		r.APIVersion = APIVersionValue
+	}
+	if strings.TrimSpace(r.Kind) == "" {
+		r.Kind = RBACHygieneReportKind
+	}
+}
+
+func (r *SubjectPermissionReview) EnsureDefaults() {
+	if strings.TrimSpace(r.APIVersion) == "" {
+		r.APIVersion = APIVersionValue
+	}
+	if strings.TrimSpace(r.Kind) == "" {
+		r.Kind = SubjectPermissionReviewKind
+	}
+}
+
 func (r *RoleGraphReview) EnsureDefaults() {
 	if strings.TrimSpace(r.APIVersion) == "" {
 		r.APIVersion = APIVersionValue
@@ -250,6 +761,179 @@ func (s RoleGraphReviewSpec) Validate() error {
 	if podPhaseMode != PodPhaseModeActive && podPhaseMode != PodPhaseModeAll && podPhaseMode != PodPhaseModeRunning {
 		return fmt.Errorf("invalid podPhaseMode %q", s.PodPhaseMode)
 	}
+	if s.Object != nil {
+		if !s.Selector.IsEmpty() {
+			return errors.New("selector and object are mutually exclusive")
+		}
+		if s.Object.Resource == "" || s.Object.Verb == "" {
+			return errors.New("object.resource and object.verb are required")
+		}
+	}
+	switch s.IncludePolicyRules {
+	case "", PolicyRulesModeNone, PolicyRulesModeMatched, PolicyRulesModeAll:
+	default:
+		return fmt.Errorf("invalid includePolicyRules %q", s.IncludePolicyRules)
+	}
+	if s.MinRiskScore < 0 || s.MinRiskScore > MaxRiskScore {
+		return fmt.Errorf("minRiskScore must be between 0 and %d", MaxRiskScore)
+	}
+	if err := s.NamespaceScope.Validate(); err != nil {
+		return err
+	}
+	if err := s.Selector.Subjects.Validate(); err != nil {
+		return err
+	}
+	if err := validateObjectFilter("role", s.RoleLabelSelector, s.RoleNamePatterns); err != nil {
+		return err
+	}
+
+	return validateObjectFilter("binding", s.BindingLabelSelector, s.BindingNamePatterns)
+}
+
+// Validate checks subject kinds and name patterns. A nil selector is valid.
+func (s *SubjectSelector) Validate() error {
+	if s == nil {
+		return nil
+	}
+	for _, kind := range s.Kinds {
+		if kind != SubjectKindUser && kind != SubjectKindGroup && kind != SubjectKindServiceAccount {
+			return fmt.Errorf("invalid selector.subjects.kinds entry %q", kind)
+		}
+	}
+	for _, pattern := range s.NamePatterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid selector.subjects.namePatterns entry %q: %w", pattern, err)
+		}
+	}
+	if s.NameRegex != "" {
+		if _, err := regexp.Compile(s.NameRegex); err != nil {
+			return fmt.Errorf("invalid selector.subjects.nameRegex: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Validate checks the namespace selector and globs.
+func (s NamespaceScope) Validate() error {
+	if s.Selector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(s.Selector); err != nil {
+			return fmt.Errorf("invalid namespaceScope.selector: %w", err)
+		}
+	}
+	for _, pattern := range s.Patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid namespaceScope.patterns entry %q: %w", pattern, err)
+		}
+	}
+	for _, pattern := range s.Exclude {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid namespaceScope.exclude entry %q: %w", pattern, err)
+		}
+	}
+
+	return nil
+}
+
+func validateObjectFilter(prefix string, selector *metav1.LabelSelector, patterns []string) error {
+	if selector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(selector); err != nil {
+			return fmt.Errorf("invalid %sLabelSelector: %w", prefix, err)
+		}
+	}
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid %sNamePatterns entry %q: %w", prefix, pattern, err)
+		}
+	}
+
+	return nil
+}
+
+// IsEmpty reports whether the selector has no rule criteria. Subjects is
+// not a rule criterion.
+func (s Selector) IsEmpty() bool {
+	return len(s.APIGroups) == 0 && len(s.Resources) == 0 && len(s.Verbs) == 0 &&
+		len(s.ResourceNames) == 0 && len(s.NonResourceURLs) == 0
+}
+
+func (s SubjectPermissionReviewSpec) Validate() error {
+	switch s.Subject.Kind {
+	case SubjectKindUser, SubjectKindGroup:
+	case SubjectKindServiceAccount:
+		if s.Subject.Namespace == "" {
+			return errors.New("subject.namespace is required for ServiceAccount subjects")
+		}
+	default:
+		return fmt.Errorf("invalid subject.kind %q", s.Subject.Kind)
+	}
+	if s.Subject.Name == "" {
+		return errors.New("subject.name is required")
+	}
+
+	return s.NamespaceScope.Validate()
+}
+
+func (s RBACHygieneReportSpec) Validate() error {
+	for _, check := range s.Checks {
+		if !slices.Contains(AllHygieneChecks, check) {
+			return fmt.Errorf("invalid check %q", check)
+		}
+	}
+
+	return s.NamespaceScope.Validate()
+}
+
+func (s *RoleGraphDiffSpec) EnsureDefaults() {
+	if s.MatchMode == "" {
+		s.MatchMode = MatchModeAny
+	}
+	if s.WildcardMode == "" {
+		s.WildcardMode = WildcardModeExpand
+	}
+}
+
+func (s RoleGraphDiffSpec) Validate() error {
+	if s.From.IsEmpty() {
+		return errors.New("from.generation or from.time is required")
+	}
+	if err := s.From.Validate("from"); err != nil {
+		return err
+	}
+	if err := s.To.Validate("to"); err != nil {
+		return err
+	}
+
+	return s.ReviewSpec().Validate()
+}
+
+// ReviewSpec returns the RoleGraphReview query run against both snapshots.
+func (s RoleGraphDiffSpec) ReviewSpec() RoleGraphReviewSpec {
+	spec := RoleGraphReviewSpec{
+		Selector:               s.Selector,
+		MatchMode:              s.MatchMode,
+		WildcardMode:           s.WildcardMode,
+		NamespaceScope:         s.NamespaceScope,
+		FilterPhantomAPIs:      s.FilterPhantomAPIs,
+		Object:                 s.Object,
+		ResourceMapByNamespace: s.ResourceMapByNamespace,
+	}
+	spec.EnsureDefaults()
+
+	return spec
+}
+
+func (p SnapshotPoint) IsEmpty() bool {
+	return p.Generation == 0 && p.Time == nil
+}
+
+func (p SnapshotPoint) Validate(field string) error {
+	if p.Generation < 0 {
+		return fmt.Errorf("%s.generation must not be negative", field)
+	}
+	if p.Generation != 0 && p.Time != nil {
+		return fmt.Errorf("%s.generation and %s.time are mutually exclusive", field, field)
+	}
 
 	return nil
 }
@@ -279,8 +963,56 @@ func (RoleGraphReviewStatus) OpenAPIModelName() string {
 }
 func (Selector) OpenAPIModelName() string       { return openAPIPrefix + "Selector" }
 func (NamespaceScope) OpenAPIModelName() string { return openAPIPrefix + "NamespaceScope" }
+func (SubjectSelector) OpenAPIModelName() string {
+	return openAPIPrefix + "SubjectSelector"
+}
 func (Graph) OpenAPIModelName() string          { return openAPIPrefix + "Graph" }
 func (GraphNode) OpenAPIModelName() string      { return openAPIPrefix + "GraphNode" }
 func (GraphEdge) OpenAPIModelName() string      { return openAPIPrefix + "GraphEdge" }
 func (RuleRef) OpenAPIModelName() string        { return openAPIPrefix + "RuleRef" }
 func (ResourceMapRow) OpenAPIModelName() string { return openAPIPrefix + "ResourceMapRow" }
+func (ObjectTarget) OpenAPIModelName() string   { return openAPIPrefix + "ObjectTarget" }
+func (EffectiveScope) OpenAPIModelName() string { return openAPIPrefix + "EffectiveScope" }
+func (AggregationMatch) OpenAPIModelName() string {
+	return openAPIPrefix + "AggregationMatch"
+}
+func (RiskReason) OpenAPIModelName() string { return openAPIPrefix + "RiskReason" }
+func (PolicyRule) OpenAPIModelName() string { return openAPIPrefix + "PolicyRule" }
+
+func (ServiceAccountInfo) OpenAPIModelName() string { return openAPIPrefix + "ServiceAccountInfo" }
+
+func (CloudIdentity) OpenAPIModelName() string { return openAPIPrefix + "CloudIdentity" }
+
+func (TokenExposure) OpenAPIModelName() string { return openAPIPrefix + "TokenExposure" }
+
+func (ProjectedToken) OpenAPIModelName() string { return openAPIPrefix + "ProjectedToken" }
+
+func (SubjectPermissionReview) OpenAPIModelName() string {
+	return openAPIPrefix + "SubjectPermissionReview"
+}
+func (SubjectPermissionReviewSpec) OpenAPIModelName() string {
+	return openAPIPrefix + "SubjectPermissionReviewSpec"
+}
+func (SubjectPermissionReviewStatus) OpenAPIModelName() string {
+	return openAPIPrefix + "SubjectPermissionReviewStatus"
+}
+func (SubjectRef) OpenAPIModelName() string        { return openAPIPrefix + "SubjectRef" }
+func (SubjectPermission) OpenAPIModelName() string { return openAPIPrefix + "SubjectPermission" }
+
+func (RBACHygieneReport) OpenAPIModelName() string { return openAPIPrefix + "RBACHygieneReport" }
+func (RBACHygieneReportSpec) OpenAPIModelName() string {
+	return openAPIPrefix + "RBACHygieneReportSpec"
+}
+func (RBACHygieneReportStatus) OpenAPIModelName() string {
+	return openAPIPrefix + "RBACHygieneReportStatus"
+}
+func (HygieneFinding) OpenAPIModelName() string { return openAPIPrefix + "HygieneFinding" }
+
+func (RoleGraphDiff) OpenAPIModelName() string       { return openAPIPrefix + "RoleGraphDiff" }
+func (RoleGraphDiffSpec) OpenAPIModelName() string   { return openAPIPrefix + "RoleGraphDiffSpec" }
+func (RoleGraphDiffStatus) OpenAPIModelName() string { return openAPIPrefix + "RoleGraphDiffStatus" }
+func (RoleGraphChanges) OpenAPIModelName() string    { return openAPIPrefix + "RoleGraphChanges" }
+func (SnapshotPoint) OpenAPIModelName() string       { return openAPIPrefix + "SnapshotPoint" }
+func (SnapshotRef) OpenAPIModelName() string         { return openAPIPrefix + "SnapshotRef" }
+func (ObjectRef) OpenAPIModelName() string           { return openAPIPrefix + "ObjectRef" }
+func (BindingSubject) OpenAPIModelName() string      { return openAPIPrefix + "BindingSubject" }
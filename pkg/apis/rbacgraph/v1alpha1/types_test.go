@@ -1,6 +1,11 @@
 package v1alpha1
 
-import "testing"
+import (
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
 
 func TestRoleGraphReviewSpecEnsureDefaultsRuntime(t *testing.T) {
 	spec := RoleGraphReviewSpec{}
@@ -37,3 +42,174 @@ func TestRoleGraphReviewSpecNormalizeRuntimeFlags(t *testing.T) {
 		t.Fatalf("expected single warning, got %d", len(warnings))
 	}
 }
+
+func TestSubjectPermissionReviewSpecValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		subject SubjectRef
+		wantErr bool
+	}{
+		{name: "user", subject: SubjectRef{Kind: SubjectKindUser, Name: "alice"}},
+		{name: "group", subject: SubjectRef{Kind: SubjectKindGroup, Name: "devs"}},
+		{name: "service account", subject: SubjectRef{Kind: SubjectKindServiceAccount, Namespace: "ci", Name: "builder"}},
+		{name: "service account without namespace", subject: SubjectRef{Kind: SubjectKindServiceAccount, Name: "builder"}, wantErr: true},
+		{name: "missing name", subject: SubjectRef{Kind: SubjectKindUser}, wantErr: true},
+		{name: "invalid kind", subject: SubjectRef{Kind: "Robot", Name: "r2"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := SubjectPermissionReviewSpec{Subject: tt.subject}.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error=%v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestRoleGraphReviewSpecValidateObject(t *testing.T) {
+	spec := RoleGraphReviewSpec{Object: &ObjectTarget{Resource: "secrets", Namespace: "prod", Name: "db-creds", Verb: "get"}}
+	spec.EnsureDefaults()
+	if err := spec.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	spec.Selector = Selector{Verbs: []string{"get"}}
+	if err := spec.Validate(); err == nil {
+		t.Fatalf("expected error when selector and object are both set")
+	}
+
+	spec = RoleGraphReviewSpec{Object: &ObjectTarget{Resource: "secrets"}}
+	spec.EnsureDefaults()
+	if err := spec.Validate(); err == nil {
+		t.Fatalf("expected error when object.verb is missing")
+	}
+}
+
+func TestRoleGraphReviewSpecValidateMinRiskScore(t *testing.T) {
+	for _, score := range []int{-1, MaxRiskScore + 1} {
+		spec := RoleGraphReviewSpec{MinRiskScore: score}
+		spec.EnsureDefaults()
+		if err := spec.Validate(); err == nil {
+			t.Fatalf("expected error for minRiskScore=%d", score)
+		}
+	}
+}
+
+func TestRoleGraphReviewSpecValidateIncludePolicyRules(t *testing.T) {
+	for _, mode := range []PolicyRulesMode{"", PolicyRulesModeNone, PolicyRulesModeMatched, PolicyRulesModeAll} {
+		spec := RoleGraphReviewSpec{IncludePolicyRules: mode}
+		spec.EnsureDefaults()
+		if err := spec.Validate(); err != nil {
+			t.Fatalf("expected includePolicyRules=%q to be valid, got %v", mode, err)
+		}
+	}
+	spec := RoleGraphReviewSpec{IncludePolicyRules: "some"}
+	spec.EnsureDefaults()
+	if err := spec.Validate(); err == nil {
+		t.Fatal("expected invalid includePolicyRules error")
+	}
+}
+
+func TestNamespaceScopeValidate(t *testing.T) {
+	valid := NamespaceScope{
+		Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "payments"}},
+		Patterns: []string{"tenant-*"},
+		Exclude:  []string{"tenant-sandbox", "kube-*"},
+	}
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("expected valid namespace scope, got %v", err)
+	}
+	for _, scope := range []NamespaceScope{
+		{Selector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "team", Operator: "Bogus"}}}},
+		{Patterns: []string{"tenant-["}},
+		{Exclude: []string{"["}},
+	} {
+		spec := RoleGraphReviewSpec{NamespaceScope: scope}
+		spec.EnsureDefaults()
+		if err := spec.Validate(); err == nil || !strings.Contains(err.Error(), "namespaceScope") {
+			t.Fatalf("expected namespaceScope error for %#v, got %v", scope, err)
+		}
+	}
+}
+
+func TestRoleGraphReviewSpecValidateMetadataFilters(t *testing.T) {
+	for name, spec := range map[string]RoleGraphReviewSpec{
+		"role selector": {RoleLabelSelector: &metav1.LabelSelector{
+			MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "team", Operator: "Like"}},
+		}},
+		"binding selector": {BindingLabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"bad key!": "x"}}},
+		"role pattern":     {RoleNamePatterns: []string{"system:[*"}},
+		"binding pattern":  {BindingNamePatterns: []string{"["}},
+	} {
+		spec.EnsureDefaults()
+		if err := spec.Validate(); err == nil {
+			t.Fatalf("expected error for invalid %s", name)
+		}
+	}
+
+	spec := RoleGraphReviewSpec{
+		RoleLabelSelector:   &metav1.LabelSelector{MatchLabels: map[string]string{"app.kubernetes.io/managed-by": "Helm"}},
+		BindingNamePatterns: []string{"system:*"},
+	}
+	spec.EnsureDefaults()
+	if err := spec.Validate(); err != nil {
+		t.Fatalf("expected valid metadata filters, got %v", err)
+	}
+}
+
+func TestRoleGraphReviewSpecValidateSubjectSelector(t *testing.T) {
+	for name, subjects := range map[string]*SubjectSelector{
+		"kind":    {Kinds: []string{"Robot"}},
+		"pattern": {NamePatterns: []string{"ci-["}},
+		"regex":   {NameRegex: "ci-("},
+	} {
+		spec := RoleGraphReviewSpec{Selector: Selector{Subjects: subjects}}
+		spec.EnsureDefaults()
+		if err := spec.Validate(); err == nil {
+			t.Fatalf("expected error for invalid subject %s", name)
+		}
+	}
+
+	spec := RoleGraphReviewSpec{
+		Selector: Selector{Subjects: &SubjectSelector{Kinds: []string{SubjectKindServiceAccount}, NameRegex: "ci-.*"}},
+		Object:   &ObjectTarget{Resource: "deployments", Verb: "delete"},
+	}
+	spec.EnsureDefaults()
+	if err := spec.Validate(); err != nil {
+		t.Fatalf("expected subjects to combine with object, got %v", err)
+	}
+}
+
+func TestRBACHygieneReportSpecValidate(t *testing.T) {
+	if err := (RBACHygieneReportSpec{Checks: AllHygieneChecks}).Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := (RBACHygieneReportSpec{Checks: []HygieneCheck{"orphanedPods"}}).Validate(); err == nil {
+		t.Fatalf("expected error for unknown check")
+	}
+}
+
+func TestRoleGraphDiffSpecValidate(t *testing.T) {
+	now := metav1.Now()
+	tests := []struct {
+		name    string
+		spec    RoleGraphDiffSpec
+		wantErr bool
+	}{
+		{name: "generation", spec: RoleGraphDiffSpec{From: SnapshotPoint{Generation: 1}}},
+		{name: "time to current", spec: RoleGraphDiffSpec{From: SnapshotPoint{Time: &now}}},
+		{name: "missing from", spec: RoleGraphDiffSpec{}, wantErr: true},
+		{name: "both fields", spec: RoleGraphDiffSpec{From: SnapshotPoint{Generation: 1, Time: &now}}, wantErr: true},
+		{name: "negative to", spec: RoleGraphDiffSpec{From: SnapshotPoint{Generation: 1}, To: SnapshotPoint{Generation: -1}}, wantErr: true},
+		{name: "invalid matchMode", spec: RoleGraphDiffSpec{From: SnapshotPoint{Generation: 1}, MatchMode: "some"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.spec.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
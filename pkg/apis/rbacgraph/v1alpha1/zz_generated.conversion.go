@@ -25,6 +25,7 @@ import (
 	rbacgraph "k8s-role-graph/pkg/apis/rbacgraph"
 	unsafe "unsafe"
 
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	conversion "k8s.io/apimachinery/pkg/conversion"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
@@ -36,6 +37,46 @@ func init() {
 // RegisterConversions adds conversion functions to the given scheme.
 // Public to allow building arbitrary schemes.
 func RegisterConversions(s *runtime.Scheme) error {
+	if err := s.AddGeneratedConversionFunc((*AggregationMatch)(nil), (*rbacgraph.AggregationMatch)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_AggregationMatch_To_rbacgraph_AggregationMatch(a.(*AggregationMatch), b.(*rbacgraph.AggregationMatch), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*rbacgraph.AggregationMatch)(nil), (*AggregationMatch)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_rbacgraph_AggregationMatch_To_v1alpha1_AggregationMatch(a.(*rbacgraph.AggregationMatch), b.(*AggregationMatch), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*BindingSubject)(nil), (*rbacgraph.BindingSubject)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_BindingSubject_To_rbacgraph_BindingSubject(a.(*BindingSubject), b.(*rbacgraph.BindingSubject), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*rbacgraph.BindingSubject)(nil), (*BindingSubject)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_rbacgraph_BindingSubject_To_v1alpha1_BindingSubject(a.(*rbacgraph.BindingSubject), b.(*BindingSubject), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*CloudIdentity)(nil), (*rbacgraph.CloudIdentity)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_CloudIdentity_To_rbacgraph_CloudIdentity(a.(*CloudIdentity), b.(*rbacgraph.CloudIdentity), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*rbacgraph.CloudIdentity)(nil), (*CloudIdentity)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_rbacgraph_CloudIdentity_To_v1alpha1_CloudIdentity(a.(*rbacgraph.CloudIdentity), b.(*CloudIdentity), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*EffectiveScope)(nil), (*rbacgraph.EffectiveScope)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_EffectiveScope_To_rbacgraph_EffectiveScope(a.(*EffectiveScope), b.(*rbacgraph.EffectiveScope), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*rbacgraph.EffectiveScope)(nil), (*EffectiveScope)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_rbacgraph_EffectiveScope_To_v1alpha1_EffectiveScope(a.(*rbacgraph.EffectiveScope), b.(*EffectiveScope), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*Graph)(nil), (*rbacgraph.Graph)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_Graph_To_rbacgraph_Graph(a.(*Graph), b.(*rbacgraph.Graph), scope)
 	}); err != nil {
@@ -66,6 +107,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*HygieneFinding)(nil), (*rbacgraph.HygieneFinding)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_HygieneFinding_To_rbacgraph_HygieneFinding(a.(*HygieneFinding), b.(*rbacgraph.HygieneFinding), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*rbacgraph.HygieneFinding)(nil), (*HygieneFinding)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_rbacgraph_HygieneFinding_To_v1alpha1_HygieneFinding(a.(*rbacgraph.HygieneFinding), b.(*HygieneFinding), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*NamespaceScope)(nil), (*rbacgraph.NamespaceScope)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_NamespaceScope_To_rbacgraph_NamespaceScope(a.(*NamespaceScope), b.(*rbacgraph.NamespaceScope), scope)
 	}); err != nil {
@@ -96,6 +147,76 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ObjectRef)(nil), (*rbacgraph.ObjectRef)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ObjectRef_To_rbacgraph_ObjectRef(a.(*ObjectRef), b.(*rbacgraph.ObjectRef), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*rbacgraph.ObjectRef)(nil), (*ObjectRef)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_rbacgraph_ObjectRef_To_v1alpha1_ObjectRef(a.(*rbacgraph.ObjectRef), b.(*ObjectRef), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ObjectTarget)(nil), (*rbacgraph.ObjectTarget)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ObjectTarget_To_rbacgraph_ObjectTarget(a.(*ObjectTarget), b.(*rbacgraph.ObjectTarget), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*rbacgraph.ObjectTarget)(nil), (*ObjectTarget)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_rbacgraph_ObjectTarget_To_v1alpha1_ObjectTarget(a.(*rbacgraph.ObjectTarget), b.(*ObjectTarget), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*PolicyRule)(nil), (*rbacgraph.PolicyRule)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_PolicyRule_To_rbacgraph_PolicyRule(a.(*PolicyRule), b.(*rbacgraph.PolicyRule), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*rbacgraph.PolicyRule)(nil), (*PolicyRule)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_rbacgraph_PolicyRule_To_v1alpha1_PolicyRule(a.(*rbacgraph.PolicyRule), b.(*PolicyRule), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ProjectedToken)(nil), (*rbacgraph.ProjectedToken)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ProjectedToken_To_rbacgraph_ProjectedToken(a.(*ProjectedToken), b.(*rbacgraph.ProjectedToken), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*rbacgraph.ProjectedToken)(nil), (*ProjectedToken)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_rbacgraph_ProjectedToken_To_v1alpha1_ProjectedToken(a.(*rbacgraph.ProjectedToken), b.(*ProjectedToken), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*RBACHygieneReport)(nil), (*rbacgraph.RBACHygieneReport)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_RBACHygieneReport_To_rbacgraph_RBACHygieneReport(a.(*RBACHygieneReport), b.(*rbacgraph.RBACHygieneReport), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*rbacgraph.RBACHygieneReport)(nil), (*RBACHygieneReport)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_rbacgraph_RBACHygieneReport_To_v1alpha1_RBACHygieneReport(a.(*rbacgraph.RBACHygieneReport), b.(*RBACHygieneReport), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*RBACHygieneReportSpec)(nil), (*rbacgraph.RBACHygieneReportSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_RBACHygieneReportSpec_To_rbacgraph_RBACHygieneReportSpec(a.(*RBACHygieneReportSpec), b.(*rbacgraph.RBACHygieneReportSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*rbacgraph.RBACHygieneReportSpec)(nil), (*RBACHygieneReportSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_rbacgraph_RBACHygieneReportSpec_To_v1alpha1_RBACHygieneReportSpec(a.(*rbacgraph.RBACHygieneReportSpec), b.(*RBACHygieneReportSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*RBACHygieneReportStatus)(nil), (*rbacgraph.RBACHygieneReportStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_RBACHygieneReportStatus_To_rbacgraph_RBACHygieneReportStatus(a.(*RBACHygieneReportStatus), b.(*rbacgraph.RBACHygieneReportStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*rbacgraph.RBACHygieneReportStatus)(nil), (*RBACHygieneReportStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_rbacgraph_RBACHygieneReportStatus_To_v1alpha1_RBACHygieneReportStatus(a.(*rbacgraph.RBACHygieneReportStatus), b.(*RBACHygieneReportStatus), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ResourceMapRow)(nil), (*rbacgraph.ResourceMapRow)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_ResourceMapRow_To_rbacgraph_ResourceMapRow(a.(*ResourceMapRow), b.(*rbacgraph.ResourceMapRow), scope)
 	}); err != nil {
@@ -106,6 +227,56 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*RiskReason)(nil), (*rbacgraph.RiskReason)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_RiskReason_To_rbacgraph_RiskReason(a.(*RiskReason), b.(*rbacgraph.RiskReason), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*rbacgraph.RiskReason)(nil), (*RiskReason)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_rbacgraph_RiskReason_To_v1alpha1_RiskReason(a.(*rbacgraph.RiskReason), b.(*RiskReason), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*RoleGraphChanges)(nil), (*rbacgraph.RoleGraphChanges)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_RoleGraphChanges_To_rbacgraph_RoleGraphChanges(a.(*RoleGraphChanges), b.(*rbacgraph.RoleGraphChanges), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*rbacgraph.RoleGraphChanges)(nil), (*RoleGraphChanges)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_rbacgraph_RoleGraphChanges_To_v1alpha1_RoleGraphChanges(a.(*rbacgraph.RoleGraphChanges), b.(*RoleGraphChanges), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*RoleGraphDiff)(nil), (*rbacgraph.RoleGraphDiff)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_RoleGraphDiff_To_rbacgraph_RoleGraphDiff(a.(*RoleGraphDiff), b.(*rbacgraph.RoleGraphDiff), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*rbacgraph.RoleGraphDiff)(nil), (*RoleGraphDiff)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_rbacgraph_RoleGraphDiff_To_v1alpha1_RoleGraphDiff(a.(*rbacgraph.RoleGraphDiff), b.(*RoleGraphDiff), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*RoleGraphDiffSpec)(nil), (*rbacgraph.RoleGraphDiffSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_RoleGraphDiffSpec_To_rbacgraph_RoleGraphDiffSpec(a.(*RoleGraphDiffSpec), b.(*rbacgraph.RoleGraphDiffSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*rbacgraph.RoleGraphDiffSpec)(nil), (*RoleGraphDiffSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_rbacgraph_RoleGraphDiffSpec_To_v1alpha1_RoleGraphDiffSpec(a.(*rbacgraph.RoleGraphDiffSpec), b.(*RoleGraphDiffSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*RoleGraphDiffStatus)(nil), (*rbacgraph.RoleGraphDiffStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_RoleGraphDiffStatus_To_rbacgraph_RoleGraphDiffStatus(a.(*RoleGraphDiffStatus), b.(*rbacgraph.RoleGraphDiffStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*rbacgraph.RoleGraphDiffStatus)(nil), (*RoleGraphDiffStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_rbacgraph_RoleGraphDiffStatus_To_v1alpha1_RoleGraphDiffStatus(a.(*rbacgraph.RoleGraphDiffStatus), b.(*RoleGraphDiffStatus), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*RoleGraphReview)(nil), (*rbacgraph.RoleGraphReview)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_RoleGraphReview_To_rbacgraph_RoleGraphReview(a.(*RoleGraphReview), b.(*rbacgraph.RoleGraphReview), scope)
 	}); err != nil {
@@ -156,9 +327,209 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ServiceAccountInfo)(nil), (*rbacgraph.ServiceAccountInfo)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ServiceAccountInfo_To_rbacgraph_ServiceAccountInfo(a.(*ServiceAccountInfo), b.(*rbacgraph.ServiceAccountInfo), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*rbacgraph.ServiceAccountInfo)(nil), (*ServiceAccountInfo)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_rbacgraph_ServiceAccountInfo_To_v1alpha1_ServiceAccountInfo(a.(*rbacgraph.ServiceAccountInfo), b.(*ServiceAccountInfo), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*SnapshotPoint)(nil), (*rbacgraph.SnapshotPoint)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_SnapshotPoint_To_rbacgraph_SnapshotPoint(a.(*SnapshotPoint), b.(*rbacgraph.SnapshotPoint), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*rbacgraph.SnapshotPoint)(nil), (*SnapshotPoint)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_rbacgraph_SnapshotPoint_To_v1alpha1_SnapshotPoint(a.(*rbacgraph.SnapshotPoint), b.(*SnapshotPoint), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*SnapshotRef)(nil), (*rbacgraph.SnapshotRef)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_SnapshotRef_To_rbacgraph_SnapshotRef(a.(*SnapshotRef), b.(*rbacgraph.SnapshotRef), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*rbacgraph.SnapshotRef)(nil), (*SnapshotRef)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_rbacgraph_SnapshotRef_To_v1alpha1_SnapshotRef(a.(*rbacgraph.SnapshotRef), b.(*SnapshotRef), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*SubjectPermission)(nil), (*rbacgraph.SubjectPermission)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_SubjectPermission_To_rbacgraph_SubjectPermission(a.(*SubjectPermission), b.(*rbacgraph.SubjectPermission), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*rbacgraph.SubjectPermission)(nil), (*SubjectPermission)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_rbacgraph_SubjectPermission_To_v1alpha1_SubjectPermission(a.(*rbacgraph.SubjectPermission), b.(*SubjectPermission), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*SubjectPermissionReview)(nil), (*rbacgraph.SubjectPermissionReview)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_SubjectPermissionReview_To_rbacgraph_SubjectPermissionReview(a.(*SubjectPermissionReview), b.(*rbacgraph.SubjectPermissionReview), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*rbacgraph.SubjectPermissionReview)(nil), (*SubjectPermissionReview)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_rbacgraph_SubjectPermissionReview_To_v1alpha1_SubjectPermissionReview(a.(*rbacgraph.SubjectPermissionReview), b.(*SubjectPermissionReview), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*SubjectPermissionReviewSpec)(nil), (*rbacgraph.SubjectPermissionReviewSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_SubjectPermissionReviewSpec_To_rbacgraph_SubjectPermissionReviewSpec(a.(*SubjectPermissionReviewSpec), b.(*rbacgraph.SubjectPermissionReviewSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*rbacgraph.SubjectPermissionReviewSpec)(nil), (*SubjectPermissionReviewSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_rbacgraph_SubjectPermissionReviewSpec_To_v1alpha1_SubjectPermissionReviewSpec(a.(*rbacgraph.SubjectPermissionReviewSpec), b.(*SubjectPermissionReviewSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*SubjectPermissionReviewStatus)(nil), (*rbacgraph.SubjectPermissionReviewStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_SubjectPermissionReviewStatus_To_rbacgraph_SubjectPermissionReviewStatus(a.(*SubjectPermissionReviewStatus), b.(*rbacgraph.SubjectPermissionReviewStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*rbacgraph.SubjectPermissionReviewStatus)(nil), (*SubjectPermissionReviewStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_rbacgraph_SubjectPermissionReviewStatus_To_v1alpha1_SubjectPermissionReviewStatus(a.(*rbacgraph.SubjectPermissionReviewStatus), b.(*SubjectPermissionReviewStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*SubjectRef)(nil), (*rbacgraph.SubjectRef)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_SubjectRef_To_rbacgraph_SubjectRef(a.(*SubjectRef), b.(*rbacgraph.SubjectRef), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*rbacgraph.SubjectRef)(nil), (*SubjectRef)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_rbacgraph_SubjectRef_To_v1alpha1_SubjectRef(a.(*rbacgraph.SubjectRef), b.(*SubjectRef), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*SubjectSelector)(nil), (*rbacgraph.SubjectSelector)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_SubjectSelector_To_rbacgraph_SubjectSelector(a.(*SubjectSelector), b.(*rbacgraph.SubjectSelector), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*rbacgraph.SubjectSelector)(nil), (*SubjectSelector)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_rbacgraph_SubjectSelector_To_v1alpha1_SubjectSelector(a.(*rbacgraph.SubjectSelector), b.(*SubjectSelector), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*TokenExposure)(nil), (*rbacgraph.TokenExposure)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_TokenExposure_To_rbacgraph_TokenExposure(a.(*TokenExposure), b.(*rbacgraph.TokenExposure), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*rbacgraph.TokenExposure)(nil), (*TokenExposure)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_rbacgraph_TokenExposure_To_v1alpha1_TokenExposure(a.(*rbacgraph.TokenExposure), b.(*TokenExposure), scope)
+	}); err != nil {
+		return err
+	}
+	return nil
+}
+
+func autoConvert_v1alpha1_AggregationMatch_To_rbacgraph_AggregationMatch(in *AggregationMatch, out *rbacgraph.AggregationMatch, s conversion.Scope) error {
+	out.SelectorIndex = in.SelectorIndex
+	out.Selector = in.Selector
+	out.MatchedLabels = *(*map[string]string)(unsafe.Pointer(&in.MatchedLabels))
+	return nil
+}
+
+// Convert_v1alpha1_AggregationMatch_To_rbacgraph_AggregationMatch is an autogenerated conversion function.
+func Convert_v1alpha1_AggregationMatch_To_rbacgraph_AggregationMatch(in *AggregationMatch, out *rbacgraph.AggregationMatch, s conversion.Scope) error {
+	return autoConvert_v1alpha1_AggregationMatch_To_rbacgraph_AggregationMatch(in, out, s)
+}
+
+func autoConvert_rbacgraph_AggregationMatch_To_v1alpha1_AggregationMatch(in *rbacgraph.AggregationMatch, out *AggregationMatch, s conversion.Scope) error {
+	out.SelectorIndex = in.SelectorIndex
+	out.Selector = in.Selector
+	out.MatchedLabels = *(*map[string]string)(unsafe.Pointer(&in.MatchedLabels))
+	return nil
+}
+
+// Convert_rbacgraph_AggregationMatch_To_v1alpha1_AggregationMatch is an autogenerated conversion function.
+func Convert_rbacgraph_AggregationMatch_To_v1alpha1_AggregationMatch(in *rbacgraph.AggregationMatch, out *AggregationMatch, s conversion.Scope) error {
+	return autoConvert_rbacgraph_AggregationMatch_To_v1alpha1_AggregationMatch(in, out, s)
+}
+
+func autoConvert_v1alpha1_BindingSubject_To_rbacgraph_BindingSubject(in *BindingSubject, out *rbacgraph.BindingSubject, s conversion.Scope) error {
+	if err := Convert_v1alpha1_ObjectRef_To_rbacgraph_ObjectRef(&in.Binding, &out.Binding, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_SubjectRef_To_rbacgraph_SubjectRef(&in.Subject, &out.Subject, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha1_BindingSubject_To_rbacgraph_BindingSubject is an autogenerated conversion function.
+func Convert_v1alpha1_BindingSubject_To_rbacgraph_BindingSubject(in *BindingSubject, out *rbacgraph.BindingSubject, s conversion.Scope) error {
+	return autoConvert_v1alpha1_BindingSubject_To_rbacgraph_BindingSubject(in, out, s)
+}
+
+func autoConvert_rbacgraph_BindingSubject_To_v1alpha1_BindingSubject(in *rbacgraph.BindingSubject, out *BindingSubject, s conversion.Scope) error {
+	if err := Convert_rbacgraph_ObjectRef_To_v1alpha1_ObjectRef(&in.Binding, &out.Binding, s); err != nil {
+		return err
+	}
+	if err := Convert_rbacgraph_SubjectRef_To_v1alpha1_SubjectRef(&in.Subject, &out.Subject, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_rbacgraph_BindingSubject_To_v1alpha1_BindingSubject is an autogenerated conversion function.
+func Convert_rbacgraph_BindingSubject_To_v1alpha1_BindingSubject(in *rbacgraph.BindingSubject, out *BindingSubject, s conversion.Scope) error {
+	return autoConvert_rbacgraph_BindingSubject_To_v1alpha1_BindingSubject(in, out, s)
+}
+
+func autoConvert_v1alpha1_CloudIdentity_To_rbacgraph_CloudIdentity(in *CloudIdentity, out *rbacgraph.CloudIdentity, s conversion.Scope) error {
+	out.Provider = rbacgraph.CloudIdentityProvider(in.Provider)
+	out.Annotation = in.Annotation
+	out.Identity = in.Identity
+	return nil
+}
+
+// Convert_v1alpha1_CloudIdentity_To_rbacgraph_CloudIdentity is an autogenerated conversion function.
+func Convert_v1alpha1_CloudIdentity_To_rbacgraph_CloudIdentity(in *CloudIdentity, out *rbacgraph.CloudIdentity, s conversion.Scope) error {
+	return autoConvert_v1alpha1_CloudIdentity_To_rbacgraph_CloudIdentity(in, out, s)
+}
+
+func autoConvert_rbacgraph_CloudIdentity_To_v1alpha1_CloudIdentity(in *rbacgraph.CloudIdentity, out *CloudIdentity, s conversion.Scope) error {
+	out.Provider = CloudIdentityProvider(in.Provider)
+	out.Annotation = in.Annotation
+	out.Identity = in.Identity
+	return nil
+}
+
+// Convert_rbacgraph_CloudIdentity_To_v1alpha1_CloudIdentity is an autogenerated conversion function.
+func Convert_rbacgraph_CloudIdentity_To_v1alpha1_CloudIdentity(in *rbacgraph.CloudIdentity, out *CloudIdentity, s conversion.Scope) error {
+	return autoConvert_rbacgraph_CloudIdentity_To_v1alpha1_CloudIdentity(in, out, s)
+}
+
+func autoConvert_v1alpha1_EffectiveScope_To_rbacgraph_EffectiveScope(in *EffectiveScope, out *rbacgraph.EffectiveScope, s conversion.Scope) error {
+	out.ClusterWide = in.ClusterWide
+	out.Namespaces = *(*[]string)(unsafe.Pointer(&in.Namespaces))
+	return nil
+}
+
+// Convert_v1alpha1_EffectiveScope_To_rbacgraph_EffectiveScope is an autogenerated conversion function.
+func Convert_v1alpha1_EffectiveScope_To_rbacgraph_EffectiveScope(in *EffectiveScope, out *rbacgraph.EffectiveScope, s conversion.Scope) error {
+	return autoConvert_v1alpha1_EffectiveScope_To_rbacgraph_EffectiveScope(in, out, s)
+}
+
+func autoConvert_rbacgraph_EffectiveScope_To_v1alpha1_EffectiveScope(in *rbacgraph.EffectiveScope, out *EffectiveScope, s conversion.Scope) error {
+	out.ClusterWide = in.ClusterWide
+	out.Namespaces = *(*[]string)(unsafe.Pointer(&in.Namespaces))
 	return nil
 }
 
+// Convert_rbacgraph_EffectiveScope_To_v1alpha1_EffectiveScope is an autogenerated conversion function.
+func Convert_rbacgraph_EffectiveScope_To_v1alpha1_EffectiveScope(in *rbacgraph.EffectiveScope, out *EffectiveScope, s conversion.Scope) error {
+	return autoConvert_rbacgraph_EffectiveScope_To_v1alpha1_EffectiveScope(in, out, s)
+}
+
 func autoConvert_v1alpha1_Graph_To_rbacgraph_Graph(in *Graph, out *rbacgraph.Graph, s conversion.Scope) error {
 	out.Nodes = *(*[]rbacgraph.GraphNode)(unsafe.Pointer(&in.Nodes))
 	out.Edges = *(*[]rbacgraph.GraphEdge)(unsafe.Pointer(&in.Edges))
@@ -188,6 +559,8 @@ func autoConvert_v1alpha1_GraphEdge_To_rbacgraph_GraphEdge(in *GraphEdge, out *r
 	out.Type = rbacgraph.GraphEdgeType(in.Type)
 	out.RuleRefs = *(*[]rbacgraph.RuleRef)(unsafe.Pointer(&in.RuleRefs))
 	out.Explain = in.Explain
+	out.Scope = (*rbacgraph.EffectiveScope)(unsafe.Pointer(in.Scope))
+	out.AggregationMatches = *(*[]rbacgraph.AggregationMatch)(unsafe.Pointer(&in.AggregationMatches))
 	return nil
 }
 
@@ -203,6 +576,8 @@ func autoConvert_rbacgraph_GraphEdge_To_v1alpha1_GraphEdge(in *rbacgraph.GraphEd
 	out.Type = GraphEdgeType(in.Type)
 	out.RuleRefs = *(*[]RuleRef)(unsafe.Pointer(&in.RuleRefs))
 	out.Explain = in.Explain
+	out.Scope = (*EffectiveScope)(unsafe.Pointer(in.Scope))
+	out.AggregationMatches = *(*[]AggregationMatch)(unsafe.Pointer(&in.AggregationMatches))
 	return nil
 }
 
@@ -225,6 +600,14 @@ func autoConvert_v1alpha1_GraphNode_To_rbacgraph_GraphNode(in *GraphNode, out *r
 	out.WorkloadKind = in.WorkloadKind
 	out.Synthetic = in.Synthetic
 	out.HiddenCount = in.HiddenCount
+	out.RiskScore = in.RiskScore
+	out.RiskReasons = *(*[]rbacgraph.RiskReason)(unsafe.Pointer(&in.RiskReasons))
+	out.PolicyRules = *(*[]rbacgraph.PolicyRule)(unsafe.Pointer(&in.PolicyRules))
+	out.RuleCount = in.RuleCount
+	out.ResourceVersion = in.ResourceVersion
+	out.NamespaceState = rbacgraph.NamespaceState(in.NamespaceState)
+	out.ServiceAccount = (*rbacgraph.ServiceAccountInfo)(unsafe.Pointer(in.ServiceAccount))
+	out.TokenExposure = (*rbacgraph.TokenExposure)(unsafe.Pointer(in.TokenExposure))
 	return nil
 }
 
@@ -247,6 +630,14 @@ func autoConvert_rbacgraph_GraphNode_To_v1alpha1_GraphNode(in *rbacgraph.GraphNo
 	out.WorkloadKind = in.WorkloadKind
 	out.Synthetic = in.Synthetic
 	out.HiddenCount = in.HiddenCount
+	out.RiskScore = in.RiskScore
+	out.RiskReasons = *(*[]RiskReason)(unsafe.Pointer(&in.RiskReasons))
+	out.PolicyRules = *(*[]PolicyRule)(unsafe.Pointer(&in.PolicyRules))
+	out.RuleCount = in.RuleCount
+	out.ResourceVersion = in.ResourceVersion
+	out.NamespaceState = NamespaceState(in.NamespaceState)
+	out.ServiceAccount = (*ServiceAccountInfo)(unsafe.Pointer(in.ServiceAccount))
+	out.TokenExposure = (*TokenExposure)(unsafe.Pointer(in.TokenExposure))
 	return nil
 }
 
@@ -255,9 +646,48 @@ func Convert_rbacgraph_GraphNode_To_v1alpha1_GraphNode(in *rbacgraph.GraphNode,
 	return autoConvert_rbacgraph_GraphNode_To_v1alpha1_GraphNode(in, out, s)
 }
 
+func autoConvert_v1alpha1_HygieneFinding_To_rbacgraph_HygieneFinding(in *HygieneFinding, out *rbacgraph.HygieneFinding, s conversion.Scope) error {
+	out.Check = rbacgraph.HygieneCheck(in.Check)
+	out.Kind = in.Kind
+	out.Namespace = in.Namespace
+	out.Name = in.Name
+	out.RoleRefKind = in.RoleRefKind
+	out.RoleRefName = in.RoleRefName
+	out.Subject = (*rbacgraph.SubjectRef)(unsafe.Pointer(in.Subject))
+	out.Unresolved = in.Unresolved
+	out.Message = in.Message
+	return nil
+}
+
+// Convert_v1alpha1_HygieneFinding_To_rbacgraph_HygieneFinding is an autogenerated conversion function.
+func Convert_v1alpha1_HygieneFinding_To_rbacgraph_HygieneFinding(in *HygieneFinding, out *rbacgraph.HygieneFinding, s conversion.Scope) error {
+	return autoConvert_v1alpha1_HygieneFinding_To_rbacgraph_HygieneFinding(in, out, s)
+}
+
+func autoConvert_rbacgraph_HygieneFinding_To_v1alpha1_HygieneFinding(in *rbacgraph.HygieneFinding, out *HygieneFinding, s conversion.Scope) error {
+	out.Check = HygieneCheck(in.Check)
+	out.Kind = in.Kind
+	out.Namespace = in.Namespace
+	out.Name = in.Name
+	out.RoleRefKind = in.RoleRefKind
+	out.RoleRefName = in.RoleRefName
+	out.Subject = (*SubjectRef)(unsafe.Pointer(in.Subject))
+	out.Unresolved = in.Unresolved
+	out.Message = in.Message
+	return nil
+}
+
+// Convert_rbacgraph_HygieneFinding_To_v1alpha1_HygieneFinding is an autogenerated conversion function.
+func Convert_rbacgraph_HygieneFinding_To_v1alpha1_HygieneFinding(in *rbacgraph.HygieneFinding, out *HygieneFinding, s conversion.Scope) error {
+	return autoConvert_rbacgraph_HygieneFinding_To_v1alpha1_HygieneFinding(in, out, s)
+}
+
 func autoConvert_v1alpha1_NamespaceScope_To_rbacgraph_NamespaceScope(in *NamespaceScope, out *rbacgraph.NamespaceScope, s conversion.Scope) error {
 	out.Namespaces = *(*[]string)(unsafe.Pointer(&in.Namespaces))
 	out.Strict = in.Strict
+	out.Selector = (*v1.LabelSelector)(unsafe.Pointer(in.Selector))
+	out.Patterns = *(*[]string)(unsafe.Pointer(&in.Patterns))
+	out.Exclude = *(*[]string)(unsafe.Pointer(&in.Exclude))
 	return nil
 }
 
@@ -269,6 +699,9 @@ func Convert_v1alpha1_NamespaceScope_To_rbacgraph_NamespaceScope(in *NamespaceSc
 func autoConvert_rbacgraph_NamespaceScope_To_v1alpha1_NamespaceScope(in *rbacgraph.NamespaceScope, out *NamespaceScope, s conversion.Scope) error {
 	out.Namespaces = *(*[]string)(unsafe.Pointer(&in.Namespaces))
 	out.Strict = in.Strict
+	out.Selector = (*v1.LabelSelector)(unsafe.Pointer(in.Selector))
+	out.Patterns = *(*[]string)(unsafe.Pointer(&in.Patterns))
+	out.Exclude = *(*[]string)(unsafe.Pointer(&in.Exclude))
 	return nil
 }
 
@@ -323,36 +756,408 @@ func Convert_rbacgraph_NonResourceURLList_To_v1alpha1_NonResourceURLList(in *rba
 	return autoConvert_rbacgraph_NonResourceURLList_To_v1alpha1_NonResourceURLList(in, out, s)
 }
 
-func autoConvert_v1alpha1_ResourceMapRow_To_rbacgraph_ResourceMapRow(in *ResourceMapRow, out *rbacgraph.ResourceMapRow, s conversion.Scope) error {
+func autoConvert_v1alpha1_ObjectRef_To_rbacgraph_ObjectRef(in *ObjectRef, out *rbacgraph.ObjectRef, s conversion.Scope) error {
+	out.Kind = in.Kind
+	out.Namespace = in.Namespace
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_v1alpha1_ObjectRef_To_rbacgraph_ObjectRef is an autogenerated conversion function.
+func Convert_v1alpha1_ObjectRef_To_rbacgraph_ObjectRef(in *ObjectRef, out *rbacgraph.ObjectRef, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ObjectRef_To_rbacgraph_ObjectRef(in, out, s)
+}
+
+func autoConvert_rbacgraph_ObjectRef_To_v1alpha1_ObjectRef(in *rbacgraph.ObjectRef, out *ObjectRef, s conversion.Scope) error {
+	out.Kind = in.Kind
+	out.Namespace = in.Namespace
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_rbacgraph_ObjectRef_To_v1alpha1_ObjectRef is an autogenerated conversion function.
+func Convert_rbacgraph_ObjectRef_To_v1alpha1_ObjectRef(in *rbacgraph.ObjectRef, out *ObjectRef, s conversion.Scope) error {
+	return autoConvert_rbacgraph_ObjectRef_To_v1alpha1_ObjectRef(in, out, s)
+}
+
+func autoConvert_v1alpha1_ObjectTarget_To_rbacgraph_ObjectTarget(in *ObjectTarget, out *rbacgraph.ObjectTarget, s conversion.Scope) error {
 	out.APIGroup = in.APIGroup
 	out.Resource = in.Resource
+	out.Namespace = in.Namespace
+	out.Name = in.Name
 	out.Verb = in.Verb
-	out.RoleCount = in.RoleCount
-	out.BindingCount = in.BindingCount
-	out.SubjectCount = in.SubjectCount
 	return nil
 }
 
-// Convert_v1alpha1_ResourceMapRow_To_rbacgraph_ResourceMapRow is an autogenerated conversion function.
-func Convert_v1alpha1_ResourceMapRow_To_rbacgraph_ResourceMapRow(in *ResourceMapRow, out *rbacgraph.ResourceMapRow, s conversion.Scope) error {
-	return autoConvert_v1alpha1_ResourceMapRow_To_rbacgraph_ResourceMapRow(in, out, s)
+// Convert_v1alpha1_ObjectTarget_To_rbacgraph_ObjectTarget is an autogenerated conversion function.
+func Convert_v1alpha1_ObjectTarget_To_rbacgraph_ObjectTarget(in *ObjectTarget, out *rbacgraph.ObjectTarget, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ObjectTarget_To_rbacgraph_ObjectTarget(in, out, s)
 }
 
-func autoConvert_rbacgraph_ResourceMapRow_To_v1alpha1_ResourceMapRow(in *rbacgraph.ResourceMapRow, out *ResourceMapRow, s conversion.Scope) error {
+func autoConvert_rbacgraph_ObjectTarget_To_v1alpha1_ObjectTarget(in *rbacgraph.ObjectTarget, out *ObjectTarget, s conversion.Scope) error {
 	out.APIGroup = in.APIGroup
 	out.Resource = in.Resource
+	out.Namespace = in.Namespace
+	out.Name = in.Name
 	out.Verb = in.Verb
-	out.RoleCount = in.RoleCount
-	out.BindingCount = in.BindingCount
-	out.SubjectCount = in.SubjectCount
 	return nil
 }
 
-// Convert_rbacgraph_ResourceMapRow_To_v1alpha1_ResourceMapRow is an autogenerated conversion function.
+// Convert_rbacgraph_ObjectTarget_To_v1alpha1_ObjectTarget is an autogenerated conversion function.
+func Convert_rbacgraph_ObjectTarget_To_v1alpha1_ObjectTarget(in *rbacgraph.ObjectTarget, out *ObjectTarget, s conversion.Scope) error {
+	return autoConvert_rbacgraph_ObjectTarget_To_v1alpha1_ObjectTarget(in, out, s)
+}
+
+func autoConvert_v1alpha1_PolicyRule_To_rbacgraph_PolicyRule(in *PolicyRule, out *rbacgraph.PolicyRule, s conversion.Scope) error {
+	out.Index = in.Index
+	out.Verbs = *(*[]string)(unsafe.Pointer(&in.Verbs))
+	out.APIGroups = *(*[]string)(unsafe.Pointer(&in.APIGroups))
+	out.Resources = *(*[]string)(unsafe.Pointer(&in.Resources))
+	out.ResourceNames = *(*[]string)(unsafe.Pointer(&in.ResourceNames))
+	out.NonResourceURLs = *(*[]string)(unsafe.Pointer(&in.NonResourceURLs))
+	return nil
+}
+
+// Convert_v1alpha1_PolicyRule_To_rbacgraph_PolicyRule is an autogenerated conversion function.
+func Convert_v1alpha1_PolicyRule_To_rbacgraph_PolicyRule(in *PolicyRule, out *rbacgraph.PolicyRule, s conversion.Scope) error {
+	return autoConvert_v1alpha1_PolicyRule_To_rbacgraph_PolicyRule(in, out, s)
+}
+
+func autoConvert_rbacgraph_PolicyRule_To_v1alpha1_PolicyRule(in *rbacgraph.PolicyRule, out *PolicyRule, s conversion.Scope) error {
+	out.Index = in.Index
+	out.Verbs = *(*[]string)(unsafe.Pointer(&in.Verbs))
+	out.APIGroups = *(*[]string)(unsafe.Pointer(&in.APIGroups))
+	out.Resources = *(*[]string)(unsafe.Pointer(&in.Resources))
+	out.ResourceNames = *(*[]string)(unsafe.Pointer(&in.ResourceNames))
+	out.NonResourceURLs = *(*[]string)(unsafe.Pointer(&in.NonResourceURLs))
+	return nil
+}
+
+// Convert_rbacgraph_PolicyRule_To_v1alpha1_PolicyRule is an autogenerated conversion function.
+func Convert_rbacgraph_PolicyRule_To_v1alpha1_PolicyRule(in *rbacgraph.PolicyRule, out *PolicyRule, s conversion.Scope) error {
+	return autoConvert_rbacgraph_PolicyRule_To_v1alpha1_PolicyRule(in, out, s)
+}
+
+func autoConvert_v1alpha1_ProjectedToken_To_rbacgraph_ProjectedToken(in *ProjectedToken, out *rbacgraph.ProjectedToken, s conversion.Scope) error {
+	out.Volume = in.Volume
+	out.Audience = in.Audience
+	out.ExpirationSeconds = in.ExpirationSeconds
+	return nil
+}
+
+// Convert_v1alpha1_ProjectedToken_To_rbacgraph_ProjectedToken is an autogenerated conversion function.
+func Convert_v1alpha1_ProjectedToken_To_rbacgraph_ProjectedToken(in *ProjectedToken, out *rbacgraph.ProjectedToken, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ProjectedToken_To_rbacgraph_ProjectedToken(in, out, s)
+}
+
+func autoConvert_rbacgraph_ProjectedToken_To_v1alpha1_ProjectedToken(in *rbacgraph.ProjectedToken, out *ProjectedToken, s conversion.Scope) error {
+	out.Volume = in.Volume
+	out.Audience = in.Audience
+	out.ExpirationSeconds = in.ExpirationSeconds
+	return nil
+}
+
+// Convert_rbacgraph_ProjectedToken_To_v1alpha1_ProjectedToken is an autogenerated conversion function.
+func Convert_rbacgraph_ProjectedToken_To_v1alpha1_ProjectedToken(in *rbacgraph.ProjectedToken, out *ProjectedToken, s conversion.Scope) error {
+	return autoConvert_rbacgraph_ProjectedToken_To_v1alpha1_ProjectedToken(in, out, s)
+}
+
+func autoConvert_v1alpha1_RBACHygieneReport_To_rbacgraph_RBACHygieneReport(in *RBACHygieneReport, out *rbacgraph.RBACHygieneReport, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1alpha1_RBACHygieneReportSpec_To_rbacgraph_RBACHygieneReportSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_RBACHygieneReportStatus_To_rbacgraph_RBACHygieneReportStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha1_RBACHygieneReport_To_rbacgraph_RBACHygieneReport is an autogenerated conversion function.
+func Convert_v1alpha1_RBACHygieneReport_To_rbacgraph_RBACHygieneReport(in *RBACHygieneReport, out *rbacgraph.RBACHygieneReport, s conversion.Scope) error {
+	return autoConvert_v1alpha1_RBACHygieneReport_To_rbacgraph_RBACHygieneReport(in, out, s)
+}
+
+func autoConvert_rbacgraph_RBACHygieneReport_To_v1alpha1_RBACHygieneReport(in *rbacgraph.RBACHygieneReport, out *RBACHygieneReport, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_rbacgraph_RBACHygieneReportSpec_To_v1alpha1_RBACHygieneReportSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_rbacgraph_RBACHygieneReportStatus_To_v1alpha1_RBACHygieneReportStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_rbacgraph_RBACHygieneReport_To_v1alpha1_RBACHygieneReport is an autogenerated conversion function.
+func Convert_rbacgraph_RBACHygieneReport_To_v1alpha1_RBACHygieneReport(in *rbacgraph.RBACHygieneReport, out *RBACHygieneReport, s conversion.Scope) error {
+	return autoConvert_rbacgraph_RBACHygieneReport_To_v1alpha1_RBACHygieneReport(in, out, s)
+}
+
+func autoConvert_v1alpha1_RBACHygieneReportSpec_To_rbacgraph_RBACHygieneReportSpec(in *RBACHygieneReportSpec, out *rbacgraph.RBACHygieneReportSpec, s conversion.Scope) error {
+	if err := Convert_v1alpha1_NamespaceScope_To_rbacgraph_NamespaceScope(&in.NamespaceScope, &out.NamespaceScope, s); err != nil {
+		return err
+	}
+	out.Checks = *(*[]rbacgraph.HygieneCheck)(unsafe.Pointer(&in.Checks))
+	out.IncludeSystemObjects = in.IncludeSystemObjects
+	return nil
+}
+
+// Convert_v1alpha1_RBACHygieneReportSpec_To_rbacgraph_RBACHygieneReportSpec is an autogenerated conversion function.
+func Convert_v1alpha1_RBACHygieneReportSpec_To_rbacgraph_RBACHygieneReportSpec(in *RBACHygieneReportSpec, out *rbacgraph.RBACHygieneReportSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha1_RBACHygieneReportSpec_To_rbacgraph_RBACHygieneReportSpec(in, out, s)
+}
+
+func autoConvert_rbacgraph_RBACHygieneReportSpec_To_v1alpha1_RBACHygieneReportSpec(in *rbacgraph.RBACHygieneReportSpec, out *RBACHygieneReportSpec, s conversion.Scope) error {
+	if err := Convert_rbacgraph_NamespaceScope_To_v1alpha1_NamespaceScope(&in.NamespaceScope, &out.NamespaceScope, s); err != nil {
+		return err
+	}
+	out.Checks = *(*[]HygieneCheck)(unsafe.Pointer(&in.Checks))
+	out.IncludeSystemObjects = in.IncludeSystemObjects
+	return nil
+}
+
+// Convert_rbacgraph_RBACHygieneReportSpec_To_v1alpha1_RBACHygieneReportSpec is an autogenerated conversion function.
+func Convert_rbacgraph_RBACHygieneReportSpec_To_v1alpha1_RBACHygieneReportSpec(in *rbacgraph.RBACHygieneReportSpec, out *RBACHygieneReportSpec, s conversion.Scope) error {
+	return autoConvert_rbacgraph_RBACHygieneReportSpec_To_v1alpha1_RBACHygieneReportSpec(in, out, s)
+}
+
+func autoConvert_v1alpha1_RBACHygieneReportStatus_To_rbacgraph_RBACHygieneReportStatus(in *RBACHygieneReportStatus, out *rbacgraph.RBACHygieneReportStatus, s conversion.Scope) error {
+	out.Findings = *(*[]rbacgraph.HygieneFinding)(unsafe.Pointer(&in.Findings))
+	out.Warnings = *(*[]string)(unsafe.Pointer(&in.Warnings))
+	return nil
+}
+
+// Convert_v1alpha1_RBACHygieneReportStatus_To_rbacgraph_RBACHygieneReportStatus is an autogenerated conversion function.
+func Convert_v1alpha1_RBACHygieneReportStatus_To_rbacgraph_RBACHygieneReportStatus(in *RBACHygieneReportStatus, out *rbacgraph.RBACHygieneReportStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_RBACHygieneReportStatus_To_rbacgraph_RBACHygieneReportStatus(in, out, s)
+}
+
+func autoConvert_rbacgraph_RBACHygieneReportStatus_To_v1alpha1_RBACHygieneReportStatus(in *rbacgraph.RBACHygieneReportStatus, out *RBACHygieneReportStatus, s conversion.Scope) error {
+	out.Findings = *(*[]HygieneFinding)(unsafe.Pointer(&in.Findings))
+	out.Warnings = *(*[]string)(unsafe.Pointer(&in.Warnings))
+	return nil
+}
+
+// Convert_rbacgraph_RBACHygieneReportStatus_To_v1alpha1_RBACHygieneReportStatus is an autogenerated conversion function.
+func Convert_rbacgraph_RBACHygieneReportStatus_To_v1alpha1_RBACHygieneReportStatus(in *rbacgraph.RBACHygieneReportStatus, out *RBACHygieneReportStatus, s conversion.Scope) error {
+	return autoConvert_rbacgraph_RBACHygieneReportStatus_To_v1alpha1_RBACHygieneReportStatus(in, out, s)
+}
+
+func autoConvert_v1alpha1_ResourceMapRow_To_rbacgraph_ResourceMapRow(in *ResourceMapRow, out *rbacgraph.ResourceMapRow, s conversion.Scope) error {
+	out.APIGroup = in.APIGroup
+	out.Resource = in.Resource
+	out.Verb = in.Verb
+	out.Namespace = in.Namespace
+	out.RoleCount = in.RoleCount
+	out.BindingCount = in.BindingCount
+	out.SubjectCount = in.SubjectCount
+	out.Scope = (*rbacgraph.EffectiveScope)(unsafe.Pointer(in.Scope))
+	return nil
+}
+
+// Convert_v1alpha1_ResourceMapRow_To_rbacgraph_ResourceMapRow is an autogenerated conversion function.
+func Convert_v1alpha1_ResourceMapRow_To_rbacgraph_ResourceMapRow(in *ResourceMapRow, out *rbacgraph.ResourceMapRow, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ResourceMapRow_To_rbacgraph_ResourceMapRow(in, out, s)
+}
+
+func autoConvert_rbacgraph_ResourceMapRow_To_v1alpha1_ResourceMapRow(in *rbacgraph.ResourceMapRow, out *ResourceMapRow, s conversion.Scope) error {
+	out.APIGroup = in.APIGroup
+	out.Resource = in.Resource
+	out.Verb = in.Verb
+	out.Namespace = in.Namespace
+	out.RoleCount = in.RoleCount
+	out.BindingCount = in.BindingCount
+	out.SubjectCount = in.SubjectCount
+	out.Scope = (*EffectiveScope)(unsafe.Pointer(in.Scope))
+	return nil
+}
+
+// Convert_rbacgraph_ResourceMapRow_To_v1alpha1_ResourceMapRow is an autogenerated conversion function.
 func Convert_rbacgraph_ResourceMapRow_To_v1alpha1_ResourceMapRow(in *rbacgraph.ResourceMapRow, out *ResourceMapRow, s conversion.Scope) error {
 	return autoConvert_rbacgraph_ResourceMapRow_To_v1alpha1_ResourceMapRow(in, out, s)
 }
 
+func autoConvert_v1alpha1_RiskReason_To_rbacgraph_RiskReason(in *RiskReason, out *rbacgraph.RiskReason, s conversion.Scope) error {
+	out.ID = in.ID
+	out.Description = in.Description
+	out.Score = in.Score
+	return nil
+}
+
+// Convert_v1alpha1_RiskReason_To_rbacgraph_RiskReason is an autogenerated conversion function.
+func Convert_v1alpha1_RiskReason_To_rbacgraph_RiskReason(in *RiskReason, out *rbacgraph.RiskReason, s conversion.Scope) error {
+	return autoConvert_v1alpha1_RiskReason_To_rbacgraph_RiskReason(in, out, s)
+}
+
+func autoConvert_rbacgraph_RiskReason_To_v1alpha1_RiskReason(in *rbacgraph.RiskReason, out *RiskReason, s conversion.Scope) error {
+	out.ID = in.ID
+	out.Description = in.Description
+	out.Score = in.Score
+	return nil
+}
+
+// Convert_rbacgraph_RiskReason_To_v1alpha1_RiskReason is an autogenerated conversion function.
+func Convert_rbacgraph_RiskReason_To_v1alpha1_RiskReason(in *rbacgraph.RiskReason, out *RiskReason, s conversion.Scope) error {
+	return autoConvert_rbacgraph_RiskReason_To_v1alpha1_RiskReason(in, out, s)
+}
+
+func autoConvert_v1alpha1_RoleGraphChanges_To_rbacgraph_RoleGraphChanges(in *RoleGraphChanges, out *rbacgraph.RoleGraphChanges, s conversion.Scope) error {
+	out.Roles = *(*[]rbacgraph.ObjectRef)(unsafe.Pointer(&in.Roles))
+	out.Bindings = *(*[]rbacgraph.ObjectRef)(unsafe.Pointer(&in.Bindings))
+	out.Subjects = *(*[]rbacgraph.SubjectRef)(unsafe.Pointer(&in.Subjects))
+	out.BindingSubjects = *(*[]rbacgraph.BindingSubject)(unsafe.Pointer(&in.BindingSubjects))
+	out.Permissions = *(*[]rbacgraph.ResourceMapRow)(unsafe.Pointer(&in.Permissions))
+	return nil
+}
+
+// Convert_v1alpha1_RoleGraphChanges_To_rbacgraph_RoleGraphChanges is an autogenerated conversion function.
+func Convert_v1alpha1_RoleGraphChanges_To_rbacgraph_RoleGraphChanges(in *RoleGraphChanges, out *rbacgraph.RoleGraphChanges, s conversion.Scope) error {
+	return autoConvert_v1alpha1_RoleGraphChanges_To_rbacgraph_RoleGraphChanges(in, out, s)
+}
+
+func autoConvert_rbacgraph_RoleGraphChanges_To_v1alpha1_RoleGraphChanges(in *rbacgraph.RoleGraphChanges, out *RoleGraphChanges, s conversion.Scope) error {
+	out.Roles = *(*[]ObjectRef)(unsafe.Pointer(&in.Roles))
+	out.Bindings = *(*[]ObjectRef)(unsafe.Pointer(&in.Bindings))
+	out.Subjects = *(*[]SubjectRef)(unsafe.Pointer(&in.Subjects))
+	out.BindingSubjects = *(*[]BindingSubject)(unsafe.Pointer(&in.BindingSubjects))
+	out.Permissions = *(*[]ResourceMapRow)(unsafe.Pointer(&in.Permissions))
+	return nil
+}
+
+// Convert_rbacgraph_RoleGraphChanges_To_v1alpha1_RoleGraphChanges is an autogenerated conversion function.
+func Convert_rbacgraph_RoleGraphChanges_To_v1alpha1_RoleGraphChanges(in *rbacgraph.RoleGraphChanges, out *RoleGraphChanges, s conversion.Scope) error {
+	return autoConvert_rbacgraph_RoleGraphChanges_To_v1alpha1_RoleGraphChanges(in, out, s)
+}
+
+func autoConvert_v1alpha1_RoleGraphDiff_To_rbacgraph_RoleGraphDiff(in *RoleGraphDiff, out *rbacgraph.RoleGraphDiff, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1alpha1_RoleGraphDiffSpec_To_rbacgraph_RoleGraphDiffSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_RoleGraphDiffStatus_To_rbacgraph_RoleGraphDiffStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha1_RoleGraphDiff_To_rbacgraph_RoleGraphDiff is an autogenerated conversion function.
+func Convert_v1alpha1_RoleGraphDiff_To_rbacgraph_RoleGraphDiff(in *RoleGraphDiff, out *rbacgraph.RoleGraphDiff, s conversion.Scope) error {
+	return autoConvert_v1alpha1_RoleGraphDiff_To_rbacgraph_RoleGraphDiff(in, out, s)
+}
+
+func autoConvert_rbacgraph_RoleGraphDiff_To_v1alpha1_RoleGraphDiff(in *rbacgraph.RoleGraphDiff, out *RoleGraphDiff, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_rbacgraph_RoleGraphDiffSpec_To_v1alpha1_RoleGraphDiffSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_rbacgraph_RoleGraphDiffStatus_To_v1alpha1_RoleGraphDiffStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_rbacgraph_RoleGraphDiff_To_v1alpha1_RoleGraphDiff is an autogenerated conversion function.
+func Convert_rbacgraph_RoleGraphDiff_To_v1alpha1_RoleGraphDiff(in *rbacgraph.RoleGraphDiff, out *RoleGraphDiff, s conversion.Scope) error {
+	return autoConvert_rbacgraph_RoleGraphDiff_To_v1alpha1_RoleGraphDiff(in, out, s)
+}
+
+func autoConvert_v1alpha1_RoleGraphDiffSpec_To_rbacgraph_RoleGraphDiffSpec(in *RoleGraphDiffSpec, out *rbacgraph.RoleGraphDiffSpec, s conversion.Scope) error {
+	if err := Convert_v1alpha1_Selector_To_rbacgraph_Selector(&in.Selector, &out.Selector, s); err != nil {
+		return err
+	}
+	out.MatchMode = rbacgraph.MatchMode(in.MatchMode)
+	out.WildcardMode = rbacgraph.WildcardMode(in.WildcardMode)
+	if err := Convert_v1alpha1_NamespaceScope_To_rbacgraph_NamespaceScope(&in.NamespaceScope, &out.NamespaceScope, s); err != nil {
+		return err
+	}
+	out.FilterPhantomAPIs = in.FilterPhantomAPIs
+	out.Object = (*rbacgraph.ObjectTarget)(unsafe.Pointer(in.Object))
+	out.ResourceMapByNamespace = in.ResourceMapByNamespace
+	if err := Convert_v1alpha1_SnapshotPoint_To_rbacgraph_SnapshotPoint(&in.From, &out.From, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_SnapshotPoint_To_rbacgraph_SnapshotPoint(&in.To, &out.To, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha1_RoleGraphDiffSpec_To_rbacgraph_RoleGraphDiffSpec is an autogenerated conversion function.
+func Convert_v1alpha1_RoleGraphDiffSpec_To_rbacgraph_RoleGraphDiffSpec(in *RoleGraphDiffSpec, out *rbacgraph.RoleGraphDiffSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha1_RoleGraphDiffSpec_To_rbacgraph_RoleGraphDiffSpec(in, out, s)
+}
+
+func autoConvert_rbacgraph_RoleGraphDiffSpec_To_v1alpha1_RoleGraphDiffSpec(in *rbacgraph.RoleGraphDiffSpec, out *RoleGraphDiffSpec, s conversion.Scope) error {
+	if err := Convert_rbacgraph_Selector_To_v1alpha1_Selector(&in.Selector, &out.Selector, s); err != nil {
+		return err
+	}
+	out.MatchMode = MatchMode(in.MatchMode)
+	out.WildcardMode = WildcardMode(in.WildcardMode)
+	if err := Convert_rbacgraph_NamespaceScope_To_v1alpha1_NamespaceScope(&in.NamespaceScope, &out.NamespaceScope, s); err != nil {
+		return err
+	}
+	out.FilterPhantomAPIs = in.FilterPhantomAPIs
+	out.Object = (*ObjectTarget)(unsafe.Pointer(in.Object))
+	out.ResourceMapByNamespace = in.ResourceMapByNamespace
+	if err := Convert_rbacgraph_SnapshotPoint_To_v1alpha1_SnapshotPoint(&in.From, &out.From, s); err != nil {
+		return err
+	}
+	if err := Convert_rbacgraph_SnapshotPoint_To_v1alpha1_SnapshotPoint(&in.To, &out.To, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_rbacgraph_RoleGraphDiffSpec_To_v1alpha1_RoleGraphDiffSpec is an autogenerated conversion function.
+func Convert_rbacgraph_RoleGraphDiffSpec_To_v1alpha1_RoleGraphDiffSpec(in *rbacgraph.RoleGraphDiffSpec, out *RoleGraphDiffSpec, s conversion.Scope) error {
+	return autoConvert_rbacgraph_RoleGraphDiffSpec_To_v1alpha1_RoleGraphDiffSpec(in, out, s)
+}
+
+func autoConvert_v1alpha1_RoleGraphDiffStatus_To_rbacgraph_RoleGraphDiffStatus(in *RoleGraphDiffStatus, out *rbacgraph.RoleGraphDiffStatus, s conversion.Scope) error {
+	if err := Convert_v1alpha1_SnapshotRef_To_rbacgraph_SnapshotRef(&in.From, &out.From, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_SnapshotRef_To_rbacgraph_SnapshotRef(&in.To, &out.To, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_RoleGraphChanges_To_rbacgraph_RoleGraphChanges(&in.Added, &out.Added, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_RoleGraphChanges_To_rbacgraph_RoleGraphChanges(&in.Removed, &out.Removed, s); err != nil {
+		return err
+	}
+	out.Warnings = *(*[]string)(unsafe.Pointer(&in.Warnings))
+	return nil
+}
+
+// Convert_v1alpha1_RoleGraphDiffStatus_To_rbacgraph_RoleGraphDiffStatus is an autogenerated conversion function.
+func Convert_v1alpha1_RoleGraphDiffStatus_To_rbacgraph_RoleGraphDiffStatus(in *RoleGraphDiffStatus, out *rbacgraph.RoleGraphDiffStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_RoleGraphDiffStatus_To_rbacgraph_RoleGraphDiffStatus(in, out, s)
+}
+
+func autoConvert_rbacgraph_RoleGraphDiffStatus_To_v1alpha1_RoleGraphDiffStatus(in *rbacgraph.RoleGraphDiffStatus, out *RoleGraphDiffStatus, s conversion.Scope) error {
+	if err := Convert_rbacgraph_SnapshotRef_To_v1alpha1_SnapshotRef(&in.From, &out.From, s); err != nil {
+		return err
+	}
+	if err := Convert_rbacgraph_SnapshotRef_To_v1alpha1_SnapshotRef(&in.To, &out.To, s); err != nil {
+		return err
+	}
+	if err := Convert_rbacgraph_RoleGraphChanges_To_v1alpha1_RoleGraphChanges(&in.Added, &out.Added, s); err != nil {
+		return err
+	}
+	if err := Convert_rbacgraph_RoleGraphChanges_To_v1alpha1_RoleGraphChanges(&in.Removed, &out.Removed, s); err != nil {
+		return err
+	}
+	out.Warnings = *(*[]string)(unsafe.Pointer(&in.Warnings))
+	return nil
+}
+
+// Convert_rbacgraph_RoleGraphDiffStatus_To_v1alpha1_RoleGraphDiffStatus is an autogenerated conversion function.
+func Convert_rbacgraph_RoleGraphDiffStatus_To_v1alpha1_RoleGraphDiffStatus(in *rbacgraph.RoleGraphDiffStatus, out *RoleGraphDiffStatus, s conversion.Scope) error {
+	return autoConvert_rbacgraph_RoleGraphDiffStatus_To_v1alpha1_RoleGraphDiffStatus(in, out, s)
+}
+
 func autoConvert_v1alpha1_RoleGraphReview_To_rbacgraph_RoleGraphReview(in *RoleGraphReview, out *rbacgraph.RoleGraphReview, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1alpha1_RoleGraphReviewSpec_To_rbacgraph_RoleGraphReviewSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -401,6 +1206,17 @@ func autoConvert_v1alpha1_RoleGraphReviewSpec_To_rbacgraph_RoleGraphReviewSpec(i
 	out.MaxPodsPerSubject = in.MaxPodsPerSubject
 	out.MaxWorkloadsPerPod = in.MaxWorkloadsPerPod
 	out.FilterPhantomAPIs = in.FilterPhantomAPIs
+	out.Object = (*rbacgraph.ObjectTarget)(unsafe.Pointer(in.Object))
+	out.ResourceMapByNamespace = in.ResourceMapByNamespace
+	out.IncludeEscalationPaths = in.IncludeEscalationPaths
+	out.MinRiskScore = in.MinRiskScore
+	out.SortByRiskScore = in.SortByRiskScore
+	out.RoleLabelSelector = (*v1.LabelSelector)(unsafe.Pointer(in.RoleLabelSelector))
+	out.RoleNamePatterns = *(*[]string)(unsafe.Pointer(&in.RoleNamePatterns))
+	out.BindingLabelSelector = (*v1.LabelSelector)(unsafe.Pointer(in.BindingLabelSelector))
+	out.BindingNamePatterns = *(*[]string)(unsafe.Pointer(&in.BindingNamePatterns))
+	out.AsOf = (*v1.Time)(unsafe.Pointer(in.AsOf))
+	out.IncludePolicyRules = rbacgraph.PolicyRulesMode(in.IncludePolicyRules)
 	return nil
 }
 
@@ -425,6 +1241,17 @@ func autoConvert_rbacgraph_RoleGraphReviewSpec_To_v1alpha1_RoleGraphReviewSpec(i
 	out.MaxPodsPerSubject = in.MaxPodsPerSubject
 	out.MaxWorkloadsPerPod = in.MaxWorkloadsPerPod
 	out.FilterPhantomAPIs = in.FilterPhantomAPIs
+	out.Object = (*ObjectTarget)(unsafe.Pointer(in.Object))
+	out.ResourceMapByNamespace = in.ResourceMapByNamespace
+	out.IncludeEscalationPaths = in.IncludeEscalationPaths
+	out.MinRiskScore = in.MinRiskScore
+	out.SortByRiskScore = in.SortByRiskScore
+	out.RoleLabelSelector = (*v1.LabelSelector)(unsafe.Pointer(in.RoleLabelSelector))
+	out.RoleNamePatterns = *(*[]string)(unsafe.Pointer(&in.RoleNamePatterns))
+	out.BindingLabelSelector = (*v1.LabelSelector)(unsafe.Pointer(in.BindingLabelSelector))
+	out.BindingNamePatterns = *(*[]string)(unsafe.Pointer(&in.BindingNamePatterns))
+	out.AsOf = (*v1.Time)(unsafe.Pointer(in.AsOf))
+	out.IncludePolicyRules = PolicyRulesMode(in.IncludePolicyRules)
 	return nil
 }
 
@@ -445,6 +1272,8 @@ func autoConvert_v1alpha1_RoleGraphReviewStatus_To_rbacgraph_RoleGraphReviewStat
 		return err
 	}
 	out.ResourceMap = *(*[]rbacgraph.ResourceMapRow)(unsafe.Pointer(&in.ResourceMap))
+	out.SnapshotGeneration = in.SnapshotGeneration
+	out.SnapshotBuiltAt = (*v1.Time)(unsafe.Pointer(in.SnapshotBuiltAt))
 	return nil
 }
 
@@ -465,6 +1294,8 @@ func autoConvert_rbacgraph_RoleGraphReviewStatus_To_v1alpha1_RoleGraphReviewStat
 		return err
 	}
 	out.ResourceMap = *(*[]ResourceMapRow)(unsafe.Pointer(&in.ResourceMap))
+	out.SnapshotGeneration = in.SnapshotGeneration
+	out.SnapshotBuiltAt = (*v1.Time)(unsafe.Pointer(in.SnapshotBuiltAt))
 	return nil
 }
 
@@ -521,6 +1352,7 @@ func autoConvert_v1alpha1_Selector_To_rbacgraph_Selector(in *Selector, out *rbac
 	out.Verbs = *(*[]string)(unsafe.Pointer(&in.Verbs))
 	out.ResourceNames = *(*[]string)(unsafe.Pointer(&in.ResourceNames))
 	out.NonResourceURLs = *(*[]string)(unsafe.Pointer(&in.NonResourceURLs))
+	out.Subjects = (*rbacgraph.SubjectSelector)(unsafe.Pointer(in.Subjects))
 	return nil
 }
 
@@ -535,6 +1367,7 @@ func autoConvert_rbacgraph_Selector_To_v1alpha1_Selector(in *rbacgraph.Selector,
 	out.Verbs = *(*[]string)(unsafe.Pointer(&in.Verbs))
 	out.ResourceNames = *(*[]string)(unsafe.Pointer(&in.ResourceNames))
 	out.NonResourceURLs = *(*[]string)(unsafe.Pointer(&in.NonResourceURLs))
+	out.Subjects = (*SubjectSelector)(unsafe.Pointer(in.Subjects))
 	return nil
 }
 
@@ -542,3 +1375,289 @@ func autoConvert_rbacgraph_Selector_To_v1alpha1_Selector(in *rbacgraph.Selector,
 func Convert_rbacgraph_Selector_To_v1alpha1_Selector(in *rbacgraph.Selector, out *Selector, s conversion.Scope) error {
 	return autoConvert_rbacgraph_Selector_To_v1alpha1_Selector(in, out, s)
 }
+
+func autoConvert_v1alpha1_ServiceAccountInfo_To_rbacgraph_ServiceAccountInfo(in *ServiceAccountInfo, out *rbacgraph.ServiceAccountInfo, s conversion.Scope) error {
+	out.Exists = in.Exists
+	out.AutomountServiceAccountToken = (*bool)(unsafe.Pointer(in.AutomountServiceAccountToken))
+	out.ImagePullSecrets = *(*[]string)(unsafe.Pointer(&in.ImagePullSecrets))
+	out.CloudIdentities = *(*[]rbacgraph.CloudIdentity)(unsafe.Pointer(&in.CloudIdentities))
+	out.PodCount = in.PodCount
+	return nil
+}
+
+// Convert_v1alpha1_ServiceAccountInfo_To_rbacgraph_ServiceAccountInfo is an autogenerated conversion function.
+func Convert_v1alpha1_ServiceAccountInfo_To_rbacgraph_ServiceAccountInfo(in *ServiceAccountInfo, out *rbacgraph.ServiceAccountInfo, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ServiceAccountInfo_To_rbacgraph_ServiceAccountInfo(in, out, s)
+}
+
+func autoConvert_rbacgraph_ServiceAccountInfo_To_v1alpha1_ServiceAccountInfo(in *rbacgraph.ServiceAccountInfo, out *ServiceAccountInfo, s conversion.Scope) error {
+	out.Exists = in.Exists
+	out.AutomountServiceAccountToken = (*bool)(unsafe.Pointer(in.AutomountServiceAccountToken))
+	out.ImagePullSecrets = *(*[]string)(unsafe.Pointer(&in.ImagePullSecrets))
+	out.CloudIdentities = *(*[]CloudIdentity)(unsafe.Pointer(&in.CloudIdentities))
+	out.PodCount = in.PodCount
+	return nil
+}
+
+// Convert_rbacgraph_ServiceAccountInfo_To_v1alpha1_ServiceAccountInfo is an autogenerated conversion function.
+func Convert_rbacgraph_ServiceAccountInfo_To_v1alpha1_ServiceAccountInfo(in *rbacgraph.ServiceAccountInfo, out *ServiceAccountInfo, s conversion.Scope) error {
+	return autoConvert_rbacgraph_ServiceAccountInfo_To_v1alpha1_ServiceAccountInfo(in, out, s)
+}
+
+func autoConvert_v1alpha1_SnapshotPoint_To_rbacgraph_SnapshotPoint(in *SnapshotPoint, out *rbacgraph.SnapshotPoint, s conversion.Scope) error {
+	out.Generation = in.Generation
+	out.Time = (*v1.Time)(unsafe.Pointer(in.Time))
+	return nil
+}
+
+// Convert_v1alpha1_SnapshotPoint_To_rbacgraph_SnapshotPoint is an autogenerated conversion function.
+func Convert_v1alpha1_SnapshotPoint_To_rbacgraph_SnapshotPoint(in *SnapshotPoint, out *rbacgraph.SnapshotPoint, s conversion.Scope) error {
+	return autoConvert_v1alpha1_SnapshotPoint_To_rbacgraph_SnapshotPoint(in, out, s)
+}
+
+func autoConvert_rbacgraph_SnapshotPoint_To_v1alpha1_SnapshotPoint(in *rbacgraph.SnapshotPoint, out *SnapshotPoint, s conversion.Scope) error {
+	out.Generation = in.Generation
+	out.Time = (*v1.Time)(unsafe.Pointer(in.Time))
+	return nil
+}
+
+// Convert_rbacgraph_SnapshotPoint_To_v1alpha1_SnapshotPoint is an autogenerated conversion function.
+func Convert_rbacgraph_SnapshotPoint_To_v1alpha1_SnapshotPoint(in *rbacgraph.SnapshotPoint, out *SnapshotPoint, s conversion.Scope) error {
+	return autoConvert_rbacgraph_SnapshotPoint_To_v1alpha1_SnapshotPoint(in, out, s)
+}
+
+func autoConvert_v1alpha1_SnapshotRef_To_rbacgraph_SnapshotRef(in *SnapshotRef, out *rbacgraph.SnapshotRef, s conversion.Scope) error {
+	out.Generation = in.Generation
+	out.BuiltAt = (*v1.Time)(unsafe.Pointer(in.BuiltAt))
+	return nil
+}
+
+// Convert_v1alpha1_SnapshotRef_To_rbacgraph_SnapshotRef is an autogenerated conversion function.
+func Convert_v1alpha1_SnapshotRef_To_rbacgraph_SnapshotRef(in *SnapshotRef, out *rbacgraph.SnapshotRef, s conversion.Scope) error {
+	return autoConvert_v1alpha1_SnapshotRef_To_rbacgraph_SnapshotRef(in, out, s)
+}
+
+func autoConvert_rbacgraph_SnapshotRef_To_v1alpha1_SnapshotRef(in *rbacgraph.SnapshotRef, out *SnapshotRef, s conversion.Scope) error {
+	out.Generation = in.Generation
+	out.BuiltAt = (*v1.Time)(unsafe.Pointer(in.BuiltAt))
+	return nil
+}
+
+// Convert_rbacgraph_SnapshotRef_To_v1alpha1_SnapshotRef is an autogenerated conversion function.
+func Convert_rbacgraph_SnapshotRef_To_v1alpha1_SnapshotRef(in *rbacgraph.SnapshotRef, out *SnapshotRef, s conversion.Scope) error {
+	return autoConvert_rbacgraph_SnapshotRef_To_v1alpha1_SnapshotRef(in, out, s)
+}
+
+func autoConvert_v1alpha1_SubjectPermission_To_rbacgraph_SubjectPermission(in *SubjectPermission, out *rbacgraph.SubjectPermission, s conversion.Scope) error {
+	out.APIGroup = in.APIGroup
+	out.Resource = in.Resource
+	out.Verb = in.Verb
+	out.ResourceNames = *(*[]string)(unsafe.Pointer(&in.ResourceNames))
+	out.NonResourceURLs = *(*[]string)(unsafe.Pointer(&in.NonResourceURLs))
+	out.ClusterWide = in.ClusterWide
+	out.Namespaces = *(*[]string)(unsafe.Pointer(&in.Namespaces))
+	out.Roles = *(*[]string)(unsafe.Pointer(&in.Roles))
+	out.Bindings = *(*[]string)(unsafe.Pointer(&in.Bindings))
+	return nil
+}
+
+// Convert_v1alpha1_SubjectPermission_To_rbacgraph_SubjectPermission is an autogenerated conversion function.
+func Convert_v1alpha1_SubjectPermission_To_rbacgraph_SubjectPermission(in *SubjectPermission, out *rbacgraph.SubjectPermission, s conversion.Scope) error {
+	return autoConvert_v1alpha1_SubjectPermission_To_rbacgraph_SubjectPermission(in, out, s)
+}
+
+func autoConvert_rbacgraph_SubjectPermission_To_v1alpha1_SubjectPermission(in *rbacgraph.SubjectPermission, out *SubjectPermission, s conversion.Scope) error {
+	out.APIGroup = in.APIGroup
+	out.Resource = in.Resource
+	out.Verb = in.Verb
+	out.ResourceNames = *(*[]string)(unsafe.Pointer(&in.ResourceNames))
+	out.NonResourceURLs = *(*[]string)(unsafe.Pointer(&in.NonResourceURLs))
+	out.ClusterWide = in.ClusterWide
+	out.Namespaces = *(*[]string)(unsafe.Pointer(&in.Namespaces))
+	out.Roles = *(*[]string)(unsafe.Pointer(&in.Roles))
+	out.Bindings = *(*[]string)(unsafe.Pointer(&in.Bindings))
+	return nil
+}
+
+// Convert_rbacgraph_SubjectPermission_To_v1alpha1_SubjectPermission is an autogenerated conversion function.
+func Convert_rbacgraph_SubjectPermission_To_v1alpha1_SubjectPermission(in *rbacgraph.SubjectPermission, out *SubjectPermission, s conversion.Scope) error {
+	return autoConvert_rbacgraph_SubjectPermission_To_v1alpha1_SubjectPermission(in, out, s)
+}
+
+func autoConvert_v1alpha1_SubjectPermissionReview_To_rbacgraph_SubjectPermissionReview(in *SubjectPermissionReview, out *rbacgraph.SubjectPermissionReview, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1alpha1_SubjectPermissionReviewSpec_To_rbacgraph_SubjectPermissionReviewSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_SubjectPermissionReviewStatus_To_rbacgraph_SubjectPermissionReviewStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha1_SubjectPermissionReview_To_rbacgraph_SubjectPermissionReview is an autogenerated conversion function.
+func Convert_v1alpha1_SubjectPermissionReview_To_rbacgraph_SubjectPermissionReview(in *SubjectPermissionReview, out *rbacgraph.SubjectPermissionReview, s conversion.Scope) error {
+	return autoConvert_v1alpha1_SubjectPermissionReview_To_rbacgraph_SubjectPermissionReview(in, out, s)
+}
+
+func autoConvert_rbacgraph_SubjectPermissionReview_To_v1alpha1_SubjectPermissionReview(in *rbacgraph.SubjectPermissionReview, out *SubjectPermissionReview, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_rbacgraph_SubjectPermissionReviewSpec_To_v1alpha1_SubjectPermissionReviewSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_rbacgraph_SubjectPermissionReviewStatus_To_v1alpha1_SubjectPermissionReviewStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_rbacgraph_SubjectPermissionReview_To_v1alpha1_SubjectPermissionReview is an autogenerated conversion function.
+func Convert_rbacgraph_SubjectPermissionReview_To_v1alpha1_SubjectPermissionReview(in *rbacgraph.SubjectPermissionReview, out *SubjectPermissionReview, s conversion.Scope) error {
+	return autoConvert_rbacgraph_SubjectPermissionReview_To_v1alpha1_SubjectPermissionReview(in, out, s)
+}
+
+func autoConvert_v1alpha1_SubjectPermissionReviewSpec_To_rbacgraph_SubjectPermissionReviewSpec(in *SubjectPermissionReviewSpec, out *rbacgraph.SubjectPermissionReviewSpec, s conversion.Scope) error {
+	if err := Convert_v1alpha1_SubjectRef_To_rbacgraph_SubjectRef(&in.Subject, &out.Subject, s); err != nil {
+		return err
+	}
+	out.Groups = *(*[]string)(unsafe.Pointer(&in.Groups))
+	if err := Convert_v1alpha1_NamespaceScope_To_rbacgraph_NamespaceScope(&in.NamespaceScope, &out.NamespaceScope, s); err != nil {
+		return err
+	}
+	out.IncludeRuleMetadata = in.IncludeRuleMetadata
+	return nil
+}
+
+// Convert_v1alpha1_SubjectPermissionReviewSpec_To_rbacgraph_SubjectPermissionReviewSpec is an autogenerated conversion function.
+func Convert_v1alpha1_SubjectPermissionReviewSpec_To_rbacgraph_SubjectPermissionReviewSpec(in *SubjectPermissionReviewSpec, out *rbacgraph.SubjectPermissionReviewSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha1_SubjectPermissionReviewSpec_To_rbacgraph_SubjectPermissionReviewSpec(in, out, s)
+}
+
+func autoConvert_rbacgraph_SubjectPermissionReviewSpec_To_v1alpha1_SubjectPermissionReviewSpec(in *rbacgraph.SubjectPermissionReviewSpec, out *SubjectPermissionReviewSpec, s conversion.Scope) error {
+	if err := Convert_rbacgraph_SubjectRef_To_v1alpha1_SubjectRef(&in.Subject, &out.Subject, s); err != nil {
+		return err
+	}
+	out.Groups = *(*[]string)(unsafe.Pointer(&in.Groups))
+	if err := Convert_rbacgraph_NamespaceScope_To_v1alpha1_NamespaceScope(&in.NamespaceScope, &out.NamespaceScope, s); err != nil {
+		return err
+	}
+	out.IncludeRuleMetadata = in.IncludeRuleMetadata
+	return nil
+}
+
+// Convert_rbacgraph_SubjectPermissionReviewSpec_To_v1alpha1_SubjectPermissionReviewSpec is an autogenerated conversion function.
+func Convert_rbacgraph_SubjectPermissionReviewSpec_To_v1alpha1_SubjectPermissionReviewSpec(in *rbacgraph.SubjectPermissionReviewSpec, out *SubjectPermissionReviewSpec, s conversion.Scope) error {
+	return autoConvert_rbacgraph_SubjectPermissionReviewSpec_To_v1alpha1_SubjectPermissionReviewSpec(in, out, s)
+}
+
+func autoConvert_v1alpha1_SubjectPermissionReviewStatus_To_rbacgraph_SubjectPermissionReviewStatus(in *SubjectPermissionReviewStatus, out *rbacgraph.SubjectPermissionReviewStatus, s conversion.Scope) error {
+	out.MatchedRoles = in.MatchedRoles
+	out.MatchedBindings = in.MatchedBindings
+	out.EffectiveGroups = *(*[]string)(unsafe.Pointer(&in.EffectiveGroups))
+	out.Warnings = *(*[]string)(unsafe.Pointer(&in.Warnings))
+	out.Permissions = *(*[]rbacgraph.SubjectPermission)(unsafe.Pointer(&in.Permissions))
+	if err := Convert_v1alpha1_Graph_To_rbacgraph_Graph(&in.Graph, &out.Graph, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha1_SubjectPermissionReviewStatus_To_rbacgraph_SubjectPermissionReviewStatus is an autogenerated conversion function.
+func Convert_v1alpha1_SubjectPermissionReviewStatus_To_rbacgraph_SubjectPermissionReviewStatus(in *SubjectPermissionReviewStatus, out *rbacgraph.SubjectPermissionReviewStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_SubjectPermissionReviewStatus_To_rbacgraph_SubjectPermissionReviewStatus(in, out, s)
+}
+
+func autoConvert_rbacgraph_SubjectPermissionReviewStatus_To_v1alpha1_SubjectPermissionReviewStatus(in *rbacgraph.SubjectPermissionReviewStatus, out *SubjectPermissionReviewStatus, s conversion.Scope) error {
+	out.MatchedRoles = in.MatchedRoles
+	out.MatchedBindings = in.MatchedBindings
+	out.EffectiveGroups = *(*[]string)(unsafe.Pointer(&in.EffectiveGroups))
+	out.Warnings = *(*[]string)(unsafe.Pointer(&in.Warnings))
+	out.Permissions = *(*[]SubjectPermission)(unsafe.Pointer(&in.Permissions))
+	if err := Convert_rbacgraph_Graph_To_v1alpha1_Graph(&in.Graph, &out.Graph, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_rbacgraph_SubjectPermissionReviewStatus_To_v1alpha1_SubjectPermissionReviewStatus is an autogenerated conversion function.
+func Convert_rbacgraph_SubjectPermissionReviewStatus_To_v1alpha1_SubjectPermissionReviewStatus(in *rbacgraph.SubjectPermissionReviewStatus, out *SubjectPermissionReviewStatus, s conversion.Scope) error {
+	return autoConvert_rbacgraph_SubjectPermissionReviewStatus_To_v1alpha1_SubjectPermissionReviewStatus(in, out, s)
+}
+
+func autoConvert_v1alpha1_SubjectRef_To_rbacgraph_SubjectRef(in *SubjectRef, out *rbacgraph.SubjectRef, s conversion.Scope) error {
+	out.Kind = in.Kind
+	out.Name = in.Name
+	out.Namespace = in.Namespace
+	return nil
+}
+
+// Convert_v1alpha1_SubjectRef_To_rbacgraph_SubjectRef is an autogenerated conversion function.
+func Convert_v1alpha1_SubjectRef_To_rbacgraph_SubjectRef(in *SubjectRef, out *rbacgraph.SubjectRef, s conversion.Scope) error {
+	return autoConvert_v1alpha1_SubjectRef_To_rbacgraph_SubjectRef(in, out, s)
+}
+
+func autoConvert_rbacgraph_SubjectRef_To_v1alpha1_SubjectRef(in *rbacgraph.SubjectRef, out *SubjectRef, s conversion.Scope) error {
+	out.Kind = in.Kind
+	out.Name = in.Name
+	out.Namespace = in.Namespace
+	return nil
+}
+
+// Convert_rbacgraph_SubjectRef_To_v1alpha1_SubjectRef is an autogenerated conversion function.
+func Convert_rbacgraph_SubjectRef_To_v1alpha1_SubjectRef(in *rbacgraph.SubjectRef, out *SubjectRef, s conversion.Scope) error {
+	return autoConvert_rbacgraph_SubjectRef_To_v1alpha1_SubjectRef(in, out, s)
+}
+
+func autoConvert_v1alpha1_SubjectSelector_To_rbacgraph_SubjectSelector(in *SubjectSelector, out *rbacgraph.SubjectSelector, s conversion.Scope) error {
+	out.Kinds = *(*[]string)(unsafe.Pointer(&in.Kinds))
+	out.Names = *(*[]string)(unsafe.Pointer(&in.Names))
+	out.NamePatterns = *(*[]string)(unsafe.Pointer(&in.NamePatterns))
+	out.NameRegex = in.NameRegex
+	out.Namespaces = *(*[]string)(unsafe.Pointer(&in.Namespaces))
+	return nil
+}
+
+// Convert_v1alpha1_SubjectSelector_To_rbacgraph_SubjectSelector is an autogenerated conversion function.
+func Convert_v1alpha1_SubjectSelector_To_rbacgraph_SubjectSelector(in *SubjectSelector, out *rbacgraph.SubjectSelector, s conversion.Scope) error {
+	return autoConvert_v1alpha1_SubjectSelector_To_rbacgraph_SubjectSelector(in, out, s)
+}
+
+func autoConvert_rbacgraph_SubjectSelector_To_v1alpha1_SubjectSelector(in *rbacgraph.SubjectSelector, out *SubjectSelector, s conversion.Scope) error {
+	out.Kinds = *(*[]string)(unsafe.Pointer(&in.Kinds))
+	out.Names = *(*[]string)(unsafe.Pointer(&in.Names))
+	out.NamePatterns = *(*[]string)(unsafe.Pointer(&in.NamePatterns))
+	out.NameRegex = in.NameRegex
+	out.Namespaces = *(*[]string)(unsafe.Pointer(&in.Namespaces))
+	return nil
+}
+
+// Convert_rbacgraph_SubjectSelector_To_v1alpha1_SubjectSelector is an autogenerated conversion function.
+func Convert_rbacgraph_SubjectSelector_To_v1alpha1_SubjectSelector(in *rbacgraph.SubjectSelector, out *SubjectSelector, s conversion.Scope) error {
+	return autoConvert_rbacgraph_SubjectSelector_To_v1alpha1_SubjectSelector(in, out, s)
+}
+
+func autoConvert_v1alpha1_TokenExposure_To_rbacgraph_TokenExposure(in *TokenExposure, out *rbacgraph.TokenExposure, s conversion.Scope) error {
+	out.Exposed = in.Exposed
+	out.Automount = in.Automount
+	out.AutomountSource = rbacgraph.TokenAutomountSource(in.AutomountSource)
+	out.ProjectedTokens = *(*[]rbacgraph.ProjectedToken)(unsafe.Pointer(&in.ProjectedTokens))
+	return nil
+}
+
+// Convert_v1alpha1_TokenExposure_To_rbacgraph_TokenExposure is an autogenerated conversion function.
+func Convert_v1alpha1_TokenExposure_To_rbacgraph_TokenExposure(in *TokenExposure, out *rbacgraph.TokenExposure, s conversion.Scope) error {
+	return autoConvert_v1alpha1_TokenExposure_To_rbacgraph_TokenExposure(in, out, s)
+}
+
+func autoConvert_rbacgraph_TokenExposure_To_v1alpha1_TokenExposure(in *rbacgraph.TokenExposure, out *TokenExposure, s conversion.Scope) error {
+	out.Exposed = in.Exposed
+	out.Automount = in.Automount
+	out.AutomountSource = TokenAutomountSource(in.AutomountSource)
+	out.ProjectedTokens = *(*[]ProjectedToken)(unsafe.Pointer(&in.ProjectedTokens))
+	return nil
+}
+
+// Convert_rbacgraph_TokenExposure_To_v1alpha1_TokenExposure is an autogenerated conversion function.
+func Convert_rbacgraph_TokenExposure_To_v1alpha1_TokenExposure(in *rbacgraph.TokenExposure, out *TokenExposure, s conversion.Scope) error {
+	return autoConvert_rbacgraph_TokenExposure_To_v1alpha1_TokenExposure(in, out, s)
+}
@@ -22,9 +22,88 @@ limitations under the License.
 package v1alpha1
 
 import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AggregationMatch) DeepCopyInto(out *AggregationMatch) {
+	*out = *in
+	if in.MatchedLabels != nil {
+		in, out := &in.MatchedLabels, &out.MatchedLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AggregationMatch.
+func (in *AggregationMatch) DeepCopy() *AggregationMatch {
+	if in == nil {
+		return nil
+	}
+	out := new(AggregationMatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BindingSubject) DeepCopyInto(out *BindingSubject) {
+	*out = *in
+	out.Binding = in.Binding
+	out.Subject = in.Subject
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BindingSubject.
+func (in *BindingSubject) DeepCopy() *BindingSubject {
+	if in == nil {
+		return nil
+	}
+	out := new(BindingSubject)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudIdentity) DeepCopyInto(out *CloudIdentity) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudIdentity.
+func (in *CloudIdentity) DeepCopy() *CloudIdentity {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudIdentity)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EffectiveScope) DeepCopyInto(out *EffectiveScope) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EffectiveScope.
+func (in *EffectiveScope) DeepCopy() *EffectiveScope {
+	if in == nil {
+		return nil
+	}
+	out := new(EffectiveScope)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Graph) DeepCopyInto(out *Graph) {
 	*out = *in
@@ -65,6 +144,18 @@ func (in *GraphEdge) DeepCopyInto(out *GraphEdge) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Scope != nil {
+		in, out := &in.Scope, &out.Scope
+		*out = new(EffectiveScope)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AggregationMatches != nil {
+		in, out := &in.AggregationMatches, &out.AggregationMatches
+		*out = make([]AggregationMatch, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -107,6 +198,28 @@ func (in *GraphNode) DeepCopyInto(out *GraphNode) {
 			(*out)[key] = val
 		}
 	}
+	if in.RiskReasons != nil {
+		in, out := &in.RiskReasons, &out.RiskReasons
+		*out = make([]RiskReason, len(*in))
+		copy(*out, *in)
+	}
+	if in.PolicyRules != nil {
+		in, out := &in.PolicyRules, &out.PolicyRules
+		*out = make([]PolicyRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ServiceAccount != nil {
+		in, out := &in.ServiceAccount, &out.ServiceAccount
+		*out = new(ServiceAccountInfo)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TokenExposure != nil {
+		in, out := &in.TokenExposure, &out.TokenExposure
+		*out = new(TokenExposure)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -120,6 +233,27 @@ func (in *GraphNode) DeepCopy() *GraphNode {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HygieneFinding) DeepCopyInto(out *HygieneFinding) {
+	*out = *in
+	if in.Subject != nil {
+		in, out := &in.Subject, &out.Subject
+		*out = new(SubjectRef)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HygieneFinding.
+func (in *HygieneFinding) DeepCopy() *HygieneFinding {
+	if in == nil {
+		return nil
+	}
+	out := new(HygieneFinding)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NamespaceScope) DeepCopyInto(out *NamespaceScope) {
 	*out = *in
@@ -128,6 +262,21 @@ func (in *NamespaceScope) DeepCopyInto(out *NamespaceScope) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Patterns != nil {
+		in, out := &in.Patterns, &out.Patterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Exclude != nil {
+		in, out := &in.Exclude, &out.Exclude
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -201,23 +350,96 @@ func (in *NonResourceURLList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ResourceMapRow) DeepCopyInto(out *ResourceMapRow) {
+func (in *ObjectRef) DeepCopyInto(out *ObjectRef) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceMapRow.
-func (in *ResourceMapRow) DeepCopy() *ResourceMapRow {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectRef.
+func (in *ObjectRef) DeepCopy() *ObjectRef {
 	if in == nil {
 		return nil
 	}
-	out := new(ResourceMapRow)
+	out := new(ObjectRef)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RoleGraphReview) DeepCopyInto(out *RoleGraphReview) {
+func (in *ObjectTarget) DeepCopyInto(out *ObjectTarget) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectTarget.
+func (in *ObjectTarget) DeepCopy() *ObjectTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyRule) DeepCopyInto(out *PolicyRule) {
+	*out = *in
+	if in.Verbs != nil {
+		in, out := &in.Verbs, &out.Verbs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.APIGroups != nil {
+		in, out := &in.APIGroups, &out.APIGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ResourceNames != nil {
+		in, out := &in.ResourceNames, &out.ResourceNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NonResourceURLs != nil {
+		in, out := &in.NonResourceURLs, &out.NonResourceURLs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyRule.
+func (in *PolicyRule) DeepCopy() *PolicyRule {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectedToken) DeepCopyInto(out *ProjectedToken) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectedToken.
+func (in *ProjectedToken) DeepCopy() *ProjectedToken {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectedToken)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RBACHygieneReport) DeepCopyInto(out *RBACHygieneReport) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -226,18 +448,18 @@ func (in *RoleGraphReview) DeepCopyInto(out *RoleGraphReview) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleGraphReview.
-func (in *RoleGraphReview) DeepCopy() *RoleGraphReview {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RBACHygieneReport.
+func (in *RBACHygieneReport) DeepCopy() *RBACHygieneReport {
 	if in == nil {
 		return nil
 	}
-	out := new(RoleGraphReview)
+	out := new(RBACHygieneReport)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *RoleGraphReview) DeepCopyObject() runtime.Object {
+func (in *RBACHygieneReport) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -245,125 +467,671 @@ func (in *RoleGraphReview) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RoleGraphReviewSpec) DeepCopyInto(out *RoleGraphReviewSpec) {
+func (in *RBACHygieneReportSpec) DeepCopyInto(out *RBACHygieneReportSpec) {
 	*out = *in
-	in.Selector.DeepCopyInto(&out.Selector)
 	in.NamespaceScope.DeepCopyInto(&out.NamespaceScope)
+	if in.Checks != nil {
+		in, out := &in.Checks, &out.Checks
+		*out = make([]HygieneCheck, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleGraphReviewSpec.
-func (in *RoleGraphReviewSpec) DeepCopy() *RoleGraphReviewSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RBACHygieneReportSpec.
+func (in *RBACHygieneReportSpec) DeepCopy() *RBACHygieneReportSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(RoleGraphReviewSpec)
+	out := new(RBACHygieneReportSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RoleGraphReviewStatus) DeepCopyInto(out *RoleGraphReviewStatus) {
+func (in *RBACHygieneReportStatus) DeepCopyInto(out *RBACHygieneReportStatus) {
 	*out = *in
+	if in.Findings != nil {
+		in, out := &in.Findings, &out.Findings
+		*out = make([]HygieneFinding, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Warnings != nil {
 		in, out := &in.Warnings, &out.Warnings
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.KnownGaps != nil {
-		in, out := &in.KnownGaps, &out.KnownGaps
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	in.Graph.DeepCopyInto(&out.Graph)
-	if in.ResourceMap != nil {
-		in, out := &in.ResourceMap, &out.ResourceMap
-		*out = make([]ResourceMapRow, len(*in))
-		copy(*out, *in)
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleGraphReviewStatus.
-func (in *RoleGraphReviewStatus) DeepCopy() *RoleGraphReviewStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RBACHygieneReportStatus.
+func (in *RBACHygieneReportStatus) DeepCopy() *RBACHygieneReportStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(RoleGraphReviewStatus)
+	out := new(RBACHygieneReportStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RuleRef) DeepCopyInto(out *RuleRef) {
+func (in *ResourceMapRow) DeepCopyInto(out *ResourceMapRow) {
 	*out = *in
-	if in.ResourceNames != nil {
-		in, out := &in.ResourceNames, &out.ResourceNames
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.NonResourceURLs != nil {
-		in, out := &in.NonResourceURLs, &out.NonResourceURLs
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.Scope != nil {
+		in, out := &in.Scope, &out.Scope
+		*out = new(EffectiveScope)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.ExpandedRefs != nil {
-		in, out := &in.ExpandedRefs, &out.ExpandedRefs
-		*out = make([]RuleRef, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceMapRow.
+func (in *ResourceMapRow) DeepCopy() *ResourceMapRow {
+	if in == nil {
+		return nil
 	}
+	out := new(ResourceMapRow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RiskReason) DeepCopyInto(out *RiskReason) {
+	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuleRef.
-func (in *RuleRef) DeepCopy() *RuleRef {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RiskReason.
+func (in *RiskReason) DeepCopy() *RiskReason {
 	if in == nil {
 		return nil
 	}
-	out := new(RuleRef)
+	out := new(RiskReason)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Selector) DeepCopyInto(out *Selector) {
+func (in *RoleGraphChanges) DeepCopyInto(out *RoleGraphChanges) {
 	*out = *in
-	if in.APIGroups != nil {
-		in, out := &in.APIGroups, &out.APIGroups
-		*out = make([]string, len(*in))
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]ObjectRef, len(*in))
 		copy(*out, *in)
 	}
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = make([]string, len(*in))
+	if in.Bindings != nil {
+		in, out := &in.Bindings, &out.Bindings
+		*out = make([]ObjectRef, len(*in))
 		copy(*out, *in)
 	}
-	if in.Verbs != nil {
-		in, out := &in.Verbs, &out.Verbs
-		*out = make([]string, len(*in))
+	if in.Subjects != nil {
+		in, out := &in.Subjects, &out.Subjects
+		*out = make([]SubjectRef, len(*in))
 		copy(*out, *in)
 	}
-	if in.ResourceNames != nil {
-		in, out := &in.ResourceNames, &out.ResourceNames
-		*out = make([]string, len(*in))
+	if in.BindingSubjects != nil {
+		in, out := &in.BindingSubjects, &out.BindingSubjects
+		*out = make([]BindingSubject, len(*in))
 		copy(*out, *in)
 	}
-	if in.NonResourceURLs != nil {
-		in, out := &in.NonResourceURLs, &out.NonResourceURLs
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.Permissions != nil {
+		in, out := &in.Permissions, &out.Permissions
+		*out = make([]ResourceMapRow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Selector.
-func (in *Selector) DeepCopy() *Selector {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleGraphChanges.
+func (in *RoleGraphChanges) DeepCopy() *RoleGraphChanges {
 	if in == nil {
 		return nil
 	}
-	out := new(Selector)
+	out := new(RoleGraphChanges)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleGraphDiff) DeepCopyInto(out *RoleGraphDiff) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleGraphDiff.
+func (in *RoleGraphDiff) DeepCopy() *RoleGraphDiff {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleGraphDiff)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RoleGraphDiff) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleGraphDiffSpec) DeepCopyInto(out *RoleGraphDiffSpec) {
+	*out = *in
+	in.Selector.DeepCopyInto(&out.Selector)
+	in.NamespaceScope.DeepCopyInto(&out.NamespaceScope)
+	if in.Object != nil {
+		in, out := &in.Object, &out.Object
+		*out = new(ObjectTarget)
+		**out = **in
+	}
+	in.From.DeepCopyInto(&out.From)
+	in.To.DeepCopyInto(&out.To)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleGraphDiffSpec.
+func (in *RoleGraphDiffSpec) DeepCopy() *RoleGraphDiffSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleGraphDiffSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleGraphDiffStatus) DeepCopyInto(out *RoleGraphDiffStatus) {
+	*out = *in
+	in.From.DeepCopyInto(&out.From)
+	in.To.DeepCopyInto(&out.To)
+	in.Added.DeepCopyInto(&out.Added)
+	in.Removed.DeepCopyInto(&out.Removed)
+	if in.Warnings != nil {
+		in, out := &in.Warnings, &out.Warnings
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleGraphDiffStatus.
+func (in *RoleGraphDiffStatus) DeepCopy() *RoleGraphDiffStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleGraphDiffStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleGraphReview) DeepCopyInto(out *RoleGraphReview) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleGraphReview.
+func (in *RoleGraphReview) DeepCopy() *RoleGraphReview {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleGraphReview)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RoleGraphReview) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleGraphReviewSpec) DeepCopyInto(out *RoleGraphReviewSpec) {
+	*out = *in
+	in.Selector.DeepCopyInto(&out.Selector)
+	in.NamespaceScope.DeepCopyInto(&out.NamespaceScope)
+	if in.Object != nil {
+		in, out := &in.Object, &out.Object
+		*out = new(ObjectTarget)
+		**out = **in
+	}
+	if in.RoleLabelSelector != nil {
+		in, out := &in.RoleLabelSelector, &out.RoleLabelSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RoleNamePatterns != nil {
+		in, out := &in.RoleNamePatterns, &out.RoleNamePatterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.BindingLabelSelector != nil {
+		in, out := &in.BindingLabelSelector, &out.BindingLabelSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BindingNamePatterns != nil {
+		in, out := &in.BindingNamePatterns, &out.BindingNamePatterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AsOf != nil {
+		in, out := &in.AsOf, &out.AsOf
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleGraphReviewSpec.
+func (in *RoleGraphReviewSpec) DeepCopy() *RoleGraphReviewSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleGraphReviewSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleGraphReviewStatus) DeepCopyInto(out *RoleGraphReviewStatus) {
+	*out = *in
+	if in.Warnings != nil {
+		in, out := &in.Warnings, &out.Warnings
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.KnownGaps != nil {
+		in, out := &in.KnownGaps, &out.KnownGaps
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.Graph.DeepCopyInto(&out.Graph)
+	if in.ResourceMap != nil {
+		in, out := &in.ResourceMap, &out.ResourceMap
+		*out = make([]ResourceMapRow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SnapshotBuiltAt != nil {
+		in, out := &in.SnapshotBuiltAt, &out.SnapshotBuiltAt
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleGraphReviewStatus.
+func (in *RoleGraphReviewStatus) DeepCopy() *RoleGraphReviewStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleGraphReviewStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuleRef) DeepCopyInto(out *RuleRef) {
+	*out = *in
+	if in.ResourceNames != nil {
+		in, out := &in.ResourceNames, &out.ResourceNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NonResourceURLs != nil {
+		in, out := &in.NonResourceURLs, &out.NonResourceURLs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExpandedRefs != nil {
+		in, out := &in.ExpandedRefs, &out.ExpandedRefs
+		*out = make([]RuleRef, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuleRef.
+func (in *RuleRef) DeepCopy() *RuleRef {
+	if in == nil {
+		return nil
+	}
+	out := new(RuleRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Selector) DeepCopyInto(out *Selector) {
+	*out = *in
+	if in.APIGroups != nil {
+		in, out := &in.APIGroups, &out.APIGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Verbs != nil {
+		in, out := &in.Verbs, &out.Verbs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ResourceNames != nil {
+		in, out := &in.ResourceNames, &out.ResourceNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NonResourceURLs != nil {
+		in, out := &in.NonResourceURLs, &out.NonResourceURLs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Subjects != nil {
+		in, out := &in.Subjects, &out.Subjects
+		*out = new(SubjectSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Selector.
+func (in *Selector) DeepCopy() *Selector {
+	if in == nil {
+		return nil
+	}
+	out := new(Selector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceAccountInfo) DeepCopyInto(out *ServiceAccountInfo) {
+	*out = *in
+	if in.AutomountServiceAccountToken != nil {
+		in, out := &in.AutomountServiceAccountToken, &out.AutomountServiceAccountToken
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CloudIdentities != nil {
+		in, out := &in.CloudIdentities, &out.CloudIdentities
+		*out = make([]CloudIdentity, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceAccountInfo.
+func (in *ServiceAccountInfo) DeepCopy() *ServiceAccountInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceAccountInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnapshotPoint) DeepCopyInto(out *SnapshotPoint) {
+	*out = *in
+	if in.Time != nil {
+		in, out := &in.Time, &out.Time
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotPoint.
+func (in *SnapshotPoint) DeepCopy() *SnapshotPoint {
+	if in == nil {
+		return nil
+	}
+	out := new(SnapshotPoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnapshotRef) DeepCopyInto(out *SnapshotRef) {
+	*out = *in
+	if in.BuiltAt != nil {
+		in, out := &in.BuiltAt, &out.BuiltAt
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotRef.
+func (in *SnapshotRef) DeepCopy() *SnapshotRef {
+	if in == nil {
+		return nil
+	}
+	out := new(SnapshotRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubjectPermission) DeepCopyInto(out *SubjectPermission) {
+	*out = *in
+	if in.ResourceNames != nil {
+		in, out := &in.ResourceNames, &out.ResourceNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NonResourceURLs != nil {
+		in, out := &in.NonResourceURLs, &out.NonResourceURLs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Bindings != nil {
+		in, out := &in.Bindings, &out.Bindings
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubjectPermission.
+func (in *SubjectPermission) DeepCopy() *SubjectPermission {
+	if in == nil {
+		return nil
+	}
+	out := new(SubjectPermission)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubjectPermissionReview) DeepCopyInto(out *SubjectPermissionReview) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubjectPermissionReview.
+func (in *SubjectPermissionReview) DeepCopy() *SubjectPermissionReview {
+	if in == nil {
+		return nil
+	}
+	out := new(SubjectPermissionReview)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SubjectPermissionReview) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubjectPermissionReviewSpec) DeepCopyInto(out *SubjectPermissionReviewSpec) {
+	*out = *in
+	out.Subject = in.Subject
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.NamespaceScope.DeepCopyInto(&out.NamespaceScope)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubjectPermissionReviewSpec.
+func (in *SubjectPermissionReviewSpec) DeepCopy() *SubjectPermissionReviewSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SubjectPermissionReviewSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubjectPermissionReviewStatus) DeepCopyInto(out *SubjectPermissionReviewStatus) {
+	*out = *in
+	if in.EffectiveGroups != nil {
+		in, out := &in.EffectiveGroups, &out.EffectiveGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Warnings != nil {
+		in, out := &in.Warnings, &out.Warnings
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Permissions != nil {
+		in, out := &in.Permissions, &out.Permissions
+		*out = make([]SubjectPermission, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.Graph.DeepCopyInto(&out.Graph)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubjectPermissionReviewStatus.
+func (in *SubjectPermissionReviewStatus) DeepCopy() *SubjectPermissionReviewStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SubjectPermissionReviewStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubjectRef) DeepCopyInto(out *SubjectRef) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubjectRef.
+func (in *SubjectRef) DeepCopy() *SubjectRef {
+	if in == nil {
+		return nil
+	}
+	out := new(SubjectRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubjectSelector) DeepCopyInto(out *SubjectSelector) {
+	*out = *in
+	if in.Kinds != nil {
+		in, out := &in.Kinds, &out.Kinds
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Names != nil {
+		in, out := &in.Names, &out.Names
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NamePatterns != nil {
+		in, out := &in.NamePatterns, &out.NamePatterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubjectSelector.
+func (in *SubjectSelector) DeepCopy() *SubjectSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(SubjectSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TokenExposure) DeepCopyInto(out *TokenExposure) {
+	*out = *in
+	if in.ProjectedTokens != nil {
+		in, out := &in.ProjectedTokens, &out.ProjectedTokens
+		*out = make([]ProjectedToken, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TokenExposure.
+func (in *TokenExposure) DeepCopy() *TokenExposure {
+	if in == nil {
+		return nil
+	}
+	out := new(TokenExposure)
 	in.DeepCopyInto(out)
 	return out
 }
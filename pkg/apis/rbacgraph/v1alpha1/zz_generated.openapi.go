@@ -32,72 +32,243 @@ import (
 
 func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenAPIDefinition {
 	return map[string]common.OpenAPIDefinition{
-		Graph{}.OpenAPIModelName():                        schema_pkg_apis_rbacgraph_v1alpha1_Graph(ref),
-		GraphEdge{}.OpenAPIModelName():                    schema_pkg_apis_rbacgraph_v1alpha1_GraphEdge(ref),
-		GraphNode{}.OpenAPIModelName():                    schema_pkg_apis_rbacgraph_v1alpha1_GraphNode(ref),
-		NamespaceScope{}.OpenAPIModelName():               schema_pkg_apis_rbacgraph_v1alpha1_NamespaceScope(ref),
-		NonResourceURLEntry{}.OpenAPIModelName():          schema_pkg_apis_rbacgraph_v1alpha1_NonResourceURLEntry(ref),
-		NonResourceURLList{}.OpenAPIModelName():           schema_pkg_apis_rbacgraph_v1alpha1_NonResourceURLList(ref),
-		ResourceMapRow{}.OpenAPIModelName():               schema_pkg_apis_rbacgraph_v1alpha1_ResourceMapRow(ref),
-		RoleGraphReview{}.OpenAPIModelName():              schema_pkg_apis_rbacgraph_v1alpha1_RoleGraphReview(ref),
-		RoleGraphReviewSpec{}.OpenAPIModelName():          schema_pkg_apis_rbacgraph_v1alpha1_RoleGraphReviewSpec(ref),
-		RoleGraphReviewStatus{}.OpenAPIModelName():        schema_pkg_apis_rbacgraph_v1alpha1_RoleGraphReviewStatus(ref),
-		RuleRef{}.OpenAPIModelName():                      schema_pkg_apis_rbacgraph_v1alpha1_RuleRef(ref),
-		Selector{}.OpenAPIModelName():                     schema_pkg_apis_rbacgraph_v1alpha1_Selector(ref),
-		resource.Quantity{}.OpenAPIModelName():            schema_apimachinery_pkg_api_resource_Quantity(ref),
-		v1.APIGroup{}.OpenAPIModelName():                  schema_pkg_apis_meta_v1_APIGroup(ref),
-		v1.APIGroupList{}.OpenAPIModelName():              schema_pkg_apis_meta_v1_APIGroupList(ref),
-		v1.APIResource{}.OpenAPIModelName():               schema_pkg_apis_meta_v1_APIResource(ref),
-		v1.APIResourceList{}.OpenAPIModelName():           schema_pkg_apis_meta_v1_APIResourceList(ref),
-		v1.APIVersions{}.OpenAPIModelName():               schema_pkg_apis_meta_v1_APIVersions(ref),
-		v1.ApplyOptions{}.OpenAPIModelName():              schema_pkg_apis_meta_v1_ApplyOptions(ref),
-		v1.Condition{}.OpenAPIModelName():                 schema_pkg_apis_meta_v1_Condition(ref),
-		v1.CreateOptions{}.OpenAPIModelName():             schema_pkg_apis_meta_v1_CreateOptions(ref),
-		v1.DeleteOptions{}.OpenAPIModelName():             schema_pkg_apis_meta_v1_DeleteOptions(ref),
-		v1.Duration{}.OpenAPIModelName():                  schema_pkg_apis_meta_v1_Duration(ref),
-		v1.FieldSelectorRequirement{}.OpenAPIModelName():  schema_pkg_apis_meta_v1_FieldSelectorRequirement(ref),
-		v1.FieldsV1{}.OpenAPIModelName():                  schema_pkg_apis_meta_v1_FieldsV1(ref),
-		v1.GetOptions{}.OpenAPIModelName():                schema_pkg_apis_meta_v1_GetOptions(ref),
-		v1.GroupKind{}.OpenAPIModelName():                 schema_pkg_apis_meta_v1_GroupKind(ref),
-		v1.GroupResource{}.OpenAPIModelName():             schema_pkg_apis_meta_v1_GroupResource(ref),
-		v1.GroupVersion{}.OpenAPIModelName():              schema_pkg_apis_meta_v1_GroupVersion(ref),
-		v1.GroupVersionForDiscovery{}.OpenAPIModelName():  schema_pkg_apis_meta_v1_GroupVersionForDiscovery(ref),
-		v1.GroupVersionKind{}.OpenAPIModelName():          schema_pkg_apis_meta_v1_GroupVersionKind(ref),
-		v1.GroupVersionResource{}.OpenAPIModelName():      schema_pkg_apis_meta_v1_GroupVersionResource(ref),
-		v1.InternalEvent{}.OpenAPIModelName():             schema_pkg_apis_meta_v1_InternalEvent(ref),
-		v1.LabelSelector{}.OpenAPIModelName():             schema_pkg_apis_meta_v1_LabelSelector(ref),
-		v1.LabelSelectorRequirement{}.OpenAPIModelName():  schema_pkg_apis_meta_v1_LabelSelectorRequirement(ref),
-		v1.List{}.OpenAPIModelName():                      schema_pkg_apis_meta_v1_List(ref),
-		v1.ListMeta{}.OpenAPIModelName():                  schema_pkg_apis_meta_v1_ListMeta(ref),
-		v1.ListOptions{}.OpenAPIModelName():               schema_pkg_apis_meta_v1_ListOptions(ref),
-		v1.ManagedFieldsEntry{}.OpenAPIModelName():        schema_pkg_apis_meta_v1_ManagedFieldsEntry(ref),
-		v1.MicroTime{}.OpenAPIModelName():                 schema_pkg_apis_meta_v1_MicroTime(ref),
-		v1.ObjectMeta{}.OpenAPIModelName():                schema_pkg_apis_meta_v1_ObjectMeta(ref),
-		v1.OwnerReference{}.OpenAPIModelName():            schema_pkg_apis_meta_v1_OwnerReference(ref),
-		v1.PartialObjectMetadata{}.OpenAPIModelName():     schema_pkg_apis_meta_v1_PartialObjectMetadata(ref),
-		v1.PartialObjectMetadataList{}.OpenAPIModelName(): schema_pkg_apis_meta_v1_PartialObjectMetadataList(ref),
-		v1.Patch{}.OpenAPIModelName():                     schema_pkg_apis_meta_v1_Patch(ref),
-		v1.PatchOptions{}.OpenAPIModelName():              schema_pkg_apis_meta_v1_PatchOptions(ref),
-		v1.Preconditions{}.OpenAPIModelName():             schema_pkg_apis_meta_v1_Preconditions(ref),
-		v1.RootPaths{}.OpenAPIModelName():                 schema_pkg_apis_meta_v1_RootPaths(ref),
-		v1.ServerAddressByClientCIDR{}.OpenAPIModelName(): schema_pkg_apis_meta_v1_ServerAddressByClientCIDR(ref),
-		v1.Status{}.OpenAPIModelName():                    schema_pkg_apis_meta_v1_Status(ref),
-		v1.StatusCause{}.OpenAPIModelName():               schema_pkg_apis_meta_v1_StatusCause(ref),
-		v1.StatusDetails{}.OpenAPIModelName():             schema_pkg_apis_meta_v1_StatusDetails(ref),
-		v1.Table{}.OpenAPIModelName():                     schema_pkg_apis_meta_v1_Table(ref),
-		v1.TableColumnDefinition{}.OpenAPIModelName():     schema_pkg_apis_meta_v1_TableColumnDefinition(ref),
-		v1.TableOptions{}.OpenAPIModelName():              schema_pkg_apis_meta_v1_TableOptions(ref),
-		v1.TableRow{}.OpenAPIModelName():                  schema_pkg_apis_meta_v1_TableRow(ref),
-		v1.TableRowCondition{}.OpenAPIModelName():         schema_pkg_apis_meta_v1_TableRowCondition(ref),
-		v1.Time{}.OpenAPIModelName():                      schema_pkg_apis_meta_v1_Time(ref),
-		v1.Timestamp{}.OpenAPIModelName():                 schema_pkg_apis_meta_v1_Timestamp(ref),
-		v1.TypeMeta{}.OpenAPIModelName():                  schema_pkg_apis_meta_v1_TypeMeta(ref),
-		v1.UpdateOptions{}.OpenAPIModelName():             schema_pkg_apis_meta_v1_UpdateOptions(ref),
-		v1.WatchEvent{}.OpenAPIModelName():                schema_pkg_apis_meta_v1_WatchEvent(ref),
-		runtime.RawExtension{}.OpenAPIModelName():         schema_k8sio_apimachinery_pkg_runtime_RawExtension(ref),
-		runtime.TypeMeta{}.OpenAPIModelName():             schema_k8sio_apimachinery_pkg_runtime_TypeMeta(ref),
-		runtime.Unknown{}.OpenAPIModelName():              schema_k8sio_apimachinery_pkg_runtime_Unknown(ref),
-		version.Info{}.OpenAPIModelName():                 schema_k8sio_apimachinery_pkg_version_Info(ref),
+		AggregationMatch{}.OpenAPIModelName():              schema_pkg_apis_rbacgraph_v1alpha1_AggregationMatch(ref),
+		BindingSubject{}.OpenAPIModelName():                schema_pkg_apis_rbacgraph_v1alpha1_BindingSubject(ref),
+		CloudIdentity{}.OpenAPIModelName():                 schema_pkg_apis_rbacgraph_v1alpha1_CloudIdentity(ref),
+		EffectiveScope{}.OpenAPIModelName():                schema_pkg_apis_rbacgraph_v1alpha1_EffectiveScope(ref),
+		Graph{}.OpenAPIModelName():                         schema_pkg_apis_rbacgraph_v1alpha1_Graph(ref),
+		GraphEdge{}.OpenAPIModelName():                     schema_pkg_apis_rbacgraph_v1alpha1_GraphEdge(ref),
+		GraphNode{}.OpenAPIModelName():                     schema_pkg_apis_rbacgraph_v1alpha1_GraphNode(ref),
+		HygieneFinding{}.OpenAPIModelName():                schema_pkg_apis_rbacgraph_v1alpha1_HygieneFinding(ref),
+		NamespaceScope{}.OpenAPIModelName():                schema_pkg_apis_rbacgraph_v1alpha1_NamespaceScope(ref),
+		NonResourceURLEntry{}.OpenAPIModelName():           schema_pkg_apis_rbacgraph_v1alpha1_NonResourceURLEntry(ref),
+		NonResourceURLList{}.OpenAPIModelName():            schema_pkg_apis_rbacgraph_v1alpha1_NonResourceURLList(ref),
+		ObjectRef{}.OpenAPIModelName():                     schema_pkg_apis_rbacgraph_v1alpha1_ObjectRef(ref),
+		ObjectTarget{}.OpenAPIModelName():                  schema_pkg_apis_rbacgraph_v1alpha1_ObjectTarget(ref),
+		PolicyRule{}.OpenAPIModelName():                    schema_pkg_apis_rbacgraph_v1alpha1_PolicyRule(ref),
+		ProjectedToken{}.OpenAPIModelName():                schema_pkg_apis_rbacgraph_v1alpha1_ProjectedToken(ref),
+		RBACHygieneReport{}.OpenAPIModelName():             schema_pkg_apis_rbacgraph_v1alpha1_RBACHygieneReport(ref),
+		RBACHygieneReportSpec{}.OpenAPIModelName():         schema_pkg_apis_rbacgraph_v1alpha1_RBACHygieneReportSpec(ref),
+		RBACHygieneReportStatus{}.OpenAPIModelName():       schema_pkg_apis_rbacgraph_v1alpha1_RBACHygieneReportStatus(ref),
+		ResourceMapRow{}.OpenAPIModelName():                schema_pkg_apis_rbacgraph_v1alpha1_ResourceMapRow(ref),
+		RiskReason{}.OpenAPIModelName():                    schema_pkg_apis_rbacgraph_v1alpha1_RiskReason(ref),
+		RoleGraphChanges{}.OpenAPIModelName():              schema_pkg_apis_rbacgraph_v1alpha1_RoleGraphChanges(ref),
+		RoleGraphDiff{}.OpenAPIModelName():                 schema_pkg_apis_rbacgraph_v1alpha1_RoleGraphDiff(ref),
+		RoleGraphDiffSpec{}.OpenAPIModelName():             schema_pkg_apis_rbacgraph_v1alpha1_RoleGraphDiffSpec(ref),
+		RoleGraphDiffStatus{}.OpenAPIModelName():           schema_pkg_apis_rbacgraph_v1alpha1_RoleGraphDiffStatus(ref),
+		RoleGraphReview{}.OpenAPIModelName():               schema_pkg_apis_rbacgraph_v1alpha1_RoleGraphReview(ref),
+		RoleGraphReviewSpec{}.OpenAPIModelName():           schema_pkg_apis_rbacgraph_v1alpha1_RoleGraphReviewSpec(ref),
+		RoleGraphReviewStatus{}.OpenAPIModelName():         schema_pkg_apis_rbacgraph_v1alpha1_RoleGraphReviewStatus(ref),
+		RuleRef{}.OpenAPIModelName():                       schema_pkg_apis_rbacgraph_v1alpha1_RuleRef(ref),
+		Selector{}.OpenAPIModelName():                      schema_pkg_apis_rbacgraph_v1alpha1_Selector(ref),
+		ServiceAccountInfo{}.OpenAPIModelName():            schema_pkg_apis_rbacgraph_v1alpha1_ServiceAccountInfo(ref),
+		SnapshotPoint{}.OpenAPIModelName():                 schema_pkg_apis_rbacgraph_v1alpha1_SnapshotPoint(ref),
+		SnapshotRef{}.OpenAPIModelName():                   schema_pkg_apis_rbacgraph_v1alpha1_SnapshotRef(ref),
+		SubjectPermission{}.OpenAPIModelName():             schema_pkg_apis_rbacgraph_v1alpha1_SubjectPermission(ref),
+		SubjectPermissionReview{}.OpenAPIModelName():       schema_pkg_apis_rbacgraph_v1alpha1_SubjectPermissionReview(ref),
+		SubjectPermissionReviewSpec{}.OpenAPIModelName():   schema_pkg_apis_rbacgraph_v1alpha1_SubjectPermissionReviewSpec(ref),
+		SubjectPermissionReviewStatus{}.OpenAPIModelName(): schema_pkg_apis_rbacgraph_v1alpha1_SubjectPermissionReviewStatus(ref),
+		SubjectRef{}.OpenAPIModelName():                    schema_pkg_apis_rbacgraph_v1alpha1_SubjectRef(ref),
+		SubjectSelector{}.OpenAPIModelName():               schema_pkg_apis_rbacgraph_v1alpha1_SubjectSelector(ref),
+		TokenExposure{}.OpenAPIModelName():                 schema_pkg_apis_rbacgraph_v1alpha1_TokenExposure(ref),
+		resource.Quantity{}.OpenAPIModelName():             schema_apimachinery_pkg_api_resource_Quantity(ref),
+		v1.APIGroup{}.OpenAPIModelName():                   schema_pkg_apis_meta_v1_APIGroup(ref),
+		v1.APIGroupList{}.OpenAPIModelName():               schema_pkg_apis_meta_v1_APIGroupList(ref),
+		v1.APIResource{}.OpenAPIModelName():                schema_pkg_apis_meta_v1_APIResource(ref),
+		v1.APIResourceList{}.OpenAPIModelName():            schema_pkg_apis_meta_v1_APIResourceList(ref),
+		v1.APIVersions{}.OpenAPIModelName():                schema_pkg_apis_meta_v1_APIVersions(ref),
+		v1.ApplyOptions{}.OpenAPIModelName():               schema_pkg_apis_meta_v1_ApplyOptions(ref),
+		v1.Condition{}.OpenAPIModelName():                  schema_pkg_apis_meta_v1_Condition(ref),
+		v1.CreateOptions{}.OpenAPIModelName():              schema_pkg_apis_meta_v1_CreateOptions(ref),
+		v1.DeleteOptions{}.OpenAPIModelName():              schema_pkg_apis_meta_v1_DeleteOptions(ref),
+		v1.Duration{}.OpenAPIModelName():                   schema_pkg_apis_meta_v1_Duration(ref),
+		v1.FieldSelectorRequirement{}.OpenAPIModelName():   schema_pkg_apis_meta_v1_FieldSelectorRequirement(ref),
+		v1.FieldsV1{}.OpenAPIModelName():                   schema_pkg_apis_meta_v1_FieldsV1(ref),
+		v1.GetOptions{}.OpenAPIModelName():                 schema_pkg_apis_meta_v1_GetOptions(ref),
+		v1.GroupKind{}.OpenAPIModelName():                  schema_pkg_apis_meta_v1_GroupKind(ref),
+		v1.GroupResource{}.OpenAPIModelName():              schema_pkg_apis_meta_v1_GroupResource(ref),
+		v1.GroupVersion{}.OpenAPIModelName():               schema_pkg_apis_meta_v1_GroupVersion(ref),
+		v1.GroupVersionForDiscovery{}.OpenAPIModelName():   schema_pkg_apis_meta_v1_GroupVersionForDiscovery(ref),
+		v1.GroupVersionKind{}.OpenAPIModelName():           schema_pkg_apis_meta_v1_GroupVersionKind(ref),
+		v1.GroupVersionResource{}.OpenAPIModelName():       schema_pkg_apis_meta_v1_GroupVersionResource(ref),
+		v1.InternalEvent{}.OpenAPIModelName():              schema_pkg_apis_meta_v1_InternalEvent(ref),
+		v1.LabelSelector{}.OpenAPIModelName():              schema_pkg_apis_meta_v1_LabelSelector(ref),
+		v1.LabelSelectorRequirement{}.OpenAPIModelName():   schema_pkg_apis_meta_v1_LabelSelectorRequirement(ref),
+		v1.List{}.OpenAPIModelName():                       schema_pkg_apis_meta_v1_List(ref),
+		v1.ListMeta{}.OpenAPIModelName():                   schema_pkg_apis_meta_v1_ListMeta(ref),
+		v1.ListOptions{}.OpenAPIModelName():                schema_pkg_apis_meta_v1_ListOptions(ref),
+		v1.ManagedFieldsEntry{}.OpenAPIModelName():         schema_pkg_apis_meta_v1_ManagedFieldsEntry(ref),
+		v1.MicroTime{}.OpenAPIModelName():                  schema_pkg_apis_meta_v1_MicroTime(ref),
+		v1.ObjectMeta{}.OpenAPIModelName():                 schema_pkg_apis_meta_v1_ObjectMeta(ref),
+		v1.OwnerReference{}.OpenAPIModelName():             schema_pkg_apis_meta_v1_OwnerReference(ref),
+		v1.PartialObjectMetadata{}.OpenAPIModelName():      schema_pkg_apis_meta_v1_PartialObjectMetadata(ref),
+		v1.PartialObjectMetadataList{}.OpenAPIModelName():  schema_pkg_apis_meta_v1_PartialObjectMetadataList(ref),
+		v1.Patch{}.OpenAPIModelName():                      schema_pkg_apis_meta_v1_Patch(ref),
+		v1.PatchOptions{}.OpenAPIModelName():               schema_pkg_apis_meta_v1_PatchOptions(ref),
+		v1.Preconditions{}.OpenAPIModelName():              schema_pkg_apis_meta_v1_Preconditions(ref),
+		v1.RootPaths{}.OpenAPIModelName():                  schema_pkg_apis_meta_v1_RootPaths(ref),
+		v1.ServerAddressByClientCIDR{}.OpenAPIModelName():  schema_pkg_apis_meta_v1_ServerAddressByClientCIDR(ref),
+		v1.Status{}.OpenAPIModelName():                     schema_pkg_apis_meta_v1_Status(ref),
+		v1.StatusCause{}.OpenAPIModelName():                schema_pkg_apis_meta_v1_StatusCause(ref),
+		v1.StatusDetails{}.OpenAPIModelName():              schema_pkg_apis_meta_v1_StatusDetails(ref),
+		v1.Table{}.OpenAPIModelName():                      schema_pkg_apis_meta_v1_Table(ref),
+		v1.TableColumnDefinition{}.OpenAPIModelName():      schema_pkg_apis_meta_v1_TableColumnDefinition(ref),
+		v1.TableOptions{}.OpenAPIModelName():               schema_pkg_apis_meta_v1_TableOptions(ref),
+		v1.TableRow{}.OpenAPIModelName():                   schema_pkg_apis_meta_v1_TableRow(ref),
+		v1.TableRowCondition{}.OpenAPIModelName():          schema_pkg_apis_meta_v1_TableRowCondition(ref),
+		v1.Time{}.OpenAPIModelName():                       schema_pkg_apis_meta_v1_Time(ref),
+		v1.Timestamp{}.OpenAPIModelName():                  schema_pkg_apis_meta_v1_Timestamp(ref),
+		v1.TypeMeta{}.OpenAPIModelName():                   schema_pkg_apis_meta_v1_TypeMeta(ref),
+		v1.UpdateOptions{}.OpenAPIModelName():              schema_pkg_apis_meta_v1_UpdateOptions(ref),
+		v1.WatchEvent{}.OpenAPIModelName():                 schema_pkg_apis_meta_v1_WatchEvent(ref),
+		runtime.RawExtension{}.OpenAPIModelName():          schema_k8sio_apimachinery_pkg_runtime_RawExtension(ref),
+		runtime.TypeMeta{}.OpenAPIModelName():              schema_k8sio_apimachinery_pkg_runtime_TypeMeta(ref),
+		runtime.Unknown{}.OpenAPIModelName():               schema_k8sio_apimachinery_pkg_runtime_Unknown(ref),
+		version.Info{}.OpenAPIModelName():                  schema_k8sio_apimachinery_pkg_version_Info(ref),
+	}
+}
+
+func schema_pkg_apis_rbacgraph_v1alpha1_AggregationMatch(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "AggregationMatch is an aggregationRule selector of the target ClusterRole that selects the source, with the source labels it matched on.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"selectorIndex": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SelectorIndex is the position of the selector in aggregationRule.clusterRoleSelectors.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"selector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Selector is the selector in label selector syntax.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"matchedLabels": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MatchedLabels are the source labels the selector constrains.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"selectorIndex", "selector"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_rbacgraph_v1alpha1_BindingSubject(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "BindingSubject is a subject of a binding.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"binding": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref(ObjectRef{}.OpenAPIModelName()),
+						},
+					},
+					"subject": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref(SubjectRef{}.OpenAPIModelName()),
+						},
+					},
+				},
+				Required: []string{"binding", "subject"},
+			},
+		},
+		Dependencies: []string{
+			ObjectRef{}.OpenAPIModelName(), SubjectRef{}.OpenAPIModelName()},
+	}
+}
+
+func schema_pkg_apis_rbacgraph_v1alpha1_CloudIdentity(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "CloudIdentity is a cloud IAM identity a ServiceAccount is bound to through an annotation.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"provider": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Possible enum values:\n - `\"aws\"`\n - `\"azure\"`\n - `\"gcp\"`",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+							Enum:        []interface{}{"aws", "azure", "gcp"},
+						},
+					},
+					"annotation": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"identity": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+				},
+				Required: []string{"provider", "annotation", "identity"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_rbacgraph_v1alpha1_EffectiveScope(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "EffectiveScope describes where a grant applies: cluster-wide for ClusterRoleBindings, or only in the listed namespaces for RoleBindings (including RoleBindings that reference a ClusterRole).",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"clusterWide": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"boolean"},
+							Format: "",
+						},
+					},
+					"namespaces": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 }
 
@@ -171,11 +342,11 @@ func schema_pkg_apis_rbacgraph_v1alpha1_GraphEdge(ref common.ReferenceCallback)
 					},
 					"type": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Possible enum values:\n - `\"aggregates\"`\n - `\"grants\"`\n - `\"ownedBy\"`\n - `\"runsAs\"`\n - `\"subjects\"`",
+							Description: "Possible enum values:\n - `\"aggregates\"`\n - `\"canBind\"`\n - `\"canEscalate\"`\n - `\"canImpersonate\"` Escalation edges are only emitted with IncludeEscalationPaths. They point from a subject to a subject it can become, or to a role it can grant to itself.\n - `\"canMintToken\"`\n - `\"canReadToken\"`\n - `\"canRunAs\"`\n - `\"grants\"`\n - `\"ownedBy\"`\n - `\"runsAs\"`\n - `\"subjects\"`",
 							Default:     "",
 							Type:        []string{"string"},
 							Format:      "",
-							Enum:        []interface{}{"aggregates", "grants", "ownedBy", "runsAs", "subjects"},
+							Enum:        []interface{}{"aggregates", "canBind", "canEscalate", "canImpersonate", "canMintToken", "canReadToken", "canRunAs", "grants", "ownedBy", "runsAs", "subjects"},
 						},
 					},
 					"ruleRefs": {
@@ -197,12 +368,32 @@ func schema_pkg_apis_rbacgraph_v1alpha1_GraphEdge(ref common.ReferenceCallback)
 							Format: "",
 						},
 					},
+					"scope": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Scope is set on grants and subjects edges and tells whether the binding applies cluster-wide or only in its own namespace.",
+							Ref:         ref(EffectiveScope{}.OpenAPIModelName()),
+						},
+					},
+					"aggregationMatches": {
+						SchemaProps: spec.SchemaProps{
+							Description: "AggregationMatches is set on aggregates edges and explains why the target ClusterRole aggregates the source.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref(AggregationMatch{}.OpenAPIModelName()),
+									},
+								},
+							},
+						},
+					},
 				},
 				Required: []string{"id", "from", "to", "type"},
 			},
 		},
 		Dependencies: []string{
-			RuleRef{}.OpenAPIModelName()},
+			AggregationMatch{}.OpenAPIModelName(), EffectiveScope{}.OpenAPIModelName(), RuleRef{}.OpenAPIModelName()},
 	}
 }
 
@@ -328,202 +519,280 @@ func schema_pkg_apis_rbacgraph_v1alpha1_GraphNode(ref common.ReferenceCallback)
 							Format: "int32",
 						},
 					},
-				},
-				Required: []string{"id", "type", "name"},
-			},
-		},
-		Dependencies: []string{
-			RuleRef{}.OpenAPIModelName()},
-	}
-}
-
-func schema_pkg_apis_rbacgraph_v1alpha1_NamespaceScope(ref common.ReferenceCallback) common.OpenAPIDefinition {
-	return common.OpenAPIDefinition{
-		Schema: spec.Schema{
-			SchemaProps: spec.SchemaProps{
-				Type: []string{"object"},
-				Properties: map[string]spec.Schema{
-					"namespaces": {
-						SchemaProps: spec.SchemaProps{
-							Type: []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: "",
-										Type:    []string{"string"},
-										Format:  "",
-									},
-								},
-							},
-						},
-					},
-					"strict": {
-						SchemaProps: spec.SchemaProps{
-							Type:   []string{"boolean"},
-							Format: "",
-						},
-					},
-				},
-			},
-		},
-	}
-}
-
-func schema_pkg_apis_rbacgraph_v1alpha1_NonResourceURLEntry(ref common.ReferenceCallback) common.OpenAPIDefinition {
-	return common.OpenAPIDefinition{
-		Schema: spec.Schema{
-			SchemaProps: spec.SchemaProps{
-				Description: "NonResourceURLEntry represents a single non-resource URL with its verbs and source roles.",
-				Type:        []string{"object"},
-				Properties: map[string]spec.Schema{
-					"url": {
+					"riskScore": {
 						SchemaProps: spec.SchemaProps{
-							Default: "",
-							Type:    []string{"string"},
-							Format:  "",
+							Description: "RiskScore is the sum of the distinct risk reasons of the node, capped at 100. Bindings, subjects, pods and workloads inherit the reasons of everything that grants them access.",
+							Type:        []string{"integer"},
+							Format:      "int32",
 						},
 					},
-					"verbs": {
+					"riskReasons": {
 						SchemaProps: spec.SchemaProps{
 							Type: []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
-										Default: "",
-										Type:    []string{"string"},
-										Format:  "",
+										Default: map[string]interface{}{},
+										Ref:     ref(RiskReason{}.OpenAPIModelName()),
 									},
 								},
 							},
 						},
 					},
-					"roles": {
+					"policyRules": {
 						SchemaProps: spec.SchemaProps{
-							Type: []string{"array"},
+							Description: "PolicyRules, RuleCount and ResourceVersion are set on role nodes with IncludePolicyRules. RuleCount counts every rule of the role, also when only the matched ones are embedded.",
+							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
-										Default: "",
-										Type:    []string{"string"},
-										Format:  "",
+										Default: map[string]interface{}{},
+										Ref:     ref(PolicyRule{}.OpenAPIModelName()),
 									},
 								},
 							},
 						},
 					},
-				},
-				Required: []string{"url", "verbs", "roles"},
-			},
-		},
-	}
-}
-
-func schema_pkg_apis_rbacgraph_v1alpha1_NonResourceURLList(ref common.ReferenceCallback) common.OpenAPIDefinition {
-	return common.OpenAPIDefinition{
-		Schema: spec.Schema{
-			SchemaProps: spec.SchemaProps{
-				Description: "NonResourceURLList is a list of non-resource URLs found across ClusterRole rules.",
-				Type:        []string{"object"},
-				Properties: map[string]spec.Schema{
-					"kind": {
+					"ruleCount": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
-							Type:        []string{"string"},
-							Format:      "",
+							Type:   []string{"integer"},
+							Format: "int32",
 						},
 					},
-					"apiVersion": {
+					"resourceVersion": {
 						SchemaProps: spec.SchemaProps{
-							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"namespaceState": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NamespaceState is set on Roles, RoleBindings and ServiceAccounts whose namespace no longer exists or is terminating.\n\nPossible enum values:\n - `\"missing\"`\n - `\"terminating\"`",
 							Type:        []string{"string"},
 							Format:      "",
+							Enum:        []interface{}{"missing", "terminating"},
 						},
 					},
-					"metadata": {
+					"serviceAccount": {
 						SchemaProps: spec.SchemaProps{
-							Default: map[string]interface{}{},
-							Ref:     ref(v1.ListMeta{}.OpenAPIModelName()),
+							Description: "ServiceAccount is set on serviceAccount nodes once ServiceAccounts are indexed. The annotations of the ServiceAccount are in Annotations.",
+							Ref:         ref(ServiceAccountInfo{}.OpenAPIModelName()),
 						},
 					},
-					"items": {
+					"tokenExposure": {
 						SchemaProps: spec.SchemaProps{
-							Type: []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref(NonResourceURLEntry{}.OpenAPIModelName()),
-									},
-								},
-							},
+							Description: "TokenExposure is set on pod nodes.",
+							Ref:         ref(TokenExposure{}.OpenAPIModelName()),
 						},
 					},
 				},
-				Required: []string{"items"},
+				Required: []string{"id", "type", "name"},
 			},
 		},
 		Dependencies: []string{
-			NonResourceURLEntry{}.OpenAPIModelName(), v1.ListMeta{}.OpenAPIModelName()},
+			PolicyRule{}.OpenAPIModelName(), RiskReason{}.OpenAPIModelName(), RuleRef{}.OpenAPIModelName(), ServiceAccountInfo{}.OpenAPIModelName(), TokenExposure{}.OpenAPIModelName()},
 	}
 }
 
-func schema_pkg_apis_rbacgraph_v1alpha1_ResourceMapRow(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_rbacgraph_v1alpha1_HygieneFinding(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Type: []string{"object"},
+				Description: "HygieneFinding is a single problem. Kind, Namespace and Name identify the role or binding it was found on; RoleRefKind/RoleRefName and Subject are set for missingRole and missingServiceAccount findings respectively.",
+				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"apiGroup": {
+					"check": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
 						},
 					},
-					"resource": {
+					"kind": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
-						},
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
 					},
-					"verb": {
+					"namespace": {
 						SchemaProps: spec.SchemaProps{
 							Type:   []string{"string"},
 							Format: "",
 						},
 					},
-					"roleCount": {
+					"name": {
 						SchemaProps: spec.SchemaProps{
-							Default: 0,
-							Type:    []string{"integer"},
-							Format:  "int32",
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
 						},
 					},
-					"bindingCount": {
+					"roleRefKind": {
 						SchemaProps: spec.SchemaProps{
-							Default: 0,
-							Type:    []string{"integer"},
-							Format:  "int32",
+							Type:   []string{"string"},
+							Format: "",
 						},
 					},
-					"subjectCount": {
+					"roleRefName": {
 						SchemaProps: spec.SchemaProps{
-							Default: 0,
-							Type:    []string{"integer"},
-							Format:  "int32",
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"subject": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref(SubjectRef{}.OpenAPIModelName()),
+						},
+					},
+					"unresolved": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Unresolved is set when the referenced role, ServiceAccount or the bindings of the role lie outside the caller's scope, so the finding may not be a problem.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
 						},
 					},
 				},
-				Required: []string{"roleCount", "bindingCount", "subjectCount"},
+				Required: []string{"check", "kind", "name", "message"},
 			},
 		},
+		Dependencies: []string{
+			SubjectRef{}.OpenAPIModelName()},
 	}
 }
 
-func schema_pkg_apis_rbacgraph_v1alpha1_RoleGraphReview(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_rbacgraph_v1alpha1_NamespaceScope(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "RoleGraphReview queries the RBAC role graph and returns matched roles, bindings, subjects, and optionally pods/workloads as a directed graph.",
+				Description: "NamespaceScope restricts results to namespaces. A namespace is in scope when it is listed in Namespaces, matches one of Patterns or has labels matching Selector, and does not match Exclude. Without Namespaces, Patterns and Selector every namespace is in scope.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"namespaces": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"strict": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"boolean"},
+							Format: "",
+						},
+					},
+					"selector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Selector matches namespace labels, e.g. \"team=payments\". Namespaces that are not indexed never match.",
+							Ref:         ref(v1.LabelSelector{}.OpenAPIModelName()),
+						},
+					},
+					"patterns": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Patterns are path.Match globs over namespace names, e.g. \"tenant-*\".",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"exclude": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Exclude drops namespaces by name or path.Match glob, even when they are otherwise in scope.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			v1.LabelSelector{}.OpenAPIModelName()},
+	}
+}
+
+func schema_pkg_apis_rbacgraph_v1alpha1_NonResourceURLEntry(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "NonResourceURLEntry represents a single non-resource URL with its verbs and source roles.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"url": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"verbs": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"roles": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"url", "verbs", "roles"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_rbacgraph_v1alpha1_NonResourceURLList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "NonResourceURLList is a list of non-resource URLs found across ClusterRole rules.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
 					"kind": {
@@ -543,117 +812,1584 @@ func schema_pkg_apis_rbacgraph_v1alpha1_RoleGraphReview(ref common.ReferenceCall
 					"metadata": {
 						SchemaProps: spec.SchemaProps{
 							Default: map[string]interface{}{},
-							Ref:     ref(v1.ObjectMeta{}.OpenAPIModelName()),
+							Ref:     ref(v1.ListMeta{}.OpenAPIModelName()),
 						},
 					},
-					"spec": {
+					"items": {
 						SchemaProps: spec.SchemaProps{
-							Default: map[string]interface{}{},
-							Ref:     ref(RoleGraphReviewSpec{}.OpenAPIModelName()),
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref(NonResourceURLEntry{}.OpenAPIModelName()),
+									},
+								},
+							},
 						},
 					},
-					"status": {
+				},
+				Required: []string{"items"},
+			},
+		},
+		Dependencies: []string{
+			NonResourceURLEntry{}.OpenAPIModelName(), v1.ListMeta{}.OpenAPIModelName()},
+	}
+}
+
+func schema_pkg_apis_rbacgraph_v1alpha1_ObjectRef(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ObjectRef identifies a role or binding.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
 						SchemaProps: spec.SchemaProps{
-							Default: map[string]interface{}{},
-							Ref:     ref(RoleGraphReviewStatus{}.OpenAPIModelName()),
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"namespace": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
 						},
 					},
 				},
-				Required: []string{"spec"},
+				Required: []string{"kind", "name"},
 			},
 		},
-		Dependencies: []string{
-			RoleGraphReviewSpec{}.OpenAPIModelName(), RoleGraphReviewStatus{}.OpenAPIModelName(), v1.ObjectMeta{}.OpenAPIModelName()},
 	}
 }
 
-func schema_pkg_apis_rbacgraph_v1alpha1_RoleGraphReviewSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_rbacgraph_v1alpha1_ObjectTarget(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Type: []string{"object"},
+				Description: "ObjectTarget names a concrete object and the verb to check against it. Only bindings that apply in the object's namespace and rules whose resourceNames (if any) include the object's name are considered.",
+				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"selector": {
+					"apiGroup": {
 						SchemaProps: spec.SchemaProps{
-							Default: map[string]interface{}{},
-							Ref:     ref(Selector{}.OpenAPIModelName()),
+							Type:   []string{"string"},
+							Format: "",
 						},
 					},
-					"matchMode": {
+					"resource": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Possible enum values:\n - `\"all\"`\n - `\"any\"`",
-							Type:        []string{"string"},
-							Format:      "",
-							Enum:        []interface{}{"all", "any"},
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
 						},
 					},
-					"wildcardMode": {
+					"namespace": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Possible enum values:\n - `\"exact\"`\n - `\"expand\"`",
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"verb": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+				},
+				Required: []string{"resource", "verb"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_rbacgraph_v1alpha1_PolicyRule(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PolicyRule is a rule of a role as written in the role, with its position.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"index": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Index is the position of the rule in the role, as in RuleRef.SourceRuleIndex.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"verbs": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"apiGroups": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"resources": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"resourceNames": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"nonResourceURLs": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"index", "verbs"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_rbacgraph_v1alpha1_ProjectedToken(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ProjectedToken is a ServiceAccount token projected into a pod volume.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"volume": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"audience": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Audience is empty for the API server audience.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"expirationSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"integer"},
+							Format: "int64",
+						},
+					},
+				},
+				Required: []string{"volume"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_rbacgraph_v1alpha1_RBACHygieneReport(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RBACHygieneReport lists RBAC objects that the graph queries skip silently: bindings to roles that do not exist, ServiceAccount subjects that do not exist, roles that nothing binds and bindings without subjects.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref(v1.ObjectMeta{}.OpenAPIModelName()),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref(RBACHygieneReportSpec{}.OpenAPIModelName()),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref(RBACHygieneReportStatus{}.OpenAPIModelName()),
+						},
+					},
+				},
+				Required: []string{"spec"},
+			},
+		},
+		Dependencies: []string{
+			RBACHygieneReportSpec{}.OpenAPIModelName(), RBACHygieneReportStatus{}.OpenAPIModelName(), v1.ObjectMeta{}.OpenAPIModelName()},
+	}
+}
+
+func schema_pkg_apis_rbacgraph_v1alpha1_RBACHygieneReportSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"namespaceScope": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref(NamespaceScope{}.OpenAPIModelName()),
+						},
+					},
+					"checks": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Checks limits the report to the listed checks. Empty runs all checks.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"includeSystemObjects": {
+						SchemaProps: spec.SchemaProps{
+							Description: "IncludeSystemObjects reports unbound roles and empty bindings whose name starts with \"system:\". They are skipped by default because the control plane ships many of them.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			NamespaceScope{}.OpenAPIModelName()},
+	}
+}
+
+func schema_pkg_apis_rbacgraph_v1alpha1_RBACHygieneReportStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"findings": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref(HygieneFinding{}.OpenAPIModelName()),
+									},
+								},
+							},
+						},
+					},
+					"warnings": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"findings"},
+			},
+		},
+		Dependencies: []string{
+			HygieneFinding{}.OpenAPIModelName()},
+	}
+}
+
+func schema_pkg_apis_rbacgraph_v1alpha1_ResourceMapRow(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"apiGroup": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"resource": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"verb": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"namespace": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Namespace is only set when ResourceMapByNamespace is requested.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"roleCount": {
+						SchemaProps: spec.SchemaProps{
+							Default: 0,
+							Type:    []string{"integer"},
+							Format:  "int32",
+						},
+					},
+					"bindingCount": {
+						SchemaProps: spec.SchemaProps{
+							Default: 0,
+							Type:    []string{"integer"},
+							Format:  "int32",
+						},
+					},
+					"subjectCount": {
+						SchemaProps: spec.SchemaProps{
+							Default: 0,
+							Type:    []string{"integer"},
+							Format:  "int32",
+						},
+					},
+					"scope": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Scope aggregates the effective scope of all bindings contributing to the row. It is nil when no binding grants the row.",
+							Ref:         ref(EffectiveScope{}.OpenAPIModelName()),
+						},
+					},
+				},
+				Required: []string{"roleCount", "bindingCount", "subjectCount"},
+			},
+		},
+		Dependencies: []string{
+			EffectiveScope{}.OpenAPIModelName()},
+	}
+}
+
+func schema_pkg_apis_rbacgraph_v1alpha1_RiskReason(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RiskReason is one risk catalog entry that contributed to a node's score.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"id": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"description": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"score": {
+						SchemaProps: spec.SchemaProps{
+							Default: 0,
+							Type:    []string{"integer"},
+							Format:  "int32",
+						},
+					},
+				},
+				Required: []string{"id", "score"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_rbacgraph_v1alpha1_RoleGraphChanges(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RoleGraphChanges lists objects matched in only one of the two snapshots. A role whose rules changed so that it starts matching the selector is reported as added even though the object already existed. Permissions are compared by apiGroup, resource, verb and namespace. BindingSubjects reports subjects added to or removed from a binding, so access gained by a subject that already held other grants is not missed.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"roles": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref(ObjectRef{}.OpenAPIModelName()),
+									},
+								},
+							},
+						},
+					},
+					"bindings": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref(ObjectRef{}.OpenAPIModelName()),
+									},
+								},
+							},
+						},
+					},
+					"subjects": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref(SubjectRef{}.OpenAPIModelName()),
+									},
+								},
+							},
+						},
+					},
+					"bindingSubjects": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref(BindingSubject{}.OpenAPIModelName()),
+									},
+								},
+							},
+						},
+					},
+					"permissions": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref(ResourceMapRow{}.OpenAPIModelName()),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"roles", "bindings", "subjects", "bindingSubjects", "permissions"},
+			},
+		},
+		Dependencies: []string{
+			BindingSubject{}.OpenAPIModelName(), ObjectRef{}.OpenAPIModelName(), ResourceMapRow{}.OpenAPIModelName(), SubjectRef{}.OpenAPIModelName()},
+	}
+}
+
+func schema_pkg_apis_rbacgraph_v1alpha1_RoleGraphDiff(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RoleGraphDiff runs the same query against two snapshots and returns the roles, bindings, subjects and resource map rows that appear in only one of them.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref(v1.ObjectMeta{}.OpenAPIModelName()),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref(RoleGraphDiffSpec{}.OpenAPIModelName()),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref(RoleGraphDiffStatus{}.OpenAPIModelName()),
+						},
+					},
+				},
+				Required: []string{"spec"},
+			},
+		},
+		Dependencies: []string{
+			RoleGraphDiffSpec{}.OpenAPIModelName(), RoleGraphDiffStatus{}.OpenAPIModelName(), v1.ObjectMeta{}.OpenAPIModelName()},
+	}
+}
+
+func schema_pkg_apis_rbacgraph_v1alpha1_RoleGraphDiffSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RoleGraphDiffSpec carries the RoleGraphReview query fields that decide which roles, bindings and subjects match, plus the two snapshots to compare.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"selector": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref(Selector{}.OpenAPIModelName()),
+						},
+					},
+					"matchMode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Possible enum values:\n - `\"all\"`\n - `\"any\"`",
+							Type:        []string{"string"},
+							Format:      "",
+							Enum:        []interface{}{"all", "any"},
+						},
+					},
+					"wildcardMode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Possible enum values:\n - `\"exact\"`\n - `\"expand\"`",
+							Type:        []string{"string"},
+							Format:      "",
+							Enum:        []interface{}{"exact", "expand"},
+						},
+					},
+					"namespaceScope": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref(NamespaceScope{}.OpenAPIModelName()),
+						},
+					},
+					"filterPhantomAPIs": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"boolean"},
+							Format: "",
+						},
+					},
+					"object": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref(ObjectTarget{}.OpenAPIModelName()),
+						},
+					},
+					"resourceMapByNamespace": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"boolean"},
+							Format: "",
+						},
+					},
+					"from": {
+						SchemaProps: spec.SchemaProps{
+							Description: "From is the older snapshot. It is required.",
+							Default:     map[string]interface{}{},
+							Ref:         ref(SnapshotPoint{}.OpenAPIModelName()),
+						},
+					},
+					"to": {
+						SchemaProps: spec.SchemaProps{
+							Description: "To is the newer snapshot. Empty means the current snapshot.",
+							Default:     map[string]interface{}{},
+							Ref:         ref(SnapshotPoint{}.OpenAPIModelName()),
+						},
+					},
+				},
+				Required: []string{"from"},
+			},
+		},
+		Dependencies: []string{
+			NamespaceScope{}.OpenAPIModelName(), ObjectTarget{}.OpenAPIModelName(), Selector{}.OpenAPIModelName(), SnapshotPoint{}.OpenAPIModelName()},
+	}
+}
+
+func schema_pkg_apis_rbacgraph_v1alpha1_RoleGraphDiffStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"from": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref(SnapshotRef{}.OpenAPIModelName()),
+						},
+					},
+					"to": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref(SnapshotRef{}.OpenAPIModelName()),
+						},
+					},
+					"added": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref(RoleGraphChanges{}.OpenAPIModelName()),
+						},
+					},
+					"removed": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref(RoleGraphChanges{}.OpenAPIModelName()),
+						},
+					},
+					"warnings": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"from", "to", "added", "removed"},
+			},
+		},
+		Dependencies: []string{
+			RoleGraphChanges{}.OpenAPIModelName(), SnapshotRef{}.OpenAPIModelName()},
+	}
+}
+
+func schema_pkg_apis_rbacgraph_v1alpha1_RoleGraphReview(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RoleGraphReview queries the RBAC role graph and returns matched roles, bindings, subjects, and optionally pods/workloads as a directed graph.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref(v1.ObjectMeta{}.OpenAPIModelName()),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref(RoleGraphReviewSpec{}.OpenAPIModelName()),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref(RoleGraphReviewStatus{}.OpenAPIModelName()),
+						},
+					},
+				},
+				Required: []string{"spec"},
+			},
+		},
+		Dependencies: []string{
+			RoleGraphReviewSpec{}.OpenAPIModelName(), RoleGraphReviewStatus{}.OpenAPIModelName(), v1.ObjectMeta{}.OpenAPIModelName()},
+	}
+}
+
+func schema_pkg_apis_rbacgraph_v1alpha1_RoleGraphReviewSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"selector": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref(Selector{}.OpenAPIModelName()),
+						},
+					},
+					"matchMode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Possible enum values:\n - `\"all\"`\n - `\"any\"`",
+							Type:        []string{"string"},
+							Format:      "",
+							Enum:        []interface{}{"all", "any"},
+						},
+					},
+					"wildcardMode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Possible enum values:\n - `\"exact\"`\n - `\"expand\"`",
+							Type:        []string{"string"},
+							Format:      "",
+							Enum:        []interface{}{"exact", "expand"},
+						},
+					},
+					"includeRuleMetadata": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"boolean"},
+							Format: "",
+						},
+					},
+					"namespaceScope": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref(NamespaceScope{}.OpenAPIModelName()),
+						},
+					},
+					"includePods": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"boolean"},
+							Format: "",
+						},
+					},
+					"includeWorkloads": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"boolean"},
+							Format: "",
+						},
+					},
+					"podPhaseMode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Possible enum values:\n - `\"active\"`\n - `\"all\"`\n - `\"running\"`",
+							Type:        []string{"string"},
+							Format:      "",
+							Enum:        []interface{}{"active", "all", "running"},
+						},
+					},
+					"maxPodsPerSubject": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"integer"},
+							Format: "int32",
+						},
+					},
+					"maxWorkloadsPerPod": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"integer"},
+							Format: "int32",
+						},
+					},
+					"filterPhantomAPIs": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"boolean"},
+							Format: "",
+						},
+					},
+					"object": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Object switches the review to \"who can <verb> this object\" mode. It is mutually exclusive with Selector.",
+							Ref:         ref(ObjectTarget{}.OpenAPIModelName()),
+						},
+					},
+					"resourceMapByNamespace": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ResourceMapByNamespace splits resource map rows by the namespace in which the granting binding applies. Cluster-wide grants keep an empty namespace.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"includeEscalationPaths": {
+						SchemaProps: spec.SchemaProps{
+							Description: "IncludeEscalationPaths adds transitive \"can become\" edges for matched subjects: impersonation, pod/workload creation, token secret reads, token minting and role bind/escalate.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"minRiskScore": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MinRiskScore drops roles whose risk score is below the threshold, together with the bindings and subjects reached only through them.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"sortByRiskScore": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SortByRiskScore orders graph nodes by descending risk score instead of by type and name.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"roleLabelSelector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RoleLabelSelector keeps only roles whose labels match, e.g. \"app.kubernetes.io/managed-by=Helm\" or \"kubernetes.io/bootstrapping notin (rbac-defaults)\".",
+							Ref:         ref(v1.LabelSelector{}.OpenAPIModelName()),
+						},
+					},
+					"roleNamePatterns": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RoleNamePatterns keeps only roles whose name matches one of the path.Match globs.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"bindingLabelSelector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "BindingLabelSelector keeps only bindings whose labels match. Roles left without a matching binding are dropped.",
+							Ref:         ref(v1.LabelSelector{}.OpenAPIModelName()),
+						},
+					},
+					"bindingNamePatterns": {
+						SchemaProps: spec.SchemaProps{
+							Description: "BindingNamePatterns keeps only bindings whose name matches one of the path.Match globs. Roles left without a matching binding are dropped.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"asOf": {
+						SchemaProps: spec.SchemaProps{
+							Description: "AsOf evaluates the query against the snapshot that was current at the given time instead of the latest one. Requires snapshot history to be enabled on the server.",
+							Ref:         ref(v1.Time{}.OpenAPIModelName()),
+						},
+					},
+					"includePolicyRules": {
+						SchemaProps: spec.SchemaProps{
+							Description: "IncludePolicyRules embeds the rules of each role node, together with its rule count and resourceVersion: \"matched\" embeds the rules that matched the selector, \"all\" every rule of the role. Defaults to \"none\".\n\nPossible enum values:\n - `\"all\"`\n - `\"matched\"`\n - `\"none\"`",
+							Type:        []string{"string"},
+							Format:      "",
+							Enum:        []interface{}{"all", "matched", "none"},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			NamespaceScope{}.OpenAPIModelName(), ObjectTarget{}.OpenAPIModelName(), Selector{}.OpenAPIModelName(), v1.LabelSelector{}.OpenAPIModelName(), v1.Time{}.OpenAPIModelName()},
+	}
+}
+
+func schema_pkg_apis_rbacgraph_v1alpha1_RoleGraphReviewStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"matchedRoles": {
+						SchemaProps: spec.SchemaProps{
+							Default: 0,
+							Type:    []string{"integer"},
+							Format:  "int32",
+						},
+					},
+					"matchedBindings": {
+						SchemaProps: spec.SchemaProps{
+							Default: 0,
+							Type:    []string{"integer"},
+							Format:  "int32",
+						},
+					},
+					"matchedSubjects": {
+						SchemaProps: spec.SchemaProps{
+							Default: 0,
+							Type:    []string{"integer"},
+							Format:  "int32",
+						},
+					},
+					"matchedPods": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"integer"},
+							Format: "int32",
+						},
+					},
+					"matchedWorkloads": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"integer"},
+							Format: "int32",
+						},
+					},
+					"warnings": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"knownGaps": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"graph": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref(Graph{}.OpenAPIModelName()),
+						},
+					},
+					"resourceMap": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref(ResourceMapRow{}.OpenAPIModelName()),
+									},
+								},
+							},
+						},
+					},
+					"snapshotGeneration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SnapshotGeneration and SnapshotBuiltAt identify the snapshot the query was evaluated against.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"snapshotBuiltAt": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref(v1.Time{}.OpenAPIModelName()),
+						},
+					},
+				},
+				Required: []string{"matchedRoles", "matchedBindings", "matchedSubjects", "graph", "resourceMap"},
+			},
+		},
+		Dependencies: []string{
+			Graph{}.OpenAPIModelName(), ResourceMapRow{}.OpenAPIModelName(), v1.Time{}.OpenAPIModelName()},
+	}
+}
+
+func schema_pkg_apis_rbacgraph_v1alpha1_RuleRef(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"apiGroup": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"resource": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"subresource": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"verb": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"resourceNames": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"nonResourceURLs": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"sourceObjectUID": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"sourceRuleIndex": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"integer"},
+							Format: "int32",
+						},
+					},
+					"phantom": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"boolean"},
+							Format: "",
+						},
+					},
+					"unsupportedVerb": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"boolean"},
+							Format: "",
+						},
+					},
+					"expandedRefs": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref(RuleRef{}.OpenAPIModelName()),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			RuleRef{}.OpenAPIModelName()},
+	}
+}
+
+func schema_pkg_apis_rbacgraph_v1alpha1_Selector(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"apiGroups": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"resources": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"verbs": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"resourceNames": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"nonResourceURLs": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"subjects": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Subjects keeps only matching binding subjects. Bindings and roles left without a matching subject are dropped. It may be combined with Object.",
+							Ref:         ref(SubjectSelector{}.OpenAPIModelName()),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			SubjectSelector{}.OpenAPIModelName()},
+	}
+}
+
+func schema_pkg_apis_rbacgraph_v1alpha1_ServiceAccountInfo(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ServiceAccountInfo describes the ServiceAccount behind a serviceAccount node.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"exists": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Exists is false when the subject refers to a ServiceAccount that is not indexed; the remaining fields are then empty.",
+							Default:     false,
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"automountServiceAccountToken": {
+						SchemaProps: spec.SchemaProps{
+							Description: "AutomountServiceAccountToken is the setting of the ServiceAccount; nil leaves the decision to its pods.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"imagePullSecrets": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"cloudIdentities": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CloudIdentities lists the cloud IAM identities the ServiceAccount is bound to, such as IRSA or Workload Identity.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref(CloudIdentity{}.OpenAPIModelName()),
+									},
+								},
+							},
+						},
+					},
+					"podCount": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PodCount is the number of indexed pods running as the ServiceAccount, in any phase.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+				Required: []string{"exists", "podCount"},
+			},
+		},
+		Dependencies: []string{
+			CloudIdentity{}.OpenAPIModelName()},
+	}
+}
+
+func schema_pkg_apis_rbacgraph_v1alpha1_SnapshotPoint(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "SnapshotPoint selects a snapshot by generation or by time. At most one field may be set.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"generation": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"integer"},
+							Format: "int64",
+						},
+					},
+					"time": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref(v1.Time{}.OpenAPIModelName()),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			v1.Time{}.OpenAPIModelName()},
+	}
+}
+
+func schema_pkg_apis_rbacgraph_v1alpha1_SnapshotRef(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "SnapshotRef identifies the snapshot a point resolved to.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"generation": {
+						SchemaProps: spec.SchemaProps{
+							Default: 0,
+							Type:    []string{"integer"},
+							Format:  "int64",
+						},
+					},
+					"builtAt": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref(v1.Time{}.OpenAPIModelName()),
+						},
+					},
+				},
+				Required: []string{"generation"},
+			},
+		},
+		Dependencies: []string{
+			v1.Time{}.OpenAPIModelName()},
+	}
+}
+
+func schema_pkg_apis_rbacgraph_v1alpha1_SubjectPermission(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "SubjectPermission is a single effective permission of the reviewed subject. ClusterWide is set when at least one ClusterRoleBinding grants it; otherwise Namespaces lists where RoleBindings make it effective.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"apiGroup": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"resource": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"verb": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"resourceNames": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"nonResourceURLs": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"clusterWide": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"boolean"},
+							Format: "",
+						},
+					},
+					"namespaces": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"roles": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"bindings": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"verb", "roles", "bindings"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_rbacgraph_v1alpha1_SubjectPermissionReview(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "SubjectPermissionReview returns the effective permissions of a single User, Group or ServiceAccount together with the bindings and roles that grant them and the namespaces they apply in.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
 							Type:        []string{"string"},
 							Format:      "",
-							Enum:        []interface{}{"exact", "expand"},
 						},
 					},
-					"includeRuleMetadata": {
+					"apiVersion": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"boolean"},
-							Format: "",
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"namespaceScope": {
+					"metadata": {
 						SchemaProps: spec.SchemaProps{
 							Default: map[string]interface{}{},
-							Ref:     ref(NamespaceScope{}.OpenAPIModelName()),
+							Ref:     ref(v1.ObjectMeta{}.OpenAPIModelName()),
 						},
 					},
-					"includePods": {
+					"spec": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"boolean"},
-							Format: "",
+							Default: map[string]interface{}{},
+							Ref:     ref(SubjectPermissionReviewSpec{}.OpenAPIModelName()),
 						},
 					},
-					"includeWorkloads": {
+					"status": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"boolean"},
-							Format: "",
+							Default: map[string]interface{}{},
+							Ref:     ref(SubjectPermissionReviewStatus{}.OpenAPIModelName()),
 						},
 					},
-					"podPhaseMode": {
+				},
+				Required: []string{"spec"},
+			},
+		},
+		Dependencies: []string{
+			SubjectPermissionReviewSpec{}.OpenAPIModelName(), SubjectPermissionReviewStatus{}.OpenAPIModelName(), v1.ObjectMeta{}.OpenAPIModelName()},
+	}
+}
+
+func schema_pkg_apis_rbacgraph_v1alpha1_SubjectPermissionReviewSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"subject": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Possible enum values:\n - `\"active\"`\n - `\"all\"`\n - `\"running\"`",
-							Type:        []string{"string"},
-							Format:      "",
-							Enum:        []interface{}{"active", "all", "running"},
+							Default: map[string]interface{}{},
+							Ref:     ref(SubjectRef{}.OpenAPIModelName()),
 						},
 					},
-					"maxPodsPerSubject": {
+					"groups": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"integer"},
-							Format: "int32",
+							Description: "Groups lists additional group memberships of a User subject. Implicit groups (system:authenticated, system:serviceaccounts, ...) are added automatically.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
 						},
 					},
-					"maxWorkloadsPerPod": {
+					"namespaceScope": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"integer"},
-							Format: "int32",
+							Default: map[string]interface{}{},
+							Ref:     ref(NamespaceScope{}.OpenAPIModelName()),
 						},
 					},
-					"filterPhantomAPIs": {
+					"includeRuleMetadata": {
 						SchemaProps: spec.SchemaProps{
 							Type:   []string{"boolean"},
 							Format: "",
 						},
 					},
 				},
+				Required: []string{"subject"},
 			},
 		},
 		Dependencies: []string{
-			NamespaceScope{}.OpenAPIModelName(), Selector{}.OpenAPIModelName()},
+			NamespaceScope{}.OpenAPIModelName(), SubjectRef{}.OpenAPIModelName()},
 	}
 }
 
-func schema_pkg_apis_rbacgraph_v1alpha1_RoleGraphReviewStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_rbacgraph_v1alpha1_SubjectPermissionReviewStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
@@ -673,26 +2409,7 @@ func schema_pkg_apis_rbacgraph_v1alpha1_RoleGraphReviewStatus(ref common.Referen
 							Format:  "int32",
 						},
 					},
-					"matchedSubjects": {
-						SchemaProps: spec.SchemaProps{
-							Default: 0,
-							Type:    []string{"integer"},
-							Format:  "int32",
-						},
-					},
-					"matchedPods": {
-						SchemaProps: spec.SchemaProps{
-							Type:   []string{"integer"},
-							Format: "int32",
-						},
-					},
-					"matchedWorkloads": {
-						SchemaProps: spec.SchemaProps{
-							Type:   []string{"integer"},
-							Format: "int32",
-						},
-					},
-					"warnings": {
+					"effectiveGroups": {
 						SchemaProps: spec.SchemaProps{
 							Type: []string{"array"},
 							Items: &spec.SchemaOrArray{
@@ -706,7 +2423,7 @@ func schema_pkg_apis_rbacgraph_v1alpha1_RoleGraphReviewStatus(ref common.Referen
 							},
 						},
 					},
-					"knownGaps": {
+					"warnings": {
 						SchemaProps: spec.SchemaProps{
 							Type: []string{"array"},
 							Items: &spec.SchemaOrArray{
@@ -720,152 +2437,78 @@ func schema_pkg_apis_rbacgraph_v1alpha1_RoleGraphReviewStatus(ref common.Referen
 							},
 						},
 					},
-					"graph": {
-						SchemaProps: spec.SchemaProps{
-							Default: map[string]interface{}{},
-							Ref:     ref(Graph{}.OpenAPIModelName()),
-						},
-					},
-					"resourceMap": {
+					"permissions": {
 						SchemaProps: spec.SchemaProps{
 							Type: []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
-										Ref:     ref(ResourceMapRow{}.OpenAPIModelName()),
+										Ref:     ref(SubjectPermission{}.OpenAPIModelName()),
 									},
 								},
 							},
 						},
 					},
+					"graph": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref(Graph{}.OpenAPIModelName()),
+						},
+					},
 				},
-				Required: []string{"matchedRoles", "matchedBindings", "matchedSubjects", "graph", "resourceMap"},
+				Required: []string{"matchedRoles", "matchedBindings", "permissions", "graph"},
 			},
 		},
 		Dependencies: []string{
-			Graph{}.OpenAPIModelName(), ResourceMapRow{}.OpenAPIModelName()},
+			Graph{}.OpenAPIModelName(), SubjectPermission{}.OpenAPIModelName()},
 	}
 }
 
-func schema_pkg_apis_rbacgraph_v1alpha1_RuleRef(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_rbacgraph_v1alpha1_SubjectRef(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
 				Type: []string{"object"},
 				Properties: map[string]spec.Schema{
-					"apiVersion": {
-						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
-						},
-					},
-					"apiGroup": {
-						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
-						},
-					},
-					"resource": {
-						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
-						},
-					},
-					"subresource": {
-						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
-						},
-					},
-					"verb": {
-						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
-						},
-					},
-					"resourceNames": {
+					"kind": {
 						SchemaProps: spec.SchemaProps{
-							Type: []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: "",
-										Type:    []string{"string"},
-										Format:  "",
-									},
-								},
-							},
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
 						},
 					},
-					"nonResourceURLs": {
+					"name": {
 						SchemaProps: spec.SchemaProps{
-							Type: []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: "",
-										Type:    []string{"string"},
-										Format:  "",
-									},
-								},
-							},
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
 						},
 					},
-					"sourceObjectUID": {
+					"namespace": {
 						SchemaProps: spec.SchemaProps{
 							Type:   []string{"string"},
 							Format: "",
 						},
 					},
-					"sourceRuleIndex": {
-						SchemaProps: spec.SchemaProps{
-							Type:   []string{"integer"},
-							Format: "int32",
-						},
-					},
-					"phantom": {
-						SchemaProps: spec.SchemaProps{
-							Type:   []string{"boolean"},
-							Format: "",
-						},
-					},
-					"unsupportedVerb": {
-						SchemaProps: spec.SchemaProps{
-							Type:   []string{"boolean"},
-							Format: "",
-						},
-					},
-					"expandedRefs": {
-						SchemaProps: spec.SchemaProps{
-							Type: []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref(RuleRef{}.OpenAPIModelName()),
-									},
-								},
-							},
-						},
-					},
 				},
+				Required: []string{"kind", "name"},
 			},
 		},
-		Dependencies: []string{
-			RuleRef{}.OpenAPIModelName()},
 	}
 }
 
-func schema_pkg_apis_rbacgraph_v1alpha1_Selector(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_rbacgraph_v1alpha1_SubjectSelector(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Type: []string{"object"},
+				Description: "SubjectSelector matches binding subjects. Set fields are ANDed; Names, NamePatterns and NameRegex are alternatives, a subject matches if any of them does.",
+				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"apiGroups": {
+					"kinds": {
 						SchemaProps: spec.SchemaProps{
-							Type: []string{"array"},
+							Description: "Kinds is a list of User, Group and ServiceAccount.",
+							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
@@ -877,9 +2520,10 @@ func schema_pkg_apis_rbacgraph_v1alpha1_Selector(ref common.ReferenceCallback) c
 							},
 						},
 					},
-					"resources": {
+					"names": {
 						SchemaProps: spec.SchemaProps{
-							Type: []string{"array"},
+							Description: "Names are exact subject names.",
+							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
@@ -891,9 +2535,10 @@ func schema_pkg_apis_rbacgraph_v1alpha1_Selector(ref common.ReferenceCallback) c
 							},
 						},
 					},
-					"verbs": {
+					"namePatterns": {
 						SchemaProps: spec.SchemaProps{
-							Type: []string{"array"},
+							Description: "NamePatterns are path.Match globs, e.g. \"ci-*\".",
+							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
@@ -905,9 +2550,17 @@ func schema_pkg_apis_rbacgraph_v1alpha1_Selector(ref common.ReferenceCallback) c
 							},
 						},
 					},
-					"resourceNames": {
+					"nameRegex": {
 						SchemaProps: spec.SchemaProps{
-							Type: []string{"array"},
+							Description: "NameRegex is an RE2 expression matched against the whole name.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"namespaces": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Namespaces keeps only ServiceAccount subjects in these namespaces.",
+							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
@@ -919,23 +2572,64 @@ func schema_pkg_apis_rbacgraph_v1alpha1_Selector(ref common.ReferenceCallback) c
 							},
 						},
 					},
-					"nonResourceURLs": {
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_rbacgraph_v1alpha1_TokenExposure(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "TokenExposure describes whether the ServiceAccount token of a pod is mounted where its containers can use it against the API server.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"exposed": {
 						SchemaProps: spec.SchemaProps{
-							Type: []string{"array"},
+							Description: "Exposed is true when the token is automounted or a projected token with the API server audience is mounted into a container.",
+							Default:     false,
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"automount": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Automount is the effective automountServiceAccountToken of the pod, taken from AutomountSource.",
+							Default:     false,
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"automountSource": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Possible enum values:\n - `\"default\"`\n - `\"pod\"`\n - `\"serviceAccount\"`",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+							Enum:        []interface{}{"default", "pod", "serviceAccount"},
+						},
+					},
+					"projectedTokens": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ProjectedTokens are the projected ServiceAccount tokens mounted into at least one container, including the automounted one.",
+							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
-										Default: "",
-										Type:    []string{"string"},
-										Format:  "",
+										Default: map[string]interface{}{},
+										Ref:     ref(ProjectedToken{}.OpenAPIModelName()),
 									},
 								},
 							},
 						},
 					},
 				},
+				Required: []string{"exposed", "automount", "automountSource"},
 			},
 		},
+		Dependencies: []string{
+			ProjectedToken{}.OpenAPIModelName()},
 	}
 }
 